@@ -0,0 +1,114 @@
+package taggo
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/bobg/errors"
+	"golang.org/x/mod/semver"
+	"gopkg.in/yaml.v3"
+
+	"github.com/bobg/taggo/gitutil"
+)
+
+// ActionInfo describes the GitHub Action defined in a module's repository.
+// See Result.Action.
+type ActionInfo struct {
+	// File is the Action's metadata file,
+	// action.yml or action.yaml at the repository root.
+	File string
+
+	// Name is the Action's name from File,
+	// or "" if it has none.
+	Name string
+
+	// MajorTag is the floating major-version tag,
+	// such as v1,
+	// that Action users reference to get the latest release in Result.LatestVersion's major version.
+	// It is empty if there is no latest version or if it is a prerelease.
+	MajorTag string
+
+	// MajorTagMissing is true if MajorTag does not exist.
+	// (If it exists but points elsewhere than the latest release,
+	// it is in Result.StaleFloatingTags.)
+	MajorTagMissing bool
+
+	// ReleaseOffBranch is true if the latest version tag
+	// is not reachable from Result.LatestCommit,
+	// so that Action users get code that is not on the branch.
+	ReleaseOffBranch bool
+
+	// MetadataChanged is true if File has changed
+	// between the latest version and Result.LatestCommit.
+	MetadataChanged bool
+}
+
+// actionFiles are the names GitHub accepts for an Action's metadata file.
+var actionFiles = []string{"action.yml", "action.yaml"}
+
+// actionInfo returns information about the GitHub Action defined at the root of the repository in repodir,
+// or nil if there is none.
+// The tags map is from tag name to commit hash,
+// latestVersionRev is the revision of r.LatestVersion for git commands,
+// and r is the result of checking the module at the repository root so far.
+func actionInfo(ctx context.Context, git, repodir string, tags map[string]string, latestVersionRev string, r Result) (*ActionInfo, error) {
+	var (
+		file string
+		data []byte
+	)
+	for _, f := range actionFiles {
+		var err error
+		data, err = os.ReadFile(filepath.Join(repodir, f))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading %s", f)
+		}
+		file = f
+		break
+	}
+	if file == "" {
+		return nil, nil
+	}
+
+	info := &ActionInfo{File: file}
+
+	// A malformed metadata file is for GitHub to report.
+	var metadata struct {
+		Name string `yaml:"name"`
+	}
+	if err := yaml.Unmarshal(data, &metadata); err == nil {
+		info.Name = metadata.Name
+	}
+
+	if r.LatestVersion == "" {
+		return info, nil
+	}
+
+	if semver.Prerelease(r.LatestVersion) == "" {
+		info.MajorTag = semver.Major(r.LatestVersion)
+		_, ok := tags[info.MajorTag]
+		info.MajorTagMissing = !ok
+	}
+
+	if r.LatestCommit == "" {
+		return info, nil
+	}
+
+	onBranch, err := gitutil.IsAncestor(ctx, git, repodir, latestVersionRev, r.LatestCommit)
+	if err != nil {
+		return nil, errors.Wrapf(err, "checking whether %s is an ancestor of %s", r.LatestVersion, r.LatestCommit)
+	}
+	info.ReleaseOffBranch = !onBranch
+
+	if !r.LatestCommitHasVersionTag {
+		info.MetadataChanged, err = gitutil.TreeChanged(ctx, git, repodir, latestVersionRev, r.LatestCommit, file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "comparing %s in %s and %s", file, r.LatestVersion, r.LatestCommit)
+		}
+	}
+
+	return info, nil
+}
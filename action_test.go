@@ -0,0 +1,116 @@
+package taggo_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bobg/modver/v2"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/internal/testutil"
+)
+
+func TestAction(t *testing.T) {
+	repodir := cloneBundle(t, "unstable") // latest version v0.1.2
+
+	check := func() taggo.Result {
+		t.Helper()
+		result, err := taggo.Check(context.Background(), "", repodir, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	if result := check(); result.Action != nil {
+		t.Fatalf("got Action %+v without action.yml, want nil", result.Action)
+	}
+
+	if err := os.WriteFile(filepath.Join(repodir, "action.yml"), []byte("name: Hello\nruns:\n  using: docker\n  image: Dockerfile\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	testutil.Git(t, repodir, "add", "action.yml")
+	testutil.Git(t, repodir, "commit", "-q", "-m", "add action")
+
+	result := check()
+	want := &taggo.ActionInfo{
+		File:            "action.yml",
+		Name:            "Hello",
+		MajorTag:        "v0",
+		MajorTagMissing: true,
+		MetadataChanged: true,
+	}
+	if diff := cmp.Diff(want, result.Action); diff != "" {
+		t.Errorf("Action mismatch (-want +got):\n%s", diff)
+	}
+
+	var found bool
+	for _, f := range result.Findings() {
+		if f.ID != "action-major-tag-missing" {
+			continue
+		}
+		found = true
+		if want := "git tag v0 v0.1.2^{commit} && git push origin v0"; f.Remediation != want {
+			t.Errorf("got remediation %q, want %q", f.Remediation, want)
+		}
+	}
+	if !found {
+		t.Error("no action-major-tag-missing finding")
+	}
+
+	// A later release made on another branch.
+	testutil.Git(t, repodir, "tag", "v0", "v0.1.2")
+	testutil.Git(t, repodir, "checkout", "-q", "-b", "side", "v0.1.2")
+	testutil.Git(t, repodir, "commit", "-q", "--allow-empty", "-m", "side")
+	testutil.Git(t, repodir, "tag", "v0.1.3")
+	testutil.Git(t, repodir, "checkout", "-q", "main")
+
+	result = check()
+	want = &taggo.ActionInfo{
+		File:             "action.yml",
+		Name:             "Hello",
+		MajorTag:         "v0",
+		ReleaseOffBranch: true,
+		MetadataChanged:  true,
+	}
+	if diff := cmp.Diff(want, result.Action); diff != "" {
+		t.Errorf("Action mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestActionReleaseSteps(t *testing.T) {
+	r := taggo.Result{
+		DefaultBranch:    "main",
+		Remote:           "origin",
+		ForgeKind:        taggo.ForgeGitHub,
+		LatestVersion:    "v1.2.3",
+		LatestMajor:      1,
+		LatestMinor:      2,
+		LatestPatch:      3,
+		LatestCommit:     "abc123",
+		ModverResultCode: modver.Minor,
+		NewMajor:         1,
+		NewMinor:         3,
+		Action:           &taggo.ActionInfo{File: "action.yml", MajorTag: "v1"},
+	}
+
+	for _, f := range r.Findings() {
+		if f.ID != "action-release-steps" {
+			continue
+		}
+		if f.Severity != taggo.SeverityInfo {
+			t.Errorf("got severity %s, want %s", f.Severity, taggo.SeverityInfo)
+		}
+		if want := "To release the Action: tag v1.3.0, move v1 to it, push both, and publish a GitHub release for v1.3.0"; f.Message != want {
+			t.Errorf("got message %q, want %q", f.Message, want)
+		}
+		if want := "git tag -a -m 'Version v1.3.0' v1.3.0 abc123 && git tag -f v1 abc123 && git push origin v1.3.0 && git push -f origin v1 && gh release create v1.3.0 --generate-notes"; f.Remediation != want {
+			t.Errorf("got remediation %q, want %q", f.Remediation, want)
+		}
+		return
+	}
+	t.Error("no action-release-steps finding")
+}
@@ -0,0 +1,91 @@
+package taggo
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/bobg/modver/v2"
+)
+
+// Possible values for APIChange.Kind.
+const (
+	APIAdded   = "added"
+	APIRemoved = "removed"
+	APIChanged = "changed"
+)
+
+// APIChange is a change to the module's API found by Modver,
+// explaining its result.
+type APIChange struct {
+	// Kind is [APIAdded], [APIRemoved], or [APIChanged].
+	Kind string
+
+	// Package is the import path of the package containing the change,
+	// if known.
+	Package string
+
+	// Symbol is the name of the added, removed, or changed identifier,
+	// if known.
+	Symbol string
+
+	// Reason is Modver's explanation of the change.
+	Reason string
+}
+
+var (
+	// These match Modver's explanations of its results.
+	// A result that Modver wraps in another reads innermost first,
+	// as in "T went from struct to non-struct: checking T",
+	// so the outermost explanation is at the end.
+	apiObjectRegex   = regexp.MustCompile(`^no object (\S+) in (old|new) version of package (\S+)$`)
+	apiPkgRegex      = regexp.MustCompile(`^no (old|new) version of package (\S+)$`)
+	apiCheckingRegex = regexp.MustCompile(`(?:^|: )checking (\S+)$`)
+	apiPackageRegex  = regexp.MustCompile(`\bpackage (\S+)`)
+)
+
+// apiChanges extracts the API changes from a Modver result.
+// Modver explains its result with the first change it finds that determines it,
+// so there is at most one.
+func apiChanges(r modver.Result) []APIChange {
+	if r.Code() == modver.None {
+		return nil
+	}
+	reason := strings.TrimPrefix(r.String(), r.Code().String())
+	reason = strings.TrimPrefix(reason, ": ")
+	if reason == "" {
+		return nil
+	}
+	return []APIChange{parseAPIChange(reason)}
+}
+
+// parseAPIChange classifies the change that Modver explains with reason.
+// Modver's result type exposes only the explanation's text,
+// not its parts.
+func parseAPIChange(reason string) APIChange {
+	change := APIChange{Kind: APIChanged, Reason: reason}
+
+	// "no object" in the old version means it was added,
+	// and in the new version that it was removed.
+	kind := func(version string) string {
+		if version == "old" {
+			return APIAdded
+		}
+		return APIRemoved
+	}
+
+	if m := apiObjectRegex.FindStringSubmatch(reason); m != nil {
+		change.Kind, change.Symbol, change.Package = kind(m[2]), m[1], m[3]
+		return change
+	}
+	if m := apiPkgRegex.FindStringSubmatch(reason); m != nil {
+		change.Kind, change.Package = kind(m[1]), m[2]
+		return change
+	}
+	if m := apiCheckingRegex.FindStringSubmatch(reason); m != nil {
+		change.Symbol = m[1]
+	}
+	if m := apiPackageRegex.FindStringSubmatch(reason); m != nil {
+		change.Package = strings.TrimRight(m[1], ":,;")
+	}
+	return change
+}
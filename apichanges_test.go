@@ -0,0 +1,51 @@
+package taggo
+
+import (
+	"testing"
+
+	"github.com/bobg/modver/v2"
+)
+
+func TestParseAPIChange(t *testing.T) {
+	cases := []struct {
+		reason string
+		want   APIChange
+	}{{
+		reason: "no object F in old version of package example.com/x/y",
+		want:   APIChange{Kind: APIAdded, Package: "example.com/x/y", Symbol: "F"},
+	}, {
+		reason: "no object F in new version of package example.com/x/y",
+		want:   APIChange{Kind: APIRemoved, Package: "example.com/x/y", Symbol: "F"},
+	}, {
+		reason: "no old version of package example.com/x/y",
+		want:   APIChange{Kind: APIAdded, Package: "example.com/x/y"},
+	}, {
+		reason: "no new version of package example.com/x/y",
+		want:   APIChange{Kind: APIRemoved, Package: "example.com/x/y"},
+	}, {
+		reason: "checking T",
+		want:   APIChange{Kind: APIChanged, Symbol: "T"},
+	}, {
+		reason: "old struct field A was removed from example.com/x.T: in type example.com/x.T: checking T",
+		want:   APIChange{Kind: APIChanged, Symbol: "T"},
+	}, {
+		reason: "minimum Go version changed from 1.21 to 1.22",
+		want:   APIChange{Kind: APIChanged},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.reason, func(t *testing.T) {
+			tc.want.Reason = tc.reason
+			if got := parseAPIChange(tc.reason); got != tc.want {
+				t.Errorf("got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+
+	if got := apiChanges(modver.Major); got != nil {
+		t.Errorf("got %+v for a result without explanation, want nil", got)
+	}
+	if got := apiChanges(modver.None); got != nil {
+		t.Errorf("got %+v for None, want nil", got)
+	}
+}
@@ -0,0 +1,133 @@
+package taggo
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/bobg/errors"
+)
+
+// BaselineFile is the name of the Taggo baseline file,
+// which Taggo looks for at the root of the repository.
+const BaselineFile = ".taggo-baseline.json"
+
+// Baseline records known warnings,
+// so that Taggo can report only new ones.
+// See [DescribeOptions].
+type Baseline struct {
+	Entries []BaselineEntry `json:"entries"`
+}
+
+// BaselineEntry is a warning recorded in a [Baseline].
+type BaselineEntry struct {
+	// Module is the module's directory relative to the repository root,
+	// "." for the root.
+	Module string `json:"module"`
+
+	// ID is the finding ID.
+	ID string `json:"id"`
+
+	// Message is the text of the warning.
+	// A warning with the same ID but a different message
+	// (e.g. naming a different version)
+	// is a new warning.
+	Message string `json:"message"`
+}
+
+// NewBaseline makes a [Baseline] recording the warnings in results.
+func NewBaseline(results []Result) Baseline {
+	var b Baseline
+	b.add(results)
+	return b
+}
+
+// Update replaces the entries in b for the modules of results
+// with their current warnings,
+// leaving the entries for other modules alone.
+func (b *Baseline) Update(results []Result) {
+	replaced := make(map[string]bool)
+	for _, r := range results {
+		replaced[baselineModule(r)] = true
+	}
+	entries := b.Entries[:0]
+	for _, e := range b.Entries {
+		if !replaced[e.Module] {
+			entries = append(entries, e)
+		}
+	}
+	b.Entries = entries
+	b.add(results)
+}
+
+func (b *Baseline) add(results []Result) {
+	for _, r := range results {
+		module := baselineModule(r)
+		for _, f := range r.Findings() {
+			if f.Kind == FindingWarning {
+				b.Entries = append(b.Entries, BaselineEntry{Module: module, ID: f.ID, Message: f.Message})
+			}
+		}
+	}
+	sort.SliceStable(b.Entries, func(i, j int) bool {
+		ei, ej := b.Entries[i], b.Entries[j]
+		if ei.Module != ej.Module {
+			return ei.Module < ej.Module
+		}
+		return ei.ID < ej.ID
+	})
+}
+
+// Contains tells whether b records the warning f in r.
+func (b *Baseline) Contains(r Result, f Finding) bool {
+	if b == nil {
+		return false
+	}
+	module := baselineModule(r)
+	for _, e := range b.Entries {
+		if e.Module == module && e.ID == f.ID && e.Message == f.Message {
+			return true
+		}
+	}
+	return false
+}
+
+func baselineModule(r Result) string {
+	return path.Clean(filepath.ToSlash(r.ModuleSubdir))
+}
+
+// LoadBaseline reads the baseline file from the root of the repository in repodir.
+// If there is no such file,
+// it returns nil and no error.
+func LoadBaseline(repodir string) (*Baseline, error) {
+	filename := filepath.Join(repodir, BaselineFile)
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %s", filename)
+	}
+
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", filename)
+	}
+	return &b, nil
+}
+
+// Write writes b to the baseline file at the root of the repository in repodir.
+func (b Baseline) Write(repodir string) error {
+	if b.Entries == nil {
+		b.Entries = []BaselineEntry{}
+	}
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "encoding baseline")
+	}
+	filename := filepath.Join(repodir, BaselineFile)
+	err = os.WriteFile(filename, append(data, '\n'), 0644)
+	return errors.Wrapf(err, "writing %s", filename)
+}
@@ -0,0 +1,82 @@
+package taggo_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bobg/taggo"
+)
+
+func TestBaseline(t *testing.T) {
+	repodir := t.TempDir()
+
+	r := taggo.Result{
+		Modpath:               "example.com/x",
+		DefaultBranch:         "main",
+		LatestVersion:         "v0.1.0",
+		LatestVersionUnstable: true,
+	}
+
+	b, err := taggo.LoadBaseline(repodir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b != nil {
+		t.Fatalf("got baseline %v from missing file, want nil", b)
+	}
+
+	if err := taggo.NewBaseline([]taggo.Result{r}).Write(repodir); err != nil {
+		t.Fatal(err)
+	}
+	b, err = taggo.LoadBaseline(repodir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	known := len(b.Entries)
+	var found bool
+	for _, e := range b.Entries {
+		if e.Module != "." {
+			t.Errorf("got baseline entry for module %q, want .", e.Module)
+		}
+		if e.ID == "unstable" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("got baseline entries %v, want one for unstable", b.Entries)
+	}
+
+	buf := new(bytes.Buffer)
+	if n := r.DescribeWith(buf, taggo.DescribeOptions{Baseline: b}); n != 0 {
+		t.Errorf("got %d warnings with baseline, want 0", n)
+	}
+	if strings.Contains(buf.String(), "unstable") {
+		t.Errorf("baselined warning was displayed:\n%s", buf)
+	}
+	if !strings.Contains(buf.String(), fmt.Sprintf("Suppressed %d known warning", known)) {
+		t.Errorf("missing note about suppressed warnings:\n%s", buf)
+	}
+
+	// A new warning, and a known one with a changed message, are both reported.
+	r2 := r
+	r2.LatestVersion = "v0.2.0"
+	r2.ModpathMismatch = true
+	if n := r2.DescribeWith(new(bytes.Buffer), taggo.DescribeOptions{Baseline: b}); n != 2 {
+		t.Errorf("got %d new warnings, want 2", n)
+	}
+
+	// Updating for one module leaves the others alone.
+	r3 := taggo.Result{Modpath: "example.com/x/sub", ModuleSubdir: "sub", DefaultBranch: "main", LatestVersion: "v1.0.0"}
+	b.Update([]taggo.Result{r3})
+	var dot int
+	for _, e := range b.Entries {
+		if e.Module == "." {
+			dot++
+		}
+	}
+	if dot != known {
+		t.Errorf("after update, got %d baseline entries for module ., want %d", dot, known)
+	}
+}
@@ -0,0 +1,280 @@
+package taggo
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bobg/errors"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// Blocker is something standing in the way of a stable v1 release of a module.
+// See [V1Blockers].
+type Blocker struct {
+	// Kind is the kind of blocker.
+	Kind BlockerKind
+
+	// Subject is what the blocker is about:
+	// a dependency's module path for BlockerUnstableDependency,
+	// a list of versions for BlockerBreakingChanges,
+	// or the qualified name of an exported identifier for BlockerAPIMarker.
+	Subject string
+
+	// Message is a human-readable description of the blocker.
+	Message string
+}
+
+// BlockerKind is the type of Blocker.Kind.
+type BlockerKind string
+
+// Possible values for Blocker.Kind.
+const (
+	// BlockerUnstableDependency is a direct dependency at a v0 version or a pseudo-version.
+	BlockerUnstableDependency BlockerKind = "unstable-dependency"
+
+	// BlockerBreakingChanges is a recent history of minor-version releases,
+	// which for a v0 module may signal breaking changes.
+	BlockerBreakingChanges BlockerKind = "breaking-changes"
+
+	// BlockerAPIMarker is an exported identifier whose doc comment says it is experimental or unfinished.
+	BlockerAPIMarker BlockerKind = "api-marker"
+)
+
+// breakingChangesWindow is how far back [V1Blockers] looks for minor-version releases.
+const breakingChangesWindow = 365 * 24 * time.Hour
+
+// apiMarkers are the words in doc comments that [V1Blockers] reports.
+var apiMarkers = []string{"TODO", "Experimental", "EXPERIMENTAL", "Unstable", "UNSTABLE"}
+
+// V1Blockers analyzes a v0 module
+// for concrete obstacles to releasing a stable v1:
+// direct dependencies that are themselves unstable (at v0 or a pseudo-version);
+// minor-version releases in the past year,
+// which for a v0 module may signal breaking changes;
+// and exported identifiers whose doc comments contain TODO or Experimental markers.
+//
+// The module is analyzed as it is in the working tree of repodir.
+// If the module's latest version is already v1 or later,
+// the result is nil.
+func V1Blockers(ctx context.Context, git, repodir, moduledir string) ([]Blocker, error) {
+	result, err := Check(ctx, git, repodir, moduledir, WithVersionHistory())
+	if err != nil {
+		return nil, errors.Wrap(err, "checking module")
+	}
+	if result.LatestVersion != "" && result.LatestMajor >= 1 {
+		return nil, nil
+	}
+
+	var blockers []Blocker
+
+	// Unstable dependencies.
+
+	moddir := filepath.Join(repodir, result.ModuleSubdir)
+	gomodPath := filepath.Join(moddir, "go.mod")
+	gomodBytes, err := os.ReadFile(gomodPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %s", gomodPath)
+	}
+	gomod, err := modfile.ParseLax(gomodPath, gomodBytes, noopFixer)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", gomodPath)
+	}
+	for _, req := range gomod.Require {
+		if req.Indirect {
+			continue
+		}
+		switch {
+		case module.IsPseudoVersion(req.Mod.Version):
+			blockers = append(blockers, Blocker{
+				Kind:    BlockerUnstableDependency,
+				Subject: req.Mod.Path,
+				Message: fmt.Sprintf("Dependency %s is at pseudo-version %s", req.Mod.Path, req.Mod.Version),
+			})
+		case semver.Major(req.Mod.Version) == "v0":
+			blockers = append(blockers, Blocker{
+				Kind:    BlockerUnstableDependency,
+				Subject: req.Mod.Path,
+				Message: fmt.Sprintf("Dependency %s is at unstable version %s", req.Mod.Path, req.Mod.Version),
+			})
+		}
+	}
+
+	// Recent minor-version releases.
+
+	var recentMinors []string
+	for i, v := range result.Versions {
+		if i == 0 {
+			continue // the first release is not a change
+		}
+		if semver.Prerelease(v.Version) != "" || !strings.HasSuffix(semver.Canonical(v.Version), ".0") {
+			continue
+		}
+		if time.Since(v.released()) <= breakingChangesWindow {
+			recentMinors = append(recentMinors, v.Version)
+		}
+	}
+	if len(recentMinors) > 0 {
+		blockers = append(blockers, Blocker{
+			Kind:    BlockerBreakingChanges,
+			Subject: strings.Join(recentMinors, ", "),
+			Message: fmt.Sprintf("%d minor-version release(s) in the past year (%s), which may signal an API still in flux", len(recentMinors), strings.Join(recentMinors, ", ")),
+		})
+	}
+
+	// Experimental or unfinished exported API.
+
+	markerBlockers, err := apiMarkerBlockers(moddir, result.Modpath, apiMarkers)
+	if err != nil {
+		return nil, errors.Wrap(err, "scanning exported API")
+	}
+	blockers = append(blockers, markerBlockers...)
+
+	return blockers, nil
+}
+
+// apiMarkerBlockers finds the exported identifiers in the importable packages of the module in moddir
+// whose doc comments contain any of markers.
+func apiMarkerBlockers(moddir, modpath string, markers []string) ([]Blocker, error) {
+	var (
+		blockers []Blocker
+		fset     = token.NewFileSet()
+	)
+
+	err := filepath.WalkDir(moddir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(moddir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if rel == "." {
+				return nil
+			}
+			if isNonImportableDir(rel) {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(p, "go.mod")); err == nil {
+				return filepath.SkipDir // nested module
+			}
+			return nil
+		}
+
+		if !strings.HasSuffix(p, ".go") || strings.HasSuffix(p, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, p, nil, parser.ParseComments)
+		if err != nil {
+			return nil // let the compiler report this
+		}
+		if file.Name.Name == "main" {
+			return nil
+		}
+
+		pkgpath := modpath
+		if dir := path.Dir(rel); dir != "." {
+			pkgpath += "/" + dir
+		}
+
+		check := func(name string, doc *ast.CommentGroup) {
+			if !ast.IsExported(name) || doc == nil {
+				return
+			}
+			text := doc.Text()
+			for _, marker := range markers {
+				if strings.Contains(text, marker) {
+					pos := fset.Position(doc.Pos())
+					blockers = append(blockers, Blocker{
+						Kind:    BlockerAPIMarker,
+						Subject: pkgpath + "." + name,
+						Message: fmt.Sprintf("Exported %s.%s is marked %s (%s:%d)", pkgpath, name, marker, rel, pos.Line),
+					})
+					return
+				}
+			}
+		}
+
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				name := decl.Name.Name
+				if decl.Recv != nil && len(decl.Recv.List) > 0 {
+					recv := receiverTypeName(decl.Recv.List[0].Type)
+					if !ast.IsExported(recv) {
+						continue
+					}
+					name = recv + "." + name
+					if !ast.IsExported(decl.Name.Name) {
+						continue
+					}
+				}
+				check(name, decl.Doc)
+
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						doc := spec.Doc
+						if doc == nil {
+							doc = decl.Doc
+						}
+						check(spec.Name.Name, doc)
+
+					case *ast.ValueSpec:
+						doc := spec.Doc
+						if doc == nil {
+							doc = decl.Doc
+						}
+						for _, name := range spec.Names {
+							check(name.Name, doc)
+						}
+					}
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(blockers, func(i, j int) bool {
+		return blockers[i].Subject < blockers[j].Subject
+	})
+	return blockers, nil
+}
+
+// receiverTypeName returns the name of the type in a method receiver expression.
+func receiverTypeName(expr ast.Expr) string {
+	for {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.Ident:
+			return e.Name
+		default:
+			return ""
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package taggo_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bobg/taggo"
+)
+
+func TestV1Blockers(t *testing.T) {
+	repodir := cloneBundle(t, "unstable")
+
+	const src = `package x
+
+// New makes a thing.
+//
+// Experimental: this may change.
+func New() {}
+
+// helper is unexported, TODO.
+func helper() {}
+`
+	if err := os.WriteFile(filepath.Join(repodir, "new.go"), []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	blockers, err := taggo.V1Blockers(context.Background(), "", repodir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []taggo.Blocker{{
+		Kind:    taggo.BlockerAPIMarker,
+		Subject: "x.New",
+		Message: "Exported x.New is marked Experimental (new.go:3)",
+	}}
+	if diff := cmp.Diff(want, blockers); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,66 @@
+package taggo
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo/gitutil"
+)
+
+// unbuildableVersions checks out each of the given versions of the module in moduledir
+// into a temporary worktree
+// and runs "go build ./..." there.
+// It returns the versions for which the build fails.
+// If offline is true, the go command may use only the local module cache.
+func unbuildableVersions(ctx context.Context, git, repodir, moduledir, versionPrefix string, versions []string, offline bool) ([]string, error) {
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		return nil, errors.Wrap(err, "finding go binary")
+	}
+
+	tmpdir, err := os.MkdirTemp("", "taggo-build")
+	if err != nil {
+		return nil, errors.Wrap(err, "creating temp dir")
+	}
+	defer os.RemoveAll(tmpdir)
+
+	var result []string
+	for _, version := range versions {
+		ok, err := versionBuilds(ctx, git, gobin, repodir, moduledir, versionPrefix+version, filepath.Join(tmpdir, version), offline)
+		if err != nil {
+			return nil, errors.Wrapf(err, "building %s", version)
+		}
+		if !ok {
+			result = append(result, version)
+		}
+	}
+	return result, nil
+}
+
+func versionBuilds(ctx context.Context, git, gobin, repodir, moduledir, tag, worktree string, offline bool) (bool, error) {
+	cmd, done := gitutil.Command(ctx, git, repodir, "worktree", "add", "--detach", worktree, tag)
+	output, err := cmd.CombinedOutput()
+	if err = done(err); err != nil {
+		return false, errors.Wrapf(err, "running %s: %s", cmd, output)
+	}
+	defer func() {
+		cmd := exec.CommandContext(context.WithoutCancel(ctx), git, "worktree", "remove", "--force", worktree)
+		cmd.Dir = repodir
+		_ = cmd.Run()
+	}()
+
+	cmd = exec.CommandContext(ctx, gobin, "build", "./...")
+	cmd.Dir = filepath.Join(worktree, moduledir)
+	cmd.Env = goCmdEnv(offline)
+	err = cmd.Run()
+
+	var ee *exec.ExitError
+	if errors.As(err, &ee) {
+		return false, nil
+	}
+	return err == nil, errors.Wrapf(err, "running %s", cmd)
+}
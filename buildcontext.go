@@ -0,0 +1,39 @@
+package taggo
+
+import "strings"
+
+// BuildContext holds the build constraints under which Modver loads a module's packages
+// when comparing two versions of it.
+// API that exists only behind build tags,
+// or only on some platforms,
+// is invisible to the comparison without them.
+// The zero BuildContext means the defaults of the go command:
+// no extra tags and the host's GOOS and GOARCH.
+type BuildContext struct {
+	// Tags are build tags to satisfy, as in "go build -tags".
+	Tags []string `yaml:"tags"`
+
+	// GOOS and GOARCH, if set, are the target operating system and architecture.
+	GOOS   string `yaml:"goos"`
+	GOARCH string `yaml:"goarch"`
+}
+
+func (bc BuildContext) isSet() bool {
+	return len(bc.Tags) > 0 || bc.GOOS != "" || bc.GOARCH != ""
+}
+
+// String describes bc in go-command terms,
+// as in "GOOS=linux GOARCH=arm64 -tags=integration".
+func (bc BuildContext) String() string {
+	var parts []string
+	if bc.GOOS != "" {
+		parts = append(parts, "GOOS="+bc.GOOS)
+	}
+	if bc.GOARCH != "" {
+		parts = append(parts, "GOARCH="+bc.GOARCH)
+	}
+	if len(bc.Tags) > 0 {
+		parts = append(parts, "-tags="+strings.Join(bc.Tags, ","))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,62 @@
+package taggo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"text/template"
+	"time"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo/gitutil"
+)
+
+// buildMetadataVars is the data available to a Config.BuildMetadata template.
+type buildMetadataVars struct {
+	Commit      string    // full hash of the commit being tagged
+	ShortCommit string    // first 7 hex digits of Commit
+	Time        time.Time // commit time, in UTC
+	Date        string    // commit date, as YYYYMMDD
+}
+
+var buildMetadataRegex = regexp.MustCompile(`^[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*$`)
+
+// buildMetadata expands the build-metadata template tmpl for commit.
+func buildMetadata(ctx context.Context, git, repodir, tmpl, commit string) (string, error) {
+	t, err := template.New("").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", errors.Wrap(err, "parsing build-metadata template")
+	}
+
+	s, err := gitutil.CommitTime(ctx, git, repodir, commit)
+	if err != nil {
+		return "", errors.Wrapf(err, "getting commit time of %s", commit)
+	}
+	when, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing commit time %s", s)
+	}
+	when = when.UTC()
+
+	vars := buildMetadataVars{
+		Commit:      commit,
+		ShortCommit: commit,
+		Time:        when,
+		Date:        when.Format("20060102"),
+	}
+	if len(vars.ShortCommit) > 7 {
+		vars.ShortCommit = vars.ShortCommit[:7]
+	}
+
+	buf := new(bytes.Buffer)
+	if err := t.Execute(buf, vars); err != nil {
+		return "", errors.Wrap(err, "expanding build-metadata template")
+	}
+	result := buf.String()
+	if !buildMetadataRegex.MatchString(result) {
+		return "", fmt.Errorf("build metadata %q is not dot-separated identifiers of letters, digits, and hyphens", result)
+	}
+	return result, nil
+}
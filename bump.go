@@ -0,0 +1,103 @@
+package taggo
+
+import (
+	"fmt"
+
+	"github.com/bobg/modver/v2"
+)
+
+// Possible values for the settings in a [BumpConfig].
+const (
+	BumpMajor = "major"
+	BumpMinor = "minor"
+	BumpPatch = "patch"
+)
+
+// BumpConfig is a policy mapping the kinds of change that Modver reports
+// to the version bumps that Taggo recommends for them,
+// for teams whose semantic-versioning conventions differ from the usual ones.
+// Each setting is [BumpMajor], [BumpMinor], or [BumpPatch].
+// An empty setting means the usual bump for that kind of change.
+//
+// For example, Major set to BumpMinor means Taggo never recommends a new major version,
+// and Unstable.Major set to BumpMinor means that breaking changes bump the minor version
+// while the major version is 0.
+//
+// See also Config.GoDirectiveBump.
+type BumpConfig struct {
+	BumpMap `yaml:",inline"`
+
+	// Unstable overrides the settings in BumpMap
+	// while the latest version's major version is 0.
+	Unstable BumpMap `yaml:"unstable"`
+}
+
+// BumpMap holds the settings of a [BumpConfig].
+type BumpMap struct {
+	// Major is the bump for breaking changes.
+	Major string `yaml:"major"`
+
+	// Minor is the bump for additions to the API.
+	Minor string `yaml:"minor"`
+
+	// Patch is the bump for changes that leave the API alone.
+	Patch string `yaml:"patch"`
+}
+
+// setting is the setting in m for the given Modver result code.
+func (m BumpMap) setting(code modver.ResultCode) string {
+	switch code {
+	case modver.Major:
+		return m.Major
+	case modver.Minor:
+		return m.Minor
+	case modver.Patchlevel:
+		return m.Patch
+	}
+	return ""
+}
+
+func (m BumpMap) validate() error {
+	for _, s := range []string{m.Major, m.Minor, m.Patch} {
+		switch s {
+		case "", BumpMajor, BumpMinor, BumpPatch:
+		default:
+			return fmt.Errorf("unknown bump %q (want %s, %s, or %s)", s, BumpMajor, BumpMinor, BumpPatch)
+		}
+	}
+	return nil
+}
+
+// apply returns the Modver result code whose usual bump c chooses
+// for changes of the kind in code,
+// when the latest version's major version is latestMajor.
+func (c BumpConfig) apply(code modver.ResultCode, latestMajor int) modver.ResultCode {
+	s := c.setting(code)
+	if latestMajor == 0 {
+		if u := c.Unstable.setting(code); u != "" {
+			s = u
+		}
+	}
+	switch s {
+	case BumpMajor:
+		return modver.Major
+	case BumpMinor:
+		return modver.Minor
+	case BumpPatch:
+		return modver.Patchlevel
+	}
+	return code
+}
+
+// bumpName is the name of the version bump for a Modver result code.
+func bumpName(code modver.ResultCode) string {
+	switch code {
+	case modver.Major:
+		return BumpMajor
+	case modver.Minor:
+		return BumpMinor
+	case modver.Patchlevel:
+		return BumpPatch
+	}
+	return "no"
+}
@@ -0,0 +1,117 @@
+package taggo_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bobg/modver/v2"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/internal/testutil"
+)
+
+func TestBumpPolicy(t *testing.T) {
+	repodir := cloneBundle(t, "unstable")
+
+	commit := func(src, msg string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repodir, "x.go"), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+		testutil.Git(t, repodir, "add", "x.go")
+		testutil.Git(t, repodir, "commit", "-q", "-m", msg)
+	}
+
+	commit("package x\n\nfunc F() {}\n", "add F")
+	testutil.Git(t, repodir, "tag", "v0.2.0")
+	commit("package x\n\nfunc G() {}\n", "replace F with G") // a breaking change
+
+	cases := []struct {
+		name       string
+		bump       taggo.BumpConfig
+		want       string
+		wantPolicy modver.ResultCode
+		wantMsg    string // of the bump-policy finding, if any
+	}{{
+		name: "default",
+		want: "v1.0.0",
+	}, {
+		name:       "unstable-minor",
+		bump:       taggo.BumpConfig{Unstable: taggo.BumpMap{Major: taggo.BumpMinor}},
+		want:       "v0.3.0",
+		wantPolicy: modver.Minor,
+		wantMsg:    "Bump policy calls for a minor-version bump for major changes",
+	}, {
+		name:       "unstable-overrides",
+		bump:       taggo.BumpConfig{BumpMap: taggo.BumpMap{Major: taggo.BumpPatch}, Unstable: taggo.BumpMap{Major: taggo.BumpMinor}},
+		want:       "v0.3.0",
+		wantPolicy: modver.Minor,
+		wantMsg:    "Bump policy calls for a minor-version bump for major changes",
+	}, {
+		name:       "never-major",
+		bump:       taggo.BumpConfig{BumpMap: taggo.BumpMap{Major: taggo.BumpPatch}},
+		want:       "v0.2.1",
+		wantPolicy: modver.Patchlevel,
+		wantMsg:    "Bump policy calls for a patch-version bump for major changes",
+	}, {
+		name: "other-kinds",
+		bump: taggo.BumpConfig{BumpMap: taggo.BumpMap{Minor: taggo.BumpPatch}},
+		want: "v1.0.0",
+	}}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			config := taggo.Config{Bump: c.bump}
+			result, err := taggo.Check(context.Background(), "", repodir, "", taggo.WithConfig(config))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.ModverResultCode != modver.Major {
+				t.Fatalf("got Modver result %s, want Major", result.ModverResultCode)
+			}
+			if got := result.NewVersion(); got != c.want {
+				t.Errorf("got new version %s, want %s", got, c.want)
+			}
+			if result.PolicyBump != c.wantPolicy {
+				t.Errorf("got policy bump %s, want %s", result.PolicyBump, c.wantPolicy)
+			}
+
+			var msg string
+			for _, f := range result.Findings() {
+				if f.ID == "bump-policy" {
+					msg = f.Message
+				}
+			}
+			if msg != c.wantMsg {
+				t.Errorf("got bump-policy finding %q, want %q", msg, c.wantMsg)
+			}
+		})
+	}
+}
+
+func TestLoadBumpConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	data := []byte("bump:\n  major: minor\n  unstable:\n    minor: patch\n")
+	if err := os.WriteFile(filepath.Join(dir, taggo.ConfigFile), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	config, err := taggo.LoadConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := taggo.BumpConfig{BumpMap: taggo.BumpMap{Major: taggo.BumpMinor}, Unstable: taggo.BumpMap{Minor: taggo.BumpPatch}}
+	if config.Bump != want {
+		t.Errorf("got bump config %+v, want %+v", config.Bump, want)
+	}
+
+	data = []byte("bump:\n  major: huge\n")
+	if err := os.WriteFile(filepath.Join(dir, taggo.ConfigFile), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := taggo.LoadConfig(dir); err == nil {
+		t.Error("got no error for unknown bump, want one")
+	}
+}
@@ -0,0 +1,45 @@
+package taggo
+
+import (
+	"fmt"
+	"io"
+)
+
+// ChecklistItem is one item in a release checklist.
+type ChecklistItem struct {
+	Text string
+	Done bool
+
+	// Manual is true for items that come from the configuration file
+	// rather than from Taggo's own findings.
+	Manual bool
+}
+
+// Checklist returns a release checklist for the module described by r.
+// It contains one item for each warning or OK finding,
+// checked if the finding is OK.
+// Callers may append manual items, e.g. from [Config.Checklist].
+func (r Result) Checklist() []ChecklistItem {
+	var items []ChecklistItem
+	for _, f := range r.Findings() {
+		if f.Kind == FindingInfo {
+			continue
+		}
+		items = append(items, ChecklistItem{Text: f.Message, Done: f.Kind == FindingOK})
+	}
+	return items
+}
+
+// WriteChecklist writes items to w as a Markdown task list.
+func WriteChecklist(w io.Writer, items []ChecklistItem) error {
+	for _, item := range items {
+		box := " "
+		if item.Done {
+			box = "x"
+		}
+		if _, err := fmt.Fprintf(w, "- [%s] %s\n", box, item.Text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package taggo_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bobg/taggo"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	config, err := taggo.LoadConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Checklist) != 0 {
+		t.Errorf("got checklist %v from missing config file, want none", config.Checklist)
+	}
+
+	data := []byte("checklist:\n  - Update the docs site\n  - Announce the release\n")
+	if err := os.WriteFile(filepath.Join(dir, taggo.ConfigFile), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	config, err = taggo.LoadConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"Update the docs site", "Announce the release"}
+	if diff := cmp.Diff(want, config.Checklist); diff != "" {
+		t.Errorf("checklist mismatch (-want +got):\n%s", diff)
+	}
+
+	for _, bad := range []string{
+		"policy: lockstepp\n",
+		"goDirectiveBump: major\n",
+		"rules:\n  - name: r\n    severity: fatal\n",
+		"commit:\n  message: \"Update {{.Tags\"\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, taggo.ConfigFile), []byte(bad), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := taggo.LoadConfig(dir); err == nil {
+			t.Errorf("got no error for config %q", bad)
+		}
+	}
+}
+
+func TestChecklist(t *testing.T) {
+	r := taggo.Result{
+		Modpath:       "example.com/x",
+		DefaultBranch: "main",
+		LatestCommit:  "abc123",
+	}
+
+	items := append(r.Checklist(), taggo.ChecklistItem{Text: "Update the docs site", Manual: true})
+
+	buf := new(bytes.Buffer)
+	if err := taggo.WriteChecklist(buf, items); err != nil {
+		t.Fatal(err)
+	}
+
+	const want = `- [x] Default branch: main
+- [ ] No version tags
+- [ ] Update the docs site
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("checklist mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,92 @@
+package taggo
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo/gitutil"
+)
+
+// CleanOptions control what [CheckClean] counts as uncommitted changes.
+// The zero value counts every change, staged or not, and every untracked file.
+type CleanOptions struct {
+	// IgnoreUntracked causes untracked files to be ignored.
+	IgnoreUntracked bool
+
+	// IgnorePaths lists patterns for paths to ignore,
+	// relative to the repository root,
+	// in the syntax of Config.Ignore:
+	// a pattern without a slash matches file names in any directory,
+	// and a pattern ending in "/..." matches a directory and everything below it.
+	IgnorePaths []string
+
+	// IndexOnly causes only staged changes to count,
+	// ignoring changes in the working tree and untracked files.
+	IndexOnly bool
+}
+
+// UncleanError is the error returned by [CheckClean] for a repository with uncommitted changes.
+type UncleanError struct {
+	// Paths lists the changed files, relative to the repository root.
+	Paths []string
+}
+
+func (e *UncleanError) Error() string {
+	return fmt.Sprintf("repository is not clean: %s", abbrevList(e.Paths, 3))
+}
+
+// CheckClean checks that the repository in repodir has no uncommitted changes,
+// as is required before tagging a release.
+// If it has some, the error is an [*UncleanError].
+// If git is "", CheckClean uses the result of exec.LookPath("git").
+func CheckClean(ctx context.Context, git, repodir string, opts CleanOptions) error {
+	if git == "" {
+		var err error
+		git, err = exec.LookPath("git")
+		if err != nil {
+			return errors.Wrap(err, "finding git binary")
+		}
+	}
+
+	cmd, done := gitutil.Command(ctx, git, repodir, "status", "--porcelain", "-z")
+	output, err := cmd.Output()
+	if err = done(err); err != nil {
+		return errors.Wrapf(err, "running %s", cmd)
+	}
+
+	var paths []string
+	entries := strings.Split(string(output), "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+		index, name := entry[0], entry[3:]
+		if index == 'R' || index == 'C' {
+			// The entry for a rename or copy is followed by one for the original path.
+			i++
+		}
+
+		switch {
+		case index == '?':
+			if opts.IgnoreUntracked || opts.IndexOnly {
+				continue
+			}
+		case opts.IndexOnly && index == ' ':
+			continue
+		}
+		if ignoredBy(opts.IgnorePaths, name, false) {
+			continue
+		}
+		paths = append(paths, name)
+	}
+
+	if len(paths) > 0 {
+		return &UncleanError{Paths: paths}
+	}
+	return nil
+}
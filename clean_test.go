@@ -0,0 +1,70 @@
+package taggo_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/internal/testutil"
+)
+
+func TestCheckClean(t *testing.T) {
+	repodir := t.TempDir()
+
+	write := func(filename, contents string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(repodir, filename)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(repodir, filename), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testutil.Git(t, repodir, "init", "-q", "-b", "main")
+	write("go.mod", "module example.com/x\n\ngo 1.22\n")
+	write("x.go", "package x\n")
+	testutil.Git(t, repodir, "add", ".")
+	testutil.Git(t, repodir, "commit", "-q", "-m", "initial")
+
+	ctx := context.Background()
+
+	check := func(opts taggo.CleanOptions, want ...string) {
+		t.Helper()
+		err := taggo.CheckClean(ctx, "", repodir, opts)
+		if len(want) == 0 {
+			if err != nil {
+				t.Errorf("got error %v, want none", err)
+			}
+			return
+		}
+		var uerr *taggo.UncleanError
+		if !errors.As(err, &uerr) {
+			t.Fatalf("got error %v, want UncleanError", err)
+		}
+		if diff := cmp.Diff(want, uerr.Paths); diff != "" {
+			t.Errorf("unclean paths mismatch (-want +got):\n%s", diff)
+		}
+	}
+
+	check(taggo.CleanOptions{})
+
+	write("notes.txt", "untracked\n")
+	check(taggo.CleanOptions{}, "notes.txt")
+	check(taggo.CleanOptions{IgnoreUntracked: true})
+	check(taggo.CleanOptions{IgnorePaths: []string{"*.txt"}})
+
+	write("x.go", "package x\n\n// X is new.\nfunc X() {}\n")
+	check(taggo.CleanOptions{IgnoreUntracked: true}, "x.go")
+	check(taggo.CleanOptions{IndexOnly: true})
+
+	write("gen/x.pb.go", "package gen\n")
+	testutil.Git(t, repodir, "add", "x.go", "gen/x.pb.go")
+	check(taggo.CleanOptions{IndexOnly: true}, "gen/x.pb.go", "x.go")
+	check(taggo.CleanOptions{IndexOnly: true, IgnorePaths: []string{"gen/..."}}, "x.go")
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo"
+)
+
+// writeAdvisoryStubs writes an OSV advisory stub
+// for each tag that txn added,
+// to a file in the current directory named after the tag.
+func writeAdvisoryStubs(txn *tagTxn) error {
+	now := time.Now()
+
+	for _, tag := range txn.tags {
+		r := txn.results[tag]
+		version := strings.TrimPrefix(tag, r.VersionPrefix)
+
+		var forge *taggo.Forge
+		if f, ok := r.Forge(); ok {
+			forge = &f
+		}
+
+		entry := taggo.AdvisoryStub(r, version, forge, now)
+		data, err := json.MarshalIndent(entry, "", "  ")
+		if err != nil {
+			return errors.Wrapf(err, "encoding advisory stub for %s", tag)
+		}
+
+		filename := strings.ReplaceAll(tag, "/", "_") + ".osv.json"
+		if err := os.WriteFile(filename, append(data, '\n'), 0644); err != nil {
+			return errors.Wrapf(err, "writing %s", filename)
+		}
+
+		fmt.Printf("🛡️ Wrote advisory stub %s; fill it in and submit it at https://go.dev/s/vulndb-report-new\n", filename)
+	}
+
+	return nil
+}
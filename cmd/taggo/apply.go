@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo"
+)
+
+// runApply implements "taggo apply".
+func runApply(ctx context.Context, args []string) error {
+	var (
+		dryRun      bool
+		eventsFile  string
+		file        string
+		git         string
+		lightweight bool
+		localUser   string
+		push        bool
+		remote      string
+		sign        bool
+		fs          = flag.NewFlagSet("apply", flag.ContinueOnError)
+	)
+	fs.BoolVar(&dryRun, "n", false, "validate the plan without creating any tags")
+	fs.StringVar(&eventsFile, "events", "", "append a JSONL log of tags and pushes to this file")
+	fs.StringVar(&file, "f", "-", "read the plan from this file (- for standard input)")
+	fs.StringVar(&git, "git", "", "path to git binary")
+	fs.BoolVar(&lightweight, "lightweight", false, "create lightweight tags instead of annotated ones")
+	fs.StringVar(&localUser, "local-user", "", "sign the new tags with this key (implies -s)")
+	fs.BoolVar(&push, "push", false, "push the new tags to the remote")
+	fs.StringVar(&remote, "remote", "origin", "remote to push to with -push")
+	fs.BoolVar(&sign, "s", false, "sign the new tags")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() > 1 {
+		return fmt.Errorf("usage: %s apply [-events FILE] [-f FILE] [-git GIT] [-lightweight] [-local-user KEY] [-n] [-push] [-remote REMOTE] [-s] [REPODIR]", os.Args[0])
+	}
+	if lightweight && (sign || localUser != "") {
+		return fmt.Errorf("cannot combine -lightweight with -s or -local-user")
+	}
+
+	dir := "."
+	if fs.NArg() == 1 {
+		dir = fs.Arg(0)
+	}
+	repodir, err := findRepo(ctx, git, dir)
+	if err != nil {
+		return errors.Wrap(err, "finding repository directory")
+	}
+	repodir, err = filepath.Abs(repodir)
+	if err != nil {
+		return errors.Wrapf(err, "making %s absolute", repodir)
+	}
+
+	if git == "" {
+		git, err = exec.LookPath("git")
+		if err != nil {
+			return errors.Wrap(err, "finding git binary")
+		}
+	}
+
+	var r io.Reader = os.Stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return errors.Wrapf(err, "opening %s", file)
+		}
+		defer f.Close()
+		r = f
+	}
+	plan, err := taggo.ReadPlan(r)
+	if err != nil {
+		return errors.Wrapf(err, "reading plan from %s", file)
+	}
+	if len(plan.Tags) == 0 {
+		fmt.Println("Plan has no tags")
+		return nil
+	}
+
+	config, err := taggo.LoadConfig(repodir)
+	if err != nil {
+		return errors.Wrap(err, "loading config")
+	}
+
+	var events *eventLog
+	if eventsFile != "" && !dryRun {
+		events, err = openEventLog(eventsFile, repodir)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := events.Close(); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", err)
+			}
+		}()
+	}
+
+	txn := &tagTxn{
+		git:         git,
+		repodir:     repodir,
+		sign:        sign,
+		localUser:   localUser,
+		lightweight: lightweight,
+		events:      events,
+		checkRemote: true,
+	}
+	if push {
+		txn.remote = remote
+	}
+
+	// Validate every tag before creating any.
+	var (
+		invalid int
+		seen    = make(map[string]bool)
+	)
+	for _, pt := range plan.Tags {
+		tag := pt.Tag()
+		if seen[tag] {
+			fmt.Printf("⛔️ %s: appears more than once in the plan\n", tag)
+			invalid++
+			continue
+		}
+		seen[tag] = true
+
+		commit, result, err := taggo.ValidatePlannedTag(ctx, git, repodir, pt, taggo.WithConfig(config))
+		if err != nil {
+			fmt.Printf("⛔️ %s: %s\n", tag, err)
+			invalid++
+			continue
+		}
+		fmt.Printf("✅ %s at %s\n", tag, shortHash(commit))
+		txn.addAt(tag, commit, pt.Message, result)
+	}
+	if invalid > 0 {
+		return exitErr{code: 3, err: fmt.Errorf("will not apply plan: %d of %d tag(s) invalid", invalid, len(plan.Tags))}
+	}
+	if dryRun {
+		return nil
+	}
+
+	return txn.commit(ctx)
+}
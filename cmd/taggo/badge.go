@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo"
+)
+
+// shieldsEndpoint is the JSON schema of a shields.io endpoint badge.
+// See https://shields.io/badges/endpoint-badge.
+type shieldsEndpoint struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// writeBadge writes a shields.io endpoint badge to filename
+// summarizing the warnings in results.
+func writeBadge(filename string, results []taggo.Result) error {
+	var warnings int
+	for _, r := range results {
+		for _, f := range r.Findings() {
+			if f.Kind == taggo.FindingWarning {
+				warnings++
+			}
+		}
+	}
+
+	badge := shieldsEndpoint{
+		SchemaVersion: 1,
+		Label:         "release hygiene",
+	}
+	switch {
+	case warnings == 0:
+		badge.Message, badge.Color = "no warnings", "brightgreen"
+	case warnings == 1:
+		badge.Message, badge.Color = "1 warning", "yellow"
+	case warnings < 5:
+		badge.Message, badge.Color = fmt.Sprintf("%d warnings", warnings), "yellow"
+	default:
+		badge.Message, badge.Color = fmt.Sprintf("%d warnings", warnings), "red"
+	}
+
+	data, err := json.MarshalIndent(badge, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "encoding badge")
+	}
+	err = os.WriteFile(filename, append(data, '\n'), 0644)
+	return errors.Wrapf(err, "writing %s", filename)
+}
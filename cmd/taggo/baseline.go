@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo"
+)
+
+// runBaseline implements "taggo baseline".
+func runBaseline(ctx context.Context, args []string) error {
+	var (
+		all bool
+		git string
+		fs  = flag.NewFlagSet("baseline", flag.ContinueOnError)
+	)
+	fs.BoolVar(&all, "all", false, "record the warnings of all modules in the repository, replacing the whole baseline")
+	fs.StringVar(&git, "git", "", "path to git binary")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var (
+		repodir, moduledir string
+		err                error
+	)
+	switch {
+	case all && fs.NArg() == 0:
+		repodir, err = findRepo(ctx, git, ".")
+	case all && fs.NArg() == 1:
+		repodir = fs.Arg(0)
+	case !all && fs.NArg() == 0:
+		repodir, moduledir, err = determineDirs(ctx, git, ".")
+	case !all && fs.NArg() == 1:
+		repodir, moduledir, err = determineDirs(ctx, git, fs.Arg(0))
+	case !all && fs.NArg() == 2:
+		repodir, moduledir = fs.Arg(0), fs.Arg(1)
+	default:
+		return fmt.Errorf("usage: %s baseline [-all] [-git GIT] [REPODIR] [MODULEDIR]", os.Args[0])
+	}
+	if err != nil {
+		return errors.Wrap(err, "determining directories")
+	}
+
+	config, err := taggo.LoadConfig(repodir)
+	if err != nil {
+		return errors.Wrap(err, "loading config")
+	}
+	opts := []taggo.Option{taggo.WithConfig(config)}
+
+	var results []taggo.Result
+	if all {
+		modules, err := taggo.CheckAll(ctx, git, repodir, opts...)
+		if err != nil {
+			return errors.Wrapf(err, "checking all modules in %s", repodir)
+		}
+		for _, r := range modules {
+			results = append(results, r)
+		}
+	} else {
+		r, err := taggo.Check(ctx, git, repodir, moduledir, opts...)
+		if err != nil {
+			return errors.Wrapf(err, "checking module %s in repository %s", moduledir, repodir)
+		}
+		results = append(results, r)
+	}
+
+	var baseline taggo.Baseline
+	if all {
+		baseline = taggo.NewBaseline(results)
+	} else {
+		existing, err := taggo.LoadBaseline(repodir)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			baseline = *existing
+		}
+		baseline.Update(results)
+	}
+
+	if err := baseline.Write(repodir); err != nil {
+		return err
+	}
+
+	var n int
+	for _, r := range results {
+		for _, f := range r.Findings() {
+			if f.Kind == taggo.FindingWarning {
+				n++
+			}
+		}
+	}
+	fmt.Printf("Recorded %d warning(s) in %s\n", n, taggo.BaselineFile)
+	return nil
+}
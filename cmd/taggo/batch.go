@@ -0,0 +1,304 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/gitutil"
+)
+
+// batchRepo is the outcome of checking all the modules in one repository
+// with "taggo batch".
+type batchRepo struct {
+	// Repository is the repository's directory as given on the command line or in the list file.
+	Repository string
+
+	// Modules holds the results for the repository's modules,
+	// in order of module directory.
+	Modules []batchModule `json:",omitempty"`
+
+	// Error, if set, is why the repository could not be checked.
+	Error string `json:",omitempty"`
+}
+
+// batchModule is the result for one module in a batchRepo.
+type batchModule struct {
+	// Dir is the module's directory,
+	// relative to the repository root.
+	Dir string
+
+	Result taggo.Result
+
+	// Warnings is the number of warnings that count toward the exit status,
+	// not including those in the repository's baseline file.
+	Warnings int
+}
+
+// batchOptions control checkRepos.
+type batchOptions struct {
+	git        string
+	opts       []taggo.Option
+	threshold  taggo.Severity
+	noBaseline bool
+	parallel   int
+	timeout    time.Duration
+
+	// prepare, if set, produces the directory of each repository to check,
+	// which is otherwise found from the name with findRepo.
+	prepare func(ctx context.Context, repo string) (string, error)
+}
+
+// runBatch implements "taggo batch".
+func runBatch(ctx context.Context, args []string) error {
+	var (
+		bo       batchOptions
+		doJSON   bool
+		listFile string
+		offline  bool
+		status   statusFlag
+		fs       = flag.NewFlagSet("batch", flag.ContinueOnError)
+	)
+	fs.StringVar(&listFile, "f", "", "read repository directories from this file, one per line (- for standard input)")
+	fs.StringVar(&bo.git, "git", "", "path to git binary")
+	fs.BoolVar(&doJSON, "json", false, "output in JSON format")
+	fs.BoolVar(&bo.noBaseline, "no-baseline", false, "count all warnings, including those recorded in each repository's baseline file")
+	fs.BoolVar(&offline, "offline", false, "never access the network")
+	fs.IntVar(&bo.parallel, "parallel", 4, "check up to this many repositories at once")
+	fs.Var(&status, "status", "exit with status 2 if there are warnings (with =SEVERITY, only warnings at least that severe: info, warning, or error)")
+	fs.DurationVar(&bo.timeout, "timeout", 0, "limit each git operation to this duration")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	repos := fs.Args()
+	if listFile != "" {
+		listed, err := readRepoList(listFile)
+		if err != nil {
+			return err
+		}
+		repos = append(repos, listed...)
+	}
+	if len(repos) == 0 {
+		return fmt.Errorf("usage: %s batch [-f FILE] [-git GIT] [-json] [-no-baseline] [-offline] [-parallel N] [-status[=SEVERITY]] [-timeout DURATION] [REPODIR ...]", os.Args[0])
+	}
+	if bo.parallel < 1 {
+		return fmt.Errorf("-parallel must be at least 1")
+	}
+
+	if offline {
+		bo.opts = append(bo.opts, taggo.WithOffline())
+	}
+	if bo.timeout > 0 {
+		bo.opts = append(bo.opts, taggo.WithGitTimeout(bo.timeout))
+	}
+	bo.threshold = taggo.Severity(status)
+
+	results := checkRepos(ctx, repos, bo)
+
+	if doJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return errors.Wrap(err, "encoding result")
+		}
+	} else if err := writeBatchTable(os.Stdout, results); err != nil {
+		return errors.Wrap(err, "writing summary")
+	}
+
+	return batchStatus(results, status)
+}
+
+// readRepoList reads the repository directories listed in filename,
+// one per line,
+// skipping blank lines and lines beginning with #.
+// The filename "-" means standard input.
+func readRepoList(filename string) ([]string, error) {
+	var r io.Reader = os.Stdin
+	if filename != "-" {
+		f, err := os.Open(filename)
+		if err != nil {
+			return nil, errors.Wrapf(err, "opening %s", filename)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var (
+		repos []string
+		sc    = bufio.NewScanner(r)
+	)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		repos = append(repos, line)
+	}
+	return repos, errors.Wrapf(sc.Err(), "reading %s", filename)
+}
+
+// checkRepos checks all the modules in each of the given repositories,
+// up to bo.parallel at a time.
+// The results are in the same order as repos.
+// A repository that cannot be checked does not stop the others;
+// its result has an Error instead.
+func checkRepos(ctx context.Context, repos []string, bo batchOptions) []batchRepo {
+	var (
+		results = make([]batchRepo, len(repos))
+		sem     = make(chan struct{}, bo.parallel)
+		wg      sync.WaitGroup
+	)
+	for i, repo := range repos {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			results[i] = checkRepo(ctx, repo, bo)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// checkRepo checks all the modules in one repository for checkRepos.
+func checkRepo(ctx context.Context, repo string, bo batchOptions) batchRepo {
+	result := batchRepo{Repository: repo}
+
+	err := func() error {
+		var (
+			repodir string
+			err     error
+		)
+		if bo.prepare != nil {
+			repodir, err = bo.prepare(ctx, repo)
+		} else {
+			repodir, err = findRepo(gitutil.WithTimeout(ctx, bo.timeout), bo.git, repo)
+		}
+		if err != nil {
+			return errors.Wrap(err, "finding repository directory")
+		}
+		config, err := taggo.LoadConfig(repodir)
+		if err != nil {
+			return errors.Wrap(err, "loading config")
+		}
+		var baseline *taggo.Baseline
+		if !bo.noBaseline {
+			if baseline, err = taggo.LoadBaseline(repodir); err != nil {
+				return errors.Wrap(err, "loading baseline")
+			}
+		}
+
+		opts := append([]taggo.Option{taggo.WithConfig(config)}, bo.opts...)
+		modules, err := taggo.CheckAll(ctx, bo.git, repodir, opts...)
+		if err != nil {
+			return err
+		}
+
+		describeOpts := taggo.DescribeOptions{
+			Quiet:      true,
+			Threshold:  bo.threshold,
+			Severities: config.Severity,
+			Baseline:   baseline,
+		}
+		for dir, r := range modules {
+			rel, err := filepath.Rel(repodir, dir)
+			if err != nil {
+				return errors.Wrapf(err, "getting relative path of %s", dir)
+			}
+			result.Modules = append(result.Modules, batchModule{
+				Dir:      filepath.ToSlash(rel),
+				Result:   r,
+				Warnings: r.DescribeWith(io.Discard, describeOpts),
+			})
+		}
+		slices.SortFunc(result.Modules, func(a, b batchModule) int { return strings.Compare(a.Dir, b.Dir) })
+		return nil
+	}()
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// writeBatchTable writes a table with one line per module in results,
+// followed by any errors and a summary line.
+func writeBatchTable(w io.Writer, results []batchRepo) error {
+	var (
+		modules, recommended, warnings, failed int
+		tw                                     = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	)
+	fmt.Fprintln(tw, "REPOSITORY\tMODULE\tLATEST\tRECOMMENDED\tWARNINGS")
+	for _, repo := range results {
+		if repo.Error != "" {
+			failed++
+			fmt.Fprintf(tw, "%s\t-\t-\t-\terror\n", repo.Repository)
+			continue
+		}
+		for _, m := range repo.Modules {
+			modules++
+			latest, next, ok := summaryVersions(m.Result)
+			if ok {
+				recommended++
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\n", repo.Repository, m.Result.Modpath, latest, next, m.Warnings)
+			warnings += m.Warnings
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		fmt.Fprintln(w)
+		for _, repo := range results {
+			if repo.Error != "" {
+				fmt.Fprintf(w, "%s: %s\n", repo.Repository, repo.Error)
+			}
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "\nChecked %d of %d repositories: %d module(s), %d new version(s) recommended, %d warning(s)\n", len(results)-failed, len(results), modules, recommended, warnings)
+	return err
+}
+
+// batchStatus is the error, if any, that determines the exit status of "taggo batch":
+// exit status 1 if any repository could not be checked,
+// and with -status, exit status 2 if there are warnings.
+func batchStatus(results []batchRepo, status statusFlag) error {
+	var (
+		err            error
+		failed, warned int
+	)
+	for _, repo := range results {
+		if repo.Error != "" {
+			failed++
+		}
+		for _, m := range repo.Modules {
+			warned += m.Warnings
+		}
+	}
+	if failed > 0 {
+		err = fmt.Errorf("%d of %d repositories could not be checked", failed, len(results))
+	}
+	if status != "" && warned > 0 {
+		err = errors.Join(err, exitErr{code: 2, err: fmt.Errorf("warnings found")})
+	}
+	return err
+}
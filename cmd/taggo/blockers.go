@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo"
+)
+
+// runBlockers implements "taggo blockers".
+func runBlockers(ctx context.Context, args []string) error {
+	var (
+		git    string
+		doJSON bool
+		fs     = flag.NewFlagSet("blockers", flag.ContinueOnError)
+	)
+	fs.StringVar(&git, "git", "", "path to git binary")
+	fs.BoolVar(&doJSON, "json", false, "output in JSON format")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var (
+		repodir, moduledir string
+		err                error
+	)
+	switch fs.NArg() {
+	case 0:
+		repodir, moduledir, err = determineDirs(ctx, git, ".")
+	case 1:
+		repodir, moduledir, err = determineDirs(ctx, git, fs.Arg(0))
+	case 2:
+		repodir, moduledir = fs.Arg(0), fs.Arg(1)
+	default:
+		return fmt.Errorf("usage: %s blockers [-git GIT] [-json] [REPODIR] [MODULEDIR]", os.Args[0])
+	}
+	if err != nil {
+		return errors.Wrap(err, "determining directories")
+	}
+
+	blockers, err := taggo.V1Blockers(ctx, git, repodir, moduledir)
+	if err != nil {
+		return errors.Wrapf(err, "analyzing module %s in repository %s", moduledir, repodir)
+	}
+
+	if doJSON {
+		if blockers == nil {
+			blockers = []taggo.Blocker{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err := enc.Encode(blockers)
+		return errors.Wrap(err, "encoding result")
+	}
+
+	if len(blockers) == 0 {
+		fmt.Println("✅ Nothing found blocking a v1 release")
+		return nil
+	}
+	for _, b := range blockers {
+		fmt.Printf("⛔️ %s\n", b.Message)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo"
+)
+
+// maintenanceBranch creates a branch for maintaining the current major version of the module described by r,
+// if r recommends a new major version.
+// The branch is named release/vN
+// (with any VersionPrefix)
+// and points at the latest version tag.
+// If remote is non-empty, the branch is pushed there.
+func maintenanceBranch(ctx context.Context, git, repodir, remote string, r taggo.Result) error {
+	if r.LatestVersion == "" || r.LatestMajor < 1 || r.NewMajor <= r.LatestMajor {
+		return nil
+	}
+
+	var (
+		branch = fmt.Sprintf("%srelease/v%d", r.VersionPrefix, r.LatestMajor)
+		tag    = r.VersionPrefix + r.LatestVersion
+	)
+
+	cmd := exec.CommandContext(ctx, git, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+	cmd.Dir = repodir
+	if err := cmd.Run(); err == nil {
+		fmt.Printf("🌿 Maintenance branch %s already exists\n", branch)
+		return nil
+	}
+
+	cmd = exec.CommandContext(ctx, git, "branch", branch, tag+"^{commit}")
+	cmd.Dir = repodir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "running %s: %s", cmd, strings.TrimSpace(string(output)))
+	}
+	fmt.Printf("🌿 Created maintenance branch %s at %s\n", branch, tag)
+
+	if remote == "" {
+		return nil
+	}
+
+	cmd = exec.CommandContext(ctx, git, "push", remote, "refs/heads/"+branch)
+	cmd.Dir = repodir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "running %s: %s", cmd, strings.TrimSpace(string(output)))
+	}
+	fmt.Printf("🚀 Pushed maintenance branch %s to %s\n", branch, remote)
+
+	return nil
+}
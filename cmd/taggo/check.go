@@ -0,0 +1,619 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo"
+)
+
+// checker checks one module or all the modules in a repository,
+// reports on them,
+// and adds new version tags as requested by its flags.
+type checker struct {
+	flags checkFlags
+
+	git                string
+	repodir, moduledir string
+	subtree            string // with -all, limit to modules in this subdir of repodir
+
+	config   taggo.Config
+	baseline *taggo.Baseline
+	opts     []taggo.Option
+
+	txn    *tagTxn
+	events *eventLog
+	prompt *prompter
+
+	doColor, doHyperlinks bool
+}
+
+// newChecker loads the configuration and baseline for repodir
+// and prepares to check modules there as directed by f.
+// The caller must call close when done with the checker.
+func newChecker(f checkFlags, repodir, moduledir, subtree string) (*checker, error) {
+	c := &checker{
+		flags:     f,
+		git:       f.git,
+		repodir:   repodir,
+		moduledir: moduledir,
+		subtree:   subtree,
+	}
+
+	var err error
+	c.config, err = taggo.LoadConfig(repodir)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading config")
+	}
+	if f.buildMetadata != "" {
+		c.config.BuildMetadata = f.buildMetadata
+	}
+	if f.releaseMerge != "" {
+		c.config.ReleaseMerge = f.releaseMerge
+	}
+
+	if c.lockstep() && f.add {
+		if !f.all {
+			return nil, fmt.Errorf("with the lockstep policy, use -add with -all")
+		}
+		if subtree != "" {
+			return nil, fmt.Errorf("with the lockstep policy, -add applies to the whole repository, not a subtree")
+		}
+	}
+
+	if !f.noBaseline {
+		c.baseline, err = taggo.LoadBaseline(repodir)
+		if err != nil {
+			return nil, errors.Wrap(err, "loading baseline")
+		}
+	}
+
+	c.opts = c.options()
+
+	if !f.doJSON && !f.doMetrics {
+		c.doHyperlinks, err = useTerminalFeature("hyperlinks", f.hyperlinks)
+		if err != nil {
+			return nil, err
+		}
+		c.doColor, err = useTerminalFeature("color", f.color)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if f.interactive {
+		c.prompt = newPrompter(os.Stdin, os.Stdout)
+	}
+
+	if f.eventsFile != "" {
+		c.events, err = openEventLog(f.eventsFile, repodir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c.txn = &tagTxn{
+		git:         c.git,
+		repodir:     repodir,
+		sign:        f.sign,
+		localUser:   f.localUser,
+		msg:         f.msg,
+		lightweight: f.lightweight,
+		events:      c.events,
+		timeout:     f.timeout,
+		checkRemote: !f.offline,
+	}
+	if f.api {
+		c.txn.api = &http.Client{Timeout: f.timeout}
+	}
+	if f.push {
+		c.txn.remote = c.pushRemote()
+	}
+
+	return c, nil
+}
+
+// close closes the checker's event log, if any.
+func (c *checker) close() {
+	if c.events == nil {
+		return
+	}
+	if err := c.events.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", err)
+	}
+}
+
+func (c *checker) lockstep() bool {
+	return c.config.Policy == taggo.PolicyLockstep
+}
+
+// pushRemote is the remote to push to (and with -lock, to lock).
+func (c *checker) pushRemote() string {
+	if c.flags.remote != "" {
+		return c.flags.remote
+	}
+	return "origin"
+}
+
+// options returns the options for checking modules.
+func (c *checker) options() []taggo.Option {
+	f := c.flags
+
+	opts := []taggo.Option{taggo.WithConfig(c.config)}
+	if f.reqAnnotated {
+		opts = append(opts, taggo.WithAnnotatedTagsRequired())
+	}
+	if f.dependents {
+		opts = append(opts, taggo.WithDependents())
+	}
+	if f.osv {
+		opts = append(opts, taggo.WithOSV())
+	}
+	if f.govulncheck || (f.add && c.config.Govulncheck.Block != "") {
+		opts = append(opts, taggo.WithGovulncheck())
+	}
+	if f.graduation {
+		opts = append(opts, taggo.WithGraduation())
+	}
+	if f.verifyBuilds {
+		opts = append(opts, taggo.WithBuildVerification())
+	}
+	if f.finalize {
+		opts = append(opts, taggo.WithFinalize())
+	}
+	if f.base != "" {
+		opts = append(opts, taggo.WithBase(f.base))
+	}
+	if f.target != "" {
+		opts = append(opts, taggo.WithTarget(f.target))
+	}
+	if f.tags != "" || f.goos != "" || f.goarch != "" {
+		bc := taggo.BuildContext{GOOS: f.goos, GOARCH: f.goarch}
+		if f.tags != "" {
+			bc.Tags = strings.Split(f.tags, ",")
+		}
+		opts = append(opts, taggo.WithBuildContext(bc))
+	}
+	if f.branch != "" {
+		opts = append(opts, taggo.WithBranch(f.branch))
+	}
+	if f.remote != "" {
+		opts = append(opts, taggo.WithRemote(f.remote))
+	}
+	if f.remoteTags || f.lock {
+		// With -lock, tags pushed by the previous lock holder may not have been fetched.
+		opts = append(opts, taggo.WithRemoteTags())
+	}
+	if f.doMetrics {
+		opts = append(opts, taggo.WithReleaseAge())
+	}
+	if f.verbose {
+		opts = append(opts, taggo.WithModverReport(), taggo.WithVersionHistory(), taggo.WithCommitSummaries())
+	}
+	if c.subtree != "" {
+		opts = append(opts, taggo.WithSubtree(c.subtree))
+	}
+	if f.workspace {
+		opts = append(opts, taggo.WithWorkspace())
+	}
+	if f.tidy {
+		opts = append(opts, taggo.WithTidyCheck())
+	}
+	if f.timeout > 0 {
+		opts = append(opts, taggo.WithGitTimeout(f.timeout))
+	}
+	if f.offline {
+		opts = append(opts, taggo.WithOffline())
+	}
+	return opts
+}
+
+// describe reports on r in the requested format
+// and returns the number of warnings.
+func (c *checker) describe(r taggo.Result) (int, error) {
+	f := c.flags
+
+	if !f.checklist {
+		describeOpts := taggo.DescribeOptions{
+			Quiet:      f.quiet,
+			NoEmoji:    f.noEmoji,
+			Color:      c.doColor,
+			Threshold:  taggo.Severity(f.status),
+			Severities: c.config.Severity,
+			Baseline:   c.baseline,
+		}
+		if forge, ok := r.Forge(); ok && c.doHyperlinks {
+			describeOpts.Forge = &forge
+		}
+		if f.verbose {
+			describeOpts.Verbosity = taggo.VerbosityVerbose
+		}
+		if f.summary {
+			// Count the warnings without reporting them.
+			return r.DescribeWith(io.Discard, describeOpts), nil
+		}
+		return r.DescribeWith(os.Stdout, describeOpts), nil
+	}
+	items := r.Checklist()
+	err := taggo.WriteChecklist(os.Stdout, items)
+	var warnings int
+	for _, item := range items {
+		if !item.Done {
+			warnings++
+		}
+	}
+	return warnings, errors.Wrap(err, "writing checklist")
+}
+
+func (c *checker) writeManualItems() error {
+	if !c.flags.checklist {
+		return nil
+	}
+	err := taggo.WriteChecklist(os.Stdout, manualItems(c.config, c.flags.acks))
+	return errors.Wrap(err, "writing checklist")
+}
+
+// checkTaggable reports an error if tag must not be added for r.
+func (c *checker) checkTaggable(tag string, r taggo.Result) error {
+	if len(r.LocalReplaceDirectives) > 0 && !c.flags.allowLocalReplace {
+		return fmt.Errorf("will not add tag %s: go.mod has filesystem replace directives (use -allow-local-replace to override)", tag)
+	}
+	if r.ModpathRemoteMismatch && !c.flags.allowFork {
+		return fmt.Errorf("will not add tag %s: remote %s (%s) does not correspond to module path %s, so it may be a fork whose tags the module's users will not see (use -allow-fork to override)", tag, r.Remote, r.RemoteURL, r.Modpath)
+	}
+	for _, v := range r.RuleViolations {
+		if v.Error {
+			return fmt.Errorf("will not add tag %s: policy rule %s violated: %s", tag, v.Rule, v.Message)
+		}
+	}
+	if failed := r.ReadinessFailures(); len(failed) > 0 {
+		return fmt.Errorf("will not add tag %s: readiness check(s) failed: %s", tag, strings.Join(failed, ", "))
+	}
+	if block := c.config.Govulncheck.Block; block != "" {
+		if vulns := r.BlockingVulnerabilities(block); len(vulns) > 0 {
+			var ids []string
+			for _, v := range vulns {
+				ids = append(ids, v.ID)
+			}
+			return fmt.Errorf("will not add tag %s: reachable vulnerabilities at least %s severity: %s", tag, block, strings.Join(ids, ", "))
+		}
+	}
+	if r.TidyDiff != "" && c.flags.requireTidy {
+		return fmt.Errorf("will not add tag %s: go.mod and/or go.sum are not tidy (run go mod tidy)", tag)
+	}
+	if r.Diverged() {
+		return fmt.Errorf("will not add tag %s: branch %s has diverged from %s/%s", tag, r.DefaultBranch, r.Remote, r.DefaultBranch)
+	}
+	if !r.InSeries(strings.TrimPrefix(tag, r.VersionPrefix)) {
+		return exitErr{code: 3, err: fmt.Errorf("will not add tag %s: it is outside the %s series of branch %s", tag, r.Series, r.Branch)}
+	}
+	return nil
+}
+
+// chooseTag determines the new version tag, if any, to add for r,
+// prompting the user if -interactive was given.
+func (c *checker) chooseTag(r taggo.Result) (string, bool, error) {
+	var (
+		tag string
+		ok  bool
+		err error
+	)
+	if c.prompt != nil {
+		tag, ok, err = c.prompt.ask(r)
+	} else {
+		tag, ok, err = planTag(r)
+	}
+	if err != nil || !ok {
+		return "", false, err
+	}
+	if err := c.checkTaggable(tag, r); err != nil {
+		return "", false, err
+	}
+	return tag, true, nil
+}
+
+// publish adds the tags in c.txn
+// and performs the steps requested to follow that.
+// With -update-requires,
+// modules are the modules of the repository,
+// whose requirements on one another are updated.
+func (c *checker) publish(ctx context.Context, modules map[string]taggo.Result) error {
+	f := c.flags
+
+	if f.updateRequires {
+		if err := checkGoModsClean(ctx, c.txn, modules); err != nil {
+			return err
+		}
+	}
+	if err := c.txn.commit(ctx); err != nil {
+		return err
+	}
+	if f.floating {
+		if err := moveFloatingTags(ctx, c.txn); err != nil {
+			return err
+		}
+	}
+	if f.security {
+		if err := writeAdvisoryStubs(c.txn); err != nil {
+			return err
+		}
+	}
+	if f.updateRequires {
+		if err := commitRequirementUpdates(ctx, c.txn, modules, c.config.Commit); err != nil {
+			return err
+		}
+	}
+	if f.waitProxy > 0 {
+		if err := waitForProxy(ctx, c.txn, taggo.ProxyPoll{Timeout: f.waitProxy, Warm: f.warmProxy}); err != nil {
+			return err
+		}
+	}
+	if f.pkgGoDev {
+		return requestPkgGoDev(ctx, c.txn)
+	}
+	return nil
+}
+
+// finish adds to err an error for exiting with status 2
+// if -status was given and there were warnings.
+func (c *checker) finish(err error, warnings int) error {
+	if c.flags.status != "" && warnings > 0 {
+		err = errors.Join(err, exitErr{code: 2, err: fmt.Errorf("warnings found")})
+	}
+	return err
+}
+
+// checkOne checks the module in c.moduledir.
+func (c *checker) checkOne(ctx context.Context, start time.Time) error {
+	f := c.flags
+
+	c.events.checkStarted(c.moduledir)
+	result, err := taggo.Check(ctx, c.git, c.repodir, c.moduledir, c.opts...)
+	c.events.checkFinished(c.moduledir, result, err)
+	if err != nil {
+		return errors.Wrapf(err, "checking module %s in repository %s", c.moduledir, c.repodir)
+	}
+
+	defer maybeRecordStats(start, []taggo.Result{result})
+
+	if f.badge != "" {
+		if err := writeBadge(f.badge, []taggo.Result{result}); err != nil {
+			return err
+		}
+	}
+
+	if f.doJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err := enc.Encode(result)
+		return errors.Wrap(err, "encoding result")
+	}
+	if f.doMetrics {
+		return writeMetrics(os.Stdout, []taggo.Result{result}, time.Now())
+	}
+
+	warnings, err := c.describe(result)
+	if err != nil {
+		return err
+	}
+	if err := c.writeManualItems(); err != nil {
+		return err
+	}
+
+	if f.maintBranch {
+		if err := maintenanceBranch(ctx, c.git, c.repodir, c.txn.remote, result); err != nil {
+			return errors.Wrap(err, "creating maintenance branch")
+		}
+	}
+
+	if f.add {
+		var (
+			tag string
+			ok  bool
+		)
+		tag, ok, err = c.chooseTag(result)
+		if err == nil && ok {
+			err = requireAcks(c.config, f.acks, c.prompt)
+		}
+		if err == nil && ok {
+			c.txn.add(tag, result)
+			err = c.publish(ctx, nil)
+		}
+	}
+
+	return c.finish(err, warnings)
+}
+
+// checkAll checks all the modules in c.repodir (or c.subtree).
+func (c *checker) checkAll(ctx context.Context, start time.Time) error {
+	f := c.flags
+
+	var (
+		modules  = make(map[string]taggo.Result)
+		first    = true
+		warnings int
+		tagErrs  error
+
+		// With -summary, the modules in the order they were reported.
+		summaryModules []batchModule
+
+		// With text output in path order,
+		// each module is reported as soon as it is checked.
+		streaming = !f.doJSON && !f.doMetrics && f.sortBy == "path"
+	)
+
+	// report reports on one module in text format,
+	// and creates its maintenance branch and chooses its new tag as requested.
+	report := func(mdir string, result taggo.Result) error {
+		if !f.summary {
+			if first {
+				first = false
+			} else {
+				fmt.Println()
+			}
+			fmt.Printf("%s:\n\n", mdir)
+		}
+		w, err := c.describe(result)
+		if err != nil {
+			return err
+		}
+		warnings += w
+
+		if f.summary {
+			rel, err := filepath.Rel(c.repodir, mdir)
+			if err != nil {
+				return errors.Wrapf(err, "getting relative path of %s", mdir)
+			}
+			summaryModules = append(summaryModules, batchModule{Dir: filepath.ToSlash(rel), Result: result, Warnings: w})
+		}
+
+		if f.maintBranch {
+			if err := maintenanceBranch(ctx, c.git, c.repodir, c.txn.remote, result); err != nil {
+				return errors.Wrapf(err, "creating maintenance branch for module %s", mdir)
+			}
+		}
+
+		if f.add && !c.lockstep() {
+			tag, ok, err := c.chooseTag(result)
+			var skip skipTagErr
+			if errors.As(err, &skip) {
+				fmt.Fprintf(os.Stderr, "Warning: not tagging module %s: %s\n", mdir, skip)
+			} else if err != nil {
+				tagErrs = errors.Join(tagErrs, errors.Wrapf(err, "adding tag to module %s", mdir))
+			} else if ok {
+				c.txn.add(tag, result)
+			}
+		}
+		return nil
+	}
+
+	c.events.checkStarted("")
+	err := taggo.CheckAllFunc(ctx, c.git, c.repodir, func(mdir string, result taggo.Result, err error) error {
+		c.events.checkFinished(mdir, result, err)
+		if err != nil {
+			return errors.Wrapf(err, "checking module %s", mdir)
+		}
+		modules[mdir] = result
+		if !streaming {
+			return nil
+		}
+		return report(mdir, result)
+	}, c.opts...)
+	if err != nil {
+		return errors.Wrapf(err, "checking all modules in %s", c.repodir)
+	}
+
+	dirs := make([]string, 0, len(modules))
+	for mdir := range modules {
+		dirs = append(dirs, mdir)
+	}
+	sortModuleDirs(dirs, modules, f.sortBy, c.config.Severity, c.baseline)
+
+	results := make([]taggo.Result, 0, len(dirs))
+	for _, mdir := range dirs {
+		results = append(results, modules[mdir])
+	}
+	defer maybeRecordStats(start, results)
+
+	if f.badge != "" {
+		if err := writeBadge(f.badge, results); err != nil {
+			return err
+		}
+	}
+
+	if f.doJSON {
+		err := writeModulesJSON(os.Stdout, modules, dirs)
+		return errors.Wrap(err, "encoding result")
+	}
+	if f.doMetrics {
+		return writeMetrics(os.Stdout, results, time.Now())
+	}
+	if !streaming {
+		for _, mdir := range dirs {
+			if err := report(mdir, modules[mdir]); err != nil {
+				return err
+			}
+		}
+	}
+
+	var (
+		policyReport bytes.Buffer
+		repoWarnings int
+	)
+
+	if f.workspace {
+		ws, err := taggo.ReadWorkspace(c.repodir)
+		if err != nil {
+			return errors.Wrap(err, "reading go.work")
+		}
+		repoWarnings += describeWorkspace(&policyReport, ws)
+	}
+
+	if len(modules) > 1 {
+		inf, err := taggo.InferPolicy(ctx, c.git, c.repodir, dirs)
+		if err != nil {
+			return errors.Wrap(err, "inferring release policy")
+		}
+		repoWarnings += describePolicy(&policyReport, inf, c.config.Policy, f.quiet)
+		describeUntagged(&policyReport, modules, f.quiet)
+
+		props, err := taggo.PlanPropagation(c.repodir, modules)
+		if err != nil {
+			return errors.Wrap(err, "finding requirements between modules")
+		}
+		repoWarnings += describePropagation(&policyReport, props)
+	}
+
+	var plan taggo.LockstepPlan
+	if c.lockstep() {
+		plan = taggo.PlanLockstep(modules)
+		repoWarnings += describeLockstep(&policyReport, plan, f.quiet)
+	}
+
+	// Repository-wide warnings have severity "warning".
+	if f.status.counts(taggo.SeverityWarning) {
+		warnings += repoWarnings
+	}
+
+	if f.summary {
+		if err := writeSummaryTable(os.Stdout, summaryModules); err != nil {
+			return errors.Wrap(err, "writing summary")
+		}
+	}
+	if policyReport.Len() > 0 {
+		fmt.Printf("\n%s", policyReport.Bytes())
+	}
+
+	if c.lockstep() && f.add {
+		tagErrs = addLockstepTags(c.txn, modules, plan, c.checkTaggable, c.prompt)
+	}
+
+	if len(c.config.Checklist) > 0 && f.checklist {
+		fmt.Printf("\nRelease checklist:\n\n")
+		if err := c.writeManualItems(); err != nil {
+			return err
+		}
+	}
+
+	// Tags for all modules are added together or not at all.
+	if tagErrs == nil && len(c.txn.tags) > 0 {
+		tagErrs = requireAcks(c.config, f.acks, c.prompt)
+	}
+	if tagErrs != nil {
+		err = errors.Join(tagErrs, fmt.Errorf("no tags added"))
+	} else if f.add {
+		err = c.publish(ctx, modules)
+	}
+
+	return c.finish(err, warnings)
+}
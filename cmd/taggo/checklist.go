@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bobg/taggo"
+)
+
+// ackFlag is the type of the repeatable -ack flag.
+// Each value is "all",
+// the 1-based number of a manual checklist item,
+// or the text of one.
+type ackFlag []string
+
+func (a *ackFlag) String() string {
+	return strings.Join(*a, ",")
+}
+
+func (a *ackFlag) Set(val string) error {
+	*a = append(*a, val)
+	return nil
+}
+
+// acked tells whether the manual checklist item with index i (0-based) and the given text
+// is acknowledged by a.
+func (a ackFlag) acked(i int, text string) bool {
+	for _, val := range a {
+		if val == "all" || val == text {
+			return true
+		}
+		if n, err := strconv.Atoi(val); err == nil && n == i+1 {
+			return true
+		}
+	}
+	return false
+}
+
+// manualItems returns the manual checklist items in config,
+// with those acknowledged by acks checked.
+func manualItems(config taggo.Config, acks ackFlag) []taggo.ChecklistItem {
+	var items []taggo.ChecklistItem
+	for i, text := range config.Checklist {
+		items = append(items, taggo.ChecklistItem{Text: text, Done: acks.acked(i, text), Manual: true})
+	}
+	return items
+}
+
+// requireAcks returns an error unless all the manual checklist items in config are acknowledged,
+// either by acks or, if prompt is not nil, interactively.
+func requireAcks(config taggo.Config, acks ackFlag, prompt *prompter) error {
+	var missing []string
+	for i, item := range manualItems(config, acks) {
+		if item.Done {
+			continue
+		}
+		if prompt != nil {
+			ok, err := prompt.confirm(fmt.Sprintf("Checklist item %d: %s. Done?", i+1, item.Text))
+			if err != nil {
+				return err
+			}
+			if ok {
+				continue
+			}
+		}
+		missing = append(missing, fmt.Sprintf("%d (%s)", i+1, item.Text))
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("checklist item(s) not acknowledged: %s; use -ack", strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo"
+)
+
+// Event types in the -events log.
+const (
+	eventCheckStarted  = "check-started"
+	eventCheckFinished = "check-finished"
+	eventWarning       = "warning"
+	eventTagCreated    = "tag-created"
+	eventTagDeleted    = "tag-deleted"
+	eventPushAttempted = "push-attempted"
+)
+
+// event is one line of the -events log.
+type event struct {
+	Time    time.Time `json:"time"`
+	Run     string    `json:"run"` // identifies the taggo invocation
+	Seq     int       `json:"seq"` // orders the events of a run
+	Type    string    `json:"type"`
+	Repo    string    `json:"repo,omitempty"`
+	Module  string    `json:"module,omitempty"`
+	Finding string    `json:"finding,omitempty"`
+	Message string    `json:"message,omitempty"`
+	Tag     string    `json:"tag,omitempty"`
+	Tags    []string  `json:"tags,omitempty"`
+	Commit  string    `json:"commit,omitempty"`
+	Remote  string    `json:"remote,omitempty"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// eventLog appends events to a JSONL file.
+// A nil *eventLog discards events.
+type eventLog struct {
+	mu   sync.Mutex
+	f    *os.File
+	enc  *json.Encoder
+	run  string
+	seq  int
+	err  error // first write error, if any
+	repo string
+}
+
+func openEventLog(filename, repodir string) (*eventLog, error) {
+	var id [8]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, errors.Wrap(err, "generating run ID")
+	}
+	repodir, err := filepath.Abs(repodir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "making %s absolute", repodir)
+	}
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening %s", filename)
+	}
+	return &eventLog{
+		f:    f,
+		enc:  json.NewEncoder(f),
+		run:  hex.EncodeToString(id[:]),
+		repo: repodir,
+	}, nil
+}
+
+// Close closes the log,
+// reporting the first error from writing to it, if any.
+func (l *eventLog) Close() error {
+	if l == nil {
+		return nil
+	}
+	err := l.f.Close()
+	if l.err != nil {
+		return errors.Wrap(l.err, "writing event log")
+	}
+	return errors.Wrap(err, "closing event log")
+}
+
+func (l *eventLog) emit(e event) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.seq++
+	e.Time, e.Run, e.Seq, e.Repo = time.Now().UTC(), l.run, l.seq, l.repo
+	if err := l.enc.Encode(e); err != nil && l.err == nil {
+		l.err = err
+	}
+}
+
+func (l *eventLog) checkStarted(moduledir string) {
+	l.emit(event{Type: eventCheckStarted, Module: moduledir})
+}
+
+// checkFinished records the end of a check of the module in moduledir,
+// and the warnings in its result.
+func (l *eventLog) checkFinished(moduledir string, r taggo.Result, err error) {
+	e := event{Type: eventCheckFinished, Module: moduledir}
+	if err != nil {
+		e.Error = err.Error()
+		l.emit(e)
+		return
+	}
+	l.emit(e)
+	for _, f := range r.Findings() {
+		if f.Kind == taggo.FindingWarning {
+			l.emit(event{Type: eventWarning, Module: moduledir, Finding: f.ID, Message: f.Message})
+		}
+	}
+}
+
+func (l *eventLog) tagCreated(tag, commit string, err error) {
+	e := event{Type: eventTagCreated, Tag: tag, Commit: commit}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	l.emit(e)
+}
+
+// tagDeleted records the deletion of tag,
+// from remote if that is non-empty,
+// otherwise locally.
+func (l *eventLog) tagDeleted(tag, remote string, err error) {
+	e := event{Type: eventTagDeleted, Tag: tag, Remote: remote}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	l.emit(e)
+}
+
+func (l *eventLog) pushAttempted(remote string, tags []string, err error) {
+	e := event{Type: eventPushAttempted, Remote: remote, Tags: tags}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	l.emit(e)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo"
+)
+
+// runFix implements "taggo fix".
+func runFix(ctx context.Context, args []string) error {
+	var (
+		dryRun bool
+		git    string
+		push   bool
+		remote string
+		fs     = flag.NewFlagSet("fix", flag.ContinueOnError)
+	)
+	fs.BoolVar(&dryRun, "n", false, "report the tags to add without adding them")
+	fs.StringVar(&git, "git", "", "path to git binary")
+	fs.BoolVar(&push, "push", false, "push the new tags to the remote")
+	fs.StringVar(&remote, "remote", "", "remote to push to with -push (default: the module's remote, or origin)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var (
+		repodir, moduledir string
+		err                error
+	)
+	switch fs.NArg() {
+	case 0:
+		repodir, moduledir, err = determineDirs(ctx, git, ".")
+	case 1:
+		repodir, moduledir, err = determineDirs(ctx, git, fs.Arg(0))
+	case 2:
+		repodir, moduledir = fs.Arg(0), fs.Arg(1)
+	default:
+		return fmt.Errorf("usage: %s fix [-git GIT] [-n] [-push] [-remote REMOTE] [REPODIR] [MODULEDIR]", os.Args[0])
+	}
+	if err != nil {
+		return errors.Wrap(err, "determining directories")
+	}
+
+	if git == "" {
+		git, err = exec.LookPath("git")
+		if err != nil {
+			return errors.Wrap(err, "finding git binary")
+		}
+	}
+
+	config, err := taggo.LoadConfig(repodir)
+	if err != nil {
+		return errors.Wrap(err, "loading config")
+	}
+	result, err := taggo.Check(ctx, git, repodir, moduledir, taggo.WithConfig(config))
+	if err != nil {
+		return errors.Wrapf(err, "checking module %s in repository %s", moduledir, repodir)
+	}
+
+	if len(result.MalformedVersionTags) == 0 {
+		fmt.Println("✅ No malformed version tags")
+		return nil
+	}
+
+	txn := &tagTxn{
+		git:         git,
+		repodir:     repodir,
+		checkRemote: push,
+	}
+	if push {
+		txn.remote = remote
+		if txn.remote == "" {
+			txn.remote = result.Remote
+		}
+		if txn.remote == "" {
+			txn.remote = "origin"
+		}
+	}
+
+	for _, m := range result.MalformedVersionTags {
+		tag := result.VersionPrefix + m.Version
+		if dryRun {
+			fmt.Printf("Would add tag %s at %s, duplicating %s\n", tag, shortHash(m.Commit), m.Tag)
+			continue
+		}
+		txn.addAt(tag, m.Commit, "", result)
+	}
+
+	return txn.commit(ctx)
+}
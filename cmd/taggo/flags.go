@@ -0,0 +1,270 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+)
+
+// checkFlags are the command-line flags for checking modules,
+// i.e. for taggo without a subcommand.
+type checkFlags struct {
+	// Modules to check.
+	all        bool
+	modpath    string
+	workspace  bool
+	branch     string
+	target     string
+	base       string
+	remote     string
+	remoteTags bool
+
+	// Checks.
+	allowFork         bool
+	allowLocalReplace bool
+	buildMetadata     string
+	dependents        bool
+	finalize          bool
+	goarch            string
+	goos              string
+	govulncheck       bool
+	graduation        bool
+	noBaseline        bool
+	offline           bool
+	osv               bool
+	releaseMerge      string
+	reqAnnotated      bool
+	requireTidy       bool
+	tags              string
+	tidy              bool
+	timeout           time.Duration
+	verifyBuilds      bool
+
+	// Output.
+	badge      string
+	checklist  bool
+	color      string
+	eventsFile string
+	format     string
+	hyperlinks string
+	doJSON     bool
+	doMetrics  bool // set from format
+	noEmoji    bool
+	quiet      bool
+	sortBy     string
+	status     statusFlag
+	summary    bool
+	verbose    bool
+
+	// Tagging.
+	acks           ackFlag
+	add            bool
+	api            bool
+	floating       bool
+	interactive    bool
+	lightweight    bool
+	localUser      string
+	lock           bool
+	maintBranch    bool
+	msg            string
+	pkgGoDev       bool
+	push           bool
+	security       bool
+	sign           bool
+	updateRequires bool
+	waitProxy      time.Duration
+	warmProxy      bool
+
+	git string
+}
+
+// define defines the flags in fs.
+func (f *checkFlags) define(fs *flag.FlagSet) {
+	fs.Var(&f.acks, "ack", "acknowledge a manual checklist item (by number or text, or \"all\"); may be repeated")
+	fs.BoolVar(&f.add, "add", false, "add any recommended new version tag to the repository")
+	fs.BoolVar(&f.api, "api", false, "with -add, create tags through the GitHub, GitLab, or Codeberg web API (token from GITHUB_TOKEN, GITLAB_TOKEN, or CODEBERG_TOKEN) instead of git")
+	fs.BoolVar(&f.allowFork, "allow-fork", false, "with -add, add tags even if the remote appears to be a fork of the module's repository")
+	fs.BoolVar(&f.allowLocalReplace, "allow-local-replace", false, "with -add, add tags even if go.mod has filesystem replace directives")
+	fs.BoolVar(&f.all, "all", false, "check all modules in the repository")
+	fs.StringVar(&f.badge, "badge", "", "also write a shields.io endpoint badge summarizing the warnings to this `file`")
+	fs.StringVar(&f.base, "base", "", "compare with this Git `ref` instead of the latest version tag to determine the needed version bump")
+	fs.StringVar(&f.branch, "branch", "", "analyze this `branch` (e.g. a maintenance branch like release/v1) instead of the default branch")
+	fs.StringVar(&f.buildMetadata, "build-metadata", "", "`template` for build metadata to append to new version tags (overrides the config file)")
+	fs.BoolVar(&f.checklist, "checklist", false, "output a Markdown release checklist instead of the usual report")
+	fs.StringVar(&f.color, "color", "auto", "color warnings and OK findings: auto, always, or never")
+	fs.BoolVar(&f.dependents, "dependents", false, "when recommending a major version bump, report known dependents from deps.dev (requires network)")
+	fs.BoolVar(&f.doJSON, "json", false, "output in JSON format (same as -format json)")
+	fs.StringVar(&f.eventsFile, "events", "", "append a JSONL log of checks, warnings, tags, and pushes to this `file`")
+	fs.BoolVar(&f.finalize, "finalize", false, "when the latest version is a prerelease, recommend the final release instead of the next prerelease")
+	fs.BoolVar(&f.floating, "floating", false, "with -add, also create or move the floating tags vX and vX.Y to each new release, as for GitHub Actions (pushed with -push)")
+	fs.StringVar(&f.format, "format", "text", "output format: text, json, or openmetrics")
+	fs.StringVar(&f.git, "git", "", "path to git binary")
+	fs.StringVar(&f.goarch, "goarch", "", "GOARCH under which to compare the module's API (overrides the config file)")
+	fs.StringVar(&f.goos, "goos", "", "GOOS under which to compare the module's API (overrides the config file)")
+	fs.BoolVar(&f.govulncheck, "govulncheck", false, "run govulncheck on the commit to be tagged and report reachable vulnerabilities (requires govulncheck and network)")
+	fs.BoolVar(&f.graduation, "graduation", false, "for a v0 module, evaluate readiness for v1.0.0 and suggest it when ready")
+	fs.StringVar(&f.hyperlinks, "hyperlinks", "auto", "render hashes and tags as terminal hyperlinks to the forge: auto, always, or never")
+	fs.BoolVar(&f.interactive, "interactive", false, "like -add, but prompt for each module to accept, edit, or skip the recommended new version tag")
+	fs.BoolVar(&f.lightweight, "lightweight", false, "with -add, create lightweight tags instead of annotated ones")
+	fs.StringVar(&f.localUser, "local-user", "", "with -add, sign the new version tag with this `key` (implies -s)")
+	fs.BoolVar(&f.lock, "lock", false, "with -add -push, hold a lock in the remote while checking and tagging, so that concurrent runs do not race to create conflicting tags (implies -remote-tags)")
+	fs.BoolVar(&f.maintBranch, "maintenance-branch", false, "when a new major version is recommended, create a release/vN branch at the latest version tag (pushed with -push)")
+	fs.StringVar(&f.modpath, "module", "", "check the module with this import `path`, wherever it is in the repository, instead of the one in MODULEDIR")
+	fs.StringVar(&f.msg, "m", "", "with -add, `message` for new version tag")
+	fs.BoolVar(&f.noBaseline, "no-baseline", false, "report all warnings, including those recorded in the baseline file")
+	fs.BoolVar(&f.noEmoji, "no-emoji", false, "mark lines of the report with words instead of emoji")
+	fs.StringVar(&f.tags, "tags", "", "comma-separated build `tags` under which to compare the module's API (overrides the config file)")
+	fs.StringVar(&f.target, "target", "", "analyze (and with -add, tag) this commit, branch, or other `ref` instead of the tip of the default branch")
+	fs.DurationVar(&f.timeout, "timeout", 0, "limit each git operation (and each Modver comparison) to this duration, e.g. 30s (0 means no limit)")
+	fs.BoolVar(&f.offline, "offline", false, "never access the network; skip checks that require it (-osv, -dependents, -govulncheck, -remote-tags)")
+	fs.BoolVar(&f.osv, "osv", false, "report vulnerabilities in the OSV database affecting the latest version (requires network)")
+	fs.BoolVar(&f.pkgGoDev, "pkg-go-dev", false, "with -add and -push or -api, ask pkg.go.dev to fetch the new versions' documentation (best with -wait-proxy)")
+	fs.BoolVar(&f.push, "push", false, "with -add, push new version tags to origin")
+	fs.BoolVar(&f.quiet, "q", false, "quiet mode: print warnings only")
+	fs.StringVar(&f.releaseMerge, "release-merge", "", "analyze and tag the latest merge commit whose message matches this `regexp`, instead of the branch tip (overrides the config file)")
+	fs.StringVar(&f.remote, "remote", "", "determine the default branch from this `remote` only, and push to it with -push (default: prefer origin)")
+	fs.BoolVar(&f.remoteTags, "remote-tags", false, "also discover version tags that exist only in the remote, as in a --no-tags clone (requires network)")
+	fs.BoolVar(&f.reqAnnotated, "require-annotated", false, "warn about version tags that are lightweight rather than annotated")
+	fs.BoolVar(&f.requireTidy, "require-tidy", false, "with -add, refuse to add tags if go.mod and go.sum are not tidy (implies -tidy)")
+	fs.BoolVar(&f.security, "security", false, "with -add, mark the new versions as security fixes and write OSV advisory stubs for them")
+	fs.BoolVar(&f.sign, "s", false, "with -add, sign the new version tag")
+	fs.StringVar(&f.sortBy, "sort", "path", "with -all, `order` of the modules in the output: path, or warnings for the modules with the most severe warnings first")
+	fs.BoolVar(&f.summary, "summary", false, "with -all, print a table with one line per module instead of the full report")
+	fs.Var(&f.status, "status", "exit with status 2 if there are warnings (with =SEVERITY, only warnings at least that severe: info, warning, or error)")
+	fs.BoolVar(&f.tidy, "tidy", false, "warn if go.mod and go.sum are not tidy (runs go mod tidy -diff)")
+	fs.BoolVar(&f.updateRequires, "update-requires", false, "with -all -add, update other modules' requirements on the newly tagged modules and commit the change (pushing it with -push)")
+	fs.BoolVar(&f.verbose, "v", false, "verbose mode: add details such as Modver's full report, information about each version tag, and the unreleased commits")
+	fs.BoolVar(&f.verifyBuilds, "verify-builds", false, "check out each version tag and report versions that do not build (slow)")
+	fs.DurationVar(&f.waitProxy, "wait-proxy", 0, "with -add and -push or -api, poll the module proxy ($GOPROXY) for up to this long until it serves the new versions")
+	fs.BoolVar(&f.warmProxy, "warm-proxy", false, "with -wait-proxy, request the new versions from the module proxy so that it fetches them")
+	fs.BoolVar(&f.workspace, "workspace", false, "with -all, check the modules used by the repository's go.work file instead of all those in its directory tree, and warn where the two differ")
+
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "Usage: %s [flags] [REPODIR] [MODULEDIR]\n", fs.Name())
+		fmt.Fprintf(out, "       %s [flags] -all [REPODIR | DIR/...]\n", fs.Name())
+		fmt.Fprintf(out, "       %s SUBCOMMAND [args]\n\nFlags:\n", fs.Name())
+		fs.PrintDefaults()
+	}
+}
+
+// validate fills in the flags implied by others
+// and reports an error for a combination of flags that makes no sense.
+func (f *checkFlags) validate() error {
+	if f.interactive {
+		f.add = true
+	}
+	if f.requireTidy {
+		f.tidy = true
+	}
+
+	if err := f.validateOutput(); err != nil {
+		return err
+	}
+	if err := f.validateAll(); err != nil {
+		return err
+	}
+	return f.validateTagging()
+}
+
+// validateOutput checks the flags that control the report.
+func (f *checkFlags) validateOutput() error {
+	switch f.format {
+	case "text", "openmetrics":
+		// ok
+	case "json":
+		f.doJSON = true
+	default:
+		return fmt.Errorf("unknown output format %s", f.format)
+	}
+	f.doMetrics = f.format == "openmetrics"
+	if f.doJSON && f.doMetrics {
+		return fmt.Errorf("cannot combine -json with -format openmetrics")
+	}
+	if f.quiet && f.verbose {
+		return fmt.Errorf("-q and -v are mutually exclusive")
+	}
+	switch f.sortBy {
+	case "path", "warnings":
+		// ok
+	default:
+		return fmt.Errorf("unknown sort order %s", f.sortBy)
+	}
+	return nil
+}
+
+// validateAll checks the flags that apply only with -all, or never with it.
+func (f *checkFlags) validateAll() error {
+	if f.all {
+		if f.modpath != "" {
+			return fmt.Errorf("cannot combine -module with -all")
+		}
+		if f.summary && (f.doJSON || f.doMetrics || f.checklist || f.interactive || f.verbose) {
+			return fmt.Errorf("cannot combine -summary with -json, -format, -checklist, -interactive, or -v")
+		}
+		return nil
+	}
+
+	if f.workspace {
+		return fmt.Errorf("-workspace requires -all")
+	}
+	if f.sortBy != "path" {
+		return fmt.Errorf("-sort requires -all")
+	}
+	if f.summary {
+		return fmt.Errorf("-summary requires -all")
+	}
+	if f.updateRequires {
+		return fmt.Errorf("-update-requires requires -all and -add")
+	}
+	return nil
+}
+
+// validateTagging checks the flags that control adding and pushing tags.
+func (f *checkFlags) validateTagging() error {
+	if !f.add {
+		for _, c := range []struct {
+			set  bool
+			name string
+		}{
+			{f.security, "-security"},
+			{f.floating, "-floating"},
+			{f.updateRequires, "-update-requires"},
+		} {
+			if c.set {
+				return fmt.Errorf("%s requires -add", c.name)
+			}
+		}
+	}
+	if f.base != "" && f.add {
+		return fmt.Errorf("cannot combine -base with -add or -interactive")
+	}
+	if f.lightweight && (f.sign || f.localUser != "" || f.msg != "") {
+		return fmt.Errorf("cannot combine -lightweight with -s, -local-user, or -m")
+	}
+	if f.lock && !(f.add && f.push) {
+		return fmt.Errorf("-lock requires -add and -push")
+	}
+	if f.offline && f.push {
+		return fmt.Errorf("cannot combine -offline with -push")
+	}
+	if f.api && (f.push || f.sign || f.localUser != "" || f.offline || f.floating) {
+		return fmt.Errorf("cannot combine -api with -push, -s, -local-user, -offline, or -floating")
+	}
+
+	// Steps after publishing the new tags.
+	published := f.add && (f.push || f.api)
+	if f.waitProxy < 0 {
+		return fmt.Errorf("-wait-proxy must not be negative")
+	}
+	if f.waitProxy > 0 && !published {
+		return fmt.Errorf("-wait-proxy requires -add and -push or -api")
+	}
+	if f.pkgGoDev && !published {
+		return fmt.Errorf("-pkg-go-dev requires -add and -push or -api")
+	}
+	if f.warmProxy && f.waitProxy == 0 {
+		return fmt.Errorf("-warm-proxy requires -wait-proxy")
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bobg/errors"
+	"golang.org/x/mod/semver"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/gitutil"
+)
+
+// moveFloatingTags creates or force-moves the floating tags vX and vX.Y
+// (with any VersionPrefix)
+// to the commit of each new release tag in txn,
+// as is the convention for GitHub Actions.
+// A floating tag is left alone
+// if a later release that it stands for already exists,
+// as when a patch release is made on a maintenance branch.
+// The floating tags are lightweight.
+// If txn.remote is non-empty, they are force-pushed there.
+func moveFloatingTags(ctx context.Context, txn *tagTxn) error {
+	var moved []string
+
+	for _, tag := range txn.tags {
+		var (
+			prefix  = txn.results[tag].VersionPrefix
+			version = strings.TrimPrefix(tag, prefix)
+		)
+		floating := taggo.FloatingVersions(version)
+		if len(floating) == 0 {
+			continue
+		}
+
+		var releases []string
+		err := gitutil.Refs(ctx, txn.git, txn.repodir, func(name, _ string) error {
+			v, ok := strings.CutPrefix(name, "refs/tags/"+prefix)
+			if ok && semver.IsValid(v) && !taggo.IsFloatingVersion(v) && semver.Prerelease(v) == "" {
+				releases = append(releases, v)
+			}
+			return nil
+		})
+		if err != nil {
+			return errors.Wrap(err, "getting tags")
+		}
+
+	FLOATING:
+		for _, f := range floating {
+			for _, v := range releases {
+				if semver.Compare(v, version) > 0 && (semver.Major(v) == f || semver.MajorMinor(v) == f) {
+					fmt.Printf("ℹ️ Not moving floating tag %s: later release %s exists\n", prefix+f, prefix+v)
+					continue FLOATING
+				}
+			}
+
+			cmd, done := txn.command(ctx, "tag", "-f", prefix+f, tag+"^{commit}")
+			output, err := cmd.CombinedOutput()
+			if err = done(err); err != nil {
+				return errors.Wrapf(err, "running %s: %s", cmd, strings.TrimSpace(string(output)))
+			}
+			fmt.Printf("🪄 Moved floating tag %s to %s\n", prefix+f, tag)
+			moved = append(moved, prefix+f)
+		}
+	}
+
+	if txn.remote == "" || len(moved) == 0 {
+		return nil
+	}
+
+	args := []string{"push", "--force", txn.remote}
+	for _, tag := range moved {
+		args = append(args, "refs/tags/"+tag)
+	}
+
+	cmd, done := txn.command(ctx, args...)
+	output, err := cmd.CombinedOutput()
+	if err = done(err); err != nil {
+		return errors.Wrapf(err, "running %s: %s", cmd, strings.TrimSpace(string(output)))
+	}
+	fmt.Printf("🚀 Pushed %d floating tag(s) to %s\n", len(moved), txn.remote)
+
+	return nil
+}
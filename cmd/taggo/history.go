@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo"
+)
+
+// runHistory implements "taggo history".
+func runHistory(ctx context.Context, args []string) error {
+	var (
+		git    string
+		doJSON bool
+		fs     = flag.NewFlagSet("history", flag.ContinueOnError)
+	)
+	fs.StringVar(&git, "git", "", "path to git binary")
+	fs.BoolVar(&doJSON, "json", false, "output in JSON format")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var (
+		repodir, moduledir string
+		err                error
+	)
+	switch fs.NArg() {
+	case 0:
+		repodir, moduledir, err = determineDirs(ctx, git, ".")
+	case 1:
+		repodir, moduledir, err = determineDirs(ctx, git, fs.Arg(0))
+	case 2:
+		repodir, moduledir = fs.Arg(0), fs.Arg(1)
+	default:
+		return fmt.Errorf("usage: %s history [-git GIT] [-json] [REPODIR] [MODULEDIR]", os.Args[0])
+	}
+	if err != nil {
+		return errors.Wrap(err, "determining directories")
+	}
+
+	result, err := taggo.Check(ctx, git, repodir, moduledir, taggo.WithVersionHistory())
+	if err != nil {
+		return errors.Wrapf(err, "checking module %s in repository %s", moduledir, repodir)
+	}
+
+	if doJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err := enc.Encode(result.Versions)
+		return errors.Wrap(err, "encoding result")
+	}
+
+	if len(result.Versions) == 0 {
+		fmt.Println("No version tags")
+		return nil
+	}
+
+	onDefault := "ON " + result.DefaultBranch
+	if result.DefaultBranch == "" {
+		onDefault = "ON DEFAULT BRANCH"
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "VERSION\tCOMMIT\tDATE\tTAGGER\tSIGNED\t%s\n", onDefault)
+	for _, v := range result.Versions {
+		var (
+			date   = v.CommitTime
+			tagger = "(lightweight)"
+		)
+		if v.Annotated {
+			date, tagger = v.TagTime, v.Tagger
+		}
+		reachable := "?"
+		if result.DefaultBranch != "" {
+			reachable = yesNo(v.OnDefaultBranch)
+		}
+		fmt.Fprintf(tw, "%s%s\t%s\t%s\t%s\t%s\t%s\n", result.VersionPrefix, v.Version, shortHash(v.Commit), date.Format(time.DateOnly), tagger, yesNo(v.Signed), reachable)
+	}
+	return tw.Flush()
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
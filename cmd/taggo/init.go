@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/gitutil"
+)
+
+// workflowFile is where "taggo init -github-actions" writes its workflow,
+// relative to the repository root.
+var workflowFile = filepath.Join(".github", "workflows", "taggo.yml")
+
+// runInit implements "taggo init".
+func runInit(ctx context.Context, args []string) error {
+	var (
+		actions bool
+		branch  string
+		force   bool
+		git     string
+		fs      = flag.NewFlagSet("init", flag.ContinueOnError)
+	)
+	fs.BoolVar(&actions, "github-actions", false, "also write a GitHub Actions workflow running taggo")
+	fs.StringVar(&branch, "branch", "", "the default branch, for the workflow (by default, detected)")
+	fs.BoolVar(&force, "force", false, "overwrite existing files")
+	fs.StringVar(&git, "git", "", "path to git binary")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var (
+		repodir string
+		err     error
+	)
+	switch fs.NArg() {
+	case 0:
+		repodir, err = findRepo(ctx, git, ".")
+	case 1:
+		repodir = fs.Arg(0)
+	default:
+		return fmt.Errorf("usage: %s init [-github-actions] [-branch BRANCH] [-force] [-git GIT] [REPODIR]", os.Args[0])
+	}
+	if err != nil {
+		return errors.Wrap(err, "determining repository directory")
+	}
+
+	files := [][2]string{{taggo.ConfigFile, starterConfig}}
+	if actions {
+		if branch == "" {
+			if branch, err = initBranch(ctx, git, repodir); err != nil {
+				return errors.Wrap(err, "determining default branch")
+			}
+		}
+		files = append(files, [2]string{workflowFile, starterWorkflow(branch)})
+	}
+
+	// Check everything before writing anything.
+	if !force {
+		for _, f := range files {
+			if _, err := os.Stat(filepath.Join(repodir, f[0])); err == nil {
+				return fmt.Errorf("%s already exists (use -force to overwrite it)", f[0])
+			} else if !errors.Is(err, os.ErrNotExist) {
+				return errors.Wrapf(err, "checking for %s", f[0])
+			}
+		}
+	}
+
+	for _, f := range files {
+		filename := filepath.Join(repodir, f[0])
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			return errors.Wrapf(err, "creating directory for %s", f[0])
+		}
+		if err := os.WriteFile(filename, []byte(f[1]), 0644); err != nil {
+			return errors.Wrapf(err, "writing %s", filename)
+		}
+		fmt.Printf("Wrote %s\n", f[0])
+	}
+
+	return nil
+}
+
+// initBranch guesses the repository's default branch for the starter workflow:
+// the one origin's HEAD refers to,
+// else the current branch,
+// else main.
+func initBranch(ctx context.Context, git, repodir string) (string, error) {
+	if git == "" {
+		var err error
+		git, err = exec.LookPath("git")
+		if err != nil {
+			return "", errors.Wrap(err, "finding git binary")
+		}
+	}
+
+	for _, pair := range [][2]string{
+		{"refs/remotes/origin/HEAD", "refs/remotes/origin/"},
+		{"HEAD", "refs/heads/"},
+	} {
+		ref, err := gitutil.SymbolicRef(ctx, git, repodir, pair[0])
+		if err != nil {
+			return "", err
+		}
+		if branch, ok := strings.CutPrefix(ref, pair[1]); ok {
+			return branch, nil
+		}
+	}
+	return "main", nil
+}
+
+// starterConfig is the .taggo.yaml written by "taggo init".
+// Every setting is commented out,
+// so it behaves like no configuration file at all
+// until the user chooses some.
+const starterConfig = `# Taggo configuration.
+# See https://github.com/bobg/taggo#configuration for all the settings.
+# Every setting here is commented out, leaving Taggo's defaults in effect.
+
+# How the modules in a multi-module repository are versioned:
+# independent (the default) or lockstep.
+# policy: independent
+
+# The default branch, if Taggo cannot detect it.
+# defaultBranch: main
+
+# Manual steps to acknowledge (with -ack) before adding a new version tag.
+# checklist:
+#   - Update the changelog
+
+# Report a stale release when unreleased changes are this old or this many.
+# stale:
+#   days: 90
+#   commits: 50
+
+# Require a minor-version bump for raising the go directive in go.mod.
+# goDirectiveBump: minor
+
+# Map the kinds of change Modver finds to version bumps,
+# e.g. bump the minor version for breaking changes while at v0.
+# bump:
+#   unstable:
+#     major: minor
+
+# Tag the latest release merge commit instead of the tip of the default branch.
+# releaseMerge: 'Release v?[0-9]+\.[0-9]+\.[0-9]+'
+
+# Release-readiness checks: license, vet, test, doc-markers.
+# readiness:
+#   - license
+#   - vet
+
+# Build tags and platform under which to compare API.
+# build:
+#   tags: [integration]
+#   goos: linux
+#   goarch: amd64
+
+# Files and packages to leave out of the API comparison.
+# ignore:
+#   - '*.pb.go'
+#   - internal/testutil/...
+
+# Severity overrides for warnings, keyed by finding ID.
+# severity:
+#   unstable: error
+
+# Criteria for -graduation to suggest v1.0.0 for a v0 module.
+# graduation:
+#   days: 365
+#   releases: 3
+
+# Refuse to add a tag when govulncheck finds a reachable vulnerability
+# of at least this severity: low, medium, high, or critical.
+# govulncheck:
+#   block: high
+
+# Release notes from "taggo notes": a template file for the notes,
+# and custom grouping of commits, as a Go template.
+# notes:
+#   template: .github/release-notes.tmpl
+#   group: '{{if eq .Type "docs"}}Documentation{{end}}'
+
+# The commit made by -update-requires: a message template,
+# a Signed-off-by trailer, and signing.
+# commit:
+#   message: 'chore(deps): require {{join .Tags ", "}}'
+#   signoff: true
+#   sign: true
+
+# Per-module settings, keyed by module directory.
+# modules:
+#   tools:
+#     stale:
+#       days: 180
+#     suppress:
+#       - version-suffix-unwanted
+`
+
+// starterWorkflow is the GitHub Actions workflow written by "taggo init -github-actions".
+// It checks release hygiene on each push to branch,
+// and adds and pushes the recommended version tags when run by hand.
+func starterWorkflow(branch string) string {
+	return fmt.Sprintf(`name: Taggo
+
+on:
+  push:
+    branches: [ %s ]
+  workflow_dispatch:
+
+jobs:
+  status:
+    if: ${{ github.event_name == 'push' }}
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          fetch-depth: 0
+
+      - uses: actions/setup-go@v5
+        with:
+          go-version: stable
+
+      - name: Install Taggo
+        run: go install github.com/bobg/taggo/cmd/taggo@latest
+
+      - name: Check release hygiene
+        run: taggo -all -status
+
+  release:
+    if: ${{ github.event_name == 'workflow_dispatch' }}
+    runs-on: ubuntu-latest
+    permissions:
+      contents: write
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          fetch-depth: 0
+
+      - uses: actions/setup-go@v5
+        with:
+          go-version: stable
+
+      - name: Install Taggo
+        run: go install github.com/bobg/taggo/cmd/taggo@latest
+
+      - name: Add version tags
+        run: |
+          git config user.name github-actions
+          git config user.email github-actions@github.com
+          taggo -all -add -push
+`, branch)
+}
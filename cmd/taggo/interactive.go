@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo"
+)
+
+// prompter asks the user, module by module,
+// whether to add the recommended new version tag.
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func newPrompter(in io.Reader, out io.Writer) *prompter {
+	return &prompter{in: bufio.NewReader(in), out: out}
+}
+
+// ask returns the tag, if any, that the user chooses to add for r.
+// The user may accept the recommended version, edit it, or skip the module.
+// An edited version is validated with [taggo.ValidateNewVersion].
+func (p *prompter) ask(r taggo.Result) (string, bool, error) {
+	suggested, ok := suggestedTag(r)
+	if !ok {
+		return "", false, nil
+	}
+
+	for {
+		fmt.Fprintf(p.out, "Tag commit %s as %s? [a]ccept, [e]dit, [s]kip: ", shortHash(r.LatestCommit), suggested)
+		answer, err := p.readLine()
+		if err != nil {
+			return "", false, err
+		}
+
+		switch strings.ToLower(answer) {
+		case "a", "accept", "y", "yes":
+			return suggested, true, nil
+
+		case "s", "skip", "n", "no":
+			return "", false, nil
+
+		case "e", "edit":
+			for {
+				fmt.Fprintf(p.out, "New version (without prefix %q; empty to go back): ", r.VersionPrefix)
+				version, err := p.readLine()
+				if err != nil {
+					return "", false, err
+				}
+				if version == "" {
+					break
+				}
+				if err := taggo.ValidateNewVersion(r, version); err != nil {
+					fmt.Fprintf(p.out, "⛔️ %s\n", err)
+					continue
+				}
+				return r.VersionPrefix + version, true, nil
+			}
+		}
+	}
+}
+
+// confirm asks a yes-or-no question.
+func (p *prompter) confirm(question string) (bool, error) {
+	for {
+		fmt.Fprintf(p.out, "%s [y/n]: ", question)
+		answer, err := p.readLine()
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+	}
+}
+
+func (p *prompter) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if errors.Is(err, io.EOF) && line != "" {
+		err = nil
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "reading response")
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/bobg/errors"
+	"golang.org/x/mod/semver"
+
+	"github.com/bobg/taggo"
+)
+
+// describeLockstep reports on a lockstep release plan
+// and returns the number of warnings.
+func describeLockstep(w io.Writer, plan taggo.LockstepPlan, quiet bool) int {
+	var warnings int
+
+	if !quiet {
+		if plan.CurrentVersion != "" {
+			fmt.Fprintf(w, "🔒 Lockstep policy: current version %s\n", plan.CurrentVersion)
+		} else {
+			fmt.Fprintln(w, "🔒 Lockstep policy: no version tags yet")
+		}
+	}
+	if len(plan.Inconsistent) > 0 && plan.CurrentVersion != "" {
+		fmt.Fprintf(w, "⛔️ Module(s) not at lockstep version %s: %s\n", plan.CurrentVersion, strings.Join(plan.Inconsistent, ", "))
+		warnings++
+	}
+	if plan.NewVersion != "" {
+		fmt.Fprintf(w, "⛔️ Recommended lockstep version for all modules: %s (changed: %s)\n", plan.NewVersion, strings.Join(plan.Changed, ", "))
+		warnings++
+	}
+	return warnings
+}
+
+// describePolicy reports on the release policy inferred from the version tags,
+// compared with the configured one,
+// and returns the number of warnings.
+func describePolicy(w io.Writer, inf taggo.PolicyInference, configured string, quiet bool) int {
+	if inf.Policy == "" {
+		return 0
+	}
+
+	if !quiet {
+		fmt.Fprintf(w, "ℹ️ Release policy suggested by version tags: %s\n", inf.Policy)
+	}
+
+	switch {
+	case configured == taggo.PolicyLockstep && len(inf.Independent) > 0:
+		fmt.Fprintf(w, "⛔️ Policy is lockstep, but these modules have been released independently: %s\n", strings.Join(inf.Independent, ", "))
+		return 1
+
+	case configured == "" && inf.Mixed():
+		fmt.Fprintf(w, "⛔️ Modules %s have been released in lockstep but %s have not; set the policy in %s\n", strings.Join(inf.Lockstep, ", "), strings.Join(inf.Independent, ", "), taggo.ConfigFile)
+		return 1
+	}
+
+	return 0
+}
+
+// describeUntagged writes to w a line naming the modules in modules
+// that have no version tags of their own
+// while other modules in the repository do.
+func describeUntagged(w io.Writer, modules map[string]taggo.Result, quiet bool) {
+	if quiet {
+		return
+	}
+	var dirs []string
+	for dir, r := range modules {
+		if len(r.OtherModuleVersionTags) > 0 {
+			dirs = append(dirs, dir)
+		}
+	}
+	if len(dirs) == 0 {
+		return
+	}
+	sort.Strings(dirs)
+	fmt.Fprintf(w, "ℹ️ Modules with no version tags of their own: %s\n", strings.Join(dirs, ", "))
+}
+
+// describePropagation writes to w a warning for each requirement in props,
+// returning the number of warnings.
+func describePropagation(w io.Writer, props []taggo.Propagation) int {
+	for _, p := range props {
+		why := ""
+		if p.Indirect {
+			why = " (itself because of its own requirements)"
+		}
+		fmt.Fprintf(w, "⛔️ Module %s requires %s %s, which needs a new release%s; update the requirement to %s and release %s too\n", p.Module, p.DependencyPath, p.Required, why, p.NewVersion, p.Module)
+	}
+	return len(props)
+}
+
+// addLockstepTags adds to txn a tag for every module in modules
+// at the new version in plan.
+// Each tag must first pass checkTaggable.
+// If prompt is not nil, the user is asked to confirm first.
+func addLockstepTags(txn *tagTxn, modules map[string]taggo.Result, plan taggo.LockstepPlan, checkTaggable func(string, taggo.Result) error, prompt *prompter) error {
+	if plan.NewVersion == "" {
+		return nil
+	}
+	if plan.CurrentVersion != "" && semver.Major(plan.NewVersion) != semver.Major(plan.CurrentVersion) {
+		return exitErr{code: 3, err: fmt.Errorf("will not add new major-version lockstep tags %s", plan.NewVersion)}
+	}
+
+	dirs := make([]string, 0, len(modules))
+	for dir, r := range modules {
+		if err := checkTaggable(r.VersionPrefix+plan.NewVersion, r); err != nil {
+			return errors.Wrapf(err, "in module %s", dir)
+		}
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	if prompt != nil {
+		ok, err := prompt.confirm(fmt.Sprintf("Tag all %d modules as %s?", len(dirs), plan.NewVersion))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+
+	for _, dir := range dirs {
+		r := modules[dir]
+		txn.add(r.VersionPrefix+plan.NewVersion, r)
+	}
+	return nil
+}
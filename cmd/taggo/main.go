@@ -1,20 +1,19 @@
 package main
 
 import (
-	"bufio"
 	"context"
-	"encoding/json"
 	"flag"
 	"fmt"
-	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/bobg/errors"
 
 	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/gitutil"
 )
 
 func main() {
@@ -32,174 +31,296 @@ func main() {
 	}
 }
 
-func run() error {
-	var (
-		add    bool
-		all    bool
-		doJSON bool
-		git    string
-		msg    string
-		quiet  bool
-		sign   bool
-		status bool
-	)
-	flag.BoolVar(&add, "add", false, "add any recommended new version tag to the repository")
-	flag.BoolVar(&all, "all", false, "check all modules in the repository")
-	flag.BoolVar(&doJSON, "json", false, "output in JSON format")
-	flag.StringVar(&git, "git", "", "path to git binary")
-	flag.StringVar(&msg, "m", "", "with -add, message for new version tag")
-	flag.BoolVar(&quiet, "q", false, "quiet mode: print warnings only")
-	flag.BoolVar(&sign, "s", false, "with -add, sign the new version tag")
-	flag.BoolVar(&status, "status", false, "exit with status 2 if there are warnings")
-	flag.Parse()
+// With -lock, how long to wait for another run to release the lock,
+// and how long a lock may be held before it is presumed abandoned.
+const (
+	lockWait  = 10 * time.Minute
+	lockStale = 30 * time.Minute
+)
 
-	var (
-		repodir, moduledir string
-		err                error
-	)
+// subcommands are the "taggo SUBCOMMAND ..." forms of the command.
+// Without a subcommand, taggo checks modules.
+var subcommands = map[string]func(context.Context, []string) error{
+	"apply":          runApply,
+	"batch":          runBatch,
+	"baseline":       runBaseline,
+	"blockers":       runBlockers,
+	"fix":            runFix,
+	"history":        runHistory,
+	"init":           runInit,
+	"migrate-prefix": runMigratePrefix,
+	"notes":          runNotes,
+	"org":            runOrg,
+	"pr":             runPR,
+	"pseudo":         runPseudo,
+	"schema":         runSchema,
+	"simulate":       runSimulate,
+	"stats":          runStats,
+	"undo":           runUndo,
+	"verify":         runVerify,
+}
 
-	if git == "" {
-		git, err = exec.LookPath("git")
-		if err != nil {
-			return errors.Wrap(err, "finding git binary")
-		}
+// subcommand returns the subcommand named by the first of args, if any.
+// An existing directory with the same name takes precedence,
+// so that "taggo DIR" still checks the module in DIR
+// when DIR happens to be named like a subcommand.
+func subcommand(args []string) (func(context.Context, []string) error, bool) {
+	if len(args) == 0 {
+		return nil, false
+	}
+	subcmd, ok := subcommands[args[0]]
+	if !ok {
+		return nil, false
+	}
+	if info, err := os.Stat(args[0]); err == nil && info.IsDir() {
+		return nil, false
 	}
+	return subcmd, true
+}
 
-	switch flag.NArg() {
-	case 0:
-		if all {
-			repodir, err = searchUpwardFor(".", ".git")
-			if err != nil {
-				return errors.Wrap(err, "finding repository directory")
-			}
-		} else {
-			repodir, moduledir, err = determineDirs(".")
-			if err != nil {
-				return errors.Wrap(err, "determining directories")
-			}
-		}
-	case 1:
-		if all {
-			repodir, err = searchUpwardFor(flag.Arg(0), ".git")
-			if err != nil {
-				return errors.Wrapf(err, "finding repository directory from %s", flag.Arg(0))
-			}
-		} else {
-			repodir, moduledir, err = determineDirs(flag.Arg(0))
+func run() error {
+	// Git commands run in the repository directory, not the current one,
+	// so make relative paths in the git environment absolute.
+	for _, name := range []string{"GIT_DIR", "GIT_WORK_TREE"} {
+		if val := os.Getenv(name); val != "" && !filepath.IsAbs(val) {
+			abs, err := filepath.Abs(val)
 			if err != nil {
-				return errors.Wrapf(err, "determining directories from %s", flag.Arg(0))
+				return errors.Wrapf(err, "making %s absolute", name)
 			}
+			os.Setenv(name, abs)
 		}
-	case 2:
-		if all {
-			return fmt.Errorf("cannot specify both -all and MODULEDIR")
-		}
-		repodir, moduledir = flag.Arg(0), flag.Arg(1)
+	}
 
-	default:
-		return fmt.Errorf("usage: %s [-add] [-all] [-git GIT] [-json] [-msg MSG] [-q] [-status] [REPODIR] [MODULEDIR]", os.Args[0])
+	if subcmd, ok := subcommand(os.Args[1:]); ok {
+		return subcmd(context.Background(), os.Args[2:])
+	}
+
+	start := time.Now()
+
+	var f checkFlags
+	f.define(flag.CommandLine)
+	flag.Parse()
+	if err := f.validate(); err != nil {
+		return err
+	}
+
+	if f.git == "" {
+		git, err := exec.LookPath("git")
+		if err != nil {
+			return errors.Wrap(err, "finding git binary")
+		}
+		f.git = git
 	}
 
 	ctx := context.Background()
 
-	if add {
+	repodir, moduledir, subtree, err := resolveDirs(gitutil.WithTimeout(ctx, f.timeout), f, flag.Args())
+	if err != nil {
+		return err
+	}
+
+	c, err := newChecker(f, repodir, moduledir, subtree)
+	if err != nil {
+		return err
+	}
+	defer c.close()
+
+	if f.add {
 		// Taggo won't add tags to an unclean repo.
-		if err = checkClean(ctx, git, repodir); err != nil {
+		if err = taggo.CheckClean(ctx, f.git, repodir, taggo.CleanOptions{IgnoreUntracked: true}); err != nil {
 			return errors.Wrap(err, "checking for clean repository")
 		}
 	}
 
-	if all {
-		modules, err := taggo.CheckAll(ctx, git, repodir)
+	if f.lock {
+		l, err := taggo.AcquireLock(gitutil.WithTimeout(ctx, f.timeout), f.git, repodir, taggo.LockOptions{Remote: c.pushRemote(), Wait: lockWait, Stale: lockStale})
 		if err != nil {
-			return errors.Wrapf(err, "checking all modules in %s", repodir)
+			return errors.Wrap(err, "acquiring lock")
 		}
+		defer func() {
+			// Use a fresh context in case ctx is why we're returning.
+			if err := l.Release(gitutil.WithTimeout(context.WithoutCancel(ctx), f.timeout)); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", err)
+			}
+		}()
+	}
 
-		if doJSON {
-			enc := json.NewEncoder(os.Stdout)
-			enc.SetIndent("", "  ")
-			err := enc.Encode(modules)
-			return errors.Wrap(err, "encoding result")
-		}
+	if f.all {
+		return c.checkAll(ctx, start)
+	}
+	return c.checkOne(ctx, start)
+}
 
-		var (
-			first    = true
-			warnings int
-			tagErrs  error
-		)
+// resolveDirs determines the repository and module directories to check
+// from the command-line arguments args.
+// With -all, moduledir is empty,
+// and subtree is the subdirectory of repodir to which a DIR/... argument limits the check, if any.
+func resolveDirs(ctx context.Context, f checkFlags, args []string) (repodir, moduledir, subtree string, err error) {
+	git := f.git
 
-		for mdir, result := range modules {
-			if first {
-				first = false
-			} else {
-				fmt.Println()
-			}
-			fmt.Printf("%s:\n\n", mdir)
-			warnings += result.Describe(os.Stdout, quiet)
+	switch len(args) {
+	case 0:
+		if f.all {
+			repodir, err = findRepo(ctx, git, ".")
+			return repodir, "", "", errors.Wrap(err, "finding repository directory")
+		}
+		if f.modpath != "" {
+			repodir, moduledir, err = determineModuleDirs(ctx, git, ".", f.modpath)
+			return repodir, moduledir, "", errors.Wrapf(err, "finding module %s", f.modpath)
+		}
+		repodir, moduledir, err = determineDirs(ctx, git, ".")
+		return repodir, moduledir, "", errors.Wrap(err, "determining directories")
 
-			if add {
-				if err := maybeAddTag(ctx, git, repodir, result, sign, msg); err != nil {
-					tagErrs = errors.Join(tagErrs, errors.Wrapf(err, "adding tag to module %s", mdir))
-				}
+	case 1:
+		arg := args[0]
+		if dir, ok := strings.CutSuffix(arg, "/..."); f.all && (ok || arg == "...") {
+			if !ok {
+				dir = "."
+			}
+			repodir, err = findRepo(ctx, git, dir)
+			if err != nil {
+				return "", "", "", errors.Wrapf(err, "finding repository directory from %s", dir)
 			}
+			subtree, err = subdirOf(repodir, dir)
+			return repodir, "", subtree, err
+		}
+		if f.all {
+			repodir, err = findRepo(ctx, git, arg)
+			return repodir, "", "", errors.Wrapf(err, "finding repository directory from %s", arg)
 		}
+		if f.modpath != "" {
+			repodir, moduledir, err = determineModuleDirs(ctx, git, arg, f.modpath)
+			return repodir, moduledir, "", errors.Wrapf(err, "finding module %s from %s", f.modpath, arg)
+		}
+		repodir, moduledir, err = determineDirs(ctx, git, arg)
+		return repodir, moduledir, "", errors.Wrapf(err, "determining directories from %s", arg)
+
+	case 2:
+		if f.all {
+			return "", "", "", fmt.Errorf("cannot specify both -all and MODULEDIR")
+		}
+		if f.modpath != "" {
+			return "", "", "", fmt.Errorf("cannot specify both -module and MODULEDIR")
+		}
+		return args[0], args[1], "", nil
 
-		err = tagErrs
+	default:
+		flag.Usage()
+		return "", "", "", fmt.Errorf("too many arguments")
+	}
+}
 
-		if status && warnings > 0 {
-			err = errors.Join(err, exitErr{code: 2, err: fmt.Errorf("warnings found")})
+// useTerminalFeature tells whether to use a terminal feature in the output:
+// hyperlinks to the forge hosting the repository,
+// or color,
+// as named by the flag that controls it.
+// The value of when is "auto", "always", or "never".
+// With "auto", the feature is used only when stdout is a terminal
+// (and, for color, when NO_COLOR is not set).
+func useTerminalFeature(name, when string) (bool, error) {
+	switch when {
+	case "never":
+		return false, nil
+
+	case "auto":
+		if os.Getenv("TERM") == "dumb" {
+			return false, nil
 		}
+		if name == "color" && os.Getenv("NO_COLOR") != "" {
+			return false, nil
+		}
+		fi, err := os.Stdout.Stat()
+		return err == nil && fi.Mode()&os.ModeCharDevice != 0, nil
 
-		return err
+	case "always":
+		return true, nil
 
+	default:
+		return false, fmt.Errorf("unknown -%s value %q (want auto, always, or never)", name, when)
 	}
+}
 
-	result, err := taggo.Check(ctx, git, repodir, moduledir)
-	if err != nil {
-		return errors.Wrapf(err, "checking module %s in repository %s", moduledir, repodir)
+func maybeRecordStats(start time.Time, results []taggo.Result) {
+	if !statsEnabled() {
+		return
 	}
-
-	if doJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		err := enc.Encode(result)
-		return errors.Wrap(err, "encoding result")
+	if err := recordStats(start, results); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: recording statistics: %s\n", err)
 	}
+}
 
-	warnings := result.Describe(os.Stdout, quiet)
-
-	if add {
-		err = maybeAddTag(ctx, git, repodir, result, sign, msg)
+func determineDirs(ctx context.Context, git, dir string) (repodir, moduledir string, err error) {
+	repodir, err = findRepo(ctx, git, dir)
+	if err != nil {
+		return "", "", errors.Wrap(err, "finding repository directory")
 	}
 
-	if status && warnings > 0 {
-		err = errors.Join(err, exitErr{code: 2, err: fmt.Errorf("warnings found")})
+	// With GIT_WORK_TREE or GIT_DIR set,
+	// as in a server-side hook,
+	// dir may be outside the working tree.
+	// Then look for the module at the root of the working tree.
+	if sub, err := subdirOf(repodir, dir); err != nil || sub == ".." || strings.HasPrefix(sub, ".."+string(filepath.Separator)) {
+		dir = repodir
 	}
 
-	return err
+	moduledir, err = searchUpwardFor(dir, "go.mod")
+	return repodir, moduledir, errors.Wrap(err, "finding module directory")
 }
 
-func determineDirs(dir string) (repodir, moduledir string, err error) {
-	moduledir, err = searchUpwardFor(dir, "go.mod")
+// determineModuleDirs is like [determineDirs],
+// but the module directory is that of the module with path modpath
+// anywhere in the repository containing dir.
+func determineModuleDirs(ctx context.Context, git, dir, modpath string) (repodir, moduledir string, err error) {
+	repodir, err = findRepo(ctx, git, dir)
 	if err != nil {
-		return "", "", errors.Wrap(err, "finding module directory")
+		return "", "", errors.Wrap(err, "finding repository directory")
+	}
+	moduledir, err = taggo.FindModule(repodir, modpath)
+	return repodir, moduledir, err
+}
+
+// findRepo returns the working tree of the repository containing dir.
+// Like git, it honors GIT_WORK_TREE and GIT_DIR in the environment,
+// as set in server-side hooks.
+// If git is "", it runs the git found in $PATH.
+func findRepo(ctx context.Context, git, dir string) (string, error) {
+	if worktree := os.Getenv("GIT_WORK_TREE"); worktree != "" {
+		return worktree, nil
+	}
+	if os.Getenv("GIT_DIR") != "" {
+		if git == "" {
+			git = "git"
+		}
+		cmd, done := gitutil.Command(ctx, git, dir, "rev-parse", "--show-toplevel")
+		output, err := cmd.Output()
+		if err = done(err); err != nil {
+			return "", errors.Wrapf(err, "running %s", cmd)
+		}
+		return strings.TrimSpace(string(output)), nil
 	}
-	repodir, err = searchUpwardFor(dir, ".git")
-	return repodir, moduledir, errors.Wrap(err, "finding repository directory")
+	return searchUpwardFor(dir, ".git")
 }
 
+// searchUpwardFor returns the first of dir and its ancestors
+// that contains an entry with the given name.
 func searchUpwardFor(dir, name string) (string, error) {
 	for {
 		path := filepath.Join(dir, name)
 		_, err := os.Stat(path)
 		if errors.Is(err, os.ErrNotExist) {
-			if dir == "/" {
-				return "", fmt.Errorf("no %s found", name)
+			if _, err := os.Lstat(path); err == nil {
+				// Path is a symlink or junction whose target is missing.
+				// Don't look past it for one further up.
+				return "", fmt.Errorf("%s is a link to a nonexistent target", path)
 			}
-			dir, err = filepath.Abs(filepath.Join(dir, ".."))
+			parent, ok, err := parentDir(dir)
 			if err != nil {
 				return "", errors.Wrap(err, "finding parent directory")
 			}
+			if !ok {
+				return "", fmt.Errorf("no %s found", name)
+			}
+			dir = parent
 			continue
 		}
 		if err != nil {
@@ -209,6 +330,42 @@ func searchUpwardFor(dir, name string) (string, error) {
 	}
 }
 
+// parentDir returns the absolute path of the parent of dir,
+// or false if dir is a root:
+// "/", a Windows drive root such as C:\, or the root of a UNC share such as \\server\share.
+func parentDir(dir string) (string, bool, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false, errors.Wrapf(err, "making %s absolute", dir)
+	}
+	parent := filepath.Dir(abs)
+	if parent == abs {
+		return "", false, nil
+	}
+	return parent, true, nil
+}
+
+// subdirOf returns the path of dir relative to repodir,
+// or "" if they are the same.
+func subdirOf(repodir, dir string) (string, error) {
+	absRepodir, err := filepath.Abs(repodir)
+	if err != nil {
+		return "", errors.Wrapf(err, "making %s absolute", repodir)
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", errors.Wrapf(err, "making %s absolute", dir)
+	}
+	rel, err := filepath.Rel(absRepodir, absDir)
+	if err != nil {
+		return "", errors.Wrapf(err, "finding relative path from %s to %s", repodir, dir)
+	}
+	if rel == "." {
+		return "", nil
+	}
+	return rel, nil
+}
+
 type exitErr struct {
 	code int
 	err  error
@@ -243,88 +400,3 @@ func gcd(a, b int) int {
 	}
 	return a
 }
-
-func maybeAddTag(ctx context.Context, git, repodir string, r taggo.Result, sign bool, msg string) error {
-	if r.DefaultBranch == "" {
-		return nil
-	}
-	if r.LatestCommit == "" {
-		return nil
-	}
-	if r.LatestCommitHasVersionTag {
-		return nil
-	}
-	if r.NewMajor == 0 && r.NewMinor == 0 && r.NewPatch == 0 {
-		return nil
-	}
-
-	bareTag := fmt.Sprintf("v%d.%d.%d", r.NewMajor, r.NewMinor, r.NewPatch)
-	if bareTag == r.LatestVersion {
-		return nil
-	}
-	tag := r.VersionPrefix + bareTag
-
-	if r.NewMajor != r.LatestMajor {
-		return exitErr{code: 3, err: fmt.Errorf("will not add new major-version tag %s", tag)}
-	}
-
-	if msg == "" {
-		msg = fmt.Sprintf("Version %s added by Taggo", tag)
-	}
-
-	args := []string{"tag", "-m", msg}
-	if sign {
-		args = append(args, "-s")
-	}
-	args = append(args, tag, r.LatestCommit)
-
-	cmd := exec.CommandContext(ctx, git, args...)
-	cmd.Dir = repodir
-	if err := cmd.Run(); err != nil {
-		return errors.Wrapf(err, "running %s", cmd)
-	}
-
-	fmt.Printf("🪄 Added tag %s\n", tag)
-	return nil
-}
-
-func checkClean(ctx context.Context, git, repodir string) error {
-	cmd := exec.CommandContext(ctx, git, "status", "--porcelain")
-	cmd.Dir = repodir
-	stdout, err := cmd.StdoutPipe()
-	if err != nil {
-		return errors.Wrap(err, "creating stdout pipe")
-	}
-	if err := cmd.Start(); err != nil {
-		return errors.Wrapf(err, "starting %s", cmd)
-	}
-	defer cmd.Wait()
-
-	var (
-		clean = true
-		sc    = bufio.NewScanner(stdout)
-	)
-	for sc.Scan() {
-		line := sc.Text()
-		if strings.HasPrefix(line, "??") {
-			continue
-		}
-		clean = false
-		if _, err = io.Copy(io.Discard, stdout); err != nil {
-			return errors.Wrap(err, "discarding output")
-		}
-		break
-	}
-	if err := sc.Err(); err != nil {
-		return errors.Wrapf(err, "scanning output of %s", cmd)
-	}
-
-	if err := cmd.Wait(); err != nil {
-		return errors.Wrapf(err, "waiting for %s", cmd)
-	}
-
-	if !clean {
-		return fmt.Errorf("repository is not clean")
-	}
-	return nil
-}
@@ -0,0 +1,634 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bobg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/internal/testutil"
+)
+
+func TestSearchUpwardFor(t *testing.T) {
+	root := t.TempDir()
+	repodir := filepath.Join(root, "repo")
+	subdir := filepath.Join(repodir, "a", "b")
+	if err := os.MkdirAll(subdir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(repodir, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := searchUpwardFor(subdir, ".git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != repodir {
+		t.Errorf("got %s, want %s", got, repodir)
+	}
+
+	// The search must end at the root of the filesystem (or volume).
+	if _, err := searchUpwardFor(subdir, "nonesuch-e8b3f0c1"); err == nil {
+		t.Error("got no error searching for a nonexistent name")
+	}
+
+	// A dangling link at .git is an error,
+	// not a reason to keep looking further up.
+	inner := filepath.Join(subdir, "inner")
+	if err := os.Mkdir(inner, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(root, "missing"), filepath.Join(inner, ".git")); err != nil {
+		t.Skipf("cannot create symlink: %s", err)
+	}
+	if _, err := searchUpwardFor(inner, ".git"); err == nil {
+		t.Error("got no error for a dangling .git link")
+	}
+
+	// A .git link to a real directory counts.
+	linked := filepath.Join(subdir, "linked")
+	if err := os.Mkdir(linked, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(repodir, ".git"), filepath.Join(linked, ".git")); err != nil {
+		t.Fatal(err)
+	}
+	got, err = searchUpwardFor(linked, ".git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != linked {
+		t.Errorf("got %s, want %s", got, linked)
+	}
+}
+
+func TestInit(t *testing.T) {
+	repodir := t.TempDir()
+	cmd := exec.Command("git", "init", "-q", "-b", "trunk", repodir)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("%s: %s\n%s", cmd, err, out)
+	}
+
+	ctx := context.Background()
+	if err := runInit(ctx, []string{"-github-actions", repodir}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The starter configuration must be valid, and equivalent to none.
+	config, err := taggo.LoadConfig(repodir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Policy != "" || config.Modules != nil || config.Ignore != nil {
+		t.Errorf("got non-default config %+v", config)
+	}
+
+	data, err := os.ReadFile(filepath.Join(repodir, workflowFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var workflow struct {
+		On struct {
+			Push struct {
+				Branches []string `yaml:"branches"`
+			} `yaml:"push"`
+		} `yaml:"on"`
+		Jobs map[string]any `yaml:"jobs"`
+	}
+	if err := yaml.Unmarshal(data, &workflow); err != nil {
+		t.Fatalf("parsing workflow: %s", err)
+	}
+	if got := workflow.On.Push.Branches; len(got) != 1 || got[0] != "trunk" {
+		t.Errorf("got push branches %v, want [trunk]", got)
+	}
+	if len(workflow.Jobs) != 2 {
+		t.Errorf("got %d jobs, want 2", len(workflow.Jobs))
+	}
+
+	// Existing files are not overwritten without -force.
+	err = runInit(ctx, []string{repodir})
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("got error %v, want one about an existing file", err)
+	}
+	if err := runInit(ctx, []string{"-force", "-github-actions", "-branch", "main", repodir}); err != nil {
+		t.Fatal(err)
+	}
+	data, err = os.ReadFile(filepath.Join(repodir, workflowFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "branches: [ main ]") {
+		t.Errorf("workflow does not run on pushes to main:\n%s", data)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	root := t.TempDir()
+	repodir := filepath.Join(root, "repo")
+
+	if err := os.MkdirAll(filepath.Join(repodir, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, contents := range map[string]string{
+		"go.mod":     "module example.com/x\n\ngo 1.22\n",
+		"sub/go.mod": "module example.com/x/sub\n\ngo 1.22\n",
+	} {
+		if err := os.WriteFile(filepath.Join(repodir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	testutil.Git(t, repodir, "init", "-q", "-b", "main")
+	testutil.Git(t, repodir, "add", ".")
+	testutil.Git(t, repodir, "commit", "-q", "-m", "initial")
+	testutil.Git(t, repodir, "tag", "v1.0.0")
+
+	listFile := filepath.Join(root, "repos.txt")
+	if err := os.WriteFile(listFile, []byte("# The fleet\n"+repodir+"\n\n"+filepath.Join(root, "nonesuch")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	repos, err := readRepoList(listFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repos) != 2 {
+		t.Fatalf("got repos %v, want 2", repos)
+	}
+
+	results := checkRepos(context.Background(), repos, batchOptions{parallel: 2})
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	if results[0].Error != "" {
+		t.Fatalf("got error %s for %s", results[0].Error, repos[0])
+	}
+	var dirs []string
+	for _, m := range results[0].Modules {
+		dirs = append(dirs, m.Dir)
+	}
+	if strings.Join(dirs, " ") != ". sub" {
+		t.Errorf("got module dirs %v, want [. sub]", dirs)
+	}
+	if results[1].Error == "" {
+		t.Errorf("got no error for %s", repos[1])
+	}
+
+	var buf strings.Builder
+	if err := writeBatchTable(&buf, results); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"example.com/x/sub", "Checked 1 of 2 repositories"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("table lacks %q:\n%s", want, buf.String())
+		}
+	}
+
+	err = batchStatus(results, "")
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 repositories") {
+		t.Errorf("got status error %v, want one about the repository that could not be checked", err)
+	}
+}
+
+func TestUpdateClone(t *testing.T) {
+	root := t.TempDir()
+	origin := filepath.Join(root, "origin")
+
+	if err := os.Mkdir(origin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	testutil.Git(t, origin, "init", "-q", "-b", "main")
+	testutil.Git(t, origin, "commit", "-q", "--allow-empty", "-m", "first")
+	testutil.Git(t, origin, "tag", "v1.0.0")
+
+	ctx := context.Background()
+	clone := filepath.Join(root, "cache", "acme", "x")
+	if err := updateClone(ctx, "git", clone, origin, ""); err != nil {
+		t.Fatal(err)
+	}
+	if got := testutil.Git(t, clone, "tag"); got != "v1.0.0" {
+		t.Errorf("got tags %q in clone, want v1.0.0", got)
+	}
+
+	// Updating an existing clone picks up new commits and tags.
+	testutil.Git(t, origin, "commit", "-q", "--allow-empty", "-m", "second")
+	testutil.Git(t, origin, "tag", "v1.0.1")
+	if err := updateClone(ctx, "git", clone, origin, ""); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := testutil.Git(t, clone, "rev-parse", "HEAD"), testutil.Git(t, origin, "rev-parse", "HEAD"); got != want {
+		t.Errorf("got HEAD %s in clone, want %s", got, want)
+	}
+	if got := testutil.Git(t, clone, "tag"); got != "v1.0.0\nv1.0.1" {
+		t.Errorf("got tags %q in clone, want v1.0.0 and v1.0.1", got)
+	}
+}
+
+func TestWriteSummaryTable(t *testing.T) {
+	modules := []batchModule{{
+		Dir:      ".",
+		Result:   taggo.Result{Modpath: "example.com/x", DefaultBranch: "main", LatestCommit: "abc123", LatestVersion: "v1.2.3", NewMajor: 1, NewMinor: 3},
+		Warnings: 2,
+	}, {
+		Dir:    "sub",
+		Result: taggo.Result{Modpath: "example.com/x/sub", VersionPrefix: "sub/", LatestVersion: "v0.1.0"},
+	}}
+
+	var buf strings.Builder
+	if err := writeSummaryTable(&buf, modules); err != nil {
+		t.Fatal(err)
+	}
+	want := `MODULE             LATEST      RECOMMENDED  WARNINGS
+example.com/x      v1.2.3      v1.3.0       2
+example.com/x/sub  sub/v0.1.0  -            0
+
+2 module(s), 1 new version(s) recommended, 2 warning(s)
+`
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestModuleOrder(t *testing.T) {
+	modules := map[string]taggo.Result{
+		"a": {Modpath: "example.com/x/a", DefaultBranch: "main", LatestCommit: "abc123", LatestVersion: "v1.0.0", LatestCommitHasVersionTag: true, LatestCommitHasLatestVersion: true},
+		"b": {Modpath: "example.com/x/b", DefaultBranch: "main", LatestCommit: "abc123"},
+		"c": {Modpath: "example.com/x/c", DefaultBranch: "main", LatestCommit: "abc123", LatestVersion: "v1.0.0", LatestCommitHasVersionTag: true, LatestCommitHasLatestVersion: true},
+	}
+	if a, b := modules["a"].DescribeWith(io.Discard, taggo.DescribeOptions{}), modules["b"].DescribeWith(io.Discard, taggo.DescribeOptions{}); b <= a {
+		t.Fatalf("got %d warning(s) for b and %d for a, want more for b", b, a)
+	}
+
+	dirs := []string{"c", "b", "a"}
+	sortModuleDirs(dirs, modules, "path", nil, nil)
+	if got := strings.Join(dirs, " "); got != "a b c" {
+		t.Errorf("sorted by path, got %s, want a b c", got)
+	}
+
+	var want bytes.Buffer
+	enc := json.NewEncoder(&want)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(modules); err != nil {
+		t.Fatal(err)
+	}
+	var got bytes.Buffer
+	if err := writeModulesJSON(&got, modules, dirs); err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != want.String() {
+		t.Errorf("got JSON:\n%s\nwant:\n%s", got.String(), want.String())
+	}
+
+	sortModuleDirs(dirs, modules, "warnings", nil, nil)
+	if got := strings.Join(dirs, " "); got != "b a c" {
+		t.Errorf("sorted by warnings, got %s, want b a c", got)
+	}
+
+	got.Reset()
+	if err := writeModulesJSON(&got, modules, dirs); err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]taggo.Result
+	if err := json.Unmarshal(got.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != 3 || strings.Index(got.String(), `"b"`) > strings.Index(got.String(), `"a"`) {
+		t.Errorf("got JSON with wrong keys or order:\n%s", got.String())
+	}
+}
+
+func TestCheckRemoteTags(t *testing.T) {
+	root := t.TempDir()
+	origin := filepath.Join(root, "origin")
+	clone := filepath.Join(root, "clone")
+
+	if err := os.Mkdir(origin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	testutil.Git(t, origin, "init", "-q", "-b", "main")
+	testutil.Git(t, origin, "commit", "-q", "--allow-empty", "-m", "first")
+	first := testutil.Git(t, origin, "rev-parse", "HEAD")
+	testutil.Git(t, origin, "tag", "-a", "-m", "Version 1.0.0", "v1.0.0")
+	testutil.Git(t, origin, "commit", "-q", "--allow-empty", "-m", "second")
+	second := testutil.Git(t, origin, "rev-parse", "HEAD")
+
+	testutil.Git(t, root, "clone", "-q", origin, clone)
+	testutil.Git(t, clone, "tag", "-d", "v1.0.0") // as if it had not been fetched
+	testutil.Git(t, clone, "config", "user.name", "Taggo")
+	testutil.Git(t, clone, "config", "user.email", "taggo@example.com")
+
+	ctx := context.Background()
+	r := taggo.Result{Remote: "origin"}
+
+	txn := &tagTxn{git: "git", repodir: clone, checkRemote: true}
+	txn.add("v1.0.1", taggo.Result{Remote: "origin", LatestCommit: second})
+	txn.addAt("v1.0.0", second, "", r)
+	err := txn.commit(ctx)
+	if err == nil || !strings.Contains(err.Error(), "v1.0.0 exists in remote origin at commit "+shortHash(first)) {
+		t.Errorf("got error %v, want one about v1.0.0 in the remote", err)
+	}
+	if got := testutil.Git(t, clone, "tag", "-l"); got != "" {
+		t.Errorf("got tags %q after refusing, want none", got)
+	}
+
+	// The same tag at the same commit is fine.
+	txn = &tagTxn{git: "git", repodir: clone, checkRemote: true}
+	txn.addAt("v1.0.0", first, "", r)
+	txn.addAt("v1.0.1", second, "", r)
+	if err := txn.commit(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := testutil.Git(t, clone, "tag", "-l"); got != "v1.0.0\nv1.0.1" {
+		t.Errorf("got tags %q, want v1.0.0 and v1.0.1", got)
+	}
+}
+
+func TestFix(t *testing.T) {
+	root := t.TempDir()
+	origin := filepath.Join(root, "origin")
+	clone := filepath.Join(root, "clone")
+
+	if err := os.Mkdir(origin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(origin, "go.mod"), []byte("module example.com/x\n\ngo 1.22\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	testutil.Git(t, origin, "init", "-q", "-b", "main")
+	testutil.Git(t, origin, "add", "go.mod")
+	testutil.Git(t, origin, "commit", "-q", "-m", "first")
+	first := testutil.Git(t, origin, "rev-parse", "HEAD")
+	testutil.Git(t, origin, "tag", "1.0.0")
+	testutil.Git(t, origin, "commit", "-q", "--allow-empty", "-m", "second")
+	testutil.Git(t, origin, "tag", "release-1.1.0")
+	second := testutil.Git(t, origin, "rev-parse", "HEAD")
+
+	testutil.Git(t, root, "clone", "-q", origin, clone)
+	testutil.Git(t, clone, "config", "user.name", "Taggo")
+	testutil.Git(t, clone, "config", "user.email", "taggo@example.com")
+
+	ctx := context.Background()
+
+	if err := runFix(ctx, []string{"-n", clone, ""}); err != nil {
+		t.Fatal(err)
+	}
+	if got := testutil.Git(t, clone, "tag", "-l", "v*"); got != "" {
+		t.Fatalf("got tags %q after -n, want none", got)
+	}
+
+	if err := runFix(ctx, []string{"-push", clone, ""}); err != nil {
+		t.Fatal(err)
+	}
+	for _, dir := range []string{clone, origin} {
+		if got := testutil.Git(t, dir, "rev-list", "-n", "1", "v1.0.0"); got != first {
+			t.Errorf("in %s, got v1.0.0 at %s, want %s", dir, got, first)
+		}
+		if got := testutil.Git(t, dir, "rev-list", "-n", "1", "v1.1.0"); got != second {
+			t.Errorf("in %s, got v1.1.0 at %s, want %s", dir, got, second)
+		}
+	}
+}
+
+func TestMoveFloatingTags(t *testing.T) {
+	root := t.TempDir()
+	origin := filepath.Join(root, "origin")
+	clone := filepath.Join(root, "clone")
+
+	if err := os.Mkdir(origin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	testutil.Git(t, origin, "init", "-q", "-b", "main")
+	testutil.Git(t, origin, "commit", "-q", "--allow-empty", "-m", "first")
+	first := testutil.Git(t, origin, "rev-parse", "HEAD")
+	testutil.Git(t, origin, "tag", "-a", "-m", "Version v1.0.0", "v1.0.0")
+	testutil.Git(t, origin, "tag", "v1")
+	testutil.Git(t, origin, "tag", "v1.0")
+	testutil.Git(t, origin, "commit", "-q", "--allow-empty", "-m", "second")
+	second := testutil.Git(t, origin, "rev-parse", "HEAD")
+
+	testutil.Git(t, root, "clone", "-q", origin, clone)
+	testutil.Git(t, clone, "config", "user.name", "Taggo")
+	testutil.Git(t, clone, "config", "user.email", "taggo@example.com")
+
+	ctx := context.Background()
+	r := taggo.Result{Remote: "origin"}
+
+	txn := &tagTxn{git: "git", repodir: clone, remote: "origin"}
+	txn.addAt("v1.1.0", second, "", r)
+	if err := txn.commit(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := moveFloatingTags(ctx, txn); err != nil {
+		t.Fatal(err)
+	}
+	for _, dir := range []string{clone, origin} {
+		for tag, want := range map[string]string{"v1": second, "v1.0": first, "v1.1": second} {
+			if got := testutil.Git(t, dir, "rev-list", "-n", "1", tag); got != want {
+				t.Errorf("in %s, got %s at %s, want %s", dir, tag, got, want)
+			}
+		}
+	}
+
+	// A patch release for an older minor version moves only its minor-version tag.
+	testutil.Git(t, clone, "checkout", "-q", "-b", "release-1.0", "v1.0.0")
+	testutil.Git(t, clone, "commit", "-q", "--allow-empty", "-m", "fix")
+	fix := testutil.Git(t, clone, "rev-parse", "HEAD")
+
+	txn = &tagTxn{git: "git", repodir: clone, remote: "origin"}
+	txn.addAt("v1.0.1", fix, "", r)
+	if err := txn.commit(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := moveFloatingTags(ctx, txn); err != nil {
+		t.Fatal(err)
+	}
+	for _, dir := range []string{clone, origin} {
+		for tag, want := range map[string]string{"v1": second, "v1.0": fix} {
+			if got := testutil.Git(t, dir, "rev-list", "-n", "1", tag); got != want {
+				t.Errorf("in %s, got %s at %s, want %s", dir, tag, got, want)
+			}
+		}
+	}
+}
+
+func TestSubcommand(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "verify"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	cases := []struct {
+		args []string
+		want bool
+	}{
+		{args: nil, want: false},
+		{args: []string{"notes"}, want: true},
+		{args: []string{"history", "-json"}, want: true},
+		{args: []string{"verify", "v1.2.3"}, want: false}, // a directory
+		{args: []string{"./verify"}, want: false},
+		{args: []string{"-all"}, want: false},
+	}
+	for _, tc := range cases {
+		if _, got := subcommand(tc.args); got != tc.want {
+			t.Errorf("subcommand(%q) = %v, want %v", tc.args, got, tc.want)
+		}
+	}
+}
+
+func TestSigningFailure(t *testing.T) {
+	falseBin, err := exec.LookPath("false")
+	if err != nil {
+		t.Skip("no false binary")
+	}
+
+	repodir := t.TempDir()
+	testutil.Git(t, repodir, "init", "-q", "-b", "main")
+	testutil.Git(t, repodir, "config", "user.name", "Taggo")
+	testutil.Git(t, repodir, "config", "user.email", "taggo@example.com")
+	testutil.Git(t, repodir, "config", "gpg.program", falseBin)
+	testutil.Git(t, repodir, "commit", "-q", "--allow-empty", "-m", "first")
+
+	txn := &tagTxn{git: "git", repodir: repodir, sign: true}
+	txn.addAt("v1.0.0", testutil.Git(t, repodir, "rev-parse", "HEAD"), "", taggo.Result{})
+	err = txn.commit(context.Background())
+	if err == nil {
+		t.Fatal("got no error, want one")
+	}
+	if msg := err.Error(); !strings.Contains(msg, "signing tag failed (gpg.format is openpgp): ") || !strings.Contains(msg, "exit status") {
+		t.Errorf("got error %q, want one reporting the signing failure and the git error", msg)
+	}
+	if tags := testutil.Git(t, repodir, "tag", "-l"); tags != "" {
+		t.Errorf("got tags %q after failure, want none", tags)
+	}
+}
+
+func TestPlanTag(t *testing.T) {
+	base := taggo.Result{
+		DefaultBranch: "main",
+		LatestCommit:  "ffff000000000000000000000000000000000000",
+		LatestVersion: "v1.2.3",
+		LatestMajor:   1,
+		LatestMinor:   2,
+		LatestPatch:   3,
+		NewMajor:      1,
+		NewMinor:      3,
+	}
+
+	tag, ok, err := planTag(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || tag != "v1.3.0" {
+		t.Errorf("got tag %q (ok %v), want v1.3.0", tag, ok)
+	}
+
+	major := base
+	major.NewMajor, major.NewMinor = 2, 0
+	exists := base
+	exists.NewVersionExists = true
+
+	for name, r := range map[string]taggo.Result{"major": major, "exists": exists} {
+		t.Run(name, func(t *testing.T) {
+			_, ok, err := planTag(r)
+			if ok {
+				t.Error("got ok, want not")
+			}
+			var skip skipTagErr
+			if !errors.As(err, &skip) {
+				t.Errorf("got error %v, want a skipTagErr", err)
+			}
+			var ee exitErr
+			if !errors.As(err, &ee) || ee.Code() != 3 {
+				t.Errorf("got error %v, want exit status 3", err)
+			}
+		})
+	}
+}
+
+func TestRequirementUpdates(t *testing.T) {
+	root := t.TempDir()
+	repodir := filepath.Join(root, "repo")
+	remotedir := filepath.Join(root, "remote.git")
+
+	if err := os.MkdirAll(filepath.Join(repodir, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, contents := range map[string]string{
+		"go.mod":     "module example.com/x\n\ngo 1.22\n\nrequire example.com/x/sub v0.1.0\n",
+		"sub/go.mod": "module example.com/x/sub\n\ngo 1.22\n",
+	} {
+		if err := os.WriteFile(filepath.Join(repodir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	testutil.Git(t, repodir, "init", "-q", "-b", "main")
+	testutil.Git(t, repodir, "config", "user.name", "Taggo")
+	testutil.Git(t, repodir, "config", "user.email", "taggo@example.com")
+	testutil.Git(t, repodir, "add", ".")
+	testutil.Git(t, repodir, "commit", "-q", "-m", "initial")
+	testutil.Git(t, root, "clone", "-q", "--bare", repodir, remotedir)
+	testutil.Git(t, repodir, "remote", "add", "origin", remotedir)
+
+	modules := map[string]taggo.Result{
+		repodir:                       {Modpath: "example.com/x"},
+		filepath.Join(repodir, "sub"): {Modpath: "example.com/x/sub", ModuleSubdir: "sub", VersionPrefix: "sub/"},
+	}
+	ctx := context.Background()
+	txn := &tagTxn{git: "git", repodir: repodir, remote: "origin"}
+	txn.addAt("sub/v0.2.0", testutil.Git(t, repodir, "rev-parse", "HEAD"), "", modules[filepath.Join(repodir, "sub")])
+
+	if err := checkGoModsClean(ctx, txn, modules); err != nil {
+		t.Fatal(err)
+	}
+	if err := txn.commit(ctx); err != nil {
+		t.Fatal(err)
+	}
+	cc := taggo.CommitConfig{Message: "Require {{join .Tags \" \"}} in {{join .Files \" \"}}", Signoff: true}
+	if err := commitRequirementUpdates(ctx, txn, modules, cc); err != nil {
+		t.Fatal(err)
+	}
+
+	head := testutil.Git(t, repodir, "rev-parse", "HEAD")
+	if got := testutil.Git(t, remotedir, "rev-parse", "main"); got != head {
+		t.Errorf("remote main is at %s, want the requirements commit %s", got, head)
+	}
+	gomod, err := os.ReadFile(filepath.Join(repodir, "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(gomod), "example.com/x/sub v0.2.0") {
+		t.Errorf("go.mod lacks the new requirement:\n%s", gomod)
+	}
+
+	msg := testutil.Git(t, repodir, "log", "-1", "--format=%B")
+	for _, want := range []string{"Require sub/v0.2.0 in go.mod\n", "Taggo-Tag: sub/v0.2.0\n", "Signed-off-by: Taggo <taggo@example.com>"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("commit message lacks %q:\n%s", want, msg)
+		}
+	}
+
+	got, err := requirementsCommit(ctx, "git", repodir, "sub/v0.2.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != head {
+		t.Errorf("got requirements commit %q, want %s", got, head)
+	}
+}
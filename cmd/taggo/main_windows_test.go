@@ -0,0 +1,28 @@
+package main
+
+import "testing"
+
+func TestParentDirWindows(t *testing.T) {
+	cases := []struct {
+		dir, want string
+		ok        bool
+	}{
+		{dir: `C:\`, ok: false},
+		{dir: `C:\a`, want: `C:\`, ok: true},
+		{dir: `C:\a\b`, want: `C:\a`, ok: true},
+		{dir: `\\server\share`, ok: false},
+		{dir: `\\server\share\`, ok: false},
+		{dir: `\\server\share\a`, want: `\\server\share\`, ok: true},
+	}
+	for _, c := range cases {
+		t.Run(c.dir, func(t *testing.T) {
+			got, ok, err := parentDir(c.dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != c.ok || got != c.want {
+				t.Errorf("got %q, %v; want %q, %v", got, ok, c.want, c.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo"
+)
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// writeMetrics writes gauges summarizing results to w
+// in the OpenMetrics text format,
+// suitable for the node_exporter textfile collector.
+func writeMetrics(w io.Writer, results []taggo.Result, now time.Time) error {
+	results = append([]taggo.Result(nil), results...)
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Modpath < results[j].Modpath
+	})
+
+	bw := bufio.NewWriter(w)
+
+	gauge := func(name, help string, value func(taggo.Result) (float64, bool)) {
+		fmt.Fprintf(bw, "# HELP %s %s\n", name, help)
+		fmt.Fprintf(bw, "# TYPE %s gauge\n", name)
+		for _, r := range results {
+			if v, ok := value(r); ok {
+				fmt.Fprintf(bw, "%s{module=\"%s\"} %g\n", name, labelValueEscaper.Replace(r.Modpath), v)
+			}
+		}
+	}
+
+	gauge("taggo_days_since_release", "Days since the commit of the latest version of the module.", func(r taggo.Result) (float64, bool) {
+		if r.LatestVersionTime.IsZero() {
+			return 0, false
+		}
+		return now.Sub(r.LatestVersionTime).Hours() / 24, true
+	})
+	gauge("taggo_commits_unreleased", "Commits on the default branch since the latest version of the module.", func(r taggo.Result) (float64, bool) {
+		return float64(r.UnreleasedCommits), r.LatestVersion != "" && r.DefaultBranch != ""
+	})
+	gauge("taggo_warnings", "Warnings reported by taggo for the module.", func(r taggo.Result) (float64, bool) {
+		var warnings int
+		for _, f := range r.Findings() {
+			if f.Kind == taggo.FindingWarning {
+				warnings++
+			}
+		}
+		return float64(warnings), true
+	})
+
+	fmt.Fprintln(bw, "# EOF")
+
+	return errors.Wrap(bw.Flush(), "writing metrics")
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo"
+)
+
+// runMigratePrefix implements "taggo migrate-prefix".
+func runMigratePrefix(ctx context.Context, args []string) error {
+	var (
+		dryRun     bool
+		from       string
+		git        string
+		push       bool
+		remote     string
+		versionStr string
+		fs         = flag.NewFlagSet("migrate-prefix", flag.ContinueOnError)
+	)
+	fs.BoolVar(&dryRun, "n", false, "report the tags to add without adding them")
+	fs.StringVar(&from, "from", "", "the module's former directory, whose version prefix its historical tags have (default: the repository root, with no prefix)")
+	fs.StringVar(&git, "git", "", "path to git binary")
+	fs.BoolVar(&push, "push", false, "push the new tags to the remote")
+	fs.StringVar(&remote, "remote", "origin", "remote to push to with -push")
+	fs.StringVar(&versionStr, "versions", "", "comma-separated list of the historical versions to migrate (default: all)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var (
+		repodir, moduledir string
+		err                error
+	)
+	switch fs.NArg() {
+	case 0:
+		repodir, moduledir, err = determineDirs(ctx, git, ".")
+	case 1:
+		repodir, moduledir, err = determineDirs(ctx, git, fs.Arg(0))
+	case 2:
+		repodir, moduledir = fs.Arg(0), fs.Arg(1)
+	default:
+		return fmt.Errorf("usage: %s migrate-prefix [-from DIR] [-git GIT] [-n] [-push] [-remote REMOTE] [-versions LIST] [REPODIR] [MODULEDIR]", os.Args[0])
+	}
+	if err != nil {
+		return errors.Wrap(err, "determining directories")
+	}
+
+	if git == "" {
+		git, err = exec.LookPath("git")
+		if err != nil {
+			return errors.Wrap(err, "finding git binary")
+		}
+	}
+
+	var versions []string
+	if versionStr != "" {
+		versions = strings.Split(versionStr, ",")
+	}
+
+	plan, err := taggo.PlanPrefixMigration(ctx, git, repodir, moduledir, from, versions)
+	if err != nil {
+		return errors.Wrap(err, "planning tag migration")
+	}
+
+	txn := &tagTxn{
+		git:         git,
+		repodir:     repodir,
+		checkRemote: push,
+	}
+	if push {
+		txn.remote = remote
+	}
+
+	for _, m := range plan {
+		switch {
+		case m.Skip != "":
+			fmt.Printf("ℹ️ Skipping %s: %s\n", m.NewTag, m.Skip)
+		case dryRun:
+			fmt.Printf("Would add tag %s at %s, duplicating %s\n", m.NewTag, shortHash(m.Commit), m.Tag)
+		default:
+			txn.addAt(m.NewTag, m.Commit, "", taggo.Result{Remote: remote})
+		}
+	}
+	if len(txn.tags) == 0 && !dryRun {
+		fmt.Println("No tags to add")
+		return nil
+	}
+
+	return txn.commit(ctx)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo"
+)
+
+// runNotes implements "taggo notes".
+func runNotes(ctx context.Context, args []string) error {
+	var (
+		git          string
+		doJSON       bool
+		templateFile string
+		fs           = flag.NewFlagSet("notes", flag.ContinueOnError)
+	)
+	fs.StringVar(&git, "git", "", "path to git binary")
+	fs.BoolVar(&doJSON, "json", false, "output in JSON format")
+	fs.StringVar(&templateFile, "notes-template", "", "file containing a template for the notes (default: notes.template from the config, if any)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var (
+		repodir, moduledir string
+		err                error
+	)
+	switch fs.NArg() {
+	case 0:
+		repodir, moduledir, err = determineDirs(ctx, git, ".")
+	case 1:
+		repodir, moduledir, err = determineDirs(ctx, git, fs.Arg(0))
+	case 2:
+		repodir, moduledir = fs.Arg(0), fs.Arg(1)
+	default:
+		return fmt.Errorf("usage: %s notes [-git GIT] [-json] [-notes-template FILE] [REPODIR] [MODULEDIR]", os.Args[0])
+	}
+	if err != nil {
+		return errors.Wrap(err, "determining directories")
+	}
+	if doJSON && templateFile != "" {
+		return fmt.Errorf("cannot combine -notes-template with -json")
+	}
+
+	config, err := taggo.LoadConfig(repodir)
+	if err != nil {
+		return errors.Wrap(err, "loading config")
+	}
+
+	result, err := taggo.Check(ctx, git, repodir, moduledir, taggo.WithConfig(config), taggo.WithCommitSummaries())
+	if err != nil {
+		return errors.Wrapf(err, "checking module %s in repository %s", moduledir, repodir)
+	}
+
+	notes, err := taggo.NewReleaseNotes(result, config.Notes)
+	if err != nil {
+		return errors.Wrap(err, "producing release notes")
+	}
+
+	if doJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err := enc.Encode(notes)
+		return errors.Wrap(err, "encoding release notes")
+	}
+
+	if templateFile == "" && config.Notes.Template != "" {
+		templateFile = filepath.Join(repodir, config.Notes.Template)
+	}
+	if templateFile == "" {
+		return notes.WriteMarkdown(os.Stdout)
+	}
+	tmpl, err := os.ReadFile(templateFile)
+	if err != nil {
+		return errors.Wrap(err, "reading notes template")
+	}
+	return notes.WriteTemplate(os.Stdout, string(tmpl))
+}
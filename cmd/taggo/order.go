@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/bobg/taggo"
+)
+
+// sortModuleDirs sorts dirs,
+// the directories of the modules in modules,
+// for the -sort flag.
+// With sortBy "path" they are sorted by path.
+// With "warnings" the modules with the most severe warnings come first:
+// the ones with the most error-severity warnings,
+// then (among equals) the most warning-severity ones,
+// then the most info-severity ones,
+// with ties broken by path.
+// Warnings in baseline do not count.
+func sortModuleDirs(dirs []string, modules map[string]taggo.Result, sortBy string, severities map[string]taggo.Severity, baseline *taggo.Baseline) {
+	if sortBy != "warnings" {
+		slices.Sort(dirs)
+		return
+	}
+
+	// Counts of error-, warning-, and info-severity warnings by directory.
+	counts := make(map[string][]int, len(dirs))
+	for _, dir := range dirs {
+		var (
+			r = modules[dir]
+			c = make([]int, 3)
+		)
+		for _, f := range r.Findings() {
+			if f.Kind != taggo.FindingWarning || baseline.Contains(r, f) {
+				continue
+			}
+			switch f.SeverityWith(severities) {
+			case taggo.SeverityError:
+				c[0]++
+			case taggo.SeverityWarning:
+				c[1]++
+			default:
+				c[2]++
+			}
+		}
+		counts[dir] = c
+	}
+
+	slices.SortFunc(dirs, func(a, b string) int {
+		if c := slices.Compare(counts[b], counts[a]); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+}
+
+// writeModulesJSON writes modules to w as a JSON object
+// mapping module directories to results,
+// like a json.Encoder with two-space indentation,
+// but with the keys in the order of dirs rather than sorted.
+func writeModulesJSON(w io.Writer, modules map[string]taggo.Result, dirs []string) error {
+	if len(dirs) == 0 {
+		_, err := io.WriteString(w, "{}\n")
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString("{\n")
+	for i, dir := range dirs {
+		key, err := json.Marshal(dir)
+		if err != nil {
+			return err
+		}
+		val, err := json.MarshalIndent(modules[dir], "  ", "  ")
+		if err != nil {
+			return err
+		}
+		bw.WriteString("  ")
+		bw.Write(key)
+		bw.WriteString(": ")
+		bw.Write(val)
+		if i < len(dirs)-1 {
+			bw.WriteString(",")
+		}
+		bw.WriteString("\n")
+	}
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/gitutil"
+)
+
+// runOrg implements "taggo org".
+func runOrg(ctx context.Context, args []string) error {
+	if len(args) > 0 && args[0] == "scan" {
+		return runOrgScan(ctx, args[1:])
+	}
+	return fmt.Errorf("usage: %s org scan [flags] ORG", os.Args[0])
+}
+
+// runOrgScan implements "taggo org scan".
+func runOrgScan(ctx context.Context, args []string) error {
+	var (
+		apiBase  string
+		archived bool
+		bo       batchOptions
+		cacheDir string
+		doJSON   bool
+		forks    bool
+		status   statusFlag
+		timeout  time.Duration
+		fs       = flag.NewFlagSet("org scan", flag.ContinueOnError)
+	)
+	fs.StringVar(&apiBase, "api", taggo.GitHubAPI, "base URL of the GitHub API (https://HOST/api/v3 for GitHub Enterprise Server)")
+	fs.BoolVar(&archived, "archived", false, "include archived repositories")
+	fs.StringVar(&cacheDir, "cache", "", "directory for clones of the repositories (default: taggo/orgs in the user cache directory)")
+	fs.BoolVar(&forks, "forks", false, "include forks")
+	fs.StringVar(&bo.git, "git", "", "path to git binary")
+	fs.BoolVar(&doJSON, "json", false, "output in JSON format")
+	fs.BoolVar(&bo.noBaseline, "no-baseline", false, "count all warnings, including those recorded in each repository's baseline file")
+	fs.IntVar(&bo.parallel, "parallel", 4, "clone and check up to this many repositories at once")
+	fs.Var(&status, "status", "exit with status 2 if there are warnings (with =SEVERITY, only warnings at least that severe: info, warning, or error)")
+	fs.DurationVar(&timeout, "timeout", 0, "limit each git operation during checks to this duration")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: %s org scan [-api URL] [-archived] [-cache DIR] [-forks] [-git GIT] [-json] [-no-baseline] [-parallel N] [-status[=SEVERITY]] [-timeout DURATION] ORG", os.Args[0])
+	}
+	if bo.parallel < 1 {
+		return fmt.Errorf("-parallel must be at least 1")
+	}
+	org := fs.Arg(0)
+
+	if bo.git == "" {
+		var err error
+		bo.git, err = exec.LookPath("git")
+		if err != nil {
+			return errors.Wrap(err, "finding git binary")
+		}
+	}
+	if cacheDir == "" {
+		userCache, err := os.UserCacheDir()
+		if err != nil {
+			return errors.Wrap(err, "finding user cache directory (use -cache)")
+		}
+		cacheDir = filepath.Join(userCache, "taggo", "orgs")
+	}
+
+	token := os.Getenv("GITHUB_TOKEN")
+	repos, err := taggo.GitHubOrgRepos(ctx, &http.Client{Timeout: time.Minute}, apiBase, token, org)
+	if err != nil {
+		return err
+	}
+
+	var (
+		names     []string
+		cloneURLs = make(map[string]string)
+	)
+	for _, repo := range repos {
+		if repo.Language != "Go" || (repo.Fork && !forks) || (repo.Archived && !archived) {
+			continue
+		}
+		names = append(names, repo.FullName)
+		cloneURLs[repo.FullName] = repo.CloneURL
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("no Go repositories found in %s", org)
+	}
+
+	bo.prepare = func(ctx context.Context, name string) (string, error) {
+		cloneURL := cloneURLs[name]
+		u, err := url.Parse(cloneURL)
+		if err != nil {
+			return "", errors.Wrapf(err, "parsing %s", cloneURL)
+		}
+		dir := filepath.Join(cacheDir, u.Host, filepath.FromSlash(name))
+		return dir, updateClone(ctx, bo.git, dir, cloneURL, token)
+	}
+	if timeout > 0 {
+		bo.opts = append(bo.opts, taggo.WithGitTimeout(timeout))
+	}
+	bo.threshold = taggo.Severity(status)
+
+	results := checkRepos(ctx, names, bo)
+
+	if doJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return errors.Wrap(err, "encoding result")
+		}
+	} else if err := writeBatchTable(os.Stdout, results); err != nil {
+		return errors.Wrap(err, "writing summary")
+	}
+
+	return batchStatus(results, status)
+}
+
+// updateClone makes dir a clone of the repository at cloneURL,
+// or, if it already is one,
+// brings it up to date.
+//
+// The clone is a full one.
+// A shallow clone would lack the version tags and history that Taggo analyzes,
+// and a partial one would need the token again whenever Taggo looked at an old version.
+// The working tree is always reset to the tip of the remote's default branch.
+func updateClone(ctx context.Context, git, dir, cloneURL, token string) error {
+	run := func(dir string, args ...string) error {
+		cmd, done := gitutil.Command(ctx, git, dir, args...)
+		if cmd.Env == nil {
+			cmd.Env = os.Environ()
+		}
+		cmd.Env = append(cmd.Env, "GIT_TERMINAL_PROMPT=0")
+		if token != "" {
+			// Pass the token in the environment rather than on the command line,
+			// where other users could see it.
+			basic := base64.StdEncoding.EncodeToString([]byte("x-access-token:" + token))
+			cmd.Env = append(cmd.Env,
+				"GIT_CONFIG_COUNT=1",
+				"GIT_CONFIG_KEY_0=http."+cloneURL+".extraHeader",
+				"GIT_CONFIG_VALUE_0=Authorization: Basic "+basic,
+			)
+		}
+		output, err := cmd.CombinedOutput()
+		if err = done(err); err != nil {
+			return errors.Wrapf(err, "running %s: %s", cmd, output)
+		}
+		return nil
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, ".git")); errors.Is(err, os.ErrNotExist) {
+		if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+			return errors.Wrapf(err, "creating directory for %s", dir)
+		}
+		return run(filepath.Dir(dir), "clone", "--quiet", cloneURL, filepath.Base(dir))
+	} else if err != nil {
+		return errors.Wrapf(err, "checking for existing clone in %s", dir)
+	}
+
+	if err := run(dir, "fetch", "--quiet", "--tags", "--force", "--prune", "origin"); err != nil {
+		return err
+	}
+	if err := run(dir, "remote", "set-head", "origin", "--auto"); err != nil {
+		return err
+	}
+	return run(dir, "reset", "--quiet", "--hard", "origin/HEAD")
+}
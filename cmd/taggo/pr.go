@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo"
+)
+
+// bumpOrder ranks the values of taggo.PullRequestModule.Bump.
+var bumpOrder = map[string]int{"none": 0, "patch": 1, "minor": 2, "major": 3}
+
+// runPR implements "taggo pr".
+func runPR(ctx context.Context, args []string) error {
+	var (
+		base, head string
+		doJSON     bool
+		failOn     string
+		git        string
+		fs         = flag.NewFlagSet("pr", flag.ContinueOnError)
+	)
+	fs.StringVar(&base, "base", "", "the branch the pull request merges into (required), e.g. origin/main")
+	fs.StringVar(&head, "head", "HEAD", "the pull request's branch")
+	fs.BoolVar(&doJSON, "json", false, "output in JSON format")
+	fs.StringVar(&failOn, "fail-on", "", "exit with status 2 if any module requires a bump of at least this size (patch, minor, or major)")
+	fs.StringVar(&git, "git", "", "path to git binary")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	usage := fmt.Errorf("usage: %s pr -base REF [-head REF] [-fail-on LEVEL] [-git GIT] [-json] [REPODIR]", os.Args[0])
+	if base == "" {
+		return usage
+	}
+	if failOn != "" && bumpOrder[failOn] == 0 {
+		return fmt.Errorf("-fail-on must be patch, minor, or major")
+	}
+
+	var (
+		repodir string
+		err     error
+	)
+	switch fs.NArg() {
+	case 0:
+		repodir, err = findRepo(ctx, git, ".")
+	case 1:
+		repodir = fs.Arg(0)
+	default:
+		return usage
+	}
+	if err != nil {
+		return errors.Wrap(err, "determining repository directory")
+	}
+
+	config, err := taggo.LoadConfig(repodir)
+	if err != nil {
+		return errors.Wrap(err, "loading config")
+	}
+
+	pr, err := taggo.CheckPullRequest(ctx, git, repodir, base, head, taggo.WithConfig(config))
+	if err != nil {
+		return errors.Wrapf(err, "checking pull request %s into %s", head, base)
+	}
+
+	if doJSON {
+		if pr.Modules == nil {
+			pr.Modules = []taggo.PullRequestModule{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(pr); err != nil {
+			return errors.Wrap(err, "encoding result")
+		}
+	} else if err := pr.WriteMarkdown(os.Stdout); err != nil {
+		return errors.Wrap(err, "writing summary")
+	}
+
+	if failOn != "" {
+		for _, m := range pr.Modules {
+			if bumpOrder[m.Bump] >= bumpOrder[failOn] {
+				return exitErr{code: 2, err: fmt.Errorf("module %s requires a %s version bump", m.Result.Modpath, m.Bump)}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo"
+)
+
+// waitForProxy polls the module proxy for each tag that txn added,
+// for -wait-proxy,
+// and reports when it serves the new version.
+func waitForProxy(ctx context.Context, txn *tagTxn, poll taggo.ProxyPoll) error {
+	var missing int
+
+	for _, tag := range txn.tags {
+		r := txn.results[tag]
+		version := strings.TrimPrefix(tag, r.VersionPrefix)
+
+		a, err := taggo.WaitForProxy(ctx, r.Modpath, version, poll)
+		if err != nil {
+			return errors.Wrapf(err, "waiting for module proxy to serve %s@%s", r.Modpath, version)
+		}
+		if a.Available {
+			fmt.Printf("📦 Module proxy %s serves %s@%s after %s; go get %s@%s will work\n", a.ProxyURL, r.Modpath, version, a.Elapsed.Round(time.Second), r.Modpath, version)
+		} else {
+			fmt.Printf("⏳ Module proxy %s does not serve %s@%s yet after %s\n", a.ProxyURL, r.Modpath, version, a.Elapsed.Round(time.Second))
+			missing++
+		}
+	}
+
+	if missing > 0 {
+		return fmt.Errorf("module proxy does not serve %d new version(s) within %s", missing, poll.Timeout)
+	}
+	return nil
+}
+
+// requestPkgGoDev asks pkg.go.dev to fetch each version that txn added,
+// for -pkg-go-dev.
+func requestPkgGoDev(ctx context.Context, txn *tagTxn) error {
+	for _, tag := range txn.tags {
+		r := txn.results[tag]
+		version := strings.TrimPrefix(tag, r.VersionPrefix)
+		if err := taggo.RequestPkgGoDev(ctx, r.Modpath, version); err != nil {
+			return errors.Wrapf(err, "requesting %s@%s from pkg.go.dev", r.Modpath, version)
+		}
+		fmt.Printf("📚 Requested documentation for %s@%s from pkg.go.dev; see https://pkg.go.dev/%s@%s\n", r.Modpath, version, r.Modpath, version)
+	}
+	return nil
+}
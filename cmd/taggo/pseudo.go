@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo"
+)
+
+// runPseudo implements "taggo pseudo".
+func runPseudo(ctx context.Context, args []string) error {
+	var (
+		git string
+		rev string
+		fs  = flag.NewFlagSet("pseudo", flag.ContinueOnError)
+	)
+	fs.StringVar(&git, "git", "", "path to git binary")
+	fs.StringVar(&rev, "rev", "", "revision whose version to compute (default: HEAD)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var (
+		repodir, moduledir string
+		err                error
+	)
+	switch fs.NArg() {
+	case 0:
+		repodir, moduledir, err = determineDirs(ctx, git, ".")
+	case 1:
+		repodir, moduledir, err = determineDirs(ctx, git, fs.Arg(0))
+	case 2:
+		repodir, moduledir = fs.Arg(0), fs.Arg(1)
+	default:
+		return fmt.Errorf("usage: %s pseudo [-git GIT] [-rev REV] [REPODIR] [MODULEDIR]", os.Args[0])
+	}
+	if err != nil {
+		return errors.Wrap(err, "determining directories")
+	}
+
+	version, err := taggo.PseudoVersion(ctx, git, repodir, moduledir, rev)
+	if err != nil {
+		return errors.Wrap(err, "computing pseudo-version")
+	}
+
+	fmt.Println(version)
+	return nil
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo"
+)
+
+// checkGoModsClean returns an error if the go.mod file of any module in modules
+// has uncommitted changes,
+// which -update-requires would otherwise sweep into its commit.
+func checkGoModsClean(ctx context.Context, txn *tagTxn, modules map[string]taggo.Result) error {
+	var dirty []string
+	for _, r := range modules {
+		gomodPath := path.Join(r.ModuleSubdir, "go.mod")
+		cmd, done := txn.command(ctx, "diff", "--quiet", "HEAD", "--", gomodPath)
+		err := done(cmd.Run())
+		var exitErr *exec.ExitError
+		switch {
+		case errors.As(err, &exitErr) && exitErr.ExitCode() == 1:
+			dirty = append(dirty, gomodPath)
+		case err != nil:
+			return errors.Wrapf(err, "running %s", cmd)
+		}
+	}
+	if len(dirty) > 0 {
+		sort.Strings(dirty)
+		return fmt.Errorf("cannot update requirements: uncommitted changes in %s", strings.Join(dirty, ", "))
+	}
+	return nil
+}
+
+// requirementsTrailer is the key of the trailer naming each new tag
+// in the commit made by commitRequirementUpdates.
+// It identifies the commit for "taggo undo".
+const requirementsTrailer = "Taggo-Tag"
+
+// commitRequirementUpdates updates the requirements of the modules in modules
+// on the modules newly tagged by txn
+// (see [taggo.UpdateRequirements]),
+// and commits the changed go.mod files
+// as directed by cc.
+// The commit is signed if cc says to or txn signs its tags.
+// If txn has a remote,
+// it also pushes the commit there.
+func commitRequirementUpdates(ctx context.Context, txn *tagTxn, modules map[string]taggo.Result, cc taggo.CommitConfig) error {
+	var (
+		newVersions = make(map[string]string) // module path -> new version
+		tags        []string
+		trailers    []string
+	)
+	for _, tag := range txn.tags {
+		r := txn.results[tag]
+		newVersions[r.Modpath] = strings.TrimPrefix(tag, r.VersionPrefix)
+		tags = append(tags, tag)
+		trailers = append(trailers, requirementsTrailer+": "+tag)
+	}
+
+	changed, err := taggo.UpdateRequirements(txn.repodir, modules, newVersions)
+	if err != nil {
+		return errors.Wrap(err, "updating requirements")
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	msg, err := cc.CommitMessage(taggo.CommitMessageData{Tags: tags, Files: changed})
+	if err != nil {
+		return err
+	}
+
+	args := []string{"commit", "-q", "-m", msg, "-m", strings.Join(trailers, "\n")}
+	if cc.Signoff {
+		args = append(args, "--signoff")
+	}
+	if txn.localUser != "" {
+		args = append(args, "-S"+txn.localUser)
+	} else if cc.Sign || txn.sign {
+		args = append(args, "-S")
+	}
+	args = append(args, "--")
+	args = append(args, changed...)
+	cmd, done := txn.command(ctx, args...)
+	output, err := cmd.CombinedOutput()
+	if err = done(err); err != nil {
+		return errors.Wrapf(err, "running %s: %s", cmd, bytes.TrimSpace(output))
+	}
+
+	fmt.Printf("📝 Committed updated requirements in %s (go.sum may need updating with go mod tidy)\n", strings.Join(changed, ", "))
+
+	if txn.remote == "" {
+		return nil
+	}
+	cmd, done = txn.command(ctx, "push", txn.remote, "HEAD")
+	output, err = cmd.CombinedOutput()
+	if err = done(err); err != nil {
+		return errors.Wrapf(err, "running %s: %s", cmd, bytes.TrimSpace(output))
+	}
+	fmt.Printf("🚀 Pushed the requirements commit to %s\n", txn.remote)
+
+	return nil
+}
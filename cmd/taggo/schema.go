@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo"
+)
+
+// runSchema implements "taggo schema".
+func runSchema(_ context.Context, args []string) error {
+	var (
+		all bool
+		fs  = flag.NewFlagSet("schema", flag.ContinueOnError)
+	)
+	fs.BoolVar(&all, "all", false, "describe the output of -all -json instead of -json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("usage: %s schema [-all]", os.Args[0])
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	err := enc.Encode(taggo.ResultSchema(all))
+	return errors.Wrap(err, "encoding schema")
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo"
+)
+
+// runSimulate implements "taggo simulate".
+func runSimulate(ctx context.Context, args []string) error {
+	var (
+		git string
+		rev string
+		fs  = flag.NewFlagSet("simulate", flag.ContinueOnError)
+	)
+	fs.StringVar(&git, "git", "", "path to git binary")
+	fs.StringVar(&rev, "rev", "", "revision to simulate releasing (default: the version tag if it exists, else HEAD)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() < 1 || fs.NArg() > 3 {
+		return fmt.Errorf("usage: %s simulate [-git GIT] [-rev REV] VERSION [REPODIR] [MODULEDIR]", os.Args[0])
+	}
+	version := fs.Arg(0)
+
+	var (
+		repodir, moduledir string
+		err                error
+	)
+	switch fs.NArg() {
+	case 1:
+		repodir, moduledir, err = determineDirs(ctx, git, ".")
+	case 2:
+		repodir, moduledir, err = determineDirs(ctx, git, fs.Arg(1))
+	case 3:
+		repodir, moduledir = fs.Arg(1), fs.Arg(2)
+	}
+	if err != nil {
+		return errors.Wrap(err, "determining directories")
+	}
+
+	if err := taggo.Simulate(ctx, git, repodir, moduledir, version, rev, os.Stdout); err != nil {
+		return errors.Wrapf(err, "simulating release of %s", version)
+	}
+
+	fmt.Printf("✅ Version %s can be fetched and built by consumers\n", version)
+	return nil
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo"
+)
+
+// Taggo records usage statistics only when the user opts in
+// by setting TAGGO_STATS.
+// The statistics are written to a local file and never sent anywhere.
+
+type statsRecord struct {
+	Time       time.Time      `json:"time"`
+	Seconds    float64        `json:"seconds"`
+	Modules    int            `json:"modules"`
+	Warnings   int            `json:"warnings"`
+	Categories map[string]int `json:"categories,omitempty"` // finding ID -> count of warnings
+}
+
+func statsEnabled() bool {
+	switch os.Getenv("TAGGO_STATS") {
+	case "1", "on", "true", "yes":
+		return true
+	}
+	return false
+}
+
+func statsPath() (string, error) {
+	if path := os.Getenv("TAGGO_STATS_FILE"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", errors.Wrap(err, "finding user config dir")
+	}
+	return filepath.Join(dir, "taggo", "stats.jsonl"), nil
+}
+
+// recordStats appends a record of this run to the stats file.
+func recordStats(start time.Time, results []taggo.Result) error {
+	path, err := statsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrapf(err, "creating directory for %s", path)
+	}
+
+	rec := statsRecord{
+		Time:    start,
+		Seconds: time.Since(start).Seconds(),
+		Modules: len(results),
+	}
+	for _, r := range results {
+		for _, f := range r.Findings() {
+			if f.Kind != taggo.FindingWarning {
+				continue
+			}
+			if rec.Categories == nil {
+				rec.Categories = make(map[string]int)
+			}
+			rec.Warnings++
+			rec.Categories[f.ID]++
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "opening %s", path)
+	}
+	defer f.Close()
+
+	if err := json.NewEncoder(f).Encode(rec); err != nil {
+		return errors.Wrapf(err, "writing to %s", path)
+	}
+	return f.Close()
+}
+
+// runStats implements "taggo stats".
+func runStats(_ context.Context, args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	path, err := statsPath()
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		if !statsEnabled() {
+			fmt.Println("No statistics recorded. Set TAGGO_STATS=on to record statistics (locally, in " + path + ").")
+		} else {
+			fmt.Println("No statistics recorded yet.")
+		}
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "opening %s", path)
+	}
+	defer f.Close()
+
+	var (
+		runs, runsWithWarnings, modules, warnings int
+		seconds                                   float64
+		first, last                               time.Time
+		categories                                = make(map[string]int)
+		sc                                        = bufio.NewScanner(f)
+	)
+	for sc.Scan() {
+		var rec statsRecord
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			continue // silently ignore malformed lines
+		}
+		runs++
+		if first.IsZero() || rec.Time.Before(first) {
+			first = rec.Time
+		}
+		if rec.Time.After(last) {
+			last = rec.Time
+		}
+		seconds += rec.Seconds
+		modules += rec.Modules
+		warnings += rec.Warnings
+		if rec.Warnings > 0 {
+			runsWithWarnings++
+		}
+		for id, n := range rec.Categories {
+			categories[id] += n
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return errors.Wrapf(err, "reading %s", path)
+	}
+
+	if runs == 0 {
+		fmt.Println("No statistics recorded yet.")
+		return nil
+	}
+
+	fmt.Printf("Runs: %d (%s to %s)\n", runs, first.Format(time.DateOnly), last.Format(time.DateOnly))
+	fmt.Printf("Average duration: %.1fs\n", seconds/float64(runs))
+	fmt.Printf("Modules checked: %d\n", modules)
+	fmt.Printf("Runs with warnings: %d (%.0f%%)\n", runsWithWarnings, 100*float64(runsWithWarnings)/float64(runs))
+
+	if len(categories) > 0 {
+		ids := make([]string, 0, len(categories))
+		for id := range categories {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool {
+			if categories[ids[i]] != categories[ids[j]] {
+				return categories[ids[i]] > categories[ids[j]]
+			}
+			return ids[i] < ids[j]
+		})
+
+		fmt.Printf("Warnings by category (%d total):\n", warnings)
+		for _, id := range ids {
+			fmt.Printf("  %-28s %d\n", id, categories[id])
+		}
+	}
+
+	return nil
+}
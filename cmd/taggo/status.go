@@ -0,0 +1,37 @@
+package main
+
+import "github.com/bobg/taggo"
+
+// statusFlag is the type of the -status flag.
+// A plain -status sets it to taggo.SeverityWarning;
+// -status=SEVERITY sets it to the given severity.
+// Warnings at least that severe produce exit status 2.
+type statusFlag taggo.Severity
+
+func (s *statusFlag) String() string {
+	return string(*s)
+}
+
+func (s *statusFlag) Set(val string) error {
+	if val == "true" {
+		val = string(taggo.SeverityWarning)
+	} else if val == "false" {
+		*s = ""
+		return nil
+	}
+	severity, err := taggo.ParseSeverity(val)
+	if err != nil {
+		return err
+	}
+	*s = statusFlag(severity)
+	return nil
+}
+
+func (s *statusFlag) IsBoolFlag() bool {
+	return true
+}
+
+// counts tells whether warnings of the given severity count toward the exit status.
+func (s statusFlag) counts(severity taggo.Severity) bool {
+	return s != "" && severity.AtLeast(taggo.Severity(s))
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/bobg/taggo"
+)
+
+// summaryVersions gives the latest version tag of r and its recommended new version tag,
+// each "-" if there is none,
+// for the one-line-per-module tables of -summary and "taggo batch".
+func summaryVersions(r taggo.Result) (latest, next string, recommended bool) {
+	latest, next = "-", "-"
+	if r.LatestVersion != "" {
+		latest = r.VersionPrefix + r.LatestVersion
+	}
+	if tag, ok := suggestedTag(r); ok {
+		next, recommended = tag, true
+	}
+	return latest, next, recommended
+}
+
+// writeSummaryTable writes the -summary table,
+// with one line per module,
+// followed by a line of totals.
+func writeSummaryTable(w io.Writer, modules []batchModule) error {
+	var (
+		recommended, warnings int
+		tw                    = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	)
+	fmt.Fprintln(tw, "MODULE\tLATEST\tRECOMMENDED\tWARNINGS")
+	for _, m := range modules {
+		latest, next, ok := summaryVersions(m.Result)
+		if ok {
+			recommended++
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\n", m.Result.Modpath, latest, next, m.Warnings)
+		warnings += m.Warnings
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%d module(s), %d new version(s) recommended, %d warning(s)\n", len(modules), recommended, warnings)
+	return err
+}
@@ -0,0 +1,527 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/bobg/errors"
+	"golang.org/x/mod/semver"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/gitutil"
+)
+
+// planTag determines the new version tag, if any, that should be added for r.
+// The boolean result is false if no tag is needed.
+func planTag(r taggo.Result) (string, bool, error) {
+	tag, ok := suggestedTag(r)
+	if !ok {
+		return "", false, nil
+	}
+
+	if r.NewMajor != r.LatestMajor {
+		return "", false, exitErr{code: 3, err: skipTagErr{fmt.Errorf("will not add new major-version tag %s", tag)}}
+	}
+	if r.NewVersionExists {
+		return "", false, exitErr{code: 3, err: skipTagErr{fmt.Errorf("will not add tag %s: it already exists on another branch", tag)}}
+	}
+
+	return tag, true, nil
+}
+
+// skipTagErr is the error from planTag for a module whose recommended tag Taggo declines to add.
+// With -all, such a module is skipped with a warning
+// instead of preventing the other modules' tags.
+type skipTagErr struct {
+	error
+}
+
+func (e skipTagErr) Unwrap() error {
+	return e.error
+}
+
+// suggestedTag is the recommended new version tag for r, if any,
+// including any VersionPrefix.
+func suggestedTag(r taggo.Result) (string, bool) {
+	if r.DefaultBranch == "" {
+		return "", false
+	}
+	if r.PhaseError != nil {
+		return "", false
+	}
+	if r.LatestCommit == "" {
+		return "", false
+	}
+	if r.LatestCommitHasVersionTag {
+		return "", false
+	}
+	if r.NewMajor == 0 && r.NewMinor == 0 && r.NewPatch == 0 {
+		return "", false
+	}
+
+	bareTag := r.NewVersion()
+	if semver.Compare(bareTag, r.LatestVersion) == 0 { // build metadata doesn't count
+		return "", false
+	}
+	return r.VersionPrefix + bareTag, true
+}
+
+// defaultTagMessage is the format of the message for annotated tags
+// when no -m flag is given.
+// It also identifies Taggo-created tags for "taggo undo".
+const defaultTagMessage = "Version %s added by Taggo"
+
+// tagTxn is a set of new version tags that are created (and optionally pushed) together.
+// If any step fails, the tags created locally so far are deleted.
+type tagTxn struct {
+	git, repodir string
+	sign         bool
+	localUser    string // if non-empty, sign with this key (implies sign)
+	msg          string
+	lightweight  bool
+	remote       string // if non-empty, verify against and push to this remote
+	events       *eventLog
+	timeout      time.Duration // if non-zero, limits each git operation
+	api          *http.Client  // if non-nil, create tags through the forge's web API with this client (see commitAPI)
+	checkRemote  bool          // if true, refuse to create tags that exist at other commits in the remote (see checkRemoteTags)
+
+	tags    []string
+	commits map[string]string       // tag -> commit
+	msgs    map[string]string       // tag -> message, overriding msg
+	results map[string]taggo.Result // tag -> result for the module being tagged
+}
+
+// add adds tag, for the module described by r, to txn.
+// The tag will point to r.LatestCommit.
+func (txn *tagTxn) add(tag string, r taggo.Result) {
+	txn.addAt(tag, r.LatestCommit, "", r)
+}
+
+// addAt adds tag, for the module described by r, to txn.
+// The tag will point to commit.
+// If msg is not empty, it is the tag's message,
+// overriding txn.msg.
+func (txn *tagTxn) addAt(tag, commit, msg string, r taggo.Result) {
+	if txn.commits == nil {
+		txn.commits = make(map[string]string)
+		txn.msgs = make(map[string]string)
+		txn.results = make(map[string]taggo.Result)
+	}
+	txn.tags = append(txn.tags, tag)
+	txn.commits[tag] = commit
+	if msg != "" {
+		txn.msgs[tag] = msg
+	}
+	txn.results[tag] = r
+}
+
+// commit creates the tags in txn locally,
+// then, if txn.remote is set,
+// verifies that none of them already exists in the remote
+// and pushes them all in a single atomic push.
+func (txn *tagTxn) commit(ctx context.Context) (err error) {
+	if len(txn.tags) == 0 {
+		return nil
+	}
+	if txn.api != nil {
+		return txn.commitAPI(ctx)
+	}
+
+	if txn.sign || txn.localUser != "" {
+		if err := txn.checkSigning(ctx); err != nil {
+			return err
+		}
+	}
+	if txn.checkRemote {
+		if err := txn.checkRemoteTags(ctx); err != nil {
+			return err
+		}
+	}
+
+	var created []string
+
+	defer func() {
+		if err == nil {
+			return
+		}
+		if rbErr := txn.rollback(ctx, created); rbErr != nil {
+			err = errors.Join(err, errors.Wrap(rbErr, "rolling back"))
+		}
+	}()
+
+	for _, tag := range txn.tags {
+		err := txn.createTag(ctx, tag)
+		txn.events.tagCreated(tag, txn.commits[tag], err)
+		if err != nil {
+			return errors.Wrapf(err, "creating tag %s", tag)
+		}
+		created = append(created, tag)
+	}
+
+	if txn.remote != "" {
+		conflicts, err := txn.remoteConflicts(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "checking remote %s for existing tags", txn.remote)
+		}
+		if len(conflicts) > 0 {
+			return fmt.Errorf("tag(s) already present in remote %s: %s", txn.remote, strings.Join(conflicts, ", "))
+		}
+
+		err = txn.push(ctx)
+		txn.events.pushAttempted(txn.remote, txn.tags, err)
+		if err != nil {
+			return errors.Wrapf(err, "pushing tags to %s", txn.remote)
+		}
+	}
+
+	for _, tag := range txn.tags {
+		fmt.Printf("🪄 Added tag %s\n", tag)
+	}
+	if txn.remote != "" {
+		fmt.Printf("🚀 Pushed %d tag(s) to %s\n", len(txn.tags), txn.remote)
+	}
+
+	return nil
+}
+
+func (txn *tagTxn) createTag(ctx context.Context, tag string) error {
+	args := []string{"tag"}
+	if !txn.lightweight {
+		args = append(args, "-m", txn.message(tag))
+		if txn.localUser != "" {
+			args = append(args, "-u", txn.localUser)
+		} else if txn.sign {
+			args = append(args, "-s")
+		}
+	}
+	args = append(args, tag, txn.commits[tag])
+
+	var stderr bytes.Buffer
+
+	cmd, done := txn.command(ctx, args...)
+	cmd.Stderr = &stderr
+	err := done(cmd.Run())
+
+	signing := !txn.lightweight && (txn.sign || txn.localUser != "")
+	if signing && err == nil {
+		// Some versions of Git create an unsigned tag, and exit successfully,
+		// when SSH signing fails.
+		var signed bool
+		signed, err = txn.isSigned(ctx, tag)
+		if err != nil {
+			err = errors.Wrapf(err, "checking signature of tag %s", tag)
+			if delErr := txn.rollback(ctx, []string{tag}); delErr != nil {
+				err = errors.Join(err, errors.Wrapf(delErr, "deleting tag %s", tag))
+			}
+			return err
+		}
+		if !signed {
+			if delErr := txn.rollback(ctx, []string{tag}); delErr != nil {
+				return errors.Wrapf(delErr, "deleting unsigned tag %s", tag)
+			}
+			err = fmt.Errorf("tag is unsigned")
+		}
+	}
+
+	if err != nil {
+		if signing {
+			format, _ := txn.config(ctx, "gpg.format")
+			if format == "" {
+				format = "openpgp"
+			}
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return errors.Wrapf(err, "signing tag failed (gpg.format is %s): %s", format, msg)
+			}
+			return errors.Wrapf(err, "signing tag failed (gpg.format is %s)", format)
+		}
+		return errors.Wrapf(err, "running %s: %s", cmd, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// message is the message for the annotated tag named tag.
+func (txn *tagTxn) message(tag string) string {
+	if msg := txn.msgs[tag]; msg != "" {
+		return msg
+	}
+	if txn.msg != "" {
+		return txn.msg
+	}
+	return fmt.Sprintf(defaultTagMessage, tag)
+}
+
+// commitAPI creates the tags in txn
+// through the web API of the forge hosting the remote repository,
+// instead of creating them locally and pushing them.
+// If any step fails,
+// the tags created so far are deleted, also through the API.
+func (txn *tagTxn) commitAPI(ctx context.Context) (err error) {
+	type createdTag struct {
+		tag   string
+		forge taggo.Forge
+		token string
+	}
+	var created []createdTag
+
+	defer func() {
+		if err == nil || len(created) == 0 {
+			return
+		}
+		// Use a fresh context in case ctx is the reason we're rolling back.
+		ctx := context.WithoutCancel(ctx)
+		var rolledBack []string
+		for _, c := range created {
+			delErr := c.forge.DeleteTag(ctx, txn.api, c.token, c.tag)
+			txn.events.tagDeleted(c.tag, "", delErr)
+			if delErr != nil {
+				err = errors.Join(err, errors.Wrap(delErr, "rolling back"))
+				continue
+			}
+			rolledBack = append(rolledBack, c.tag)
+		}
+		if len(rolledBack) > 0 {
+			fmt.Printf("↩️ Rolled back tag(s) %s\n", strings.Join(rolledBack, ", "))
+		}
+	}()
+
+	for _, tag := range txn.tags {
+		forge, ok := txn.results[tag].Forge()
+		if !ok {
+			return fmt.Errorf("cannot create tag %s through a forge API: remote URL %q is not on a known forge", tag, txn.results[tag].RemoteURL)
+		}
+		token := forgeToken(forge.Kind)
+		if token == "" {
+			return fmt.Errorf("cannot create tag %s through the %s API: set %s", tag, forge.Kind, strings.Join(forgeTokenVars[forge.Kind], " or "))
+		}
+
+		var msg string
+		if !txn.lightweight {
+			msg = txn.message(tag)
+		}
+
+		err := forge.CreateTag(ctx, txn.api, token, tag, txn.commits[tag], msg)
+		txn.events.tagCreated(tag, txn.commits[tag], err)
+		if err != nil {
+			return errors.Wrapf(err, "creating tag %s", tag)
+		}
+		created = append(created, createdTag{tag: tag, forge: forge, token: token})
+	}
+
+	for _, c := range created {
+		fmt.Printf("🪄 Added tag %s through the %s API\n", c.tag, c.forge.Kind)
+	}
+	return nil
+}
+
+// forgeTokenVars lists, for each kind of forge,
+// the environment variables that may hold an API token for it,
+// in order of preference.
+var forgeTokenVars = map[taggo.ForgeKind][]string{
+	taggo.ForgeGitHub:   {"GITHUB_TOKEN", "GH_TOKEN"},
+	taggo.ForgeGitLab:   {"GITLAB_TOKEN"},
+	taggo.ForgeCodeberg: {"CODEBERG_TOKEN", "GITEA_TOKEN"},
+}
+
+// forgeToken returns the API token for the given kind of forge
+// from the environment,
+// or "" if none is set.
+func forgeToken(kind taggo.ForgeKind) string {
+	for _, v := range forgeTokenVars[kind] {
+		if token := os.Getenv(v); token != "" {
+			return token
+		}
+	}
+	return ""
+}
+
+// isSigned tells whether the given annotated tag has a signature.
+func (txn *tagTxn) isSigned(ctx context.Context, tag string) (bool, error) {
+	cmd, done := txn.command(ctx, "cat-file", "tag", tag)
+	output, err := cmd.Output()
+	if err = done(err); err != nil {
+		return false, errors.Wrapf(err, "running %s", cmd)
+	}
+	for _, marker := range []string{"-----BEGIN PGP SIGNATURE-----", "-----BEGIN SSH SIGNATURE-----", "-----BEGIN SIGNED MESSAGE-----"} {
+		if bytes.Contains(output, []byte(marker)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkSigning makes sure tags can be signed with the signing configuration in effect,
+// so that a misconfiguration is reported before any tag is created.
+func (txn *tagTxn) checkSigning(ctx context.Context) error {
+	format, err := txn.config(ctx, "gpg.format")
+	if err != nil {
+		return errors.Wrap(err, "reading gpg.format")
+	}
+	if format != "ssh" || txn.localUser != "" {
+		return nil
+	}
+
+	// With gpg.format=ssh, Git has no default key to fall back on.
+	key, err := txn.config(ctx, "user.signingKey")
+	if err != nil {
+		return errors.Wrap(err, "reading user.signingKey")
+	}
+	if key == "" {
+		return fmt.Errorf("gpg.format is ssh but user.signingKey is not set; set it or use -local-user")
+	}
+	return nil
+}
+
+// checkRemoteTags makes sure that none of the tags in txn
+// exists in the remote at a different commit,
+// where it would make the push fail
+// or leave the local and remote tags silently diverged.
+// The remote for each tag is txn.remote if set,
+// otherwise the Remote of the tag's result.
+// Tags with no remote are not checked.
+func (txn *tagTxn) checkRemoteTags(ctx context.Context) error {
+	var (
+		remotes  []string
+		byRemote = make(map[string][]string) // remote -> tags
+	)
+	for _, tag := range txn.tags {
+		remote := txn.remote
+		if remote == "" {
+			remote = txn.results[tag].Remote
+		}
+		if remote == "" {
+			continue
+		}
+		if _, ok := byRemote[remote]; !ok {
+			remotes = append(remotes, remote)
+		}
+		byRemote[remote] = append(byRemote[remote], tag)
+	}
+
+	var problems []string
+	for _, remote := range remotes {
+		commits, err := txn.remoteTagCommits(ctx, remote, byRemote[remote])
+		if err != nil {
+			return errors.Wrapf(err, "checking remote %s for existing tags", remote)
+		}
+		for _, tag := range byRemote[remote] {
+			if commit, ok := commits[tag]; ok && commit != txn.commits[tag] {
+				problems = append(problems, fmt.Sprintf("%s exists in remote %s at commit %s, not %s", tag, remote, shortHash(commit), shortHash(txn.commits[tag])))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return exitErr{code: 3, err: fmt.Errorf("will not add tag(s): %s", strings.Join(problems, "; "))}
+	}
+	return nil
+}
+
+// remoteConflicts returns the tags in txn that already exist in the remote.
+func (txn *tagTxn) remoteConflicts(ctx context.Context) ([]string, error) {
+	commits, err := txn.remoteTagCommits(ctx, txn.remote, txn.tags)
+	if err != nil {
+		return nil, err
+	}
+	var conflicts []string
+	for _, tag := range txn.tags {
+		if _, ok := commits[tag]; ok {
+			conflicts = append(conflicts, tag)
+		}
+	}
+	return conflicts, nil
+}
+
+// remoteTagCommits returns the commits that those of the given tags
+// that exist in remote refer to there,
+// using a single "git ls-remote".
+func (txn *tagTxn) remoteTagCommits(ctx context.Context, remote string, tags []string) (map[string]string, error) {
+	args := []string{"ls-remote", "--tags", remote}
+	for _, tag := range tags {
+		args = append(args, "refs/tags/"+tag, "refs/tags/"+tag+"^{}")
+	}
+
+	cmd, done := txn.command(ctx, args...)
+	output, err := cmd.Output()
+	if err = done(err); err != nil {
+		return nil, errors.Wrapf(err, "running %s", cmd)
+	}
+
+	commits := make(map[string]string)
+	for _, line := range strings.Split(string(bytes.TrimSpace(output)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		name, peeled := strings.CutSuffix(strings.TrimPrefix(fields[1], "refs/tags/"), "^{}")
+		if _, ok := txn.commits[name]; !ok {
+			continue
+		}
+		// For an annotated tag, the peeled line (the commit) follows the tag object's line.
+		if _, ok := commits[name]; !ok || peeled {
+			commits[name] = fields[0]
+		}
+	}
+	return commits, nil
+}
+
+func (txn *tagTxn) push(ctx context.Context) error {
+	args := []string{"push", "--atomic", txn.remote}
+	for _, tag := range txn.tags {
+		args = append(args, "refs/tags/"+tag)
+	}
+
+	cmd, done := txn.command(ctx, args...)
+	err := done(cmd.Run())
+	return errors.Wrapf(err, "running %s", cmd)
+}
+
+// command returns a command that runs git with args in txn.repodir,
+// limited by txn.timeout if set.
+// As with [gitutil.Command],
+// the caller must pass the command's error (or nil) to done when the command has finished.
+func (txn *tagTxn) command(ctx context.Context, args ...string) (cmd *exec.Cmd, done func(error) error) {
+	return gitutil.Command(gitutil.WithTimeout(ctx, txn.timeout), txn.git, txn.repodir, args...)
+}
+
+func (txn *tagTxn) rollback(ctx context.Context, created []string) error {
+	if len(created) == 0 {
+		return nil
+	}
+
+	args := append([]string{"tag", "-d"}, created...)
+
+	// Use a fresh context in case ctx is the reason we're rolling back.
+	cmd, done := txn.command(context.WithoutCancel(ctx), args...)
+	err := done(cmd.Run())
+	for _, tag := range created {
+		txn.events.tagDeleted(tag, "", err)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "running %s", cmd)
+	}
+
+	fmt.Printf("↩️ Rolled back tag(s) %s\n", strings.Join(created, ", "))
+	return nil
+}
+
+// config returns the value of a Git config key in txn.repodir,
+// or "" if it is not set.
+func (txn *tagTxn) config(ctx context.Context, key string) (string, error) {
+	cmd, done := txn.command(ctx, "config", "--get", key)
+	output, err := cmd.Output()
+	err = done(err)
+	var ee *exec.ExitError
+	if errors.As(err, &ee) && ee.ExitCode() == 1 {
+		// Key not set.
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "running %s", cmd)
+	}
+	return string(bytes.TrimSpace(output)), nil
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/bobg/errors"
+	"golang.org/x/mod/semver"
+
+	"github.com/bobg/taggo"
+)
+
+// runUndo implements "taggo undo".
+func runUndo(ctx context.Context, args []string) error {
+	var (
+		eventsFile string
+		force      bool
+		git        string
+		remote     string
+		yes        bool
+		fs         = flag.NewFlagSet("undo", flag.ContinueOnError)
+	)
+	fs.StringVar(&eventsFile, "events", "", "find the tag to undo in this event log (see -events), and record the undo there")
+	fs.BoolVar(&force, "force", false, "skip checking whether the module proxy and checksum database have recorded the version (requires network)")
+	fs.StringVar(&git, "git", "", "path to git binary")
+	fs.StringVar(&remote, "remote", "origin", "remote from which to delete the tag (empty for none)")
+	fs.BoolVar(&yes, "y", false, "do not ask for confirmation")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() > 1 {
+		return fmt.Errorf("usage: %s undo [-events FILE] [-force] [-git GIT] [-remote REMOTE] [-y] [REPODIR]", os.Args[0])
+	}
+	dir := "."
+	if fs.NArg() == 1 {
+		dir = fs.Arg(0)
+	}
+	repodir, err := findRepo(ctx, git, dir)
+	if err != nil {
+		return errors.Wrap(err, "finding repository directory")
+	}
+	repodir, err = filepath.Abs(repodir)
+	if err != nil {
+		return errors.Wrapf(err, "making %s absolute", repodir)
+	}
+
+	if git == "" {
+		git, err = exec.LookPath("git")
+		if err != nil {
+			return errors.Wrap(err, "finding git binary")
+		}
+	}
+
+	var tag string
+	if eventsFile != "" {
+		tag, err = lastLoggedTag(eventsFile, repodir)
+	} else {
+		tag, err = lastTaggoTag(ctx, git, repodir)
+	}
+	if err != nil {
+		return err
+	}
+	if tag == "" {
+		return fmt.Errorf("found no tag created by taggo")
+	}
+
+	var (
+		moduledir = path.Dir(tag)
+		version   = path.Base(tag)
+	)
+	if moduledir == "." {
+		moduledir = ""
+	}
+	if !semver.IsValid(version) {
+		return fmt.Errorf("tag %s is not a version tag", tag)
+	}
+
+	fmt.Printf("Most recent tag created by taggo: %s\n", tag)
+
+	if !force {
+		v, err := taggo.Verify(ctx, git, repodir, filepath.Join(repodir, moduledir), version, remote)
+		if err != nil {
+			return errors.Wrap(err, "checking the module proxy and checksum database (use -force to skip)")
+		}
+		if v.ProxyHasVersion || v.SumDBHash != "" {
+			fmt.Printf("⛔️ %s@%s is already recorded by the module proxy or checksum database, so it cannot be undone.\n", v.Modpath, version)
+			fmt.Println("   Instead, release a new version whose go.mod retracts it:")
+			fmt.Printf("   retract %s\n", version)
+			return exitErr{code: 2, err: fmt.Errorf("cannot undo %s", tag)}
+		}
+	}
+
+	onRemote := false
+	if remote != "" {
+		onRemote, err = remoteHasTag(ctx, git, repodir, remote, tag)
+		if err != nil {
+			return err
+		}
+	}
+
+	reqCommit, err := requirementsCommit(ctx, git, repodir, tag)
+	if err != nil {
+		return err
+	}
+
+	if !yes {
+		question := fmt.Sprintf("Delete tag %s locally?", tag)
+		if onRemote {
+			question = fmt.Sprintf("Delete tag %s locally and from %s?", tag, remote)
+		}
+		if reqCommit != "" {
+			question = fmt.Sprintf("%s And revert commit %s, which updated requirements to it?", strings.TrimSuffix(question, "?"), reqCommit[:12])
+		}
+		ok, err := newPrompter(os.Stdin, os.Stdout).confirm(question)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+
+	var events *eventLog
+	if eventsFile != "" {
+		events, err = openEventLog(eventsFile, repodir)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := events.Close(); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", err)
+			}
+		}()
+	}
+
+	if onRemote {
+		cmd := exec.CommandContext(ctx, git, "push", remote, ":refs/tags/"+tag)
+		cmd.Dir = repodir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			err = errors.Wrapf(err, "running %s: %s", cmd, bytes.TrimSpace(output))
+		}
+		events.tagDeleted(tag, remote, err)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("🗑️ Deleted tag %s from %s\n", tag, remote)
+	}
+
+	cmd := exec.CommandContext(ctx, git, "tag", "-d", tag)
+	cmd.Dir = repodir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		err = errors.Wrapf(err, "running %s: %s", cmd, bytes.TrimSpace(output))
+	}
+	events.tagDeleted(tag, "", err)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("🗑️ Deleted tag %s locally\n", tag)
+
+	if reqCommit != "" {
+		cmd := exec.CommandContext(ctx, git, "revert", "--no-edit", reqCommit)
+		cmd.Dir = repodir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return errors.Wrapf(err, "running %s: %s", cmd, bytes.TrimSpace(output))
+		}
+		fmt.Printf("↩️ Reverted commit %s, which updated requirements to %s\n", reqCommit[:12], tag)
+		if onRemote {
+			fmt.Printf("ℹ️ The revert is not pushed; push it to %s yourself.\n", remote)
+		}
+	}
+
+	fmt.Println("ℹ️ Anyone who already fetched the tag still has it; they must delete it themselves.")
+
+	return nil
+}
+
+// requirementsCommit returns the commit after tag in repodir
+// that -update-requires made for it
+// (identified by its requirementsTrailer),
+// or "" if there is none.
+func requirementsCommit(ctx context.Context, git, repodir, tag string) (string, error) {
+	cmd := exec.CommandContext(ctx, git, "log", "--format=%H%x00%(trailers:key="+requirementsTrailer+",valueonly,separator=%x20)", "refs/tags/"+tag+"..HEAD")
+	cmd.Dir = repodir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrapf(err, "running %s", cmd)
+	}
+
+	sc := bufio.NewScanner(bytes.NewReader(output))
+	for sc.Scan() {
+		hash, tags, ok := strings.Cut(sc.Text(), "\x00")
+		if ok && slices.Contains(strings.Fields(tags), tag) {
+			return hash, nil
+		}
+	}
+	return "", errors.Wrapf(sc.Err(), "scanning output of %s", cmd)
+}
+
+// lastTaggoTag returns the most recently created annotated tag in repodir
+// with Taggo's default message,
+// or "" if there is none.
+func lastTaggoTag(ctx context.Context, git, repodir string) (string, error) {
+	cmd := exec.CommandContext(ctx, git, "for-each-ref", "--sort=-creatordate", "--format=%(refname:strip=2)%00%(objecttype)%00%(contents:subject)", "refs/tags")
+	cmd.Dir = repodir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrapf(err, "running %s", cmd)
+	}
+
+	sc := bufio.NewScanner(bytes.NewReader(output))
+	for sc.Scan() {
+		fields := strings.SplitN(sc.Text(), "\x00", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		tag, objtype, subject := fields[0], fields[1], fields[2]
+		if objtype == "tag" && subject == fmt.Sprintf(defaultTagMessage, tag) {
+			return tag, nil
+		}
+	}
+	return "", errors.Wrapf(sc.Err(), "scanning output of %s", cmd)
+}
+
+// lastLoggedTag returns the tag most recently created in repodir
+// according to the event log in filename,
+// and not since deleted,
+// or "" if there is none.
+func lastLoggedTag(filename, repodir string) (string, error) {
+	repodir, err := filepath.Abs(repodir)
+	if err != nil {
+		return "", errors.Wrapf(err, "making %s absolute", repodir)
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return "", errors.Wrapf(err, "opening %s", filename)
+	}
+	defer f.Close()
+
+	var (
+		created []string
+		dec     = json.NewDecoder(f)
+	)
+	for dec.More() {
+		var e event
+		if err := dec.Decode(&e); err != nil {
+			return "", errors.Wrapf(err, "reading %s", filename)
+		}
+		if e.Repo != repodir || e.Error != "" {
+			continue
+		}
+		switch e.Type {
+		case eventTagCreated:
+			created = append(created, e.Tag)
+		case eventTagDeleted:
+			if e.Remote != "" {
+				continue
+			}
+			for i := len(created) - 1; i >= 0; i-- {
+				if created[i] == e.Tag {
+					created = append(created[:i], created[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+	if len(created) == 0 {
+		return "", nil
+	}
+	return created[len(created)-1], nil
+}
+
+// remoteHasTag tells whether tag exists in remote.
+func remoteHasTag(ctx context.Context, git, repodir, remote, tag string) (bool, error) {
+	cmd := exec.CommandContext(ctx, git, "ls-remote", "--tags", remote, "refs/tags/"+tag)
+	cmd.Dir = repodir
+	output, err := cmd.Output()
+	if err != nil {
+		return false, errors.Wrapf(err, "running %s", cmd)
+	}
+	return len(bytes.TrimSpace(output)) > 0, nil
+}
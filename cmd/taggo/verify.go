@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo"
+)
+
+// runVerify implements "taggo verify".
+func runVerify(ctx context.Context, args []string) error {
+	var (
+		git    string
+		remote string
+		fs     = flag.NewFlagSet("verify", flag.ContinueOnError)
+	)
+	fs.StringVar(&git, "git", "", "path to git binary")
+	fs.StringVar(&remote, "remote", "origin", "remote in which to look for the version tag")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() < 1 || fs.NArg() > 3 {
+		return fmt.Errorf("usage: %s verify [-git GIT] [-remote REMOTE] VERSION [REPODIR] [MODULEDIR]", os.Args[0])
+	}
+	version := fs.Arg(0)
+
+	var (
+		repodir, moduledir string
+		err                error
+	)
+	switch fs.NArg() {
+	case 1:
+		repodir, moduledir, err = determineDirs(ctx, git, ".")
+	case 2:
+		repodir, moduledir, err = determineDirs(ctx, git, fs.Arg(1))
+	case 3:
+		repodir, moduledir = fs.Arg(1), fs.Arg(2)
+	}
+	if err != nil {
+		return errors.Wrap(err, "determining directories")
+	}
+
+	v, err := taggo.Verify(ctx, git, repodir, moduledir, version, remote)
+	if err != nil {
+		return errors.Wrapf(err, "verifying release of %s", version)
+	}
+
+	var (
+		okf   = func(format string, args ...any) { fmt.Printf("✅ "+format+"\n", args...) }
+		failf = func(format string, args ...any) { fmt.Printf("⛔️ "+format+"\n", args...) }
+	)
+
+	if v.LocalCommit != "" {
+		okf("Tag %s exists locally at %s", v.Tag, shortHash(v.LocalCommit))
+	} else {
+		failf("Tag %s does not exist locally", v.Tag)
+	}
+
+	switch {
+	case v.RemoteCommit == "":
+		failf("Tag %s does not exist in remote %s", v.Tag, remote)
+	case v.RemoteCommit != v.LocalCommit:
+		failf("Tag %s exists in remote %s at a different commit, %s", v.Tag, remote, shortHash(v.RemoteCommit))
+	default:
+		okf("Tag %s exists in remote %s at the same commit", v.Tag, remote)
+	}
+
+	switch {
+	case !v.ProxyHasVersion:
+		failf("Module proxy does not serve %s@%s", v.Modpath, version)
+	case v.ProxyCommit != "" && v.ProxyCommit != v.LocalCommit:
+		failf("Module proxy serves %s@%s from a different commit, %s", v.Modpath, version, shortHash(v.ProxyCommit))
+	default:
+		okf("Module proxy serves %s@%s", v.Modpath, version)
+	}
+
+	switch {
+	case v.SumDBHash == "":
+		failf("Checksum database has no record of %s@%s", v.Modpath, version)
+	case v.LocalCommit == "":
+		okf("Checksum database records %s@%s", v.Modpath, version)
+	case v.SumDBHash != v.ZipHash:
+		failf("Checksum database hash %s does not match local module zip hash %s", v.SumDBHash, v.ZipHash)
+	default:
+		okf("Checksum database hash matches local module zip")
+	}
+
+	if v.PkgGoDevHasVersion {
+		fmt.Printf("✅ pkg.go.dev shows %s@%s\n", v.Modpath, version)
+	} else {
+		fmt.Printf("⏳ pkg.go.dev does not show %s@%s yet (this can take a while)\n", v.Modpath, version)
+	}
+
+	if !v.OK() {
+		return exitErr{code: 2, err: fmt.Errorf("release of %s has not fully propagated", version)}
+	}
+	return nil
+}
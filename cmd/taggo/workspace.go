@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bobg/taggo"
+)
+
+// describeWorkspace writes to w a warning about each way
+// that the repository's go.work file disagrees with the modules in the repository,
+// returning the number of warnings.
+func describeWorkspace(w io.Writer, ws *taggo.Workspace) int {
+	var n int
+	if len(ws.Unlisted) > 0 {
+		fmt.Fprintf(w, "⛔️ Module(s) not used in go.work: %s\n", strings.Join(ws.Unlisted, ", "))
+		n++
+	}
+	if len(ws.Missing) > 0 {
+		fmt.Fprintf(w, "⛔️ Directories used in go.work without a go.mod: %s\n", strings.Join(ws.Missing, ", "))
+		n++
+	}
+	return n
+}
@@ -0,0 +1,47 @@
+package taggo
+
+import (
+	"context"
+	"time"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo/gitutil"
+)
+
+// CommitSummary describes one commit.
+// See Result.UnreleasedCommitSummaries.
+type CommitSummary struct {
+	// Hash is the commit hash.
+	Hash string
+
+	// Author is the commit's author, as "name <email>".
+	Author string
+
+	// Time is the committer time.
+	Time time.Time
+
+	// Subject is the first line of the commit message.
+	Subject string
+}
+
+// commitSummaries returns summaries of the commits in from..to,
+// newest first,
+// limited to those touching path if it is non-empty.
+// If from is empty,
+// it includes all the commits reachable from to.
+func commitSummaries(ctx context.Context, git, repodir, from, to, path string) ([]CommitSummary, error) {
+	details, err := gitutil.Commits(ctx, git, repodir, from, to, path)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]CommitSummary, 0, len(details))
+	for _, d := range details {
+		t, err := time.Parse(time.RFC3339, d.CommitTime)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing commit time %s", d.CommitTime)
+		}
+		result = append(result, CommitSummary{Hash: d.Hash, Author: d.Author, Time: t, Subject: d.Subject})
+	}
+	return result, nil
+}
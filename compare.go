@@ -0,0 +1,179 @@
+package taggo
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bobg/errors"
+	"github.com/bobg/modver/v2"
+	"golang.org/x/tools/go/packages"
+)
+
+// comparer compares two revisions of a module with Modver,
+// under a build context and leaving out ignored files and packages.
+type comparer struct {
+	build BuildContext
+
+	// moduledir is the module's directory relative to the repository root.
+	moduledir string
+
+	// ignore holds patterns for files and packages to leave out of the comparison
+	// (see Config.Ignore).
+	ignore []string
+}
+
+// compareRevs compares two revisions of the module in moduledir
+// (relative to the root of the repository whose Git directory is gitdir)
+// with Modver,
+// under the build context and ignore patterns in o.
+// It also returns the build context it used.
+func (o options) compareRevs(ctx context.Context, git, gitdir, moduledir, olderRev, newerRev string) (modver.Result, BuildContext, error) {
+	// Modver runs git in temporary clones of the repository,
+	// where a GIT_DIR or GIT_WORK_TREE inherited from the environment
+	// would redirect its checkouts to this repository.
+	// In that case use Modver's built-in Git implementation instead.
+	modverGit := git
+	if os.Getenv("GIT_DIR") != "" || os.Getenv("GIT_WORK_TREE") != "" {
+		modverGit = ""
+	}
+	mctx := modver.WithGit(ctx, modverGit)
+	if o.gitTimeout > 0 {
+		var cancel context.CancelFunc
+		mctx, cancel = context.WithTimeout(mctx, o.gitTimeout)
+		defer cancel()
+	}
+
+	mc := o.config.module(moduledir)
+	c := comparer{build: *mc.Build, moduledir: moduledir, ignore: mc.Ignore}
+	if o.buildContext != nil {
+		c.build = *o.buildContext
+	}
+
+	res, err := c.compareGit(mctx, gitdir, olderRev, newerRev)
+	return res, c.build, timeoutErr(ctx, mctx, err, o.gitTimeout)
+}
+
+// compareGit compares the Go packages in two revisions of the Git repository at repoURL.
+func (c comparer) compareGit(ctx context.Context, repoURL, olderRev, newerRev string) (modver.Result, error) {
+	if !c.build.isSet() && len(c.ignore) == 0 {
+		return modver.CompareGit(ctx, repoURL, olderRev, newerRev)
+	}
+	return modver.CompareGitWith(ctx, repoURL, olderRev, newerRev, c.compareDirs)
+}
+
+// compareDirs is like [modver.CompareDirs],
+// but it loads the packages of the module in c.moduledir under c.build
+// and leaves out those matching c.ignore.
+func (c comparer) compareDirs(older, newer string) (modver.Result, error) {
+	olders, err := c.loadPackages(older)
+	if err != nil {
+		return modver.None, err
+	}
+	newers, err := c.loadPackages(newer)
+	if err != nil {
+		return modver.None, err
+	}
+	return modver.Compare(olders, newers), nil
+}
+
+func (c comparer) loadPackages(clonedir string) ([]*packages.Package, error) {
+	dir := filepath.Join(clonedir, c.moduledir)
+
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo | packages.NeedModule,
+		Dir:  dir,
+
+		// The clone in dir is not part of any workspace the caller may be in.
+		Env: append(os.Environ(), "GOWORK=off"),
+	}
+	if len(c.build.Tags) > 0 {
+		cfg.BuildFlags = []string{"-tags=" + strings.Join(c.build.Tags, ",")}
+	}
+	if c.build.GOOS != "" {
+		cfg.Env = append(cfg.Env, "GOOS="+c.build.GOOS)
+	}
+	if c.build.GOARCH != "" {
+		cfg.Env = append(cfg.Env, "GOARCH="+c.build.GOARCH)
+	}
+
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading %s/...", dir)
+	}
+
+	var result []*packages.Package
+	for _, p := range pkgs {
+		if len(p.Errors) > 0 {
+			var msgs []string
+			for _, e := range p.Errors {
+				msgs = append(msgs, e.Error())
+			}
+			return nil, fmt.Errorf("error(s) loading package %s: %s", p.PkgPath, strings.Join(msgs, "; "))
+		}
+
+		var (
+			pkgdir string
+			syntax []*ast.File
+		)
+		for _, f := range p.Syntax {
+			filename, err := filepath.Rel(dir, p.Fset.Position(f.Package).Filename)
+			if err != nil {
+				return nil, errors.Wrapf(err, "locating file of package %s", p.PkgPath)
+			}
+			filename = filepath.ToSlash(filename)
+			pkgdir = path.Dir(filename)
+			if !ignoredBy(c.ignore, filename, false) {
+				syntax = append(syntax, f)
+			}
+		}
+		if pkgdir != "" && ignoredBy(c.ignore, pkgdir, true) {
+			continue
+		}
+		p.Syntax = syntax
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+// ignoredBy tells whether the file or directory at name
+// (relative to the module or repository root, slash-separated)
+// matches any of patterns.
+func ignoredBy(patterns []string, name string, isDir bool) bool {
+	for _, pattern := range patterns {
+		if matchIgnore(pattern, name, isDir) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchIgnore tells whether name matches pattern.
+// A pattern without a slash matches the last element of a file's name,
+// as in "*.pb.go".
+// Other patterns match the whole name,
+// and a pattern ending in "/..." matches a directory and everything below it,
+// as in "internal/testutil/...".
+func matchIgnore(pattern, name string, isDir bool) bool {
+	if !strings.Contains(pattern, "/") {
+		if isDir {
+			return false
+		}
+		ok, _ := path.Match(pattern, path.Base(name))
+		return ok
+	}
+	if prefix, ok := strings.CutSuffix(pattern, "/..."); ok {
+		for dir := name; dir != "." && dir != "/"; dir = path.Dir(dir) {
+			if ok, _ := path.Match(prefix, dir); ok {
+				return true
+			}
+		}
+		return false
+	}
+	ok, _ := path.Match(pattern, name)
+	return ok
+}
@@ -0,0 +1,250 @@
+package taggo
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+
+	"github.com/bobg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFile is the name of the Taggo configuration file,
+// which lives at the root of a repository.
+const ConfigFile = ".taggo.yaml"
+
+// Config is the contents of a Taggo configuration file.
+type Config struct {
+	// Policy is how the modules in the repository are versioned:
+	// PolicyIndependent (the default),
+	// where each module has its own version number,
+	// or PolicyLockstep,
+	// where all modules are always released together with the same version number
+	// (see [PlanLockstep]).
+	Policy string `yaml:"policy"`
+
+	// DefaultBranch, if set, is the name of the repository's default branch,
+	// overriding the usual detection.
+	// This is useful for a default branch with an unusual name,
+	// such as release/stable.
+	DefaultBranch string `yaml:"defaultBranch"`
+
+	// Checklist is a list of manual release-checklist items,
+	// such as "update the docs site",
+	// to be shown alongside Taggo's own findings
+	// and acknowledged before adding a new version tag.
+	Checklist []string `yaml:"checklist"`
+
+	// Stale is the threshold beyond which unreleased changes
+	// are reported as a stale release.
+	Stale StaleThreshold `yaml:"stale"`
+
+	// GoDirectiveBump, if "minor",
+	// causes raising the go or toolchain directive in go.mod
+	// to require at least a minor-version bump.
+	GoDirectiveBump string `yaml:"goDirectiveBump"`
+
+	// Bump maps the kinds of change that Modver reports to version bumps,
+	// overriding the usual mapping.
+	Bump BumpConfig `yaml:"bump"`
+
+	// BuildMetadata, if set, is a template (see [text/template])
+	// for build metadata to append to recommended new versions,
+	// as in v1.2.3+build.20240601.
+	// It may refer to {{.Commit}} and {{.ShortCommit}},
+	// the full and abbreviated hashes of the commit to be tagged,
+	// and {{.Date}} (as YYYYMMDD) and {{.Time}} (a [time.Time]),
+	// its commit time in UTC.
+	//
+	// Note that the go command ignores build metadata in module versions.
+	BuildMetadata string `yaml:"buildMetadata"`
+
+	// ReleaseMerge, if set, is a regular expression (see [regexp/syntax])
+	// matching the commit messages of the merge commits of release pull requests,
+	// as in "Release v?[0-9]+\\.[0-9]+\\.[0-9]+".
+	// When it is set,
+	// Taggo analyzes and recommends tagging the latest such merge commit on the default branch
+	// instead of the tip of the branch.
+	ReleaseMerge string `yaml:"releaseMerge"`
+
+	// Readiness lists the release-readiness checks to run on each module,
+	// beyond Taggo's own findings about versioning:
+	// any of [ReadinessLicense], [ReadinessVet], [ReadinessTest], and [ReadinessDocMarkers].
+	// The checks run on the working tree.
+	// Their results are in Result.Readiness,
+	// and a failed check is a warning.
+	Readiness []string `yaml:"readiness"`
+
+	// Rules are policy rules to evaluate for each module.
+	// Their violations are in Result.RuleViolations.
+	Rules []Rule `yaml:"rules"`
+
+	// Build is the build context under which Modver loads packages
+	// when comparing versions of a module,
+	// for API that exists only behind build tags or on some platforms.
+	Build BuildContext `yaml:"build"`
+
+	// Ignore lists patterns for files and packages to leave out
+	// when comparing versions of a module,
+	// such as generated code whose churn should not force a version bump.
+	// Patterns are relative to the module's directory and use [path.Match] syntax.
+	// A pattern without a slash matches file names in any directory,
+	// as in "*.pb.go" or "zz_generated*".
+	// A pattern ending in "/..." matches a directory and everything below it,
+	// as in "internal/testutil/...".
+	Ignore []string `yaml:"ignore"`
+
+	// Severity overrides the severities of warnings, keyed by finding ID,
+	// as in "unstable: error".
+	// See [Finding.Severity].
+	Severity map[string]Severity `yaml:"severity"`
+
+	// Graduation holds the criteria for suggesting that a v0 module release v1.0.0
+	// (see [WithGraduation]).
+	Graduation GraduationConfig `yaml:"graduation"`
+
+	// Govulncheck holds the settings for the govulncheck check
+	// (see [WithGovulncheck]).
+	Govulncheck GovulncheckConfig `yaml:"govulncheck"`
+
+	// Notes holds the settings for release notes from "taggo notes".
+	// See [NewReleaseNotes].
+	Notes NotesConfig `yaml:"notes"`
+
+	// Commit holds the settings for the commit
+	// that records updated requirements between the modules in the repository.
+	// See [UpdateRequirements].
+	Commit CommitConfig `yaml:"commit"`
+
+	// Modules holds per-module settings,
+	// keyed by module directory relative to the repository root
+	// ("." for the root).
+	Modules map[string]ModuleConfig `yaml:"modules"`
+}
+
+// ModuleConfig holds the settings for one module in a [Config].
+type ModuleConfig struct {
+	// Stale, if set, overrides Config.Stale for this module.
+	Stale *StaleThreshold `yaml:"stale"`
+
+	// Build, if set, overrides Config.Build for this module.
+	Build *BuildContext `yaml:"build"`
+
+	// Ignore lists more patterns like those in Config.Ignore for this module.
+	Ignore []string `yaml:"ignore"`
+
+	// Suppress lists the IDs of findings to suppress for this module,
+	// such as "version-suffix-unwanted" for a legacy module whose history cannot be fixed.
+	// A suppressed warning is reported as information instead,
+	// marked as suppressed,
+	// and does not count as a warning.
+	// Suppression does not lift any refusal by the command to add a tag.
+	Suppress []string `yaml:"suppress"`
+}
+
+// StaleThreshold says when unreleased changes to a module are stale:
+// when the oldest unreleased commit is more than Days days old,
+// or when there are more than Commits unreleased commits.
+// Zero values mean no limit.
+type StaleThreshold struct {
+	Days    int `yaml:"days"`
+	Commits int `yaml:"commits"`
+}
+
+func (t StaleThreshold) isSet() bool {
+	return t.Days > 0 || t.Commits > 0
+}
+
+// module returns the settings for the module in moduledir
+// (relative to the repository root, "" for the root),
+// with repository-wide settings filled in.
+func (c Config) module(moduledir string) ModuleConfig {
+	if moduledir == "" {
+		moduledir = "."
+	}
+	mc := c.Modules[filepath.ToSlash(moduledir)]
+	if mc.Stale == nil {
+		stale := c.Stale
+		mc.Stale = &stale
+	}
+	if mc.Build == nil {
+		build := c.Build
+		mc.Build = &build
+	}
+	mc.Ignore = append(slices.Clip(c.Ignore), mc.Ignore...)
+	return mc
+}
+
+// LoadConfig reads the configuration file at the root of the repository in repodir.
+// If there is no such file,
+// the result is the zero Config.
+func LoadConfig(repodir string) (Config, error) {
+	var config Config
+
+	filename := filepath.Join(repodir, ConfigFile)
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, errors.Wrapf(err, "reading %s", filename)
+	}
+
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return config, errors.Wrapf(err, "parsing %s", filename)
+	}
+	switch config.Policy {
+	case "", PolicyIndependent, PolicyLockstep:
+		// ok
+	default:
+		return config, fmt.Errorf("in %s, unknown policy %q", filename, config.Policy)
+	}
+	switch config.GoDirectiveBump {
+	case "", "minor":
+		// ok
+	default:
+		return config, fmt.Errorf("in %s, unknown goDirectiveBump setting %q", filename, config.GoDirectiveBump)
+	}
+	for _, rule := range config.Rules {
+		switch rule.Severity {
+		case "", "warning", "error":
+			// ok
+		default:
+			return config, fmt.Errorf("in %s, rule %s: unknown severity %q", filename, rule.Name, rule.Severity)
+		}
+	}
+	for _, pattern := range config.Ignore {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return config, errors.Wrapf(err, "in %s, ignore pattern %s", filename, pattern)
+		}
+	}
+	for dir, mc := range config.Modules {
+		for _, pattern := range mc.Ignore {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return config, errors.Wrapf(err, "in %s, ignore pattern %s of module %s", filename, pattern, dir)
+			}
+		}
+	}
+	if err := config.Bump.validate(); err != nil {
+		return config, errors.Wrapf(err, "in %s, bump setting", filename)
+	}
+	if err := config.Bump.Unstable.validate(); err != nil {
+		return config, errors.Wrapf(err, "in %s, bump.unstable setting", filename)
+	}
+	if block := config.Govulncheck.Block; block != "" {
+		if _, err := ParseVulnSeverity(string(block)); err != nil {
+			return config, errors.Wrapf(err, "in %s, govulncheck block setting", filename)
+		}
+	}
+	if _, err := config.Commit.template(); err != nil {
+		return config, errors.Wrapf(err, "in %s, commit message setting", filename)
+	}
+	for id, severity := range config.Severity {
+		if _, err := ParseSeverity(string(severity)); err != nil {
+			return config, errors.Wrapf(err, "in %s, severity of %s", filename, id)
+		}
+	}
+	return config, nil
+}
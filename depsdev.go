@@ -0,0 +1,47 @@
+package taggo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/bobg/errors"
+)
+
+const depsDevBaseURL = "https://api.deps.dev/v3alpha"
+
+// depsDevDependents queries deps.dev for the number of known dependents
+// of version of the Go module modpath.
+func depsDevDependents(ctx context.Context, client *http.Client, modpath, version string) (int, error) {
+	u := fmt.Sprintf("%s/systems/go/packages/%s/versions/%s:dependents", depsDevBaseURL, url.PathEscape(modpath), url.PathEscape(version))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return 0, errors.Wrapf(err, "creating request for %s", u)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, errors.Wrapf(err, "requesting %s", u)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// ok
+	case http.StatusNotFound:
+		// Deps.dev does not know this module version.
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("requesting %s: status %d", u, resp.StatusCode)
+	}
+
+	var body struct {
+		DependentCount int `json:"dependentCount"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return 0, errors.Wrapf(err, "decoding response from %s", u)
+	}
+	return body.DependentCount, nil
+}
@@ -0,0 +1,102 @@
+package taggo
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo/gitutil"
+)
+
+// NearestTag is the nearest version tag to a commit,
+// with the distance to it,
+// as reported by "git describe --tags".
+// See Result.NearestTag.
+type NearestTag struct {
+	// Tag is the nearest version tag reachable from the commit,
+	// without any VersionPrefix,
+	// or "" if there is none.
+	Tag string
+
+	// Distance is the number of commits from Tag to the commit,
+	// or of all the commits up to it if Tag is "".
+	Distance int
+
+	// Hash is the abbreviated hash of the commit.
+	Hash string
+}
+
+// String returns n in the form of "git describe --tags --always" output:
+// the tag alone if the commit has it,
+// otherwise the tag, the distance, and the abbreviated hash prefixed with "g",
+// as in v1.2.3-4-gabcdef0,
+// or only the abbreviated hash if there is no tag.
+// This is a common basis for the version strings of development builds.
+func (n NearestTag) String() string {
+	switch {
+	case n.Tag == "":
+		return n.Hash
+	case n.Distance == 0:
+		return n.Tag
+	default:
+		return fmt.Sprintf("%s-%d-g%s", n.Tag, n.Distance, n.Hash)
+	}
+}
+
+// nearestTag finds the version tag with versionPrefix nearest to commit,
+// as "git describe --tags" does.
+func nearestTag(ctx context.Context, git, repodir, versionPrefix, commit string) (*NearestTag, error) {
+	// The pattern leaves out floating tags such as v1 and v1.2.
+	cmd, done := gitutil.Command(ctx, git, repodir, "describe", "--tags", "--long", "--match", versionPrefix+"v[0-9]*.*.*", commit)
+	output, err := cmd.Output()
+	err = done(err)
+
+	var ee *exec.ExitError
+	if errors.As(err, &ee) {
+		// No tag is reachable from commit.
+		return untaggedNearestTag(ctx, git, repodir, commit)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "running %s", cmd)
+	}
+
+	// The output is TAG-DISTANCE-gHASH, where TAG may itself contain hyphens.
+	s := strings.TrimSpace(string(output))
+	rest, hash, ok1 := cutLast(s, "-g")
+	tag, distStr, ok2 := cutLast(rest, "-")
+	dist, err := strconv.Atoi(distStr)
+	if !ok1 || !ok2 || err != nil {
+		return nil, fmt.Errorf("parsing output of %s: %q", cmd, s)
+	}
+	return &NearestTag{Tag: strings.TrimPrefix(tag, versionPrefix), Distance: dist, Hash: hash}, nil
+}
+
+// untaggedNearestTag is the NearestTag for a commit with no tag reachable from it.
+func untaggedNearestTag(ctx context.Context, git, repodir, commit string) (*NearestTag, error) {
+	cmd, done := gitutil.Command(ctx, git, repodir, "rev-parse", "--short", commit)
+	output, err := cmd.Output()
+	if err = done(err); err != nil {
+		return nil, errors.Wrapf(err, "running %s", cmd)
+	}
+	n := &NearestTag{Hash: strings.TrimSpace(string(output))}
+
+	n.Distance, err = gitutil.CommitCount(ctx, git, repodir, "", commit, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "counting commits up to %s", commit)
+	}
+	return n, nil
+}
+
+// cutLast slices s around the last instance of sep,
+// returning the text before and after sep.
+// The found result reports whether sep appears in s.
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
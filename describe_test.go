@@ -0,0 +1,63 @@
+package taggo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/internal/testutil"
+)
+
+func TestNearestTag(t *testing.T) {
+	repodir := cloneBundle(t, "unstable") // latest version v0.1.2
+
+	check := func() *taggo.NearestTag {
+		t.Helper()
+		result, err := taggo.Check(context.Background(), "", repodir, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result.NearestTag
+	}
+
+	short := testutil.Git(t, repodir, "rev-parse", "--short", "HEAD")
+	got := check()
+	if diff := cmp.Diff(&taggo.NearestTag{Tag: "v0.1.2", Hash: short}, got); diff != "" {
+		t.Errorf("nearest tag mismatch (-want +got):\n%s", diff)
+	}
+	if s := got.String(); s != "v0.1.2" {
+		t.Errorf("got %s, want v0.1.2", s)
+	}
+
+	testutil.Git(t, repodir, "commit", "-q", "--allow-empty", "-m", "first")
+	testutil.Git(t, repodir, "tag", "v0.2.0-rc.1")
+	testutil.Git(t, repodir, "commit", "-q", "--allow-empty", "-m", "second")
+	testutil.Git(t, repodir, "tag", "v0") // floating tags don't count
+	short = testutil.Git(t, repodir, "rev-parse", "--short", "HEAD")
+
+	got = check()
+	if diff := cmp.Diff(&taggo.NearestTag{Tag: "v0.2.0-rc.1", Distance: 1, Hash: short}, got); diff != "" {
+		t.Errorf("nearest tag mismatch (-want +got):\n%s", diff)
+	}
+	if want := "v0.2.0-rc.1-1-g" + short; got.String() != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestNearestTagString(t *testing.T) {
+	cases := []struct {
+		n    taggo.NearestTag
+		want string
+	}{
+		{n: taggo.NearestTag{Tag: "v1.2.3", Hash: "abcdef0"}, want: "v1.2.3"},
+		{n: taggo.NearestTag{Tag: "v1.2.3", Distance: 4, Hash: "abcdef0"}, want: "v1.2.3-4-gabcdef0"},
+		{n: taggo.NearestTag{Distance: 7, Hash: "abcdef0"}, want: "abcdef0"},
+	}
+	for _, c := range cases {
+		if got := c.n.String(); got != c.want {
+			t.Errorf("got %s, want %s", got, c.want)
+		}
+	}
+}
@@ -0,0 +1,481 @@
+package taggo
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bobg/modver/v2"
+	"golang.org/x/mod/semver"
+)
+
+// Finding is a single item in the report produced by [Result.Describe].
+type Finding struct {
+	// ID identifies the kind of finding.
+	// It does not vary with the details in Message.
+	ID string
+
+	// Kind says whether this finding is informational, reports something OK, or is a warning.
+	Kind FindingKind
+
+	// Message is the human-readable text of the finding.
+	Message string
+
+	// Severity is how serious a warning is.
+	// It is SeverityInfo for findings of other kinds.
+	// See [Finding.SeverityWith] for overriding it.
+	Severity Severity
+
+	// Suppressed is true for a warning suppressed by the configuration
+	// (see ModuleConfig.Suppress).
+	// Its Kind is then FindingInfo
+	// and its Severity is SeverityInfo.
+	Suppressed bool
+
+	// Remediation, for some warnings,
+	// is a shell command (to run from the repository root) that fixes the problem.
+	Remediation string
+}
+
+// Severity is the type of Finding.Severity.
+type Severity string
+
+// Possible values for Severity, in increasing order.
+const (
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+var severityRanks = map[Severity]int{
+	SeverityInfo:    1,
+	SeverityWarning: 2,
+	SeverityError:   3,
+}
+
+// ParseSeverity checks that s is the name of a [Severity] and returns it.
+func ParseSeverity(s string) (Severity, error) {
+	if _, ok := severityRanks[Severity(s)]; !ok {
+		return "", fmt.Errorf("unknown severity %q (want info, warning, or error)", s)
+	}
+	return Severity(s), nil
+}
+
+// AtLeast tells whether s is at least as severe as other.
+func (s Severity) AtLeast(other Severity) bool {
+	return severityRanks[s] >= severityRanks[other]
+}
+
+// defaultSeverities are the severities of the warnings that are not SeverityWarning,
+// by finding ID.
+var defaultSeverities = map[string]Severity{
+	// Problems that break the module or its releases for users.
+	"diverged":                SeverityError,
+	"local-replace":           SeverityError,
+	"modpath-mismatch":        SeverityError,
+	"modpath-remote-mismatch": SeverityError,
+	"new-version-suffix":      SeverityError,
+	"no-default-branch":       SeverityError,
+	"open-vulnerability":      SeverityError,
+	"outside-series":          SeverityError,
+	"phase-error":             SeverityError,
+	"reachable-vulnerability": SeverityError,
+	"readiness-failed":        SeverityError,
+	"unbuildable-versions":    SeverityError,
+	"version-exists":          SeverityError,
+	"version-suffix-mismatch": SeverityError,
+	"version-suffix-missing":  SeverityError,
+	"version-suffix-unwanted": SeverityError,
+
+	// Conditions worth knowing about but not usually worth failing over.
+	"action-release-steps": SeverityInfo,
+	"exclude":              SeverityInfo,
+	"graduate-v1":          SeverityInfo,
+	"known-dependents":     SeverityInfo,
+	"prerelease":           SeverityInfo,
+	"replace":              SeverityInfo,
+	"unstable":             SeverityInfo,
+}
+
+// SeverityWith is the severity of f,
+// after applying overrides,
+// a map from finding ID to severity
+// such as Config.Severity.
+// Only warnings can have their severity overridden.
+func (f Finding) SeverityWith(overrides map[string]Severity) Severity {
+	if f.Kind == FindingWarning {
+		if s, ok := overrides[f.ID]; ok {
+			return s
+		}
+	}
+	return f.Severity
+}
+
+// FindingKind is the type of Finding.Kind.
+type FindingKind string
+
+// Possible values for Finding.Kind.
+const (
+	FindingInfo    FindingKind = "info"
+	FindingOK      FindingKind = "ok"
+	FindingWarning FindingKind = "warning"
+)
+
+// suppressedFindings returns the findings in r that the configuration suppresses.
+func (r Result) suppressedFindings() []Finding {
+	var result []Finding
+	for _, f := range r.Findings() {
+		if f.Suppressed {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// Findings returns the findings in r,
+// in the order that [Result.Describe] reports them.
+func (r Result) Findings() []Finding {
+	var findings []Finding
+
+	add := func(kind FindingKind, severity Severity, id, format string, args ...any) {
+		findings = append(findings, Finding{ID: id, Kind: kind, Message: fmt.Sprintf(format, args...), Severity: severity})
+	}
+	infof := func(id, format string, args ...any) { add(FindingInfo, SeverityInfo, id, format, args...) }
+	okf := func(id, format string, args ...any) { add(FindingOK, SeverityInfo, id, format, args...) }
+	warn := func(severity Severity, id, format string, args ...any) {
+		if slices.Contains(r.Suppress, id) {
+			add(FindingInfo, SeverityInfo, id, format, args...)
+			findings[len(findings)-1].Suppressed = true
+			return
+		}
+		add(FindingWarning, severity, id, format, args...)
+	}
+	warnf := func(id, format string, args ...any) {
+		severity, ok := defaultSeverities[id]
+		if !ok {
+			severity = SeverityWarning
+		}
+		warn(severity, id, format, args...)
+	}
+	errorf := func(id, format string, args ...any) { warn(SeverityError, id, format, args...) }
+
+	if r.PhaseError != nil {
+		warnf("phase-error", "Analysis incomplete: %s phase failed: %s", r.PhaseError.Phase, r.PhaseError.Message)
+	}
+
+	if r.Modpath != "" {
+		infof("module-path", "Module path: %s", r.Modpath)
+	}
+	if r.VersionPrefix != "" {
+		infof("version-prefix", "Version prefix: %s (n.b., this prefix is stripped from version tags appearing in this report)", r.VersionPrefix)
+	}
+
+	if r.Branch != "" {
+		infof("branch", "Branch: %s", r.Branch)
+		if r.Series != "" {
+			infof("series", "Release series: %s", r.Series)
+		}
+		infof("latest-commit", "Latest commit hash: %s", r.LatestCommit)
+	} else if r.DefaultBranch != "" {
+		if r.NoRemote {
+			warnf("no-remote", "No remote found; using current branch %s as the default branch", r.DefaultBranch)
+		} else {
+			okf("default-branch", "Default branch: %s", r.DefaultBranch)
+		}
+		if r.Remote != "" && r.Remote != "origin" {
+			infof("remote", "Remote: %s", r.Remote)
+		}
+		switch {
+		case r.Diverged():
+			warnf("diverged", "Branch %s has diverged from %s/%s (%d commit(s) ahead, %d behind)", r.DefaultBranch, r.Remote, r.DefaultBranch, r.Ahead, r.Behind)
+		case r.Ahead > 0:
+			infof("ahead", "Branch %s is %d commit(s) ahead of %s/%s", r.DefaultBranch, r.Ahead, r.Remote, r.DefaultBranch)
+		case r.Behind > 0:
+			infof("behind", "Branch %s is %d commit(s) behind %s/%s", r.DefaultBranch, r.Behind, r.Remote, r.DefaultBranch)
+		}
+		switch {
+		case r.Target != "":
+			infof("target", "Target commit: %s (%s)", r.LatestCommit, r.Target)
+		case r.ReleaseMergeCommit != "":
+			infof("release-merge", "Release merge commit: %s", r.ReleaseMergeCommit)
+		case r.NoReleaseMerge:
+			infof("no-release-merge", "No release merge commit on %s", r.DefaultBranch)
+		default:
+			infof("latest-commit", "Latest commit hash: %s", r.LatestCommit)
+		}
+	} else {
+		warnf("no-default-branch", "Could not determine default branch")
+	}
+	if r.LatestCommit != "" && !r.LatestCommitTime.IsZero() {
+		author, _, _ := strings.Cut(r.LatestCommitAuthor, " <")
+		if r.LatestCommitSubject == "" {
+			infof("latest-commit-details", "Latest commit by %s on %s", author, r.LatestCommitTime.Format(time.DateOnly))
+		} else {
+			infof("latest-commit-details", "Latest commit by %s on %s: %s", author, r.LatestCommitTime.Format(time.DateOnly), r.LatestCommitSubject)
+		}
+	}
+
+	if n := len(r.RemoteOnlyVersionTags); n > 0 {
+		warnf("remote-only-tags", "%d version tag(s) exist only in the remote (%s); local tags are incomplete (run git fetch --tags)", n, strings.Join(r.RemoteOnlyVersionTags, ", "))
+	}
+
+	if r.LatestVersion != "" {
+		okf("latest-version", "Latest version tag: %s", r.LatestVersion)
+		if r.Base != "" {
+			infof("base", "Comparing with %s instead of latest version %s", r.Base, r.LatestVersion)
+		}
+
+		if r.LatestVersionIsPrerelease {
+			warnf("prerelease", "Latest version %s is a prerelease", r.LatestVersion)
+		} else {
+			okf("not-prerelease", "Latest version %s is not a prerelease", r.LatestVersion)
+		}
+
+		if r.LatestVersionUnstable {
+			warnf("unstable", "Latest version %s is unstable", r.LatestVersion)
+		} else {
+			okf("stable", "Latest version %s is stable", r.LatestVersion)
+		}
+
+		if g := r.Graduation; g != nil {
+			if g.Ready {
+				warnf("graduate-v1", "Consider releasing v1.0.0: %s", strings.Join(g.Reasons(), "; "))
+			} else {
+				infof("graduation", "Not yet ready for v1.0.0: %s", strings.Join(g.Reasons(), "; "))
+			}
+		}
+
+		if len(r.UnbuildableVersions) > 0 {
+			warnf("unbuildable-versions", "Version(s) %s do not build", strings.Join(r.UnbuildableVersions, ", "))
+		}
+
+		if len(r.LightweightVersionTags) > 0 {
+			warnf("lightweight-tags", "Version tag(s) %s are lightweight, but annotated tags are required", strings.Join(r.LightweightVersionTags, ", "))
+		}
+
+		switch r.VersionSuffix {
+		case VSOK:
+			if r.LatestMajor > 1 {
+				okf("version-suffix-ok", "Module path %s has suffix matching major version %d", r.Modpath, r.LatestMajor)
+			} else {
+				okf("version-suffix-ok", "Module path %s neither needs nor has a version suffix", r.Modpath)
+			}
+		case VSMismatch:
+			warnf("version-suffix-mismatch", "Module path %s version suffix does not agree with latest version %s", r.Modpath, r.LatestVersion)
+		case VSMissing:
+			warnf("version-suffix-missing", "Module path %s lacks suffix matching major version %d", r.Modpath, r.LatestMajor)
+		case VSUnwanted:
+			warnf("version-suffix-unwanted", "Module path %s contains an unwanted version suffix", r.Modpath)
+		}
+
+		if r.DefaultBranch != "" && !r.NoReleaseMerge {
+			if r.LatestCommitHasVersionTag {
+				if r.LatestCommitHasLatestVersion {
+					okf("latest-commit-tagged", "Latest commit on the default branch has latest version tag")
+				} else {
+					warnf("latest-commit-old-version", "Latest commit on the default branch has version tag, but it is not latest version %s", r.LatestVersion)
+				}
+			} else {
+				warnf("latest-commit-untagged", "Latest commit on the default branch lacks version tag")
+				if r.UnreleasedCommits > 0 {
+					infof("commits-since", "%d unreleased commit(s) since %s", r.UnreleasedCommits, r.LatestVersion)
+				}
+
+				if r.StaleRelease {
+					warnf("stale-release", "Release is stale: %d unreleased commit(s), the oldest from %s", r.UnreleasedCommits, r.OldestUnreleasedCommitTime.Format(time.DateOnly))
+				}
+
+				// After a failed phase, nothing more is known.
+				if r.PhaseError == nil {
+					if r.GoDirectiveRaised() {
+						warnf("go-directive-raised", "Go directive raised from %s to %s since %s", r.LatestVersionGoDirective, r.GoDirective, r.LatestVersion)
+					}
+					if r.ToolchainDirectiveRaised() {
+						warnf("toolchain-directive-raised", "Toolchain directive raised to %s since %s", r.ToolchainDirective, r.LatestVersion)
+					}
+
+					if r.BuildContext != "" {
+						infof("build-context", "API compared with %s", r.BuildContext)
+					}
+					if r.NoNetChanges {
+						okf("no-net-changes", "Commits since %s leave the module unchanged; no new version tag required", r.LatestVersion)
+					} else if r.ModverResultCode == modver.None {
+						okf("modver-none", "Modver analysis: no new version tag required")
+					} else {
+						warnf("modver", "Modver analysis: %s", r.ModverResultString)
+					}
+					if r.PolicyBump != modver.None {
+						infof("bump-policy", "Bump policy calls for a %s-version bump for %s changes", bumpName(r.PolicyBump), bumpName(r.ModverResultCode))
+					}
+					if r.GoDirectiveBump {
+						warnf("go-directive-bump", "Raised go or toolchain directive requires at least a minor-version bump")
+					}
+
+					if r.FinalizesPrerelease {
+						warnf("finalize-prerelease", "Prerelease %s is ready to finalize", r.LatestVersion)
+					}
+
+					if r.ModverResultCode != modver.None || r.GoDirectiveBump || r.FinalizesPrerelease {
+						warnf("recommended-version", "Recommended new version tag: %s%s", r.VersionPrefix, r.NewVersion())
+						if !r.InSeries(r.NewVersion()) {
+							warnf("outside-series", "Recommended version %s is outside the %s series of branch %s", r.NewVersion(), r.Series, r.Branch)
+						}
+						if r.NewVersionExists {
+							warnf("version-exists", "Version %s%s already exists on another branch", r.VersionPrefix, r.NewVersion())
+						}
+						if r.NewMajor > r.LatestMajor && r.NewMajor > 1 {
+							warnf("new-version-suffix", "Module path will require new version suffix /v%d", r.NewMajor)
+						}
+						if r.NewMajor > r.LatestMajor && r.KnownDependents > 0 {
+							warnf("known-dependents", "%d known dependents of %s; breaking change will affect them", r.KnownDependents, r.LatestVersion)
+						}
+					}
+				}
+			}
+		}
+		for _, id := range r.OpenVulnerabilities {
+			if tag, ok := r.recommendedTag(); ok {
+				warnf("open-vulnerability", "Vulnerability %s affects latest version %s; if %s includes the fix, update the OSV report to say it is fixed in %s", id, r.LatestVersion, tag, tag)
+			} else {
+				warnf("open-vulnerability", "Vulnerability %s affects latest version %s", id, r.LatestVersion)
+			}
+		}
+	} else {
+		warnf("no-version-tags", "No version tags")
+		if len(r.OtherModuleVersionTags) > 0 {
+			example := r.VersionPrefix + "v0.1.0"
+			if r.VersionPrefix == "" {
+				infof("other-module-tags", "Version tags for the module at the repository root have no prefix (e.g. %s); %s belong to other modules", example, abbrevList(r.OtherModuleVersionTags, 3))
+			} else {
+				infof("other-module-tags", "Version tags for this module must have the prefix %s (e.g. %s); %s belong to other modules", r.VersionPrefix, example, abbrevList(r.OtherModuleVersionTags, 3))
+			}
+		}
+	}
+
+	if len(r.MalformedVersionTags) > 0 {
+		var tags, fixed []string
+		for _, m := range r.MalformedVersionTags {
+			tags = append(tags, m.Tag)
+			fixed = append(fixed, r.VersionPrefix+m.Version)
+		}
+		warnf("malformed-version-tags", "Tag(s) %s look like versions but are invisible to the Go toolchain; tag the same commits as %s", abbrevList(tags, 3), abbrevList(fixed, 3))
+	}
+
+	if len(r.StaleFloatingTags) > 0 {
+		var stale []string
+		for _, f := range r.StaleFloatingTags {
+			stale = append(stale, fmt.Sprintf("%s (latest release %s)", f.Tag, f.Latest))
+		}
+		warnf("stale-floating-tags", "Floating tag(s) do not point to the latest releases they stand for: %s", abbrevList(stale, 3))
+	}
+
+	if a := r.Action; a != nil {
+		if a.Name != "" {
+			infof("action", "GitHub Action: %s (%s)", a.Name, a.File)
+		} else {
+			infof("action", "GitHub Action defined in %s", a.File)
+		}
+		if a.ReleaseOffBranch {
+			warnf("action-release-off-branch", "Latest release %s is not on %s, so Action users get code that is not there", r.LatestVersion, r.DefaultBranch)
+		}
+		if a.MajorTagMissing {
+			warnf("action-major-tag-missing", "No floating tag %s for Action users to reference as @%s", a.MajorTag, a.MajorTag)
+		}
+		if a.MetadataChanged {
+			infof("action-metadata-changed", "%s has changed since %s", a.File, r.LatestVersion)
+		}
+		if tag, ok := r.recommendedTag(); ok && semver.Prerelease(tag) == "" {
+			warnf("action-release-steps", "To release the Action: tag %s, move %s to it, push both, and publish a GitHub release for %s", tag, semver.Major(tag), tag)
+		}
+	}
+
+	if len(r.LocalReplaceDirectives) > 0 {
+		warnf("local-replace", "go.mod has filesystem replace directive(s), which break the module for consumers: %s", strings.Join(r.LocalReplaceDirectives, "; "))
+	}
+	if len(r.ReplaceDirectives) > len(r.LocalReplaceDirectives) {
+		var others []string
+		for _, d := range r.ReplaceDirectives {
+			if !slices.Contains(r.LocalReplaceDirectives, d) {
+				others = append(others, d)
+			}
+		}
+		warnf("replace", "go.mod has replace directive(s), which consumers will not see: %s", strings.Join(others, "; "))
+	}
+	if len(r.ExcludeDirectives) > 0 {
+		warnf("exclude", "go.mod has exclude directive(s), which consumers will not see: %s", strings.Join(r.ExcludeDirectives, "; "))
+	}
+	if r.TidyDiff != "" {
+		warnf("untidy", "go.mod and/or go.sum are not tidy (run go mod tidy)")
+	}
+	for _, v := range r.ReachableVulnerabilities {
+		severity := string(v.Severity)
+		if severity == "" {
+			severity = "unknown"
+		}
+		msg := fmt.Sprintf("Vulnerability %s (severity %s) in %s@%s is reachable via %s", v.ID, severity, v.Module, v.Version, abbrevList(v.Symbols, 3))
+		if v.FixedVersion != "" {
+			msg += fmt.Sprintf("; fixed in %s", v.FixedVersion)
+		}
+		warnf("reachable-vulnerability", "%s", msg)
+	}
+
+	if r.ModpathMismatch {
+		warnf("modpath-mismatch", "Module path %s does not agree with module subdir in repository %s", r.Modpath, r.ModuleSubdir)
+	} else if r.ModuleSubdir != "" {
+		okf("modpath-ok", "Module path %s agrees with module subdir in repository %s", r.Modpath, r.ModuleSubdir)
+	}
+
+	if r.ModpathRemoteMismatch {
+		warnf("modpath-remote-mismatch", "Module path %s does not correspond to remote URL %s; if this is a fork, tags added here will not be visible to its users", r.Modpath, r.RemoteURL)
+	}
+
+	for _, check := range r.Readiness {
+		if check.OK {
+			okf("readiness", "Readiness check %s passed", check.Name)
+		} else {
+			warnf("readiness-failed", "Readiness check %s failed: %s", check.Name, strings.ReplaceAll(check.Detail, "\n", "; "))
+		}
+	}
+
+	for _, v := range r.RuleViolations {
+		if v.Error {
+			errorf("rule-violation", "Policy rule %s violated (error): %s", v.Rule, v.Message)
+		} else {
+			warnf("rule-violation", "Policy rule %s violated: %s", v.Rule, v.Message)
+		}
+	}
+
+	for _, check := range r.SkippedChecks {
+		infof("skipped", "Skipped %s check (offline)", check)
+	}
+
+	for i, f := range findings {
+		if f.Kind == FindingWarning {
+			findings[i].Remediation = r.remediation(f.ID)
+		}
+	}
+
+	return findings
+}
+
+// recommendedTag is the recommended new version tag, if any,
+// including any VersionPrefix.
+func (r Result) recommendedTag() (string, bool) {
+	if r.DefaultBranch == "" || r.LatestCommitHasVersionTag || r.PhaseError != nil {
+		return "", false
+	}
+	if r.LatestVersion != "" && r.ModverResultCode == modver.None && !r.GoDirectiveBump && !r.FinalizesPrerelease {
+		return "", false
+	}
+	return r.VersionPrefix + r.NewVersion(), true
+}
+
+// abbrevList joins the first n of items with commas,
+// saying how many more there are, if any.
+func abbrevList(items []string, n int) string {
+	if len(items) <= n {
+		return strings.Join(items, ", ")
+	}
+	return fmt.Sprintf("%s, and %d more", strings.Join(items[:n], ", "), len(items)-n)
+}
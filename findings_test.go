@@ -0,0 +1,275 @@
+package taggo_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/internal/testutil"
+)
+
+func TestSeverity(t *testing.T) {
+	r := taggo.Result{
+		Modpath:               "example.com/x/sub",
+		ModuleSubdir:          "other",
+		DefaultBranch:         "main",
+		LatestVersion:         "v0.1.0",
+		LatestVersionUnstable: true,
+		ModpathMismatch:       true,
+	}
+
+	severities := make(map[string]taggo.Severity)
+	for _, f := range r.Findings() {
+		severities[f.ID] = f.Severity
+	}
+	if got := severities["unstable"]; got != taggo.SeverityInfo {
+		t.Errorf("got severity %q for unstable, want info", got)
+	}
+	if got := severities["modpath-mismatch"]; got != taggo.SeverityError {
+		t.Errorf("got severity %q for modpath-mismatch, want error", got)
+	}
+	if got := severities["latest-version"]; got != taggo.SeverityInfo {
+		t.Errorf("got severity %q for latest-version, want info", got)
+	}
+
+	all := r.DescribeWith(io.Discard, taggo.DescribeOptions{})
+
+	cases := []struct {
+		name       string
+		threshold  taggo.Severity
+		severities map[string]taggo.Severity
+		want       int
+	}{{
+		name: "no threshold",
+		want: all,
+	}, {
+		name:      "info",
+		threshold: taggo.SeverityInfo,
+		want:      all,
+	}, {
+		name:      "error",
+		threshold: taggo.SeverityError,
+		want:      1,
+	}, {
+		name:       "error with override",
+		threshold:  taggo.SeverityError,
+		severities: map[string]taggo.Severity{"unstable": taggo.SeverityError, "modpath-mismatch": taggo.SeverityWarning},
+		want:       1,
+	}}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := r.DescribeWith(io.Discard, taggo.DescribeOptions{Threshold: c.threshold, Severities: c.severities})
+			if got != c.want {
+				t.Errorf("got %d warnings, want %d", got, c.want)
+			}
+		})
+	}
+
+	if _, err := taggo.ParseSeverity("fatal"); err == nil {
+		t.Error("got no error parsing unknown severity")
+	}
+}
+
+func TestSuppress(t *testing.T) {
+	r := taggo.Result{
+		Modpath:               "example.com/x",
+		DefaultBranch:         "main",
+		LatestVersion:         "v0.1.0",
+		LatestVersionUnstable: true,
+		Suppress:              []string{"unstable"},
+	}
+
+	var found bool
+	for _, f := range r.Findings() {
+		if f.ID != "unstable" {
+			continue
+		}
+		found = true
+		if !f.Suppressed || f.Kind != taggo.FindingInfo || f.Severity != taggo.SeverityInfo {
+			t.Errorf("got finding %+v, want a suppressed info finding", f)
+		}
+	}
+	if !found {
+		t.Fatal("missing suppressed finding")
+	}
+
+	buf := new(bytes.Buffer)
+	before := r.DescribeWith(io.Discard, taggo.DescribeOptions{})
+	r.Suppress = nil
+	if after := r.DescribeWith(io.Discard, taggo.DescribeOptions{}); after != before+1 {
+		t.Errorf("got %d warnings without suppression and %d with, want a difference of 1", after, before)
+	}
+
+	r.Suppress = []string{"unstable"}
+	r.DescribeWith(buf, taggo.DescribeOptions{})
+	if !strings.Contains(buf.String(), "ℹ️ (suppressed) Latest version v0.1.0 is unstable") {
+		t.Errorf("suppressed finding not shown as such:\n%s", buf)
+	}
+	buf.Reset()
+	r.DescribeWith(buf, taggo.DescribeOptions{Quiet: true})
+	if strings.Contains(buf.String(), "unstable") {
+		t.Errorf("suppressed finding shown in quiet mode:\n%s", buf)
+	}
+}
+
+func TestSuppressedFindingsJSON(t *testing.T) {
+	repodir := t.TempDir()
+
+	testutil.Git(t, repodir, "init", "-q", "-b", "main")
+	if err := os.WriteFile(filepath.Join(repodir, "go.mod"), []byte("module example.com/x\n\ngo 1.22\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	testutil.Git(t, repodir, "add", "go.mod")
+	testutil.Git(t, repodir, "commit", "-q", "-m", "initial")
+
+	config := taggo.Config{
+		Modules: map[string]taggo.ModuleConfig{
+			".": {Suppress: []string{"no-version-tags"}},
+		},
+	}
+	result, err := taggo.Check(context.Background(), "", repodir, "", taggo.WithConfig(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		SuppressedFindings []taggo.Finding
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.SuppressedFindings) != 1 {
+		t.Fatalf("got %d suppressed findings, want 1: %+v", len(decoded.SuppressedFindings), decoded.SuppressedFindings)
+	}
+	if f := decoded.SuppressedFindings[0]; f.ID != "no-version-tags" || f.Message != "No version tags" || !f.Suppressed || f.Kind != taggo.FindingInfo {
+		t.Errorf("got suppressed finding %+v, want a suppressed info finding for no-version-tags", f)
+	}
+}
+
+func TestDescribeOptions(t *testing.T) {
+	r := taggo.Result{
+		Modpath:               "example.com/x",
+		DefaultBranch:         "main",
+		LatestVersion:         "v0.1.0",
+		LatestVersionUnstable: true,
+		ModverReport:          "Minor\n  new function F\n",
+		Versions:              []taggo.VersionInfo{{Version: "v0.1.0", Commit: "0123456789abcdef", Annotated: true, Tagger: "Taggo <taggo@example.com>", OnDefaultBranch: true}},
+	}
+
+	describe := func(opts taggo.DescribeOptions) string {
+		buf := new(bytes.Buffer)
+		r.DescribeWith(buf, opts)
+		return buf.String()
+	}
+
+	out := describe(taggo.DescribeOptions{})
+	if strings.Contains(out, "Modver report") || strings.Contains(out, "Version tags") {
+		t.Errorf("got verbose details at normal verbosity:\n%s", out)
+	}
+
+	out = describe(taggo.DescribeOptions{Verbosity: taggo.VerbosityVerbose})
+	for _, want := range []string{"ℹ️ Modver report:\n    Minor\n      new function F\n", "    v0.1.0  0123456789ab  0001-01-01, tagged by Taggo <taggo@example.com>\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("verbose output lacks %q:\n%s", want, out)
+		}
+	}
+
+	out = describe(taggo.DescribeOptions{Verbosity: taggo.VerbosityQuiet})
+	if out != "⛔️ Latest version v0.1.0 is unstable\n⛔️ Latest commit on the default branch lacks version tag\n" {
+		t.Errorf("got quiet output:\n%s", out)
+	}
+
+	out = describe(taggo.DescribeOptions{NoEmoji: true, HideOK: true})
+	if strings.Contains(out, "✅") || strings.Contains(out, "OK:") || !strings.Contains(out, "WARNING: Latest version v0.1.0 is unstable\n") {
+		t.Errorf("got output without emoji or OK findings:\n%s", out)
+	}
+
+	out = describe(taggo.DescribeOptions{Color: true, Verbosity: taggo.VerbosityQuiet})
+	if !strings.Contains(out, "⛔️ \x1b[31mLatest version v0.1.0 is unstable\x1b[0m\n") {
+		t.Errorf("got colored output:\n%q", out)
+	}
+}
+
+func TestRemediation(t *testing.T) {
+	r := taggo.Result{
+		Modpath:                   "example.com/x/sub/v2",
+		ModuleSubdir:              "sub",
+		DefaultBranch:             "main",
+		Remote:                    "upstream",
+		LatestVersion:             "v1.4.0",
+		LatestMajor:               1,
+		LatestCommitHasVersionTag: true,
+		VersionSuffix:             taggo.VSUnwanted,
+		LocalReplaceDirectives:    []string{"example.com/y => ../y", "example.com/z v1.2.3 => ./z"},
+		TidyDiff:                  "diff",
+		RemoteOnlyVersionTags:     []string{"sub/v1.3.0"},
+	}
+
+	got := make(map[string]string)
+	for _, f := range r.Findings() {
+		if f.Remediation != "" {
+			got[f.ID] = f.Remediation
+		}
+	}
+	want := map[string]string{
+		"remote-only-tags":        "git fetch --tags upstream",
+		"version-suffix-unwanted": "(cd sub && go mod edit -module example.com/x/sub)",
+		"local-replace":           "(cd sub && go mod edit -dropreplace=example.com/y -dropreplace=example.com/z@v1.2.3)",
+		"untidy":                  "(cd sub && go mod tidy)",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	r = taggo.Result{
+		Modpath:       "example.com/x",
+		DefaultBranch: "main",
+		LatestCommit:  "0123456789abcdef",
+		NoRemote:      true,
+		NewMinor:      1,
+	}
+	for _, f := range r.Findings() {
+		if f.ID == "no-version-tags" {
+			if want := "git tag -a -m 'Version v0.1.0' v0.1.0 0123456789abcdef"; f.Remediation != want {
+				t.Errorf("got remediation %q, want %q", f.Remediation, want)
+			}
+		}
+	}
+}
+
+func TestLatestCommitDetails(t *testing.T) {
+	r := taggo.Result{
+		DefaultBranch:       "main",
+		LatestCommit:        "abc123",
+		LatestCommitAuthor:  "Taggo <taggo@example.com>",
+		LatestCommitTime:    time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC),
+		LatestCommitSubject: "Fix the frobnicator",
+	}
+
+	var found bool
+	for _, f := range r.Findings() {
+		if f.ID != "latest-commit-details" {
+			continue
+		}
+		found = true
+		if want := "Latest commit by Taggo on 2024-06-30: Fix the frobnicator"; f.Message != want {
+			t.Errorf("got %q, want %q", f.Message, want)
+		}
+	}
+	if !found {
+		t.Error("no latest-commit-details finding")
+	}
+}
@@ -0,0 +1,47 @@
+package taggo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bobg/errors"
+	"github.com/bobg/modules"
+	"golang.org/x/mod/modfile"
+)
+
+// FindModule returns the directory of the Go module with path modpath
+// in the repository in repodir,
+// found by scanning its go.mod files as [CheckAll] does.
+// The result has repodir as a prefix,
+// so it is suitable as the moduledir argument of [Check].
+// It is an error if no module,
+// or more than one,
+// has that path.
+func FindModule(repodir, modpath string) (string, error) {
+	var found []string
+	err := modules.Each(repodir, func(moduledir string) error {
+		gomodPath := filepath.Join(moduledir, "go.mod")
+		gomodBytes, err := os.ReadFile(gomodPath)
+		if err != nil {
+			return errors.Wrapf(err, "reading %s", gomodPath)
+		}
+		if modfile.ModulePath(gomodBytes) == modpath {
+			found = append(found, moduledir)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "scanning %s for modules", repodir)
+	}
+
+	switch len(found) {
+	case 0:
+		return "", fmt.Errorf("no module %s in repository %s", modpath, repodir)
+	case 1:
+		return found[0], nil
+	default:
+		return "", fmt.Errorf("more than one module %s in repository %s: %s", modpath, repodir, strings.Join(found, ", "))
+	}
+}
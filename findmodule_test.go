@@ -0,0 +1,78 @@
+package taggo_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bobg/taggo"
+)
+
+func TestFindModule(t *testing.T) {
+	repodir := t.TempDir()
+
+	for name, modpath := range map[string]string{
+		"go.mod":                    "example.com/x",
+		"a/go.mod":                  "example.com/x/a",
+		"b/c/go.mod":                "example.com/x/submod",
+		"b/c/testdata/m/go.mod":     "example.com/x/other",
+		"d/go.mod":                  "example.com/x/dup",
+		"e/go.mod":                  "example.com/x/dup",
+		"b/c/testdata/n/go.mod":     "example.com/x/a",
+		"b/_ignored/go.mod":         "example.com/x/ignored",
+		"vendor/example.com/go.mod": "example.com/x/vendored",
+	} {
+		filename := filepath.Join(repodir, name)
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte("module "+modpath+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := []struct {
+		modpath string
+		want    string
+		wantErr bool
+	}{{
+		modpath: "example.com/x",
+		want:    repodir,
+	}, {
+		modpath: "example.com/x/a",
+		want:    filepath.Join(repodir, "a"),
+	}, {
+		modpath: "example.com/x/submod",
+		want:    filepath.Join(repodir, "b", "c"),
+	}, {
+		modpath: "example.com/x/other",
+		wantErr: true,
+	}, {
+		modpath: "example.com/x/dup",
+		wantErr: true,
+	}, {
+		modpath: "example.com/x/ignored",
+		wantErr: true,
+	}, {
+		modpath: "example.com/x/vendored",
+		wantErr: true,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.modpath, func(t *testing.T) {
+			got, err := taggo.FindModule(repodir, tc.modpath)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("got %s, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("got %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package taggo
+
+import (
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// FloatingTag is a floating major- or minor-version tag,
+// such as v1 or v1.2,
+// that does not point to the latest release it stands for,
+// as is the convention for GitHub Actions.
+// See Result.StaleFloatingTags.
+type FloatingTag struct {
+	// Tag is the full tag name, including any version prefix.
+	Tag string
+
+	// Commit is the hash of the commit that Tag points to.
+	Commit string
+
+	// Latest is the latest release that Tag stands for,
+	// such as v1.2.3 for v1 or v1.2.
+	Latest string
+
+	// LatestCommit is the hash of the commit that Latest points to.
+	LatestCommit string
+}
+
+// IsFloatingVersion tells whether v is a floating major- or minor-version tag name
+// (after any version prefix),
+// such as v1 or v1.2.
+// Such a tag is a valid semantic version as far as [semver.IsValid] is concerned,
+// but not a module version,
+// so the Go toolchain ignores it and so does Taggo.
+func IsFloatingVersion(v string) bool {
+	return semver.IsValid(v) && (v == semver.Major(v) || v == semver.MajorMinor(v))
+}
+
+// FloatingVersions returns the floating major- and minor-version tag names
+// (after any version prefix)
+// that should point to the release version,
+// such as v1 and v1.2 for v1.2.3.
+// It returns nil for a prerelease.
+func FloatingVersions(version string) []string {
+	if !semver.IsValid(version) || semver.Prerelease(version) != "" {
+		return nil
+	}
+	return []string{semver.Major(version), semver.MajorMinor(version)}
+}
+
+// staleFloatingTags finds the floating tags with versionPrefix
+// that do not point to the latest release they stand for.
+// The tags map is from tag name to commit hash,
+// and versions is from each version (after versionPrefix) to commit hash.
+// A floating tag that stands for no release is left out.
+// The result is sorted by tag name.
+func staleFloatingTags(tags map[string]string, versionPrefix string, versions map[string]string) []FloatingTag {
+	latest := make(map[string]string) // floating version -> latest release
+	for v := range versions {
+		for _, f := range FloatingVersions(v) {
+			if l, ok := latest[f]; !ok || semver.Compare(v, l) > 0 {
+				latest[f] = v
+			}
+		}
+	}
+
+	var result []FloatingTag
+	for name, commit := range tags {
+		f, ok := strings.CutPrefix(name, versionPrefix)
+		if !ok || !IsFloatingVersion(f) {
+			continue
+		}
+		l, ok := latest[f]
+		if !ok || versions[l] == commit {
+			continue
+		}
+		result = append(result, FloatingTag{Tag: name, Commit: commit, Latest: l, LatestCommit: versions[l]})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Tag < result[j].Tag
+	})
+	return result
+}
@@ -0,0 +1,81 @@
+package taggo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/internal/testutil"
+)
+
+func TestStaleFloatingTags(t *testing.T) {
+	repodir := cloneBundle(t, "unstable") // latest version v0.1.2
+
+	release := testutil.Git(t, repodir, "rev-parse", "HEAD")
+	testutil.Git(t, repodir, "tag", "v0.1") // up to date
+	testutil.Git(t, repodir, "commit", "-q", "--allow-empty", "-m", "unreleased")
+	head := testutil.Git(t, repodir, "rev-parse", "HEAD")
+	testutil.Git(t, repodir, "tag", "v0") // stale
+	testutil.Git(t, repodir, "tag", "v1") // stands for no release
+
+	result, err := taggo.Check(context.Background(), "", repodir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.LatestVersion != "v0.1.2" {
+		t.Errorf("got latest version %s, want v0.1.2", result.LatestVersion)
+	}
+
+	want := []taggo.FloatingTag{{Tag: "v0", Commit: head, Latest: "v0.1.2", LatestCommit: release}}
+	if diff := cmp.Diff(want, result.StaleFloatingTags); diff != "" {
+		t.Errorf("stale floating tags mismatch (-want +got):\n%s", diff)
+	}
+
+	var found bool
+	for _, f := range result.Findings() {
+		if f.ID != "stale-floating-tags" {
+			continue
+		}
+		found = true
+		if want := "Floating tag(s) do not point to the latest releases they stand for: v0 (latest release v0.1.2)"; f.Message != want {
+			t.Errorf("got message %q, want %q", f.Message, want)
+		}
+		if want := "git tag -f v0 " + release + " && git push -f origin v0"; f.Remediation != want {
+			t.Errorf("got remediation %q, want %q", f.Remediation, want)
+		}
+	}
+	if !found {
+		t.Error("no stale-floating-tags finding")
+	}
+}
+
+func TestFloatingVersions(t *testing.T) {
+	cases := []struct {
+		version    string
+		want       []string
+		isFloating bool
+	}{
+		{version: "v1.2.3", want: []string{"v1", "v1.2"}},
+		{version: "v0.1.0", want: []string{"v0", "v0.1"}},
+		{version: "v1.2.3-rc.1"},
+		{version: "v1.2.3+build", want: []string{"v1", "v1.2"}},
+		{version: "v1", isFloating: true},
+		{version: "v1.2", isFloating: true},
+		{version: "1.2.3"},
+	}
+	for _, c := range cases {
+		t.Run(c.version, func(t *testing.T) {
+			if !c.isFloating {
+				if diff := cmp.Diff(c.want, taggo.FloatingVersions(c.version)); diff != "" {
+					t.Errorf("floating versions mismatch (-want +got):\n%s", diff)
+				}
+			}
+			if got := taggo.IsFloatingVersion(c.version); got != c.isFloating {
+				t.Errorf("got IsFloatingVersion %v, want %v", got, c.isFloating)
+			}
+		})
+	}
+}
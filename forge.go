@@ -0,0 +1,192 @@
+package taggo
+
+import (
+	"context"
+	"net/url"
+	"os/exec"
+	"strings"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo/gitutil"
+)
+
+// ForgeKind identifies a code-hosting service.
+type ForgeKind string
+
+// Known values for ForgeKind.
+const (
+	ForgeGitHub   ForgeKind = "github"
+	ForgeGitLab   ForgeKind = "gitlab"
+	ForgeCodeberg ForgeKind = "codeberg"
+)
+
+// Forge is a code-hosting service where a repository lives,
+// together with the web address of that repository.
+type Forge struct {
+	Kind ForgeKind
+
+	// RepoURL is the https URL of the repository's home page on the forge,
+	// e.g. "https://github.com/bobg/taggo".
+	RepoURL string
+}
+
+// CommitURL is the forge's web page for the given commit.
+func (f Forge) CommitURL(hash string) string {
+	switch f.Kind {
+	case ForgeGitLab:
+		return f.RepoURL + "/-/commit/" + hash
+	default:
+		return f.RepoURL + "/commit/" + hash
+	}
+}
+
+// TagURL is the forge's web page for the given tag.
+func (f Forge) TagURL(tag string) string {
+	switch f.Kind {
+	case ForgeGitLab:
+		return f.RepoURL + "/-/tags/" + tag
+	case ForgeCodeberg:
+		return f.RepoURL + "/src/tag/" + tag
+	default:
+		return f.RepoURL + "/releases/tag/" + tag
+	}
+}
+
+// CompareURL is the forge's web page comparing the refs base and head.
+func (f Forge) CompareURL(base, head string) string {
+	switch f.Kind {
+	case ForgeGitLab:
+		return f.RepoURL + "/-/compare/" + base + "..." + head
+	default:
+		return f.RepoURL + "/compare/" + base + "..." + head
+	}
+}
+
+// ParseForge interprets a Git remote URL,
+// in any of the forms that Git accepts
+// (https, ssh, or scp-like "git@host:owner/repo.git"),
+// as a repository on a known forge.
+// The boolean result is false if the forge is not recognized.
+func ParseForge(remoteURL string) (Forge, bool) {
+	host, path, ok := splitRemoteURL(remoteURL)
+	if !ok {
+		return Forge{}, false
+	}
+
+	var kind ForgeKind
+	switch {
+	case host == "github.com":
+		kind = ForgeGitHub
+	case host == "gitlab.com" || strings.HasPrefix(host, "gitlab."):
+		kind = ForgeGitLab
+	case host == "codeberg.org":
+		kind = ForgeCodeberg
+	default:
+		return Forge{}, false
+	}
+
+	return Forge{Kind: kind, RepoURL: "https://" + host + "/" + path}, true
+}
+
+// NormalizeRemoteURL converts a Git remote URL,
+// in any of the forms that Git accepts,
+// to the https URL of the repository,
+// e.g. "https://github.com/bobg/taggo" for "git@github.com:bobg/taggo.git".
+// The boolean result is false if remoteURL does not name a host and a path,
+// as with a local filesystem path.
+func NormalizeRemoteURL(remoteURL string) (string, bool) {
+	host, path, ok := splitRemoteURL(remoteURL)
+	if !ok {
+		return "", false
+	}
+	return "https://" + host + "/" + path, true
+}
+
+// splitRemoteURL parses a Git remote URL into its host and repository path,
+// the latter without any leading or trailing slash or ".git" suffix.
+func splitRemoteURL(remoteURL string) (host, path string, ok bool) {
+	if strings.Contains(remoteURL, "://") {
+		u, err := url.Parse(remoteURL)
+		if err != nil || u.Hostname() == "" {
+			return "", "", false
+		}
+		host, path = u.Hostname(), u.Path
+	} else {
+		// Scp-like syntax: [user@]host:path.
+		before, after, found := strings.Cut(remoteURL, ":")
+		if !found || strings.Contains(before, "/") {
+			return "", "", false
+		}
+		if _, h, found := strings.Cut(before, "@"); found {
+			before = h
+		}
+		host, path = before, after
+	}
+
+	path = strings.Trim(path, "/")
+	path = strings.TrimSuffix(path, ".git")
+	if host == "" || path == "" {
+		return "", "", false
+	}
+	host = strings.ToLower(host)
+	if h, ok := sshHostAliases[host]; ok {
+		host = h
+	}
+	return host, path, true
+}
+
+// sshHostAliases maps the alternate hosts that forges provide for SSH access
+// (e.g. on port 443, for networks that block port 22)
+// to the forge's main host.
+var sshHostAliases = map[string]string{
+	"ssh.github.com":    "github.com",
+	"altssh.gitlab.com": "gitlab.com",
+}
+
+// DetectForge looks up the URL of the given remote in a Git repository
+// and interprets it with [ParseForge].
+// The boolean result is false if the forge is not recognized.
+func DetectForge(ctx context.Context, git, repodir, remote string) (Forge, bool, error) {
+	if git == "" {
+		var err error
+		git, err = exec.LookPath("git")
+		if err != nil {
+			return Forge{}, false, errors.Wrap(err, "finding git binary")
+		}
+	}
+
+	remoteURL, err := gitutil.RemoteURL(ctx, git, repodir, remote)
+	if err != nil {
+		return Forge{}, false, errors.Wrapf(err, "getting URL of remote %s", remote)
+	}
+
+	forge, ok := ParseForge(remoteURL)
+	return forge, ok, nil
+}
+
+// modpathMatchesRemote tells whether a module path (without any major-version suffix)
+// is consistent with the https form of a remote URL,
+// i.e. whether the module path is within the repository that the remote URL names.
+// The boolean second result is false if this cannot be determined,
+// as with a vanity import path like golang.org/x/mod,
+// whose host is neither the remote's host nor a known forge.
+func modpathMatchesRemote(baseModpath, remoteURL string) (matches, ok bool) {
+	remoteHost, remotePath, ok := splitRemoteURL(remoteURL)
+	if !ok {
+		return false, false
+	}
+	modHost, _, _ := strings.Cut(baseModpath, "/")
+	modHost = strings.ToLower(modHost)
+	if modHost != remoteHost {
+		if _, known := ParseForge("https://" + baseModpath); !known {
+			return false, false
+		}
+	}
+
+	var (
+		mod  = strings.ToLower(baseModpath)
+		repo = remoteHost + "/" + strings.ToLower(remotePath)
+	)
+	return mod == repo || strings.HasPrefix(mod, repo+"/"), true
+}
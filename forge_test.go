@@ -0,0 +1,217 @@
+package taggo_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bobg/taggo"
+)
+
+func TestParseForge(t *testing.T) {
+	cases := []struct {
+		remoteURL string
+		want      taggo.Forge
+		wantOK    bool
+	}{{
+		remoteURL: "https://github.com/bobg/taggo",
+		want:      taggo.Forge{Kind: taggo.ForgeGitHub, RepoURL: "https://github.com/bobg/taggo"},
+		wantOK:    true,
+	}, {
+		remoteURL: "https://github.com/bobg/taggo.git",
+		want:      taggo.Forge{Kind: taggo.ForgeGitHub, RepoURL: "https://github.com/bobg/taggo"},
+		wantOK:    true,
+	}, {
+		remoteURL: "git@github.com:bobg/taggo.git",
+		want:      taggo.Forge{Kind: taggo.ForgeGitHub, RepoURL: "https://github.com/bobg/taggo"},
+		wantOK:    true,
+	}, {
+		remoteURL: "ssh://git@gitlab.com/group/sub/proj.git",
+		want:      taggo.Forge{Kind: taggo.ForgeGitLab, RepoURL: "https://gitlab.com/group/sub/proj"},
+		wantOK:    true,
+	}, {
+		remoteURL: "https://codeberg.org/x/y/",
+		want:      taggo.Forge{Kind: taggo.ForgeCodeberg, RepoURL: "https://codeberg.org/x/y"},
+		wantOK:    true,
+	}, {
+		remoteURL: "/home/bobg/src/taggo",
+	}, {
+		remoteURL: "https://example.com/x/y",
+	}}
+
+	for _, c := range cases {
+		t.Run(c.remoteURL, func(t *testing.T) {
+			got, ok := taggo.ParseForge(c.remoteURL)
+			if ok != c.wantOK {
+				t.Fatalf("got ok %v, want %v", ok, c.wantOK)
+			}
+			if got != c.want {
+				t.Errorf("got %+v, want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeRemoteURL(t *testing.T) {
+	cases := []struct {
+		remoteURL, want string
+		wantOK          bool
+	}{{
+		remoteURL: "git@example.com:x/y.git",
+		want:      "https://example.com/x/y",
+		wantOK:    true,
+	}, {
+		remoteURL: "ssh://git@GitHub.com/bobg/taggo.git",
+		want:      "https://github.com/bobg/taggo",
+		wantOK:    true,
+	}, {
+		remoteURL: "https://go.googlesource.com/mod/",
+		want:      "https://go.googlesource.com/mod",
+		wantOK:    true,
+	}, {
+		remoteURL: "/home/bobg/src/taggo",
+	}}
+
+	for _, c := range cases {
+		t.Run(c.remoteURL, func(t *testing.T) {
+			got, ok := taggo.NormalizeRemoteURL(c.remoteURL)
+			if ok != c.wantOK {
+				t.Fatalf("got ok %v, want %v", ok, c.wantOK)
+			}
+			if got != c.want {
+				t.Errorf("got %s, want %s", got, c.want)
+			}
+		})
+	}
+}
+
+func TestForgeCreateTag(t *testing.T) {
+	type request struct {
+		Method, Path, Auth string
+		Body               map[string]string
+	}
+
+	cases := []struct {
+		kind    taggo.ForgeKind
+		tag     string
+		message string
+		want    []request
+	}{{
+		kind:    taggo.ForgeGitHub,
+		tag:     "sub/v1.2.0",
+		message: "Release 1.2.0",
+		want: []request{{
+			Method: "POST",
+			Path:   "/api/v3/repos/owner/repo/git/tags",
+			Auth:   "Bearer secret",
+			Body:   map[string]string{"tag": "sub/v1.2.0", "message": "Release 1.2.0", "object": "abc123", "type": "commit"},
+		}, {
+			Method: "POST",
+			Path:   "/api/v3/repos/owner/repo/git/refs",
+			Auth:   "Bearer secret",
+			Body:   map[string]string{"ref": "refs/tags/sub/v1.2.0", "sha": "tagobj"},
+		}},
+	}, {
+		kind: taggo.ForgeGitHub,
+		tag:  "v1.2.0",
+		want: []request{{
+			Method: "POST",
+			Path:   "/api/v3/repos/owner/repo/git/refs",
+			Auth:   "Bearer secret",
+			Body:   map[string]string{"ref": "refs/tags/v1.2.0", "sha": "abc123"},
+		}},
+	}, {
+		kind:    taggo.ForgeGitLab,
+		tag:     "v1.2.0",
+		message: "Release 1.2.0",
+		want: []request{{
+			Method: "POST",
+			Path:   "/api/v4/projects/owner/repo/repository/tags",
+			Auth:   "secret",
+			Body:   map[string]string{"tag_name": "v1.2.0", "ref": "abc123", "message": "Release 1.2.0"},
+		}},
+	}, {
+		kind: taggo.ForgeCodeberg,
+		tag:  "v1.2.0",
+		want: []request{{
+			Method: "POST",
+			Path:   "/api/v1/repos/owner/repo/tags",
+			Auth:   "token secret",
+			Body:   map[string]string{"tag_name": "v1.2.0", "target": "abc123"},
+		}},
+	}}
+
+	for _, c := range cases {
+		t.Run(string(c.kind), func(t *testing.T) {
+			var got []request
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				r := request{Method: req.Method, Path: req.URL.Path, Auth: req.Header.Get("Authorization")}
+				if c.kind == taggo.ForgeGitLab {
+					r.Auth = req.Header.Get("PRIVATE-TOKEN")
+				}
+				if err := json.NewDecoder(req.Body).Decode(&r.Body); err != nil {
+					t.Errorf("decoding request body: %s", err)
+				}
+				got = append(got, r)
+
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"sha": "tagobj"}`))
+			}))
+			defer srv.Close()
+
+			forge := taggo.Forge{Kind: c.kind, RepoURL: srv.URL + "/owner/repo"}
+			if err := forge.CreateTag(context.Background(), srv.Client(), "secret", c.tag, "abc123", c.message); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(c.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGitHubOrgRepos(t *testing.T) {
+	var pages []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/orgs/acme/repos" {
+			t.Errorf("got path %s, want /orgs/acme/repos", req.URL.Path)
+		}
+		if got := req.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("got Authorization %q, want Bearer secret", got)
+		}
+		page := req.URL.Query().Get("page")
+		pages = append(pages, page)
+
+		// A full first page, and a partial second one.
+		n := 100
+		if page == "2" {
+			n = 1
+		}
+		var repos []taggo.OrgRepo
+		for i := 0; i < n; i++ {
+			repos = append(repos, taggo.OrgRepo{FullName: fmt.Sprintf("acme/r%s-%d", page, i), Language: "Go"})
+		}
+		json.NewEncoder(w).Encode(repos)
+	}))
+	defer srv.Close()
+
+	repos, err := taggo.GitHubOrgRepos(context.Background(), srv.Client(), srv.URL, "secret", "acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repos) != 101 {
+		t.Errorf("got %d repos, want 101", len(repos))
+	}
+	if diff := cmp.Diff([]string{"1", "2"}, pages); diff != "" {
+		t.Errorf("pages mismatch (-want +got):\n%s", diff)
+	}
+	if len(repos) > 0 && repos[100].FullName != "acme/r2-0" {
+		t.Errorf("got last repo %s, want acme/r2-0", repos[100].FullName)
+	}
+}
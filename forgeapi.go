@@ -0,0 +1,247 @@
+package taggo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/bobg/errors"
+)
+
+// CreateTag creates a tag in the forge's copy of the repository
+// through the forge's web API,
+// authenticating with the given token,
+// instead of with "git tag" and "git push".
+// The tag points to commit,
+// which must be a full commit hash that the forge already has.
+// If message is empty,
+// the tag is lightweight;
+// otherwise it is an annotated tag with that message.
+//
+// For GitHub,
+// the API is at api.github.com for github.com,
+// and at /api/v3 on the host otherwise (GitHub Enterprise Server).
+func (f Forge) CreateTag(ctx context.Context, client *http.Client, token, tag, commit, message string) error {
+	base, repo, err := f.apiBase()
+	if err != nil {
+		return err
+	}
+
+	switch f.Kind {
+	case ForgeGitHub:
+		sha := commit
+		if message != "" {
+			req := struct {
+				Tag     string `json:"tag"`
+				Message string `json:"message"`
+				Object  string `json:"object"`
+				Type    string `json:"type"`
+			}{
+				Tag:     tag,
+				Message: message,
+				Object:  commit,
+				Type:    "commit",
+			}
+			var resp struct {
+				SHA string `json:"sha"`
+			}
+			if err := f.apiRequest(ctx, client, token, http.MethodPost, base+"/repos/"+repo+"/git/tags", req, &resp); err != nil {
+				return errors.Wrapf(err, "creating tag object for %s", tag)
+			}
+			sha = resp.SHA
+		}
+		req := struct {
+			Ref string `json:"ref"`
+			SHA string `json:"sha"`
+		}{
+			Ref: "refs/tags/" + tag,
+			SHA: sha,
+		}
+		err := f.apiRequest(ctx, client, token, http.MethodPost, base+"/repos/"+repo+"/git/refs", req, nil)
+		return errors.Wrapf(err, "creating ref for %s", tag)
+
+	case ForgeGitLab:
+		req := struct {
+			TagName string `json:"tag_name"`
+			Ref     string `json:"ref"`
+			Message string `json:"message,omitempty"`
+		}{
+			TagName: tag,
+			Ref:     commit,
+			Message: message,
+		}
+		err := f.apiRequest(ctx, client, token, http.MethodPost, base+"/projects/"+url.PathEscape(repo)+"/repository/tags", req, nil)
+		return errors.Wrapf(err, "creating tag %s", tag)
+
+	default: // Codeberg (Forgejo/Gitea)
+		req := struct {
+			TagName string `json:"tag_name"`
+			Target  string `json:"target"`
+			Message string `json:"message,omitempty"`
+		}{
+			TagName: tag,
+			Target:  commit,
+			Message: message,
+		}
+		err := f.apiRequest(ctx, client, token, http.MethodPost, base+"/repos/"+repo+"/tags", req, nil)
+		return errors.Wrapf(err, "creating tag %s", tag)
+	}
+}
+
+// DeleteTag deletes a tag in the forge's copy of the repository
+// through the forge's web API,
+// authenticating with the given token.
+// It undoes [Forge.CreateTag].
+func (f Forge) DeleteTag(ctx context.Context, client *http.Client, token, tag string) error {
+	base, repo, err := f.apiBase()
+	if err != nil {
+		return err
+	}
+
+	var endpoint string
+	switch f.Kind {
+	case ForgeGitHub:
+		endpoint = base + "/repos/" + repo + "/git/refs/tags/" + tag
+	case ForgeGitLab:
+		endpoint = base + "/projects/" + url.PathEscape(repo) + "/repository/tags/" + url.PathEscape(tag)
+	default:
+		endpoint = base + "/repos/" + repo + "/tags/" + url.PathEscape(tag)
+	}
+	err = f.apiRequest(ctx, client, token, http.MethodDelete, endpoint, nil, nil)
+	return errors.Wrapf(err, "deleting tag %s", tag)
+}
+
+// GitHubAPI is the base URL of the GitHub web API for github.com.
+// For GitHub Enterprise Server it is https://HOST/api/v3.
+const GitHubAPI = "https://api.github.com"
+
+// OrgRepo is a repository belonging to a GitHub organization,
+// as listed by [GitHubOrgRepos].
+type OrgRepo struct {
+	// FullName is the repository's name with its owner, as in "bobg/taggo".
+	FullName string `json:"full_name"`
+
+	// CloneURL is the https URL for cloning the repository.
+	CloneURL string `json:"clone_url"`
+
+	// Language is the repository's primary language according to GitHub,
+	// such as "Go".
+	Language string `json:"language"`
+
+	// Fork is true if the repository is a fork.
+	Fork bool `json:"fork"`
+
+	// Archived is true if the repository is archived (read-only).
+	Archived bool `json:"archived"`
+}
+
+// orgReposPerPage is the page size for listing an organization's repositories.
+// It is the most that the GitHub API allows.
+const orgReposPerPage = 100
+
+// GitHubOrgRepos lists the repositories of the GitHub organization org
+// through the GitHub web API at apiBase
+// (such as [GitHubAPI]).
+// If token is not empty,
+// it authenticates the requests,
+// which is necessary for private repositories to be included.
+func GitHubOrgRepos(ctx context.Context, client *http.Client, apiBase, token, org string) ([]OrgRepo, error) {
+	var (
+		result []OrgRepo
+		f      = Forge{Kind: ForgeGitHub}
+	)
+	for page := 1; ; page++ {
+		endpoint := fmt.Sprintf("%s/orgs/%s/repos?per_page=%d&page=%d", strings.TrimSuffix(apiBase, "/"), url.PathEscape(org), orgReposPerPage, page)
+		var repos []OrgRepo
+		if err := f.apiRequest(ctx, client, token, http.MethodGet, endpoint, nil, &repos); err != nil {
+			return nil, errors.Wrapf(err, "listing repositories of %s", org)
+		}
+		result = append(result, repos...)
+		if len(repos) < orgReposPerPage {
+			return result, nil
+		}
+	}
+}
+
+// apiBase returns the base URL of the forge's web API,
+// and the repository's path on the forge ("owner/repo").
+func (f Forge) apiBase() (base, repo string, err error) {
+	u, err := url.Parse(f.RepoURL)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "parsing %s", f.RepoURL)
+	}
+	repo = strings.Trim(u.Path, "/")
+	if repo == "" {
+		return "", "", fmt.Errorf("no repository in %s", f.RepoURL)
+	}
+
+	host := u.Scheme + "://" + u.Host
+	switch f.Kind {
+	case ForgeGitHub:
+		if u.Host == "github.com" {
+			return GitHubAPI, repo, nil
+		}
+		return host + "/api/v3", repo, nil
+	case ForgeGitLab:
+		return host + "/api/v4", repo, nil
+	case ForgeCodeberg:
+		return host + "/api/v1", repo, nil
+	}
+	return "", "", fmt.Errorf("no API known for forge %q", f.Kind)
+}
+
+// apiRequest sends a request to the forge's web API,
+// with reqBody (if not nil) encoded as JSON,
+// decoding the JSON response into respBody (if not nil).
+func (f Forge) apiRequest(ctx context.Context, client *http.Client, token, method, endpoint string, reqBody, respBody any) error {
+	var body io.Reader
+	if reqBody != nil {
+		enc, err := json.Marshal(reqBody)
+		if err != nil {
+			return errors.Wrap(err, "encoding request")
+		}
+		body = bytes.NewReader(enc)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return errors.Wrapf(err, "creating request for %s", endpoint)
+	}
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if f.Kind == ForgeGitHub {
+		req.Header.Set("Accept", "application/vnd.github+json")
+	}
+	if token != "" {
+		switch f.Kind {
+		case ForgeGitHub:
+			req.Header.Set("Authorization", "Bearer "+token)
+		case ForgeGitLab:
+			req.Header.Set("PRIVATE-TOKEN", token)
+		default:
+			req.Header.Set("Authorization", "token "+token)
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "requesting %s", endpoint)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s %s: status %d: %s", method, endpoint, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	if respBody == nil {
+		return nil
+	}
+	err = json.NewDecoder(resp.Body).Decode(respBody)
+	return errors.Wrapf(err, "decoding response from %s", endpoint)
+}
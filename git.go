@@ -1,53 +1,24 @@
 package taggo
 
 import (
-	"bufio"
-	"bytes"
 	"context"
-	"os/exec"
-	"strings"
+	"fmt"
+	"time"
 
 	"github.com/bobg/errors"
-)
 
-func gitRefs(ctx context.Context, git, dir string, f func(name, hash string) error) error {
-	cmd := exec.CommandContext(ctx, git, "show-ref")
-	cmd.Dir = dir
-	stdout, err := cmd.StdoutPipe()
-	if err != nil {
-		return errors.Wrap(err, "creating stdout pipe")
-	}
-	if err := cmd.Start(); err != nil {
-		return errors.Wrapf(err, "starting %s", cmd)
-	}
-	defer cmd.Wait()
+	"github.com/bobg/taggo/gitutil"
+)
 
-	sc := bufio.NewScanner(stdout)
-	for sc.Scan() {
-		line := sc.Text()
-		fields := strings.Fields(line)
-		if len(fields) != 2 {
-			continue // silently ignore malformed lines
-		}
-		hash, name := fields[0], fields[1]
-		if err := f(name, hash); err != nil {
-			return err
-		}
-	}
-	if err := sc.Err(); err != nil {
-		return errors.Wrapf(err, "scanning output of %s", cmd)
-	}
-	err = cmd.Wait()
-	return errors.Wrapf(err, "waiting for %s", cmd)
-}
+// ErrGitTimeout is the error for a git command that runs longer than the limit set with [WithGitTimeout].
+var ErrGitTimeout = gitutil.ErrTimeout
 
-func gitTagCommit(ctx context.Context, git, dir, tag string) (string, error) {
-	cmd := exec.CommandContext(ctx, git, "rev-list", "-n", "1", tag)
-	cmd.Dir = dir
-	output, err := cmd.Output()
-	if err != nil {
-		return "", errors.Wrapf(err, "running %s", cmd)
+// timeoutErr returns err,
+// or, if err is due to tctx (derived from ctx) reaching its deadline,
+// an error wrapping [ErrGitTimeout].
+func timeoutErr(ctx, tctx context.Context, err error, timeout time.Duration) error {
+	if err != nil && ctx.Err() == nil && errors.Is(tctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s", ErrGitTimeout, timeout)
 	}
-	output = bytes.TrimSpace(output)
-	return string(output), nil
+	return err
 }
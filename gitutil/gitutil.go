@@ -0,0 +1,524 @@
+// Package gitutil runs git commands
+// for the low-level operations that Taggo needs,
+// such as listing refs, peeling tags to commits, and comparing revisions.
+// Each function takes the path of the git binary
+// and the directory of the repository (or working tree) in which to run it.
+package gitutil
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bobg/errors"
+)
+
+// ErrTimeout is the error for a git command that runs longer than the limit set with [WithTimeout].
+var ErrTimeout = errors.New("git operation timed out")
+
+type timeoutKey struct{}
+
+// WithTimeout returns a context that causes [Command],
+// and so every function in this package,
+// to limit each git command to timeout,
+// if it is positive.
+func WithTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	if timeout <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, timeoutKey{}, timeout)
+}
+
+type gitDirKey struct{}
+
+type gitDirs struct {
+	gitdir, worktree string
+}
+
+// WithGitDir returns a context that causes [Command],
+// and so every function in this package,
+// to run git with GIT_DIR set to gitdir
+// and, if worktree is not empty, GIT_WORK_TREE set to worktree.
+// This is for a repository whose Git directory is not in the usual place,
+// as when git runs in a server-side hook.
+// Without it, git commands see the GIT_DIR and GIT_WORK_TREE of the environment, if any.
+func WithGitDir(ctx context.Context, gitdir, worktree string) context.Context {
+	if gitdir == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, gitDirKey{}, gitDirs{gitdir: gitdir, worktree: worktree})
+}
+
+// Command returns a command that runs git with args in dir.
+// If ctx comes from [WithTimeout],
+// the command is killed when it runs longer than the timeout.
+// The caller must pass the command's error (or nil) to done when the command has finished.
+// Done returns that error,
+// or one wrapping [ErrTimeout] if the command timed out.
+func Command(ctx context.Context, git, dir string, args ...string) (cmd *exec.Cmd, done func(error) error) {
+	timeout, _ := ctx.Value(timeoutKey{}).(time.Duration)
+	if timeout <= 0 {
+		cmd = exec.CommandContext(ctx, git, args...)
+		setDirs(ctx, cmd, dir)
+		return cmd, func(err error) error { return err }
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, timeout)
+	cmd = exec.CommandContext(tctx, git, args...)
+	setDirs(ctx, cmd, dir)
+	return cmd, func(err error) error {
+		defer cancel()
+		return timeoutErr(ctx, tctx, err, timeout)
+	}
+}
+
+func setDirs(ctx context.Context, cmd *exec.Cmd, dir string) {
+	cmd.Dir = dir
+	if dirs, ok := ctx.Value(gitDirKey{}).(gitDirs); ok {
+		cmd.Env = append(os.Environ(), "GIT_DIR="+dirs.gitdir)
+		if dirs.worktree != "" {
+			cmd.Env = append(cmd.Env, "GIT_WORK_TREE="+dirs.worktree)
+		}
+	}
+}
+
+// timeoutErr returns err,
+// or, if err is due to tctx (derived from ctx) reaching its deadline,
+// an error wrapping [ErrTimeout].
+func timeoutErr(ctx, tctx context.Context, err error, timeout time.Duration) error {
+	if err != nil && ctx.Err() == nil && errors.Is(tctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s", ErrTimeout, timeout)
+	}
+	return err
+}
+
+// Refs calls f with the name and hash of each ref in the repository,
+// as listed by "git show-ref",
+// stopping at the first error from f.
+func Refs(ctx context.Context, git, dir string, f func(name, hash string) error) error {
+	cmd, done := Command(ctx, git, dir, "show-ref")
+	defer done(nil) // release the timeout on early returns, too
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return errors.Wrap(err, "creating stdout pipe")
+	}
+	if err := cmd.Start(); err != nil {
+		return errors.Wrapf(err, "starting %s", cmd)
+	}
+	defer cmd.Wait()
+
+	sc := bufio.NewScanner(stdout)
+	for sc.Scan() {
+		line := sc.Text()
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue // silently ignore malformed lines
+		}
+		hash, name := fields[0], fields[1]
+		if err := f(name, hash); err != nil {
+			return err
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return errors.Wrapf(err, "scanning output of %s", cmd)
+	}
+	err = done(cmd.Wait())
+	return errors.Wrapf(err, "waiting for %s", cmd)
+}
+
+// TagCommit returns the hash of the commit that tag refers to,
+// peeling annotated tags.
+func TagCommit(ctx context.Context, git, dir, tag string) (string, error) {
+	cmd, done := Command(ctx, git, dir, "rev-list", "-n", "1", tag)
+	output, err := cmd.Output()
+	if err = done(err); err != nil {
+		return "", errors.Wrapf(err, "running %s", cmd)
+	}
+	output = bytes.TrimSpace(output)
+	return string(output), nil
+}
+
+// RemoteURL returns the URL of the given remote.
+func RemoteURL(ctx context.Context, git, dir, remote string) (string, error) {
+	cmd, done := Command(ctx, git, dir, "remote", "get-url", remote)
+	output, err := cmd.Output()
+	if err = done(err); err != nil {
+		return "", errors.Wrapf(err, "running %s", cmd)
+	}
+	output = bytes.TrimSpace(output)
+	return string(output), nil
+}
+
+// Show returns the contents of the file at path (relative to the repository root) in revision rev.
+func Show(ctx context.Context, git, dir, rev, path string) ([]byte, error) {
+	cmd, done := Command(ctx, git, dir, "show", rev+":"+path)
+	output, err := cmd.Output()
+	err = done(err)
+	return output, errors.Wrapf(err, "running %s", cmd)
+}
+
+// CommitTime returns the committer time of rev in RFC 3339 format.
+func CommitTime(ctx context.Context, git, dir, rev string) (string, error) {
+	cmd, done := Command(ctx, git, dir, "log", "-1", "--format=%cI", rev)
+	output, err := cmd.Output()
+	if err = done(err); err != nil {
+		return "", errors.Wrapf(err, "running %s", cmd)
+	}
+	output = bytes.TrimSpace(output)
+	return string(output), nil
+}
+
+// CommitDetail is information about a commit from [CommitDetails] or [Commits].
+type CommitDetail struct {
+	Hash       string
+	Author     string // as "name <email>"
+	CommitTime string // committer time, RFC 3339
+	Subject    string
+}
+
+// commitDetailFormat is the git log format parsed by parseCommitDetails.
+const commitDetailFormat = "--format=%H%x00%an <%ae>%x00%cI%x00%s"
+
+// CommitDetails returns information about the commit rev.
+func CommitDetails(ctx context.Context, git, dir, rev string) (CommitDetail, error) {
+	cmd, done := Command(ctx, git, dir, "log", "-1", commitDetailFormat, rev)
+	output, err := cmd.Output()
+	if err = done(err); err != nil {
+		return CommitDetail{}, errors.Wrapf(err, "running %s", cmd)
+	}
+	details, err := parseCommitDetails(output)
+	if err != nil {
+		return CommitDetail{}, errors.Wrapf(err, "parsing output of %s", cmd)
+	}
+	if len(details) != 1 {
+		return CommitDetail{}, fmt.Errorf("got %d commits from %s, want 1", len(details), cmd)
+	}
+	return details[0], nil
+}
+
+// Commits returns information about the commits in the range from..to,
+// newest first,
+// limited to those touching path if it is non-empty.
+// If from is empty,
+// it includes all the commits reachable from to.
+func Commits(ctx context.Context, git, dir, from, to, path string) ([]CommitDetail, error) {
+	cmd, done := Command(ctx, git, dir, rangeArgs([]string{"log", commitDetailFormat}, from, to, path)...)
+	output, err := cmd.Output()
+	if err = done(err); err != nil {
+		return nil, errors.Wrapf(err, "running %s", cmd)
+	}
+	details, err := parseCommitDetails(output)
+	return details, errors.Wrapf(err, "parsing output of %s", cmd)
+}
+
+// CommitCount returns the number of commits in the range from..to,
+// limited to those touching path if it is non-empty.
+// If from is empty,
+// it counts all the commits reachable from to.
+func CommitCount(ctx context.Context, git, dir, from, to, path string) (int, error) {
+	cmd, done := Command(ctx, git, dir, rangeArgs([]string{"rev-list", "--count"}, from, to, path)...)
+	output, err := cmd.Output()
+	if err = done(err); err != nil {
+		return 0, errors.Wrapf(err, "running %s", cmd)
+	}
+	n, err := strconv.Atoi(string(bytes.TrimSpace(output)))
+	return n, errors.Wrapf(err, "parsing output of %s", cmd)
+}
+
+// rangeArgs appends to args the arguments selecting the commits in from..to
+// (or reachable from to, if from is empty)
+// that touch path (if it is non-empty).
+func rangeArgs(args []string, from, to, path string) []string {
+	if from == "" {
+		args = append(args, to)
+	} else {
+		args = append(args, from+".."+to)
+	}
+	if path != "" {
+		args = append(args, "--", path)
+	}
+	return args
+}
+
+func parseCommitDetails(output []byte) ([]CommitDetail, error) {
+	var result []CommitDetail
+	for _, line := range strings.Split(strings.TrimRight(string(output), "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("malformed line %q", line)
+		}
+		result = append(result, CommitDetail{Hash: fields[0], Author: fields[1], CommitTime: fields[2], Subject: fields[3]})
+	}
+	return result, nil
+}
+
+// TagDetail is information about a tag from [TagDetails].
+type TagDetail struct {
+	Annotated  bool
+	Tagger     string // annotated tags only, as "name <email>"
+	TagTime    string // annotated tags only, RFC 3339
+	CommitTime string // RFC 3339
+	Signed     bool
+}
+
+// TagDetails returns information about the tags in the repository,
+// keyed by tag name.
+func TagDetails(ctx context.Context, git, dir string) (map[string]TagDetail, error) {
+	const format = "%(refname:strip=2)%00%(objecttype)%00%(taggername) %(taggeremail)%00%(taggerdate:iso-strict)%00%(committerdate:iso-strict)%00%(*committerdate:iso-strict)%00%(if)%(contents:signature)%(then)signed%(end)"
+
+	cmd, done := Command(ctx, git, dir, "for-each-ref", "--format="+format, "refs/tags")
+	output, err := cmd.Output()
+	if err = done(err); err != nil {
+		return nil, errors.Wrapf(err, "running %s", cmd)
+	}
+
+	result := make(map[string]TagDetail)
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 7 {
+			continue // silently ignore malformed lines
+		}
+		detail := TagDetail{
+			CommitTime: fields[4],
+			Signed:     fields[6] != "",
+		}
+		if fields[1] == "tag" {
+			detail.Annotated = true
+			detail.Tagger = strings.TrimSpace(fields[2])
+			detail.TagTime = fields[3]
+			detail.CommitTime = fields[5]
+		}
+		result[fields[0]] = detail
+	}
+	return result, nil
+}
+
+// TagsMerged returns the names of the tags reachable from rev.
+func TagsMerged(ctx context.Context, git, dir, rev string) (map[string]bool, error) {
+	cmd, done := Command(ctx, git, dir, "for-each-ref", "--merged="+rev, "--format=%(refname:strip=2)", "refs/tags")
+	output, err := cmd.Output()
+	if err = done(err); err != nil {
+		return nil, errors.Wrapf(err, "running %s", cmd)
+	}
+
+	result := make(map[string]bool)
+	for _, name := range strings.Fields(string(output)) {
+		result[name] = true
+	}
+	return result, nil
+}
+
+// CommitTimes returns the committer times, in RFC 3339 format,
+// of the commits in the range from..to,
+// limited to those touching path if it is non-empty.
+// If from is empty,
+// it includes all the commits reachable from to.
+func CommitTimes(ctx context.Context, git, dir, from, to, path string) ([]string, error) {
+	cmd, done := Command(ctx, git, dir, rangeArgs([]string{"log", "--format=%cI"}, from, to, path)...)
+	output, err := cmd.Output()
+	if err = done(err); err != nil {
+		return nil, errors.Wrapf(err, "running %s", cmd)
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// TreeChanged tells whether the trees of revisions from and to differ,
+// limited to path if it is non-empty.
+func TreeChanged(ctx context.Context, git, dir, from, to, path string) (bool, error) {
+	args := []string{"diff", "--quiet", from, to}
+	if path != "" {
+		args = append(args, "--", path)
+	}
+	cmd, done := Command(ctx, git, dir, args...)
+	err := done(cmd.Run())
+
+	var ee *exec.ExitError
+	if errors.As(err, &ee) && ee.ExitCode() == 1 {
+		return true, nil
+	}
+	return false, errors.Wrapf(err, "running %s", cmd)
+}
+
+// AheadBehind returns the numbers of commits reachable from local but not upstream
+// and from upstream but not local.
+func AheadBehind(ctx context.Context, git, dir, local, upstream string) (ahead, behind int, err error) {
+	cmd, done := Command(ctx, git, dir, "rev-list", "--left-right", "--count", local+"..."+upstream)
+	output, err := cmd.Output()
+	if err = done(err); err != nil {
+		return 0, 0, errors.Wrapf(err, "running %s", cmd)
+	}
+	if _, err := fmt.Sscan(string(output), &ahead, &behind); err != nil {
+		return 0, 0, errors.Wrapf(err, "parsing output of %s", cmd)
+	}
+	return ahead, behind, nil
+}
+
+// SymbolicRef returns the ref that the symbolic ref name refers to,
+// such as refs/heads/main for HEAD,
+// or "" if name is not a symbolic ref
+// (as when HEAD is detached).
+func SymbolicRef(ctx context.Context, git, dir, name string) (string, error) {
+	cmd, done := Command(ctx, git, dir, "symbolic-ref", "--quiet", name)
+	output, err := cmd.Output()
+	err = done(err)
+
+	var ee *exec.ExitError
+	if errors.As(err, &ee) && ee.ExitCode() == 1 {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "running %s", cmd)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// RemoteTags lists the tags in the given remote.
+// It returns a map from each tag name to the commit it refers to,
+// and the set of tags that are annotated.
+func RemoteTags(ctx context.Context, git, dir, remote string) (map[string]string, map[string]bool, error) {
+	cmd, done := Command(ctx, git, dir, "ls-remote", "--tags", remote)
+	output, err := cmd.Output()
+	if err = done(err); err != nil {
+		return nil, nil, errors.Wrapf(err, "running %s", cmd)
+	}
+
+	var (
+		tags      = make(map[string]string)
+		annotated = make(map[string]bool)
+	)
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || !strings.HasPrefix(fields[1], "refs/tags/") {
+			continue
+		}
+		name := strings.TrimPrefix(fields[1], "refs/tags/")
+		if peeled, ok := strings.CutSuffix(name, "^{}"); ok {
+			tags[peeled] = fields[0] // the peeled tag is the commit
+			annotated[peeled] = true
+			continue
+		}
+		if !annotated[name] {
+			tags[name] = fields[0]
+		}
+	}
+	return tags, annotated, nil
+}
+
+// HasCommit tells whether the commit with the given hash is in the repository.
+func HasCommit(ctx context.Context, git, dir, hash string) bool {
+	cmd, done := Command(ctx, git, dir, "cat-file", "-e", hash+"^{commit}")
+	return done(cmd.Run()) == nil
+}
+
+// RemoteTagCommit returns the commit that tag refers to in the given remote,
+// or "" if the remote has no such tag.
+func RemoteTagCommit(ctx context.Context, git, dir, remote, tag string) (string, error) {
+	cmd, done := Command(ctx, git, dir, "ls-remote", remote, "refs/tags/"+tag, "refs/tags/"+tag+"^{}")
+	output, err := cmd.Output()
+	if err = done(err); err != nil {
+		return "", errors.Wrapf(err, "running %s", cmd)
+	}
+
+	var commit string
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		switch fields[1] {
+		case "refs/tags/" + tag + "^{}":
+			return fields[0], nil // the peeled tag is the commit
+		case "refs/tags/" + tag:
+			commit = fields[0]
+		}
+	}
+	return commit, nil
+}
+
+// ResolveCommit returns the hash of the commit that rev names,
+// or "" if rev does not name a commit.
+func ResolveCommit(ctx context.Context, git, dir, rev string) (string, error) {
+	cmd, done := Command(ctx, git, dir, "rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	output, err := cmd.Output()
+	err = done(err)
+
+	var ee *exec.ExitError
+	if errors.As(err, &ee) && ee.ExitCode() == 1 {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "running %s", cmd)
+	}
+	return string(bytes.TrimSpace(output)), nil
+}
+
+// IsAncestor tells whether commit ancestor is reachable from rev.
+func IsAncestor(ctx context.Context, git, dir, ancestor, rev string) (bool, error) {
+	cmd, done := Command(ctx, git, dir, "merge-base", "--is-ancestor", ancestor, rev)
+	err := done(cmd.Run())
+
+	var ee *exec.ExitError
+	if errors.As(err, &ee) && ee.ExitCode() == 1 {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "running %s", cmd)
+	}
+	return true, nil
+}
+
+// MergeBase returns the best common ancestor of commits a and b.
+func MergeBase(ctx context.Context, git, dir, a, b string) (string, error) {
+	cmd, done := Command(ctx, git, dir, "merge-base", a, b)
+	output, err := cmd.Output()
+	if err = done(err); err != nil {
+		return "", errors.Wrapf(err, "running %s", cmd)
+	}
+	return string(bytes.TrimSpace(output)), nil
+}
+
+// ChangedFiles lists the files that differ between the trees of revisions from and to.
+func ChangedFiles(ctx context.Context, git, dir, from, to string) ([]string, error) {
+	cmd, done := Command(ctx, git, dir, "diff", "--name-only", "-z", from, to)
+	output, err := cmd.Output()
+	if err = done(err); err != nil {
+		return nil, errors.Wrapf(err, "running %s", cmd)
+	}
+	var files []string
+	for _, f := range strings.Split(string(output), "\x00") {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// CommonDir returns the absolute path of the repository's Git directory,
+// the one holding its objects and refs.
+// This is dir/.git in the usual case,
+// but differs for a linked worktree (see git-worktree(1)),
+// for a repository made with --separate-git-dir,
+// and when GIT_DIR is set.
+func CommonDir(ctx context.Context, git, dir string) (string, error) {
+	cmd, done := Command(ctx, git, dir, "rev-parse", "--git-common-dir")
+	output, err := cmd.Output()
+	if err = done(err); err != nil {
+		return "", errors.Wrapf(err, "running %s", cmd)
+	}
+	common := string(bytes.TrimSpace(output))
+	if !filepath.IsAbs(common) {
+		common = filepath.Join(dir, common)
+	}
+	return filepath.Abs(common)
+}
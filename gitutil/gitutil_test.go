@@ -0,0 +1,89 @@
+package gitutil_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bobg/taggo/gitutil"
+	"github.com/bobg/taggo/internal/testutil"
+)
+
+func TestGitutil(t *testing.T) {
+	repodir := t.TempDir()
+
+	testutil.Git(t, repodir, "init", "-q", "-b", "main")
+	if err := os.WriteFile(filepath.Join(repodir, "x.txt"), []byte("x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	testutil.Git(t, repodir, "add", "x.txt")
+	testutil.Git(t, repodir, "commit", "-q", "-m", "initial")
+	commit := testutil.Git(t, repodir, "rev-parse", "HEAD")
+	testutil.Git(t, repodir, "tag", "-a", "-m", "Version v1.0.0", "v1.0.0")
+	testutil.Git(t, repodir, "tag", "light")
+
+	var (
+		ctx    = context.Background()
+		gitbin = "git"
+	)
+
+	refs := make(map[string]string)
+	err := gitutil.Refs(ctx, gitbin, repodir, func(name, hash string) error {
+		refs[name] = hash
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refs["refs/heads/main"] != commit {
+		t.Errorf("got main at %s, want %s", refs["refs/heads/main"], commit)
+	}
+	if refs["refs/tags/v1.0.0"] == commit {
+		t.Error("got annotated tag ref pointing at the commit, want the tag object")
+	}
+
+	for _, tag := range []string{"v1.0.0", "light"} {
+		got, err := gitutil.TagCommit(ctx, gitbin, repodir, tag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != commit {
+			t.Errorf("got commit %s for tag %s, want %s", got, tag, commit)
+		}
+	}
+
+	details, err := gitutil.TagDetails(ctx, gitbin, repodir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !details["v1.0.0"].Annotated || details["light"].Annotated {
+		t.Errorf("got annotated %v for v1.0.0 and %v for light, want true and false", details["v1.0.0"].Annotated, details["light"].Annotated)
+	}
+	if details["v1.0.0"].Tagger != "Taggo <taggo@example.com>" {
+		t.Errorf("got tagger %q", details["v1.0.0"].Tagger)
+	}
+
+	cd, err := gitutil.CommitDetails(ctx, gitbin, repodir, "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cd.Author != "Taggo <taggo@example.com>" || cd.Subject != "initial" || cd.CommitTime == "" {
+		t.Errorf("got commit details %+v", cd)
+	}
+
+	resolved, err := gitutil.ResolveCommit(ctx, gitbin, repodir, "v1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved != commit {
+		t.Errorf("got resolved commit %s, want %s", resolved, commit)
+	}
+	resolved, err = gitutil.ResolveCommit(ctx, gitbin, repodir, "nonesuch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved != "" {
+		t.Errorf("got commit %s for unknown rev, want none", resolved)
+	}
+}
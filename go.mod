@@ -9,6 +9,8 @@ require (
 	github.com/bobg/modver/v2 v2.10.2
 	github.com/google/go-cmp v0.6.0
 	golang.org/x/mod v0.22.0
+	golang.org/x/tools v0.25.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -32,6 +34,5 @@ require (
 	golang.org/x/net v0.29.0 // indirect
 	golang.org/x/sync v0.8.0 // indirect
 	golang.org/x/sys v0.25.0 // indirect
-	golang.org/x/tools v0.25.0 // indirect
 	gopkg.in/warnings.v0 v0.1.2 // indirect
 )
@@ -0,0 +1,55 @@
+package taggo
+
+import (
+	"context"
+	"go/version"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/bobg/taggo/gitutil"
+)
+
+// goDirectives returns the go and toolchain directives
+// in the go.mod file at path in revision rev.
+// Either or both may be empty.
+func goDirectives(ctx context.Context, git, repodir, rev, path string) (goDirective, toolchain string, err error) {
+	data, err := gitutil.Show(ctx, git, repodir, rev, path)
+	if err != nil {
+		return "", "", err
+	}
+	gomod, err := modfile.ParseLax(path, data, noopFixer)
+	if err != nil {
+		return "", "", err
+	}
+	if gomod.Go != nil {
+		goDirective = gomod.Go.Version
+	}
+	if gomod.Toolchain != nil {
+		toolchain = gomod.Toolchain.Name
+	}
+	return goDirective, toolchain, nil
+}
+
+// GoDirectiveRaised tells whether GoDirective is higher than LatestVersionGoDirective.
+func (r Result) GoDirectiveRaised() bool {
+	if r.GoDirective == "" || r.LatestVersionGoDirective == "" {
+		return false
+	}
+	return version.Compare("go"+r.GoDirective, "go"+r.LatestVersionGoDirective) > 0
+}
+
+// ToolchainDirectiveRaised tells whether ToolchainDirective is higher than LatestVersionToolchainDirective.
+// A missing toolchain directive is taken to be the same as the go directive.
+func (r Result) ToolchainDirectiveRaised() bool {
+	if r.ToolchainDirective == "" {
+		return false
+	}
+	old := r.LatestVersionToolchainDirective
+	if old == "" {
+		if r.LatestVersionGoDirective == "" {
+			return false
+		}
+		old = "go" + r.LatestVersionGoDirective
+	}
+	return version.Compare(r.ToolchainDirective, old) > 0
+}
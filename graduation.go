@@ -0,0 +1,310 @@
+package taggo
+
+import (
+	"context"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bobg/errors"
+	"github.com/bobg/modver/v2"
+	"golang.org/x/mod/semver"
+)
+
+// GraduationConfig holds the criteria in a [Config]
+// for suggesting that a v0 module release v1.0.0
+// (see [WithGraduation]).
+type GraduationConfig struct {
+	// Days is how long ago the module's first v0 release must have been.
+	// The default is 365.
+	Days int `yaml:"days"`
+
+	// Releases is how many of the module's latest v0 releases
+	// must have made no breaking changes, according to Modver.
+	// The default is 3.
+	Releases int `yaml:"releases"`
+}
+
+// Graduation is an evaluation of whether a v0 module is ready for v1.0.0.
+// See [WithGraduation].
+type Graduation struct {
+	// Ready is true if all the criteria are met,
+	// in which case Taggo suggests releasing v1.0.0.
+	Ready bool
+
+	// Criteria are the criteria evaluated, with the reasoning for each.
+	Criteria []GraduationCriterion
+}
+
+// GraduationCriterion is one of the criteria in a [Graduation].
+type GraduationCriterion struct {
+	// Kind is the kind of criterion.
+	Kind GraduationKind
+
+	// Met is true if the module meets the criterion.
+	Met bool
+
+	// Reason explains why the criterion is or is not met.
+	Reason string
+}
+
+// GraduationKind is the type of GraduationCriterion.Kind.
+type GraduationKind string
+
+// Possible values for GraduationCriterion.Kind.
+const (
+	// GraduationAge is the age of the v0 series,
+	// the time since the module's first v0 release.
+	GraduationAge GraduationKind = "age"
+
+	// GraduationStability is the absence of breaking changes,
+	// according to Modver,
+	// in the latest v0 releases and in the unreleased changes since.
+	GraduationStability GraduationKind = "api-stability"
+
+	// GraduationDocs is the presence of a package doc comment
+	// in each of the module's importable packages.
+	GraduationDocs GraduationKind = "docs"
+
+	// GraduationTests is the presence of tests
+	// in each of the module's importable packages.
+	GraduationTests GraduationKind = "tests"
+)
+
+// Default graduation criteria.
+const (
+	defaultGraduationDays     = 365
+	defaultGraduationReleases = 3
+)
+
+// Reasons returns the reasons for the criteria in g that are met, if g.Ready,
+// or for those that are not met otherwise.
+func (g Graduation) Reasons() []string {
+	var reasons []string
+	for _, c := range g.Criteria {
+		if c.Met == g.Ready {
+			reasons = append(reasons, c.Reason)
+		}
+	}
+	return reasons
+}
+
+// evalGraduation evaluates the v0 module in moduledir
+// (relative to the root of the repository in repodir, whose Git directory is gitdir)
+// for readiness to release v1.0.0.
+// The versions are the module's version tags in ascending order,
+// and r is the result of checking the module so far.
+// The module's packages are examined as they are in the working tree.
+func (o options) evalGraduation(ctx context.Context, git, repodir, gitdir, moduledir string, versions []VersionInfo, r Result) (*Graduation, error) {
+	config := o.config.Graduation
+	if config.Days <= 0 {
+		config.Days = defaultGraduationDays
+	}
+	if config.Releases <= 0 {
+		config.Releases = defaultGraduationReleases
+	}
+
+	var releases []VersionInfo
+	for _, v := range versions {
+		if semver.Major(v.Version) == "v0" && semver.Prerelease(v.Version) == "" {
+			releases = append(releases, v)
+		}
+	}
+
+	g := new(Graduation)
+
+	// Age of the v0 series.
+
+	age := GraduationCriterion{Kind: GraduationAge}
+	if len(releases) == 0 {
+		age.Reason = "no v0 releases"
+	} else {
+		first := releases[0]
+		days := int(time.Since(first.released()).Hours() / 24)
+		age.Met = days >= config.Days
+		if age.Met {
+			age.Reason = fmt.Sprintf("first v0 release %s was %d days ago", first.Version, days)
+		} else {
+			age.Reason = fmt.Sprintf("first v0 release %s was only %d days ago (want %d)", first.Version, days, config.Days)
+		}
+	}
+	g.Criteria = append(g.Criteria, age)
+
+	// Recent API stability.
+
+	stability := GraduationCriterion{Kind: GraduationStability}
+	if len(releases) <= config.Releases {
+		stability.Reason = fmt.Sprintf("only %d v0 release(s), too few to judge the stability of the last %d", len(releases), config.Releases)
+	} else {
+		var breaking []string
+		for i := len(releases) - config.Releases; i < len(releases); i++ {
+			prev, v := releases[i-1], releases[i]
+			res, _, err := o.compareRevs(ctx, git, gitdir, moduledir, prev.Commit, v.Commit)
+			if err != nil {
+				return nil, errors.Wrapf(err, "comparing %s to %s", prev.Version, v.Version)
+			}
+			if res.Code() == modver.Major {
+				breaking = append(breaking, fmt.Sprintf("%s (since %s)", v.Version, prev.Version))
+			}
+		}
+		if r.ModverResultCode == modver.Major {
+			breaking = append(breaking, "unreleased changes (since "+r.LatestVersion+")")
+		}
+		stability.Met = len(breaking) == 0
+		if stability.Met {
+			stability.Reason = fmt.Sprintf("no breaking changes in the last %d releases", config.Releases)
+		} else {
+			stability.Reason = "breaking changes in " + strings.Join(breaking, ", ")
+		}
+	}
+	g.Criteria = append(g.Criteria, stability)
+
+	// Docs and tests.
+
+	pkgs, err := packageDocsAndTests(filepath.Join(repodir, moduledir), r.Modpath)
+	if err != nil {
+		return nil, errors.Wrap(err, "examining packages")
+	}
+	var undocumented, untested []string
+	for _, p := range pkgs {
+		if !p.documented {
+			undocumented = append(undocumented, p.path)
+		}
+		if !p.tested {
+			untested = append(untested, p.path)
+		}
+	}
+
+	docs := GraduationCriterion{Kind: GraduationDocs, Met: len(pkgs) > 0 && len(undocumented) == 0}
+	switch {
+	case len(pkgs) == 0:
+		docs.Reason = "no importable packages"
+	case docs.Met:
+		docs.Reason = fmt.Sprintf("all %d package(s) have doc comments", len(pkgs))
+	default:
+		docs.Reason = fmt.Sprintf("%d of %d package(s) lack doc comments: %s", len(undocumented), len(pkgs), abbrevList(undocumented, 3))
+	}
+	g.Criteria = append(g.Criteria, docs)
+
+	tests := GraduationCriterion{Kind: GraduationTests, Met: len(pkgs) > 0 && len(untested) == 0}
+	switch {
+	case len(pkgs) == 0:
+		tests.Reason = "no importable packages"
+	case tests.Met:
+		tests.Reason = fmt.Sprintf("all %d package(s) have tests", len(pkgs))
+	default:
+		tests.Reason = fmt.Sprintf("%d of %d package(s) lack tests: %s", len(untested), len(pkgs), abbrevList(untested, 3))
+	}
+	g.Criteria = append(g.Criteria, tests)
+
+	g.Ready = true
+	for _, c := range g.Criteria {
+		g.Ready = g.Ready && c.Met
+	}
+
+	return g, nil
+}
+
+type packageInfo struct {
+	path               string
+	hasSource, isMain  bool
+	documented, tested bool
+}
+
+// packageDocsAndTests tells, for each importable non-main package of the module in moddir,
+// whether it has a package doc comment and whether it has tests.
+// The result is sorted by import path.
+func packageDocsAndTests(moddir, modpath string) ([]packageInfo, error) {
+	var (
+		pkgs = make(map[string]*packageInfo) // keyed by directory relative to moddir
+		fset = token.NewFileSet()
+	)
+
+	err := filepath.WalkDir(moddir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(moddir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if rel == "." {
+				return nil
+			}
+			if isNonImportableDir(rel) {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(p, "go.mod")); err == nil {
+				return filepath.SkipDir // nested module
+			}
+			return nil
+		}
+
+		if !strings.HasSuffix(p, ".go") {
+			return nil
+		}
+
+		dir := path.Dir(rel)
+		info := pkgs[dir]
+		if info == nil {
+			info = &packageInfo{path: modpath}
+			if dir != "." {
+				info.path += "/" + dir
+			}
+			pkgs[dir] = info
+		}
+
+		if strings.HasSuffix(p, "_test.go") {
+			info.tested = true
+			return nil
+		}
+
+		info.hasSource = true
+		file, err := parser.ParseFile(fset, p, nil, parser.PackageClauseOnly|parser.ParseComments)
+		if err != nil {
+			return nil // let the compiler report this
+		}
+		if file.Name.Name == "main" {
+			info.isMain = true
+		}
+		if file.Doc != nil {
+			info.documented = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []packageInfo
+	for _, info := range pkgs {
+		if info.hasSource && !info.isMain {
+			result = append(result, *info)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].path < result[j].path
+	})
+	return result, nil
+}
+
+// released is when v was released:
+// its tag time if it is annotated,
+// or else its commit time.
+func (v VersionInfo) released() time.Time {
+	if v.Annotated {
+		return v.TagTime
+	}
+	return v.CommitTime
+}
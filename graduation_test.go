@@ -0,0 +1,101 @@
+package taggo_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/internal/testutil"
+)
+
+func TestGraduation(t *testing.T) {
+	repodir := cloneBundle(t, "unstable") // first release v0.1.2 is from 2024
+
+	write := func(name, src string) {
+		t.Helper()
+		filename := filepath.Join(repodir, name)
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("x_test.go", "package x\n")
+	write("cmd/x/main.go", "package main\n\nfunc main() {}\n") // not importable
+	write("internal/y/y.go", "package y\n\nfunc Y() {}\n")     // not importable
+	for i, name := range []string{"F", "G", "H"} {
+		// Each release adds a function.
+		src := "// Package x does things.\npackage x\n"
+		for _, n := range []string{"F", "G", "H"}[:i+1] {
+			src += "\nfunc " + n + "() {}\n"
+		}
+		write("x.go", src)
+		testutil.Git(t, repodir, "add", ".")
+		testutil.Git(t, repodir, "commit", "-q", "-m", "add "+name)
+		testutil.Git(t, repodir, "tag", "v0."+string(rune('2'+i))+".0")
+	}
+
+	check := func(config taggo.Config) *taggo.Graduation {
+		t.Helper()
+		result, err := taggo.Check(context.Background(), "", repodir, "", taggo.WithGraduation(), taggo.WithConfig(config))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Graduation == nil {
+			t.Fatal("got no graduation evaluation")
+		}
+		return result.Graduation
+	}
+
+	g := check(taggo.Config{})
+	if !g.Ready {
+		t.Errorf("got not ready for v1.0.0: %s", strings.Join(g.Reasons(), "; "))
+	}
+	reasons := strings.Join(g.Reasons(), "; ")
+	for _, want := range []string{"first v0 release v0.1.2 was", "no breaking changes in the last 3 releases", "all 1 package(s) have doc comments", "all 1 package(s) have tests"} {
+		if !strings.Contains(reasons, want) {
+			t.Errorf("got reasons %q, want them to include %q", reasons, want)
+		}
+	}
+
+	// Too few releases to judge, and too young.
+	g = check(taggo.Config{Graduation: taggo.GraduationConfig{Days: 100000, Releases: 4}})
+	if g.Ready {
+		t.Fatal("got ready for v1.0.0, want not ready")
+	}
+	var kinds []string
+	for _, c := range g.Criteria {
+		if !c.Met {
+			kinds = append(kinds, string(c.Kind))
+		}
+	}
+	if got := strings.Join(kinds, " "); got != "age api-stability" {
+		t.Errorf("got unmet criteria %q, want age and api-stability", got)
+	}
+
+	// An undocumented, untested package.
+	write("z/z.go", "package z\n")
+	g = check(taggo.Config{})
+	if g.Ready {
+		t.Fatal("got ready for v1.0.0 with undocumented package, want not ready")
+	}
+	want := "1 of 2 package(s) lack doc comments: x/z; 1 of 2 package(s) lack tests: x/z"
+	if got := strings.Join(g.Reasons(), "; "); got != want {
+		t.Errorf("got reasons %q, want %q", got, want)
+	}
+
+	// No evaluation for a stable module.
+	testutil.Git(t, repodir, "tag", "v1.0.0")
+	result, err := taggo.Check(context.Background(), "", repodir, "", taggo.WithGraduation())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Graduation != nil {
+		t.Errorf("got graduation evaluation %+v for v1 module, want none", result.Graduation)
+	}
+}
@@ -0,0 +1,83 @@
+// Command taggo-serve runs a gRPC server
+// for checking the Go modules in the repositories in and below a root directory.
+// See package github.com/bobg/taggo/grpc/taggopb for the service definition.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/bobg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/grpc/grpcserver"
+	"github.com/bobg/taggo/grpc/taggopb"
+)
+
+func main() {
+	if err := run(context.Background(), os.Args[1:]); err != nil && !errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string) error {
+	var (
+		addr    string
+		git     string
+		offline bool
+		timeout time.Duration
+		fs      = flag.NewFlagSet("taggo-serve", flag.ContinueOnError)
+	)
+	fs.StringVar(&addr, "addr", "localhost:7117", "address to listen on")
+	fs.StringVar(&git, "git", "", "path to git binary")
+	fs.BoolVar(&offline, "offline", false, "never access the network")
+	fs.DurationVar(&timeout, "timeout", 0, "limit each git operation to this duration")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	root := "."
+	switch fs.NArg() {
+	case 0:
+	case 1:
+		root = fs.Arg(0)
+	default:
+		return fmt.Errorf("usage: %s [-addr ADDR] [-git GIT] [-offline] [-timeout DURATION] [ROOTDIR]", os.Args[0])
+	}
+
+	var opts []taggo.Option
+	if offline {
+		opts = append(opts, taggo.WithOffline())
+	}
+	if timeout > 0 {
+		opts = append(opts, taggo.WithGitTimeout(timeout))
+	}
+
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return errors.Wrapf(err, "listening on %s", addr)
+	}
+
+	srv := grpc.NewServer()
+	taggopb.RegisterTaggoServer(srv, grpcserver.New(root, git, opts...))
+	reflection.Register(srv)
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		srv.GracefulStop()
+	}()
+
+	fmt.Fprintf(os.Stderr, "Serving repositories in %s on %s\n", root, lis.Addr())
+	err = srv.Serve(lis)
+	return errors.Wrap(err, "serving")
+}
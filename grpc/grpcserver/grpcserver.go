@@ -0,0 +1,155 @@
+// Package grpcserver implements the Taggo gRPC service
+// defined in package github.com/bobg/taggo/grpc/taggopb,
+// for checking the repositories in and below a root directory.
+package grpcserver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/bobg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/grpc/taggopb"
+)
+
+// Server implements [taggopb.TaggoServer].
+// Create one with [New].
+type Server struct {
+	taggopb.UnimplementedTaggoServer
+
+	root, git string
+	opts      []taggo.Option
+}
+
+var _ taggopb.TaggoServer = (*Server)(nil)
+
+// New creates a [Server] for the repositories in and below root.
+// Requests name repositories by their paths relative to root,
+// and may not reach outside it.
+//
+// The git argument is the path to the git executable.
+// If it is empty, the server looks for "git" in PATH.
+//
+// The given options apply to every check,
+// after the settings in the repository's configuration file
+// and before the options in the request.
+func New(root, git string, opts ...taggo.Option) *Server {
+	return &Server{root: root, git: git, opts: opts}
+}
+
+// Check implements [taggopb.TaggoServer.Check].
+func (s *Server) Check(ctx context.Context, req *taggopb.CheckRequest) (*taggopb.CheckResponse, error) {
+	repodir, opts, err := s.setup(req.Repository, req.Options)
+	if err != nil {
+		return nil, err
+	}
+	moduledir, err := localPath(repodir, "module", req.Module)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := taggo.Check(ctx, s.git, repodir, moduledir, opts...)
+	if err != nil {
+		return nil, statusErr(errors.Wrapf(err, "checking module %s in repository %s", req.Module, req.Repository))
+	}
+	return &taggopb.CheckResponse{Result: taggopb.FromResult(r)}, nil
+}
+
+// CheckAll implements [taggopb.TaggoServer.CheckAll].
+func (s *Server) CheckAll(req *taggopb.CheckAllRequest, stream taggopb.Taggo_CheckAllServer) error {
+	repodir, opts, err := s.setup(req.Repository, req.Options)
+	if err != nil {
+		return err
+	}
+	if req.Subtree != "" {
+		if _, err := localPath(repodir, "subtree", req.Subtree); err != nil {
+			return err
+		}
+		opts = append(opts, taggo.WithSubtree(req.Subtree))
+	}
+
+	err = taggo.CheckAllFunc(stream.Context(), s.git, repodir, func(moduledir string, r taggo.Result, err error) error {
+		if err != nil {
+			return errors.Wrapf(err, "checking module %s", moduledir)
+		}
+		rel, err := filepath.Rel(repodir, moduledir)
+		if err != nil {
+			return errors.Wrapf(err, "getting relative path of %s", moduledir)
+		}
+		return stream.Send(&taggopb.CheckAllResponse{ModuleDir: filepath.ToSlash(rel), Result: taggopb.FromResult(r)})
+	}, opts...)
+	if err != nil {
+		return statusErr(errors.Wrapf(err, "checking modules in repository %s", req.Repository))
+	}
+	return nil
+}
+
+// setup resolves the repository directory of a request
+// and gathers the options for checking it.
+func (s *Server) setup(repository string, reqOpts *taggopb.CheckOptions) (string, []taggo.Option, error) {
+	repodir, err := localPath(s.root, "repository", repository)
+	if err != nil {
+		return "", nil, err
+	}
+	if _, err := os.Stat(repodir); errors.Is(err, os.ErrNotExist) {
+		return "", nil, status.Errorf(codes.NotFound, "no repository %s", repository)
+	}
+
+	config, err := taggo.LoadConfig(repodir)
+	if err != nil {
+		return "", nil, status.Errorf(codes.FailedPrecondition, "loading config: %s", err)
+	}
+
+	opts := append([]taggo.Option{taggo.WithConfig(config)}, s.opts...)
+	if reqOpts != nil {
+		if reqOpts.Branch != "" {
+			opts = append(opts, taggo.WithBranch(reqOpts.Branch))
+		}
+		if reqOpts.Target != "" {
+			opts = append(opts, taggo.WithTarget(reqOpts.Target))
+		}
+		if reqOpts.Base != "" {
+			opts = append(opts, taggo.WithBase(reqOpts.Base))
+		}
+		if reqOpts.VersionHistory {
+			opts = append(opts, taggo.WithVersionHistory())
+		}
+		if reqOpts.ModverReport {
+			opts = append(opts, taggo.WithModverReport())
+		}
+	}
+
+	return repodir, opts, nil
+}
+
+// localPath joins dir and the slash-separated relative path rel,
+// which names the given kind of thing in a request,
+// after checking that it stays within dir.
+// An empty rel means dir itself.
+func localPath(dir, kind, rel string) (string, error) {
+	if rel == "" {
+		return dir, nil
+	}
+	rel = filepath.FromSlash(rel)
+	if !filepath.IsLocal(rel) {
+		// Don't reveal dir, which may be the server's root.
+		return "", status.Errorf(codes.InvalidArgument, "%s %s is not a local relative path", kind, rel)
+	}
+	return filepath.Join(dir, rel), nil
+}
+
+// statusErr converts an error from Taggo to a gRPC status error.
+func statusErr(err error) error {
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	case errors.Is(err, taggo.ErrGitTimeout):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
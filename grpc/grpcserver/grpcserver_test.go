@@ -0,0 +1,131 @@
+package grpcserver_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/grpc/grpcserver"
+	"github.com/bobg/taggo/grpc/taggopb"
+	"github.com/bobg/taggo/internal/testutil"
+)
+
+func TestServer(t *testing.T) {
+	root := t.TempDir()
+	repodir := filepath.Join(root, "repo")
+	if err := os.Mkdir(repodir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	commit := func(filename, contents string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repodir, filename), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+		testutil.Git(t, repodir, "add", filename)
+		testutil.Git(t, repodir, "commit", "-q", "-m", "update "+filename)
+	}
+
+	testutil.Git(t, repodir, "init", "-q", "-b", "main")
+	commit("go.mod", "module example.com/x\n\ngo 1.22\n")
+	commit("x.go", "package x\n\nfunc X() {}\n")
+	testutil.Git(t, repodir, "tag", "v1.0.0")
+	commit("x.go", "package x\n\n// X does nothing.\nfunc X() {}\n")
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	taggopb.RegisterTaggoServer(srv, grpcserver.New(root, ""))
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := taggopb.NewTaggoClient(conn)
+
+	ctx := context.Background()
+
+	want, err := taggo.Check(ctx, "", repodir, repodir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantProto := taggopb.FromResult(want)
+
+	t.Run("check", func(t *testing.T) {
+		resp, err := client.Check(ctx, &taggopb.CheckRequest{Repository: "repo"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(resp.Result, wantProto) {
+			t.Errorf("got %v, want %v", resp.Result, wantProto)
+		}
+		if resp.Result.LatestVersion != "v1.0.0" {
+			t.Errorf("got latest version %s, want v1.0.0", resp.Result.LatestVersion)
+		}
+		if len(resp.Result.Findings) == 0 {
+			t.Error("got no findings")
+		}
+	})
+
+	t.Run("check_all", func(t *testing.T) {
+		stream, err := client.CheckAll(ctx, &taggopb.CheckAllRequest{Repository: "repo"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []*taggopb.CheckAllResponse
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, resp)
+		}
+		if len(got) != 1 {
+			t.Fatalf("got %d results, want 1", len(got))
+		}
+		if got[0].ModuleDir != "." {
+			t.Errorf("got module dir %s, want .", got[0].ModuleDir)
+		}
+		if !proto.Equal(got[0].Result, wantProto) {
+			t.Errorf("got %v, want %v", got[0].Result, wantProto)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		cases := []struct {
+			req  *taggopb.CheckRequest
+			want codes.Code
+		}{
+			{req: &taggopb.CheckRequest{Repository: "../repo"}, want: codes.InvalidArgument},
+			{req: &taggopb.CheckRequest{Repository: repodir}, want: codes.InvalidArgument},
+			{req: &taggopb.CheckRequest{Repository: "repo", Module: "../.."}, want: codes.InvalidArgument},
+			{req: &taggopb.CheckRequest{Repository: "nonesuch"}, want: codes.NotFound},
+		}
+		for _, tc := range cases {
+			_, err := client.Check(ctx, tc.req)
+			if got := status.Code(err); got != tc.want {
+				t.Errorf("for %v, got code %s (%v), want %s", tc.req, got, err, tc.want)
+			}
+		}
+	})
+}
@@ -0,0 +1,252 @@
+package taggopb
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/bobg/taggo"
+)
+
+// FromResult converts a [taggo.Result] to its protocol buffer form.
+func FromResult(r taggo.Result) *Result {
+	result := &Result{
+		SchemaVersion:                   int32(r.SchemaVersion),
+		DefaultBranch:                   r.DefaultBranch,
+		Ahead:                           int32(r.Ahead),
+		Behind:                          int32(r.Behind),
+		Branch:                          r.Branch,
+		Target:                          r.Target,
+		Base:                            r.Base,
+		LatestVersion:                   r.LatestVersion,
+		LatestCommit:                    r.LatestCommit,
+		LatestCommitHasLatestVersion:    r.LatestCommitHasLatestVersion,
+		LatestCommitHasVersionTag:       r.LatestCommitHasVersionTag,
+		LatestMajor:                     int32(r.LatestMajor),
+		LatestMinor:                     int32(r.LatestMinor),
+		LatestPatch:                     int32(r.LatestPatch),
+		LatestVersionTime:               timestamp(r.LatestVersionTime),
+		LatestVersionIsPrerelease:       r.LatestVersionIsPrerelease,
+		LatestVersionUnstable:           r.LatestVersionUnstable,
+		ExcludeDirectives:               r.ExcludeDirectives,
+		GoDirective:                     r.GoDirective,
+		ToolchainDirective:              r.ToolchainDirective,
+		LatestVersionGoDirective:        r.LatestVersionGoDirective,
+		LatestVersionToolchainDirective: r.LatestVersionToolchainDirective,
+		GoDirectiveBump:                 r.GoDirectiveBump,
+		KnownDependents:                 int32(r.KnownDependents),
+		LightweightVersionTags:          r.LightweightVersionTags,
+		LocalReplaceDirectives:          r.LocalReplaceDirectives,
+		Modpath:                         r.Modpath,
+		ModpathMismatch:                 r.ModpathMismatch,
+		ModpathRemoteMismatch:           r.ModpathRemoteMismatch,
+		ModuleSubdir:                    r.ModuleSubdir,
+		ModverResultCode:                ModverResultCode(r.ModverResultCode),
+		PolicyBump:                      ModverResultCode(r.PolicyBump),
+		ModverResultString:              r.ModverResultString,
+		ModverReport:                    r.ModverReport,
+		BuildContext:                    r.BuildContext,
+		NewMajor:                        int32(r.NewMajor),
+		NewMinor:                        int32(r.NewMinor),
+		NewPatch:                        int32(r.NewPatch),
+		NewPrerelease:                   r.NewPrerelease,
+		NewBuildMetadata:                r.NewBuildMetadata,
+		FinalizesPrerelease:             r.FinalizesPrerelease,
+		NewVersionExists:                r.NewVersionExists,
+		NoRemote:                        r.NoRemote,
+		NoReleaseMerge:                  r.NoReleaseMerge,
+		NoNetChanges:                    r.NoNetChanges,
+		OldestUnreleasedCommitTime:      timestamp(r.OldestUnreleasedCommitTime),
+		OpenVulnerabilities:             r.OpenVulnerabilities,
+		OtherModuleVersionTags:          r.OtherModuleVersionTags,
+		ReleaseMergeCommit:              r.ReleaseMergeCommit,
+		Remote:                          r.Remote,
+		RemoteUrl:                       r.RemoteURL,
+		ForgeKind:                       string(r.ForgeKind),
+		RemoteOnlyVersionTags:           r.RemoteOnlyVersionTags,
+		ReplaceDirectives:               r.ReplaceDirectives,
+		Series:                          r.Series,
+		Suppress:                        r.Suppress,
+		SkippedChecks:                   r.SkippedChecks,
+		StaleRelease:                    r.StaleRelease,
+		TidyDiff:                        r.TidyDiff,
+		UnbuildableVersions:             r.UnbuildableVersions,
+		UnreleasedCommits:               int32(r.UnreleasedCommits),
+		VersionPrefix:                   r.VersionPrefix,
+		VersionSuffix:                   versionSuffixStatuses[r.VersionSuffix],
+		TotalVersionTags:                int32(r.TotalVersionTags),
+		OldestVersion:                   r.OldestVersion,
+		OldestVersionDate:               timestamp(r.OldestVersionDate),
+		NewestVersion:                   r.NewestVersion,
+		NewestVersionDate:               timestamp(r.NewestVersionDate),
+		LatestCommitAuthor:              r.LatestCommitAuthor,
+		LatestCommitTime:                timestamp(r.LatestCommitTime),
+		LatestCommitSubject:             r.LatestCommitSubject,
+		NewVersion:                      r.NewVersion(),
+	}
+
+	if len(r.TagsPerMajor) > 0 {
+		result.TagsPerMajor = make(map[string]int32, len(r.TagsPerMajor))
+		for major, n := range r.TagsPerMajor {
+			result.TagsPerMajor[major] = int32(n)
+		}
+	}
+	for _, c := range r.APIChanges {
+		result.ApiChanges = append(result.ApiChanges, &APIChange{
+			Kind:    c.Kind,
+			Package: c.Package,
+			Symbol:  c.Symbol,
+			Reason:  c.Reason,
+		})
+	}
+	if r.PhaseError != nil {
+		result.PhaseError = &PhaseError{
+			Phase:   string(r.PhaseError.Phase),
+			Message: r.PhaseError.Message,
+		}
+	}
+	for _, v := range r.ReachableVulnerabilities {
+		result.ReachableVulnerabilities = append(result.ReachableVulnerabilities, &Vulnerability{
+			Id:           v.ID,
+			Aliases:      v.Aliases,
+			Summary:      v.Summary,
+			Severity:     string(v.Severity),
+			Module:       v.Module,
+			Version:      v.Version,
+			FixedVersion: v.FixedVersion,
+			Symbols:      v.Symbols,
+		})
+	}
+	for _, m := range r.MalformedVersionTags {
+		result.MalformedVersionTags = append(result.MalformedVersionTags, &MalformedVersionTag{
+			Tag:     m.Tag,
+			Version: m.Version,
+			Commit:  m.Commit,
+		})
+	}
+	if n := r.NearestTag; n != nil {
+		result.NearestTag = &NearestTag{
+			Tag:      n.Tag,
+			Distance: int32(n.Distance),
+			Hash:     n.Hash,
+		}
+	}
+	if a := r.Action; a != nil {
+		result.Action = &ActionInfo{
+			File:             a.File,
+			Name:             a.Name,
+			MajorTag:         a.MajorTag,
+			MajorTagMissing:  a.MajorTagMissing,
+			ReleaseOffBranch: a.ReleaseOffBranch,
+			MetadataChanged:  a.MetadataChanged,
+		}
+	}
+	for _, f := range r.StaleFloatingTags {
+		result.StaleFloatingTags = append(result.StaleFloatingTags, &FloatingTag{
+			Tag:          f.Tag,
+			Commit:       f.Commit,
+			Latest:       f.Latest,
+			LatestCommit: f.LatestCommit,
+		})
+	}
+	if r.Graduation != nil {
+		result.Graduation = &Graduation{Ready: r.Graduation.Ready}
+		for _, c := range r.Graduation.Criteria {
+			result.Graduation.Criteria = append(result.Graduation.Criteria, &GraduationCriterion{
+				Kind:   string(c.Kind),
+				Met:    c.Met,
+				Reason: c.Reason,
+			})
+		}
+	}
+	for _, c := range r.Readiness {
+		result.Readiness = append(result.Readiness, &ReadinessCheck{
+			Name:   c.Name,
+			Ok:     c.OK,
+			Detail: c.Detail,
+		})
+	}
+	for _, rem := range r.Remediations {
+		result.Remediations = append(result.Remediations, &Remediation{
+			Id:      rem.ID,
+			Command: rem.Command,
+		})
+	}
+	for _, v := range r.RuleViolations {
+		result.RuleViolations = append(result.RuleViolations, &RuleViolation{
+			Rule:    v.Rule,
+			Message: v.Message,
+			Error:   v.Error,
+		})
+	}
+	for _, v := range r.Versions {
+		result.Versions = append(result.Versions, &VersionInfo{
+			Version:         v.Version,
+			Commit:          v.Commit,
+			CommitTime:      timestamp(v.CommitTime),
+			Annotated:       v.Annotated,
+			Tagger:          v.Tagger,
+			TagTime:         timestamp(v.TagTime),
+			Signed:          v.Signed,
+			OnDefaultBranch: v.OnDefaultBranch,
+		})
+	}
+	for _, c := range r.UnreleasedCommitSummaries {
+		result.UnreleasedCommitSummaries = append(result.UnreleasedCommitSummaries, &CommitSummary{
+			Hash:    c.Hash,
+			Author:  c.Author,
+			Time:    timestamp(c.Time),
+			Subject: c.Subject,
+		})
+	}
+	for _, f := range r.SuppressedFindings {
+		result.SuppressedFindings = append(result.SuppressedFindings, finding(f))
+	}
+	for _, f := range r.Findings() {
+		result.Findings = append(result.Findings, finding(f))
+	}
+
+	return result
+}
+
+// finding converts f to a Finding message.
+func finding(f taggo.Finding) *Finding {
+	return &Finding{
+		Id:          f.ID,
+		Kind:        findingKinds[f.Kind],
+		Message:     f.Message,
+		Severity:    severities[f.Severity],
+		Suppressed:  f.Suppressed,
+		Remediation: f.Remediation,
+	}
+}
+
+var (
+	versionSuffixStatuses = map[taggo.VersionSuffixStatus]VersionSuffixStatus{
+		taggo.VSOK:       VersionSuffixStatus_VERSION_SUFFIX_STATUS_OK,
+		taggo.VSMismatch: VersionSuffixStatus_VERSION_SUFFIX_STATUS_MISMATCH,
+		taggo.VSMissing:  VersionSuffixStatus_VERSION_SUFFIX_STATUS_MISSING,
+		taggo.VSUnwanted: VersionSuffixStatus_VERSION_SUFFIX_STATUS_UNWANTED,
+	}
+
+	findingKinds = map[taggo.FindingKind]FindingKind{
+		taggo.FindingInfo:    FindingKind_FINDING_KIND_INFO,
+		taggo.FindingOK:      FindingKind_FINDING_KIND_OK,
+		taggo.FindingWarning: FindingKind_FINDING_KIND_WARNING,
+	}
+
+	severities = map[taggo.Severity]Severity{
+		taggo.SeverityInfo:    Severity_SEVERITY_INFO,
+		taggo.SeverityWarning: Severity_SEVERITY_WARNING,
+		taggo.SeverityError:   Severity_SEVERITY_ERROR,
+	}
+)
+
+// timestamp converts t to a Timestamp,
+// or to nil if t is the zero time.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
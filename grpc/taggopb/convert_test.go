@@ -0,0 +1,46 @@
+package taggopb_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/grpc/taggopb"
+)
+
+// TestResultFields checks that every field of taggo.Result has a counterpart in the Result message.
+func TestResultFields(t *testing.T) {
+	fields := (&taggopb.Result{}).ProtoReflect().Descriptor().Fields()
+
+	typ := reflect.TypeOf(taggo.Result{})
+	for i := 0; i < typ.NumField(); i++ {
+		name := snakeCase(typ.Field(i).Name)
+		if fields.ByName(protoreflect.Name(name)) == nil {
+			t.Errorf("no field %s in the Result message for taggo.Result.%s", name, typ.Field(i).Name)
+		}
+	}
+}
+
+// snakeCase converts a Go field name such as RemoteURL or APIChanges
+// to a protobuf field name such as remote_url or api_changes.
+func snakeCase(s string) string {
+	var (
+		buf   strings.Builder
+		runes = []rune(s)
+	)
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prevLower := unicode.IsLower(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				buf.WriteByte('_')
+			}
+		}
+		buf.WriteRune(unicode.ToLower(r))
+	}
+	return buf.String()
+}
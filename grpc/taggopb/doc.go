@@ -0,0 +1,10 @@
+// Package taggopb holds protocol buffer types for Taggo's results
+// and the gRPC service that produces them,
+// for consumers of Taggo that are not written in Go.
+// The definitions are in taggo.proto.
+// See package github.com/bobg/taggo/grpc/grpcserver for an implementation of the service.
+package taggopb
+
+// The generated code depends on the versions of protoc and its plugins,
+// which generate.sh pins.
+//go:generate ./generate.sh
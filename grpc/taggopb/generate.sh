@@ -0,0 +1,29 @@
+#!/bin/sh
+
+# Regenerate taggo.pb.go and taggo_grpc.pb.go from taggo.proto
+# with pinned versions of protoc and its Go plugins,
+# so that the generated code is reproducible.
+# Run this with "go generate" in this directory.
+
+set -e
+
+PROTOC_VERSION=29.3
+
+if [ "$(protoc --version 2>/dev/null)" != "libprotoc $PROTOC_VERSION" ]; then
+  echo "protoc $PROTOC_VERSION is required; see https://github.com/protocolbuffers/protobuf/releases/tag/v$PROTOC_VERSION" >&2
+  exit 1
+fi
+
+bin=$(mktemp -d)
+trap 'rm -rf "$bin"' EXIT
+
+# The plugins are built at the versions required in ../go.mod
+# (see ../tools.go).
+go build -o "$bin" google.golang.org/protobuf/cmd/protoc-gen-go google.golang.org/grpc/cmd/protoc-gen-go-grpc
+
+protoc \
+  --plugin=protoc-gen-go="$bin/protoc-gen-go" \
+  --plugin=protoc-gen-go-grpc="$bin/protoc-gen-go-grpc" \
+  --go_out=. --go_opt=paths=source_relative \
+  --go-grpc_out=. --go-grpc_opt=paths=source_relative \
+  taggo.proto
@@ -0,0 +1,2955 @@
+// Protocol buffer definitions for Taggo's results,
+// and a gRPC service producing them.
+// These mirror the Go types in github.com/bobg/taggo,
+// whose documentation has the details.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.29.3
+// source: taggo.proto
+
+package taggopb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ModverResultCode is the size of the version bump that Modver found necessary.
+type ModverResultCode int32
+
+const (
+	ModverResultCode_MODVER_RESULT_CODE_NONE       ModverResultCode = 0
+	ModverResultCode_MODVER_RESULT_CODE_PATCHLEVEL ModverResultCode = 1
+	ModverResultCode_MODVER_RESULT_CODE_MINOR      ModverResultCode = 2
+	ModverResultCode_MODVER_RESULT_CODE_MAJOR      ModverResultCode = 3
+)
+
+// Enum value maps for ModverResultCode.
+var (
+	ModverResultCode_name = map[int32]string{
+		0: "MODVER_RESULT_CODE_NONE",
+		1: "MODVER_RESULT_CODE_PATCHLEVEL",
+		2: "MODVER_RESULT_CODE_MINOR",
+		3: "MODVER_RESULT_CODE_MAJOR",
+	}
+	ModverResultCode_value = map[string]int32{
+		"MODVER_RESULT_CODE_NONE":       0,
+		"MODVER_RESULT_CODE_PATCHLEVEL": 1,
+		"MODVER_RESULT_CODE_MINOR":      2,
+		"MODVER_RESULT_CODE_MAJOR":      3,
+	}
+)
+
+func (x ModverResultCode) Enum() *ModverResultCode {
+	p := new(ModverResultCode)
+	*p = x
+	return p
+}
+
+func (x ModverResultCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ModverResultCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_taggo_proto_enumTypes[0].Descriptor()
+}
+
+func (ModverResultCode) Type() protoreflect.EnumType {
+	return &file_taggo_proto_enumTypes[0]
+}
+
+func (x ModverResultCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ModverResultCode.Descriptor instead.
+func (ModverResultCode) EnumDescriptor() ([]byte, []int) {
+	return file_taggo_proto_rawDescGZIP(), []int{0}
+}
+
+type VersionSuffixStatus int32
+
+const (
+	VersionSuffixStatus_VERSION_SUFFIX_STATUS_UNSPECIFIED VersionSuffixStatus = 0
+	VersionSuffixStatus_VERSION_SUFFIX_STATUS_OK          VersionSuffixStatus = 1
+	VersionSuffixStatus_VERSION_SUFFIX_STATUS_MISMATCH    VersionSuffixStatus = 2
+	VersionSuffixStatus_VERSION_SUFFIX_STATUS_MISSING     VersionSuffixStatus = 3
+	VersionSuffixStatus_VERSION_SUFFIX_STATUS_UNWANTED    VersionSuffixStatus = 4
+)
+
+// Enum value maps for VersionSuffixStatus.
+var (
+	VersionSuffixStatus_name = map[int32]string{
+		0: "VERSION_SUFFIX_STATUS_UNSPECIFIED",
+		1: "VERSION_SUFFIX_STATUS_OK",
+		2: "VERSION_SUFFIX_STATUS_MISMATCH",
+		3: "VERSION_SUFFIX_STATUS_MISSING",
+		4: "VERSION_SUFFIX_STATUS_UNWANTED",
+	}
+	VersionSuffixStatus_value = map[string]int32{
+		"VERSION_SUFFIX_STATUS_UNSPECIFIED": 0,
+		"VERSION_SUFFIX_STATUS_OK":          1,
+		"VERSION_SUFFIX_STATUS_MISMATCH":    2,
+		"VERSION_SUFFIX_STATUS_MISSING":     3,
+		"VERSION_SUFFIX_STATUS_UNWANTED":    4,
+	}
+)
+
+func (x VersionSuffixStatus) Enum() *VersionSuffixStatus {
+	p := new(VersionSuffixStatus)
+	*p = x
+	return p
+}
+
+func (x VersionSuffixStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VersionSuffixStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_taggo_proto_enumTypes[1].Descriptor()
+}
+
+func (VersionSuffixStatus) Type() protoreflect.EnumType {
+	return &file_taggo_proto_enumTypes[1]
+}
+
+func (x VersionSuffixStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VersionSuffixStatus.Descriptor instead.
+func (VersionSuffixStatus) EnumDescriptor() ([]byte, []int) {
+	return file_taggo_proto_rawDescGZIP(), []int{1}
+}
+
+type FindingKind int32
+
+const (
+	FindingKind_FINDING_KIND_UNSPECIFIED FindingKind = 0
+	FindingKind_FINDING_KIND_INFO        FindingKind = 1
+	FindingKind_FINDING_KIND_OK          FindingKind = 2
+	FindingKind_FINDING_KIND_WARNING     FindingKind = 3
+)
+
+// Enum value maps for FindingKind.
+var (
+	FindingKind_name = map[int32]string{
+		0: "FINDING_KIND_UNSPECIFIED",
+		1: "FINDING_KIND_INFO",
+		2: "FINDING_KIND_OK",
+		3: "FINDING_KIND_WARNING",
+	}
+	FindingKind_value = map[string]int32{
+		"FINDING_KIND_UNSPECIFIED": 0,
+		"FINDING_KIND_INFO":        1,
+		"FINDING_KIND_OK":          2,
+		"FINDING_KIND_WARNING":     3,
+	}
+)
+
+func (x FindingKind) Enum() *FindingKind {
+	p := new(FindingKind)
+	*p = x
+	return p
+}
+
+func (x FindingKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FindingKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_taggo_proto_enumTypes[2].Descriptor()
+}
+
+func (FindingKind) Type() protoreflect.EnumType {
+	return &file_taggo_proto_enumTypes[2]
+}
+
+func (x FindingKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FindingKind.Descriptor instead.
+func (FindingKind) EnumDescriptor() ([]byte, []int) {
+	return file_taggo_proto_rawDescGZIP(), []int{2}
+}
+
+type Severity int32
+
+const (
+	Severity_SEVERITY_UNSPECIFIED Severity = 0
+	Severity_SEVERITY_INFO        Severity = 1
+	Severity_SEVERITY_WARNING     Severity = 2
+	Severity_SEVERITY_ERROR       Severity = 3
+)
+
+// Enum value maps for Severity.
+var (
+	Severity_name = map[int32]string{
+		0: "SEVERITY_UNSPECIFIED",
+		1: "SEVERITY_INFO",
+		2: "SEVERITY_WARNING",
+		3: "SEVERITY_ERROR",
+	}
+	Severity_value = map[string]int32{
+		"SEVERITY_UNSPECIFIED": 0,
+		"SEVERITY_INFO":        1,
+		"SEVERITY_WARNING":     2,
+		"SEVERITY_ERROR":       3,
+	}
+)
+
+func (x Severity) Enum() *Severity {
+	p := new(Severity)
+	*p = x
+	return p
+}
+
+func (x Severity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Severity) Descriptor() protoreflect.EnumDescriptor {
+	return file_taggo_proto_enumTypes[3].Descriptor()
+}
+
+func (Severity) Type() protoreflect.EnumType {
+	return &file_taggo_proto_enumTypes[3]
+}
+
+func (x Severity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Severity.Descriptor instead.
+func (Severity) EnumDescriptor() ([]byte, []int) {
+	return file_taggo_proto_rawDescGZIP(), []int{3}
+}
+
+// CheckOptions are settings for a check.
+// The repository's .taggo.yaml file also applies.
+type CheckOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Branch, if set, is the branch to analyze instead of the default branch.
+	Branch string `protobuf:"bytes,1,opt,name=branch,proto3" json:"branch,omitempty"`
+	// Target, if set, is the ref of the commit to analyze
+	// instead of the tip of the default branch.
+	Target string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// Base, if set, is a ref to compare the module with
+	// instead of its latest version tag.
+	Base string `protobuf:"bytes,3,opt,name=base,proto3" json:"base,omitempty"`
+	// VersionHistory requests Result.versions.
+	VersionHistory bool `protobuf:"varint,4,opt,name=version_history,json=versionHistory,proto3" json:"version_history,omitempty"`
+	// ModverReport requests Result.modver_report.
+	ModverReport bool `protobuf:"varint,5,opt,name=modver_report,json=modverReport,proto3" json:"modver_report,omitempty"`
+}
+
+func (x *CheckOptions) Reset() {
+	*x = CheckOptions{}
+	mi := &file_taggo_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckOptions) ProtoMessage() {}
+
+func (x *CheckOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_taggo_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckOptions.ProtoReflect.Descriptor instead.
+func (*CheckOptions) Descriptor() ([]byte, []int) {
+	return file_taggo_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CheckOptions) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *CheckOptions) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *CheckOptions) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+func (x *CheckOptions) GetVersionHistory() bool {
+	if x != nil {
+		return x.VersionHistory
+	}
+	return false
+}
+
+func (x *CheckOptions) GetModverReport() bool {
+	if x != nil {
+		return x.ModverReport
+	}
+	return false
+}
+
+type CheckRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Repository is the repository's directory,
+	// relative to the server's root directory.
+	// Empty means the root directory itself.
+	Repository string `protobuf:"bytes,1,opt,name=repository,proto3" json:"repository,omitempty"`
+	// Module is the module's directory,
+	// relative to the repository's.
+	// Empty means the repository's root.
+	Module  string        `protobuf:"bytes,2,opt,name=module,proto3" json:"module,omitempty"`
+	Options *CheckOptions `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (x *CheckRequest) Reset() {
+	*x = CheckRequest{}
+	mi := &file_taggo_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckRequest) ProtoMessage() {}
+
+func (x *CheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taggo_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckRequest.ProtoReflect.Descriptor instead.
+func (*CheckRequest) Descriptor() ([]byte, []int) {
+	return file_taggo_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CheckRequest) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *CheckRequest) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *CheckRequest) GetOptions() *CheckOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type CheckResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *Result `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *CheckResponse) Reset() {
+	*x = CheckResponse{}
+	mi := &file_taggo_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckResponse) ProtoMessage() {}
+
+func (x *CheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_taggo_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckResponse.ProtoReflect.Descriptor instead.
+func (*CheckResponse) Descriptor() ([]byte, []int) {
+	return file_taggo_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CheckResponse) GetResult() *Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type CheckAllRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Repository is the repository's directory,
+	// relative to the server's root directory.
+	// Empty means the root directory itself.
+	Repository string `protobuf:"bytes,1,opt,name=repository,proto3" json:"repository,omitempty"`
+	// Subtree, if set, limits the check to the modules
+	// in this directory of the repository and below it.
+	Subtree string        `protobuf:"bytes,2,opt,name=subtree,proto3" json:"subtree,omitempty"`
+	Options *CheckOptions `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (x *CheckAllRequest) Reset() {
+	*x = CheckAllRequest{}
+	mi := &file_taggo_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAllRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAllRequest) ProtoMessage() {}
+
+func (x *CheckAllRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taggo_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAllRequest.ProtoReflect.Descriptor instead.
+func (*CheckAllRequest) Descriptor() ([]byte, []int) {
+	return file_taggo_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CheckAllRequest) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *CheckAllRequest) GetSubtree() string {
+	if x != nil {
+		return x.Subtree
+	}
+	return ""
+}
+
+func (x *CheckAllRequest) GetOptions() *CheckOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type CheckAllResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ModuleDir is the module's directory.
+	ModuleDir string  `protobuf:"bytes,1,opt,name=module_dir,json=moduleDir,proto3" json:"module_dir,omitempty"`
+	Result    *Result `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *CheckAllResponse) Reset() {
+	*x = CheckAllResponse{}
+	mi := &file_taggo_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAllResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAllResponse) ProtoMessage() {}
+
+func (x *CheckAllResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_taggo_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAllResponse.ProtoReflect.Descriptor instead.
+func (*CheckAllResponse) Descriptor() ([]byte, []int) {
+	return file_taggo_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CheckAllResponse) GetModuleDir() string {
+	if x != nil {
+		return x.ModuleDir
+	}
+	return ""
+}
+
+func (x *CheckAllResponse) GetResult() *Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+// Result holds the results of checking a module.
+// Unset timestamps correspond to zero times.
+type Result struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SchemaVersion                   int32                  `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	DefaultBranch                   string                 `protobuf:"bytes,2,opt,name=default_branch,json=defaultBranch,proto3" json:"default_branch,omitempty"`
+	Ahead                           int32                  `protobuf:"varint,3,opt,name=ahead,proto3" json:"ahead,omitempty"`
+	Behind                          int32                  `protobuf:"varint,4,opt,name=behind,proto3" json:"behind,omitempty"`
+	Branch                          string                 `protobuf:"bytes,5,opt,name=branch,proto3" json:"branch,omitempty"`
+	Target                          string                 `protobuf:"bytes,6,opt,name=target,proto3" json:"target,omitempty"`
+	Base                            string                 `protobuf:"bytes,7,opt,name=base,proto3" json:"base,omitempty"`
+	LatestVersion                   string                 `protobuf:"bytes,8,opt,name=latest_version,json=latestVersion,proto3" json:"latest_version,omitempty"`
+	LatestCommit                    string                 `protobuf:"bytes,9,opt,name=latest_commit,json=latestCommit,proto3" json:"latest_commit,omitempty"`
+	LatestCommitHasLatestVersion    bool                   `protobuf:"varint,10,opt,name=latest_commit_has_latest_version,json=latestCommitHasLatestVersion,proto3" json:"latest_commit_has_latest_version,omitempty"`
+	LatestCommitHasVersionTag       bool                   `protobuf:"varint,11,opt,name=latest_commit_has_version_tag,json=latestCommitHasVersionTag,proto3" json:"latest_commit_has_version_tag,omitempty"`
+	LatestMajor                     int32                  `protobuf:"varint,12,opt,name=latest_major,json=latestMajor,proto3" json:"latest_major,omitempty"`
+	LatestMinor                     int32                  `protobuf:"varint,13,opt,name=latest_minor,json=latestMinor,proto3" json:"latest_minor,omitempty"`
+	LatestPatch                     int32                  `protobuf:"varint,14,opt,name=latest_patch,json=latestPatch,proto3" json:"latest_patch,omitempty"`
+	LatestVersionTime               *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=latest_version_time,json=latestVersionTime,proto3" json:"latest_version_time,omitempty"`
+	LatestVersionIsPrerelease       bool                   `protobuf:"varint,16,opt,name=latest_version_is_prerelease,json=latestVersionIsPrerelease,proto3" json:"latest_version_is_prerelease,omitempty"`
+	LatestVersionUnstable           bool                   `protobuf:"varint,17,opt,name=latest_version_unstable,json=latestVersionUnstable,proto3" json:"latest_version_unstable,omitempty"`
+	ExcludeDirectives               []string               `protobuf:"bytes,18,rep,name=exclude_directives,json=excludeDirectives,proto3" json:"exclude_directives,omitempty"`
+	GoDirective                     string                 `protobuf:"bytes,19,opt,name=go_directive,json=goDirective,proto3" json:"go_directive,omitempty"`
+	ToolchainDirective              string                 `protobuf:"bytes,20,opt,name=toolchain_directive,json=toolchainDirective,proto3" json:"toolchain_directive,omitempty"`
+	LatestVersionGoDirective        string                 `protobuf:"bytes,21,opt,name=latest_version_go_directive,json=latestVersionGoDirective,proto3" json:"latest_version_go_directive,omitempty"`
+	LatestVersionToolchainDirective string                 `protobuf:"bytes,22,opt,name=latest_version_toolchain_directive,json=latestVersionToolchainDirective,proto3" json:"latest_version_toolchain_directive,omitempty"`
+	GoDirectiveBump                 bool                   `protobuf:"varint,23,opt,name=go_directive_bump,json=goDirectiveBump,proto3" json:"go_directive_bump,omitempty"`
+	KnownDependents                 int32                  `protobuf:"varint,24,opt,name=known_dependents,json=knownDependents,proto3" json:"known_dependents,omitempty"`
+	LightweightVersionTags          []string               `protobuf:"bytes,25,rep,name=lightweight_version_tags,json=lightweightVersionTags,proto3" json:"lightweight_version_tags,omitempty"`
+	LocalReplaceDirectives          []string               `protobuf:"bytes,26,rep,name=local_replace_directives,json=localReplaceDirectives,proto3" json:"local_replace_directives,omitempty"`
+	Modpath                         string                 `protobuf:"bytes,27,opt,name=modpath,proto3" json:"modpath,omitempty"`
+	ModpathMismatch                 bool                   `protobuf:"varint,28,opt,name=modpath_mismatch,json=modpathMismatch,proto3" json:"modpath_mismatch,omitempty"`
+	ModpathRemoteMismatch           bool                   `protobuf:"varint,29,opt,name=modpath_remote_mismatch,json=modpathRemoteMismatch,proto3" json:"modpath_remote_mismatch,omitempty"`
+	ModuleSubdir                    string                 `protobuf:"bytes,30,opt,name=module_subdir,json=moduleSubdir,proto3" json:"module_subdir,omitempty"`
+	ModverResultCode                ModverResultCode       `protobuf:"varint,31,opt,name=modver_result_code,json=modverResultCode,proto3,enum=taggo.v1.ModverResultCode" json:"modver_result_code,omitempty"`
+	ModverResultString              string                 `protobuf:"bytes,32,opt,name=modver_result_string,json=modverResultString,proto3" json:"modver_result_string,omitempty"`
+	ModverReport                    string                 `protobuf:"bytes,33,opt,name=modver_report,json=modverReport,proto3" json:"modver_report,omitempty"`
+	BuildContext                    string                 `protobuf:"bytes,34,opt,name=build_context,json=buildContext,proto3" json:"build_context,omitempty"`
+	ApiChanges                      []*APIChange           `protobuf:"bytes,35,rep,name=api_changes,json=apiChanges,proto3" json:"api_changes,omitempty"`
+	NewMajor                        int32                  `protobuf:"varint,36,opt,name=new_major,json=newMajor,proto3" json:"new_major,omitempty"`
+	NewMinor                        int32                  `protobuf:"varint,37,opt,name=new_minor,json=newMinor,proto3" json:"new_minor,omitempty"`
+	NewPatch                        int32                  `protobuf:"varint,38,opt,name=new_patch,json=newPatch,proto3" json:"new_patch,omitempty"`
+	NewPrerelease                   string                 `protobuf:"bytes,39,opt,name=new_prerelease,json=newPrerelease,proto3" json:"new_prerelease,omitempty"`
+	NewBuildMetadata                string                 `protobuf:"bytes,40,opt,name=new_build_metadata,json=newBuildMetadata,proto3" json:"new_build_metadata,omitempty"`
+	FinalizesPrerelease             bool                   `protobuf:"varint,41,opt,name=finalizes_prerelease,json=finalizesPrerelease,proto3" json:"finalizes_prerelease,omitempty"`
+	NewVersionExists                bool                   `protobuf:"varint,42,opt,name=new_version_exists,json=newVersionExists,proto3" json:"new_version_exists,omitempty"`
+	NoRemote                        bool                   `protobuf:"varint,43,opt,name=no_remote,json=noRemote,proto3" json:"no_remote,omitempty"`
+	NoReleaseMerge                  bool                   `protobuf:"varint,44,opt,name=no_release_merge,json=noReleaseMerge,proto3" json:"no_release_merge,omitempty"`
+	NoNetChanges                    bool                   `protobuf:"varint,45,opt,name=no_net_changes,json=noNetChanges,proto3" json:"no_net_changes,omitempty"`
+	OldestUnreleasedCommitTime      *timestamppb.Timestamp `protobuf:"bytes,46,opt,name=oldest_unreleased_commit_time,json=oldestUnreleasedCommitTime,proto3" json:"oldest_unreleased_commit_time,omitempty"`
+	OpenVulnerabilities             []string               `protobuf:"bytes,47,rep,name=open_vulnerabilities,json=openVulnerabilities,proto3" json:"open_vulnerabilities,omitempty"`
+	OtherModuleVersionTags          []string               `protobuf:"bytes,48,rep,name=other_module_version_tags,json=otherModuleVersionTags,proto3" json:"other_module_version_tags,omitempty"`
+	PhaseError                      *PhaseError            `protobuf:"bytes,49,opt,name=phase_error,json=phaseError,proto3" json:"phase_error,omitempty"`
+	Readiness                       []*ReadinessCheck      `protobuf:"bytes,50,rep,name=readiness,proto3" json:"readiness,omitempty"`
+	ReleaseMergeCommit              string                 `protobuf:"bytes,51,opt,name=release_merge_commit,json=releaseMergeCommit,proto3" json:"release_merge_commit,omitempty"`
+	Remediations                    []*Remediation         `protobuf:"bytes,52,rep,name=remediations,proto3" json:"remediations,omitempty"`
+	Remote                          string                 `protobuf:"bytes,53,opt,name=remote,proto3" json:"remote,omitempty"`
+	RemoteUrl                       string                 `protobuf:"bytes,54,opt,name=remote_url,json=remoteUrl,proto3" json:"remote_url,omitempty"`
+	// ForgeKind is "github", "gitlab", "codeberg", or empty.
+	ForgeKind                 string                 `protobuf:"bytes,55,opt,name=forge_kind,json=forgeKind,proto3" json:"forge_kind,omitempty"`
+	RemoteOnlyVersionTags     []string               `protobuf:"bytes,56,rep,name=remote_only_version_tags,json=remoteOnlyVersionTags,proto3" json:"remote_only_version_tags,omitempty"`
+	ReplaceDirectives         []string               `protobuf:"bytes,57,rep,name=replace_directives,json=replaceDirectives,proto3" json:"replace_directives,omitempty"`
+	RuleViolations            []*RuleViolation       `protobuf:"bytes,58,rep,name=rule_violations,json=ruleViolations,proto3" json:"rule_violations,omitempty"`
+	Series                    string                 `protobuf:"bytes,59,opt,name=series,proto3" json:"series,omitempty"`
+	Suppress                  []string               `protobuf:"bytes,60,rep,name=suppress,proto3" json:"suppress,omitempty"`
+	SkippedChecks             []string               `protobuf:"bytes,61,rep,name=skipped_checks,json=skippedChecks,proto3" json:"skipped_checks,omitempty"`
+	StaleRelease              bool                   `protobuf:"varint,62,opt,name=stale_release,json=staleRelease,proto3" json:"stale_release,omitempty"`
+	TidyDiff                  string                 `protobuf:"bytes,63,opt,name=tidy_diff,json=tidyDiff,proto3" json:"tidy_diff,omitempty"`
+	UnbuildableVersions       []string               `protobuf:"bytes,64,rep,name=unbuildable_versions,json=unbuildableVersions,proto3" json:"unbuildable_versions,omitempty"`
+	UnreleasedCommits         int32                  `protobuf:"varint,65,opt,name=unreleased_commits,json=unreleasedCommits,proto3" json:"unreleased_commits,omitempty"`
+	VersionPrefix             string                 `protobuf:"bytes,66,opt,name=version_prefix,json=versionPrefix,proto3" json:"version_prefix,omitempty"`
+	Versions                  []*VersionInfo         `protobuf:"bytes,67,rep,name=versions,proto3" json:"versions,omitempty"`
+	VersionSuffix             VersionSuffixStatus    `protobuf:"varint,68,opt,name=version_suffix,json=versionSuffix,proto3,enum=taggo.v1.VersionSuffixStatus" json:"version_suffix,omitempty"`
+	TotalVersionTags          int32                  `protobuf:"varint,69,opt,name=total_version_tags,json=totalVersionTags,proto3" json:"total_version_tags,omitempty"`
+	TagsPerMajor              map[string]int32       `protobuf:"bytes,70,rep,name=tags_per_major,json=tagsPerMajor,proto3" json:"tags_per_major,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	OldestVersion             string                 `protobuf:"bytes,71,opt,name=oldest_version,json=oldestVersion,proto3" json:"oldest_version,omitempty"`
+	OldestVersionDate         *timestamppb.Timestamp `protobuf:"bytes,72,opt,name=oldest_version_date,json=oldestVersionDate,proto3" json:"oldest_version_date,omitempty"`
+	NewestVersion             string                 `protobuf:"bytes,73,opt,name=newest_version,json=newestVersion,proto3" json:"newest_version,omitempty"`
+	NewestVersionDate         *timestamppb.Timestamp `protobuf:"bytes,74,opt,name=newest_version_date,json=newestVersionDate,proto3" json:"newest_version_date,omitempty"`
+	LatestCommitAuthor        string                 `protobuf:"bytes,75,opt,name=latest_commit_author,json=latestCommitAuthor,proto3" json:"latest_commit_author,omitempty"`
+	LatestCommitTime          *timestamppb.Timestamp `protobuf:"bytes,76,opt,name=latest_commit_time,json=latestCommitTime,proto3" json:"latest_commit_time,omitempty"`
+	LatestCommitSubject       string                 `protobuf:"bytes,77,opt,name=latest_commit_subject,json=latestCommitSubject,proto3" json:"latest_commit_subject,omitempty"`
+	UnreleasedCommitSummaries []*CommitSummary       `protobuf:"bytes,79,rep,name=unreleased_commit_summaries,json=unreleasedCommitSummaries,proto3" json:"unreleased_commit_summaries,omitempty"`
+	ReachableVulnerabilities  []*Vulnerability       `protobuf:"bytes,80,rep,name=reachable_vulnerabilities,json=reachableVulnerabilities,proto3" json:"reachable_vulnerabilities,omitempty"`
+	PolicyBump                ModverResultCode       `protobuf:"varint,81,opt,name=policy_bump,json=policyBump,proto3,enum=taggo.v1.ModverResultCode" json:"policy_bump,omitempty"`
+	Graduation                *Graduation            `protobuf:"bytes,82,opt,name=graduation,proto3" json:"graduation,omitempty"`
+	MalformedVersionTags      []*MalformedVersionTag `protobuf:"bytes,83,rep,name=malformed_version_tags,json=malformedVersionTags,proto3" json:"malformed_version_tags,omitempty"`
+	StaleFloatingTags         []*FloatingTag         `protobuf:"bytes,84,rep,name=stale_floating_tags,json=staleFloatingTags,proto3" json:"stale_floating_tags,omitempty"`
+	Action                    *ActionInfo            `protobuf:"bytes,85,opt,name=action,proto3" json:"action,omitempty"`
+	NearestTag                *NearestTag            `protobuf:"bytes,86,opt,name=nearest_tag,json=nearestTag,proto3" json:"nearest_tag,omitempty"`
+	SuppressedFindings        []*Finding             `protobuf:"bytes,87,rep,name=suppressed_findings,json=suppressedFindings,proto3" json:"suppressed_findings,omitempty"`
+	// NewVersion is the recommended new version,
+	// formed from new_major, new_minor, new_patch, new_prerelease, and new_build_metadata,
+	// without any version_prefix.
+	NewVersion string `protobuf:"bytes,100,opt,name=new_version,json=newVersion,proto3" json:"new_version,omitempty"`
+	// Findings are the items in Taggo's report,
+	// in order.
+	Findings []*Finding `protobuf:"bytes,101,rep,name=findings,proto3" json:"findings,omitempty"`
+}
+
+func (x *Result) Reset() {
+	*x = Result{}
+	mi := &file_taggo_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Result) ProtoMessage() {}
+
+func (x *Result) ProtoReflect() protoreflect.Message {
+	mi := &file_taggo_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Result.ProtoReflect.Descriptor instead.
+func (*Result) Descriptor() ([]byte, []int) {
+	return file_taggo_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Result) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *Result) GetDefaultBranch() string {
+	if x != nil {
+		return x.DefaultBranch
+	}
+	return ""
+}
+
+func (x *Result) GetAhead() int32 {
+	if x != nil {
+		return x.Ahead
+	}
+	return 0
+}
+
+func (x *Result) GetBehind() int32 {
+	if x != nil {
+		return x.Behind
+	}
+	return 0
+}
+
+func (x *Result) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+func (x *Result) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *Result) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+func (x *Result) GetLatestVersion() string {
+	if x != nil {
+		return x.LatestVersion
+	}
+	return ""
+}
+
+func (x *Result) GetLatestCommit() string {
+	if x != nil {
+		return x.LatestCommit
+	}
+	return ""
+}
+
+func (x *Result) GetLatestCommitHasLatestVersion() bool {
+	if x != nil {
+		return x.LatestCommitHasLatestVersion
+	}
+	return false
+}
+
+func (x *Result) GetLatestCommitHasVersionTag() bool {
+	if x != nil {
+		return x.LatestCommitHasVersionTag
+	}
+	return false
+}
+
+func (x *Result) GetLatestMajor() int32 {
+	if x != nil {
+		return x.LatestMajor
+	}
+	return 0
+}
+
+func (x *Result) GetLatestMinor() int32 {
+	if x != nil {
+		return x.LatestMinor
+	}
+	return 0
+}
+
+func (x *Result) GetLatestPatch() int32 {
+	if x != nil {
+		return x.LatestPatch
+	}
+	return 0
+}
+
+func (x *Result) GetLatestVersionTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LatestVersionTime
+	}
+	return nil
+}
+
+func (x *Result) GetLatestVersionIsPrerelease() bool {
+	if x != nil {
+		return x.LatestVersionIsPrerelease
+	}
+	return false
+}
+
+func (x *Result) GetLatestVersionUnstable() bool {
+	if x != nil {
+		return x.LatestVersionUnstable
+	}
+	return false
+}
+
+func (x *Result) GetExcludeDirectives() []string {
+	if x != nil {
+		return x.ExcludeDirectives
+	}
+	return nil
+}
+
+func (x *Result) GetGoDirective() string {
+	if x != nil {
+		return x.GoDirective
+	}
+	return ""
+}
+
+func (x *Result) GetToolchainDirective() string {
+	if x != nil {
+		return x.ToolchainDirective
+	}
+	return ""
+}
+
+func (x *Result) GetLatestVersionGoDirective() string {
+	if x != nil {
+		return x.LatestVersionGoDirective
+	}
+	return ""
+}
+
+func (x *Result) GetLatestVersionToolchainDirective() string {
+	if x != nil {
+		return x.LatestVersionToolchainDirective
+	}
+	return ""
+}
+
+func (x *Result) GetGoDirectiveBump() bool {
+	if x != nil {
+		return x.GoDirectiveBump
+	}
+	return false
+}
+
+func (x *Result) GetKnownDependents() int32 {
+	if x != nil {
+		return x.KnownDependents
+	}
+	return 0
+}
+
+func (x *Result) GetLightweightVersionTags() []string {
+	if x != nil {
+		return x.LightweightVersionTags
+	}
+	return nil
+}
+
+func (x *Result) GetLocalReplaceDirectives() []string {
+	if x != nil {
+		return x.LocalReplaceDirectives
+	}
+	return nil
+}
+
+func (x *Result) GetModpath() string {
+	if x != nil {
+		return x.Modpath
+	}
+	return ""
+}
+
+func (x *Result) GetModpathMismatch() bool {
+	if x != nil {
+		return x.ModpathMismatch
+	}
+	return false
+}
+
+func (x *Result) GetModpathRemoteMismatch() bool {
+	if x != nil {
+		return x.ModpathRemoteMismatch
+	}
+	return false
+}
+
+func (x *Result) GetModuleSubdir() string {
+	if x != nil {
+		return x.ModuleSubdir
+	}
+	return ""
+}
+
+func (x *Result) GetModverResultCode() ModverResultCode {
+	if x != nil {
+		return x.ModverResultCode
+	}
+	return ModverResultCode_MODVER_RESULT_CODE_NONE
+}
+
+func (x *Result) GetModverResultString() string {
+	if x != nil {
+		return x.ModverResultString
+	}
+	return ""
+}
+
+func (x *Result) GetModverReport() string {
+	if x != nil {
+		return x.ModverReport
+	}
+	return ""
+}
+
+func (x *Result) GetBuildContext() string {
+	if x != nil {
+		return x.BuildContext
+	}
+	return ""
+}
+
+func (x *Result) GetApiChanges() []*APIChange {
+	if x != nil {
+		return x.ApiChanges
+	}
+	return nil
+}
+
+func (x *Result) GetNewMajor() int32 {
+	if x != nil {
+		return x.NewMajor
+	}
+	return 0
+}
+
+func (x *Result) GetNewMinor() int32 {
+	if x != nil {
+		return x.NewMinor
+	}
+	return 0
+}
+
+func (x *Result) GetNewPatch() int32 {
+	if x != nil {
+		return x.NewPatch
+	}
+	return 0
+}
+
+func (x *Result) GetNewPrerelease() string {
+	if x != nil {
+		return x.NewPrerelease
+	}
+	return ""
+}
+
+func (x *Result) GetNewBuildMetadata() string {
+	if x != nil {
+		return x.NewBuildMetadata
+	}
+	return ""
+}
+
+func (x *Result) GetFinalizesPrerelease() bool {
+	if x != nil {
+		return x.FinalizesPrerelease
+	}
+	return false
+}
+
+func (x *Result) GetNewVersionExists() bool {
+	if x != nil {
+		return x.NewVersionExists
+	}
+	return false
+}
+
+func (x *Result) GetNoRemote() bool {
+	if x != nil {
+		return x.NoRemote
+	}
+	return false
+}
+
+func (x *Result) GetNoReleaseMerge() bool {
+	if x != nil {
+		return x.NoReleaseMerge
+	}
+	return false
+}
+
+func (x *Result) GetNoNetChanges() bool {
+	if x != nil {
+		return x.NoNetChanges
+	}
+	return false
+}
+
+func (x *Result) GetOldestUnreleasedCommitTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OldestUnreleasedCommitTime
+	}
+	return nil
+}
+
+func (x *Result) GetOpenVulnerabilities() []string {
+	if x != nil {
+		return x.OpenVulnerabilities
+	}
+	return nil
+}
+
+func (x *Result) GetOtherModuleVersionTags() []string {
+	if x != nil {
+		return x.OtherModuleVersionTags
+	}
+	return nil
+}
+
+func (x *Result) GetPhaseError() *PhaseError {
+	if x != nil {
+		return x.PhaseError
+	}
+	return nil
+}
+
+func (x *Result) GetReadiness() []*ReadinessCheck {
+	if x != nil {
+		return x.Readiness
+	}
+	return nil
+}
+
+func (x *Result) GetReleaseMergeCommit() string {
+	if x != nil {
+		return x.ReleaseMergeCommit
+	}
+	return ""
+}
+
+func (x *Result) GetRemediations() []*Remediation {
+	if x != nil {
+		return x.Remediations
+	}
+	return nil
+}
+
+func (x *Result) GetRemote() string {
+	if x != nil {
+		return x.Remote
+	}
+	return ""
+}
+
+func (x *Result) GetRemoteUrl() string {
+	if x != nil {
+		return x.RemoteUrl
+	}
+	return ""
+}
+
+func (x *Result) GetForgeKind() string {
+	if x != nil {
+		return x.ForgeKind
+	}
+	return ""
+}
+
+func (x *Result) GetRemoteOnlyVersionTags() []string {
+	if x != nil {
+		return x.RemoteOnlyVersionTags
+	}
+	return nil
+}
+
+func (x *Result) GetReplaceDirectives() []string {
+	if x != nil {
+		return x.ReplaceDirectives
+	}
+	return nil
+}
+
+func (x *Result) GetRuleViolations() []*RuleViolation {
+	if x != nil {
+		return x.RuleViolations
+	}
+	return nil
+}
+
+func (x *Result) GetSeries() string {
+	if x != nil {
+		return x.Series
+	}
+	return ""
+}
+
+func (x *Result) GetSuppress() []string {
+	if x != nil {
+		return x.Suppress
+	}
+	return nil
+}
+
+func (x *Result) GetSkippedChecks() []string {
+	if x != nil {
+		return x.SkippedChecks
+	}
+	return nil
+}
+
+func (x *Result) GetStaleRelease() bool {
+	if x != nil {
+		return x.StaleRelease
+	}
+	return false
+}
+
+func (x *Result) GetTidyDiff() string {
+	if x != nil {
+		return x.TidyDiff
+	}
+	return ""
+}
+
+func (x *Result) GetUnbuildableVersions() []string {
+	if x != nil {
+		return x.UnbuildableVersions
+	}
+	return nil
+}
+
+func (x *Result) GetUnreleasedCommits() int32 {
+	if x != nil {
+		return x.UnreleasedCommits
+	}
+	return 0
+}
+
+func (x *Result) GetVersionPrefix() string {
+	if x != nil {
+		return x.VersionPrefix
+	}
+	return ""
+}
+
+func (x *Result) GetVersions() []*VersionInfo {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+func (x *Result) GetVersionSuffix() VersionSuffixStatus {
+	if x != nil {
+		return x.VersionSuffix
+	}
+	return VersionSuffixStatus_VERSION_SUFFIX_STATUS_UNSPECIFIED
+}
+
+func (x *Result) GetTotalVersionTags() int32 {
+	if x != nil {
+		return x.TotalVersionTags
+	}
+	return 0
+}
+
+func (x *Result) GetTagsPerMajor() map[string]int32 {
+	if x != nil {
+		return x.TagsPerMajor
+	}
+	return nil
+}
+
+func (x *Result) GetOldestVersion() string {
+	if x != nil {
+		return x.OldestVersion
+	}
+	return ""
+}
+
+func (x *Result) GetOldestVersionDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OldestVersionDate
+	}
+	return nil
+}
+
+func (x *Result) GetNewestVersion() string {
+	if x != nil {
+		return x.NewestVersion
+	}
+	return ""
+}
+
+func (x *Result) GetNewestVersionDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NewestVersionDate
+	}
+	return nil
+}
+
+func (x *Result) GetLatestCommitAuthor() string {
+	if x != nil {
+		return x.LatestCommitAuthor
+	}
+	return ""
+}
+
+func (x *Result) GetLatestCommitTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LatestCommitTime
+	}
+	return nil
+}
+
+func (x *Result) GetLatestCommitSubject() string {
+	if x != nil {
+		return x.LatestCommitSubject
+	}
+	return ""
+}
+
+func (x *Result) GetUnreleasedCommitSummaries() []*CommitSummary {
+	if x != nil {
+		return x.UnreleasedCommitSummaries
+	}
+	return nil
+}
+
+func (x *Result) GetReachableVulnerabilities() []*Vulnerability {
+	if x != nil {
+		return x.ReachableVulnerabilities
+	}
+	return nil
+}
+
+func (x *Result) GetPolicyBump() ModverResultCode {
+	if x != nil {
+		return x.PolicyBump
+	}
+	return ModverResultCode_MODVER_RESULT_CODE_NONE
+}
+
+func (x *Result) GetGraduation() *Graduation {
+	if x != nil {
+		return x.Graduation
+	}
+	return nil
+}
+
+func (x *Result) GetMalformedVersionTags() []*MalformedVersionTag {
+	if x != nil {
+		return x.MalformedVersionTags
+	}
+	return nil
+}
+
+func (x *Result) GetStaleFloatingTags() []*FloatingTag {
+	if x != nil {
+		return x.StaleFloatingTags
+	}
+	return nil
+}
+
+func (x *Result) GetAction() *ActionInfo {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+func (x *Result) GetNearestTag() *NearestTag {
+	if x != nil {
+		return x.NearestTag
+	}
+	return nil
+}
+
+func (x *Result) GetSuppressedFindings() []*Finding {
+	if x != nil {
+		return x.SuppressedFindings
+	}
+	return nil
+}
+
+func (x *Result) GetNewVersion() string {
+	if x != nil {
+		return x.NewVersion
+	}
+	return ""
+}
+
+func (x *Result) GetFindings() []*Finding {
+	if x != nil {
+		return x.Findings
+	}
+	return nil
+}
+
+type APIChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Kind is "added", "removed", or "changed".
+	Kind    string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Package string `protobuf:"bytes,2,opt,name=package,proto3" json:"package,omitempty"`
+	Symbol  string `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Reason  string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *APIChange) Reset() {
+	*x = APIChange{}
+	mi := &file_taggo_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIChange) ProtoMessage() {}
+
+func (x *APIChange) ProtoReflect() protoreflect.Message {
+	mi := &file_taggo_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIChange.ProtoReflect.Descriptor instead.
+func (*APIChange) Descriptor() ([]byte, []int) {
+	return file_taggo_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *APIChange) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *APIChange) GetPackage() string {
+	if x != nil {
+		return x.Package
+	}
+	return ""
+}
+
+func (x *APIChange) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *APIChange) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type PhaseError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Phase is the name of the phase that failed,
+	// such as "refs" or "modver".
+	Phase   string `protobuf:"bytes,1,opt,name=phase,proto3" json:"phase,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *PhaseError) Reset() {
+	*x = PhaseError{}
+	mi := &file_taggo_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PhaseError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhaseError) ProtoMessage() {}
+
+func (x *PhaseError) ProtoReflect() protoreflect.Message {
+	mi := &file_taggo_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhaseError.ProtoReflect.Descriptor instead.
+func (*PhaseError) Descriptor() ([]byte, []int) {
+	return file_taggo_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PhaseError) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *PhaseError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ReadinessCheck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Ok     bool   `protobuf:"varint,2,opt,name=ok,proto3" json:"ok,omitempty"`
+	Detail string `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+}
+
+func (x *ReadinessCheck) Reset() {
+	*x = ReadinessCheck{}
+	mi := &file_taggo_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadinessCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadinessCheck) ProtoMessage() {}
+
+func (x *ReadinessCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_taggo_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadinessCheck.ProtoReflect.Descriptor instead.
+func (*ReadinessCheck) Descriptor() ([]byte, []int) {
+	return file_taggo_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReadinessCheck) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReadinessCheck) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *ReadinessCheck) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+type MalformedVersionTag struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tag     string `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Commit  string `protobuf:"bytes,3,opt,name=commit,proto3" json:"commit,omitempty"`
+}
+
+func (x *MalformedVersionTag) Reset() {
+	*x = MalformedVersionTag{}
+	mi := &file_taggo_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MalformedVersionTag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MalformedVersionTag) ProtoMessage() {}
+
+func (x *MalformedVersionTag) ProtoReflect() protoreflect.Message {
+	mi := &file_taggo_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MalformedVersionTag.ProtoReflect.Descriptor instead.
+func (*MalformedVersionTag) Descriptor() ([]byte, []int) {
+	return file_taggo_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MalformedVersionTag) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *MalformedVersionTag) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *MalformedVersionTag) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+type FloatingTag struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tag          string `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Commit       string `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	Latest       string `protobuf:"bytes,3,opt,name=latest,proto3" json:"latest,omitempty"`
+	LatestCommit string `protobuf:"bytes,4,opt,name=latest_commit,json=latestCommit,proto3" json:"latest_commit,omitempty"`
+}
+
+func (x *FloatingTag) Reset() {
+	*x = FloatingTag{}
+	mi := &file_taggo_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FloatingTag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FloatingTag) ProtoMessage() {}
+
+func (x *FloatingTag) ProtoReflect() protoreflect.Message {
+	mi := &file_taggo_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FloatingTag.ProtoReflect.Descriptor instead.
+func (*FloatingTag) Descriptor() ([]byte, []int) {
+	return file_taggo_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FloatingTag) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *FloatingTag) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+func (x *FloatingTag) GetLatest() string {
+	if x != nil {
+		return x.Latest
+	}
+	return ""
+}
+
+func (x *FloatingTag) GetLatestCommit() string {
+	if x != nil {
+		return x.LatestCommit
+	}
+	return ""
+}
+
+type NearestTag struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tag      string `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Distance int32  `protobuf:"varint,2,opt,name=distance,proto3" json:"distance,omitempty"`
+	Hash     string `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (x *NearestTag) Reset() {
+	*x = NearestTag{}
+	mi := &file_taggo_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NearestTag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NearestTag) ProtoMessage() {}
+
+func (x *NearestTag) ProtoReflect() protoreflect.Message {
+	mi := &file_taggo_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NearestTag.ProtoReflect.Descriptor instead.
+func (*NearestTag) Descriptor() ([]byte, []int) {
+	return file_taggo_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *NearestTag) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *NearestTag) GetDistance() int32 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *NearestTag) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+type ActionInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	File             string `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Name             string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	MajorTag         string `protobuf:"bytes,3,opt,name=major_tag,json=majorTag,proto3" json:"major_tag,omitempty"`
+	MajorTagMissing  bool   `protobuf:"varint,4,opt,name=major_tag_missing,json=majorTagMissing,proto3" json:"major_tag_missing,omitempty"`
+	ReleaseOffBranch bool   `protobuf:"varint,5,opt,name=release_off_branch,json=releaseOffBranch,proto3" json:"release_off_branch,omitempty"`
+	MetadataChanged  bool   `protobuf:"varint,6,opt,name=metadata_changed,json=metadataChanged,proto3" json:"metadata_changed,omitempty"`
+}
+
+func (x *ActionInfo) Reset() {
+	*x = ActionInfo{}
+	mi := &file_taggo_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionInfo) ProtoMessage() {}
+
+func (x *ActionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_taggo_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionInfo.ProtoReflect.Descriptor instead.
+func (*ActionInfo) Descriptor() ([]byte, []int) {
+	return file_taggo_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ActionInfo) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *ActionInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ActionInfo) GetMajorTag() string {
+	if x != nil {
+		return x.MajorTag
+	}
+	return ""
+}
+
+func (x *ActionInfo) GetMajorTagMissing() bool {
+	if x != nil {
+		return x.MajorTagMissing
+	}
+	return false
+}
+
+func (x *ActionInfo) GetReleaseOffBranch() bool {
+	if x != nil {
+		return x.ReleaseOffBranch
+	}
+	return false
+}
+
+func (x *ActionInfo) GetMetadataChanged() bool {
+	if x != nil {
+		return x.MetadataChanged
+	}
+	return false
+}
+
+type Graduation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ready    bool                   `protobuf:"varint,1,opt,name=ready,proto3" json:"ready,omitempty"`
+	Criteria []*GraduationCriterion `protobuf:"bytes,2,rep,name=criteria,proto3" json:"criteria,omitempty"`
+}
+
+func (x *Graduation) Reset() {
+	*x = Graduation{}
+	mi := &file_taggo_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Graduation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Graduation) ProtoMessage() {}
+
+func (x *Graduation) ProtoReflect() protoreflect.Message {
+	mi := &file_taggo_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Graduation.ProtoReflect.Descriptor instead.
+func (*Graduation) Descriptor() ([]byte, []int) {
+	return file_taggo_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Graduation) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+func (x *Graduation) GetCriteria() []*GraduationCriterion {
+	if x != nil {
+		return x.Criteria
+	}
+	return nil
+}
+
+type GraduationCriterion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Kind is "age", "api-stability", "docs", or "tests".
+	Kind   string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Met    bool   `protobuf:"varint,2,opt,name=met,proto3" json:"met,omitempty"`
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *GraduationCriterion) Reset() {
+	*x = GraduationCriterion{}
+	mi := &file_taggo_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GraduationCriterion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraduationCriterion) ProtoMessage() {}
+
+func (x *GraduationCriterion) ProtoReflect() protoreflect.Message {
+	mi := &file_taggo_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraduationCriterion.ProtoReflect.Descriptor instead.
+func (*GraduationCriterion) Descriptor() ([]byte, []int) {
+	return file_taggo_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GraduationCriterion) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *GraduationCriterion) GetMet() bool {
+	if x != nil {
+		return x.Met
+	}
+	return false
+}
+
+func (x *GraduationCriterion) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type Remediation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Command string `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+}
+
+func (x *Remediation) Reset() {
+	*x = Remediation{}
+	mi := &file_taggo_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Remediation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Remediation) ProtoMessage() {}
+
+func (x *Remediation) ProtoReflect() protoreflect.Message {
+	mi := &file_taggo_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Remediation.ProtoReflect.Descriptor instead.
+func (*Remediation) Descriptor() ([]byte, []int) {
+	return file_taggo_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Remediation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Remediation) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+type RuleViolation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rule    string `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Error   bool   `protobuf:"varint,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *RuleViolation) Reset() {
+	*x = RuleViolation{}
+	mi := &file_taggo_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleViolation) ProtoMessage() {}
+
+func (x *RuleViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_taggo_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleViolation.ProtoReflect.Descriptor instead.
+func (*RuleViolation) Descriptor() ([]byte, []int) {
+	return file_taggo_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RuleViolation) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *RuleViolation) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RuleViolation) GetError() bool {
+	if x != nil {
+		return x.Error
+	}
+	return false
+}
+
+type VersionInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version         string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Commit          string                 `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	CommitTime      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=commit_time,json=commitTime,proto3" json:"commit_time,omitempty"`
+	Annotated       bool                   `protobuf:"varint,4,opt,name=annotated,proto3" json:"annotated,omitempty"`
+	Tagger          string                 `protobuf:"bytes,5,opt,name=tagger,proto3" json:"tagger,omitempty"`
+	TagTime         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=tag_time,json=tagTime,proto3" json:"tag_time,omitempty"`
+	Signed          bool                   `protobuf:"varint,7,opt,name=signed,proto3" json:"signed,omitempty"`
+	OnDefaultBranch bool                   `protobuf:"varint,8,opt,name=on_default_branch,json=onDefaultBranch,proto3" json:"on_default_branch,omitempty"`
+}
+
+func (x *VersionInfo) Reset() {
+	*x = VersionInfo{}
+	mi := &file_taggo_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VersionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionInfo) ProtoMessage() {}
+
+func (x *VersionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_taggo_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionInfo.ProtoReflect.Descriptor instead.
+func (*VersionInfo) Descriptor() ([]byte, []int) {
+	return file_taggo_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *VersionInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *VersionInfo) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+func (x *VersionInfo) GetCommitTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CommitTime
+	}
+	return nil
+}
+
+func (x *VersionInfo) GetAnnotated() bool {
+	if x != nil {
+		return x.Annotated
+	}
+	return false
+}
+
+func (x *VersionInfo) GetTagger() string {
+	if x != nil {
+		return x.Tagger
+	}
+	return ""
+}
+
+func (x *VersionInfo) GetTagTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TagTime
+	}
+	return nil
+}
+
+func (x *VersionInfo) GetSigned() bool {
+	if x != nil {
+		return x.Signed
+	}
+	return false
+}
+
+func (x *VersionInfo) GetOnDefaultBranch() bool {
+	if x != nil {
+		return x.OnDefaultBranch
+	}
+	return false
+}
+
+type CommitSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash    string                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Author  string                 `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	Time    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	Subject string                 `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
+}
+
+func (x *CommitSummary) Reset() {
+	*x = CommitSummary{}
+	mi := &file_taggo_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitSummary) ProtoMessage() {}
+
+func (x *CommitSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_taggo_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitSummary.ProtoReflect.Descriptor instead.
+func (*CommitSummary) Descriptor() ([]byte, []int) {
+	return file_taggo_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CommitSummary) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *CommitSummary) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *CommitSummary) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *CommitSummary) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+type Vulnerability struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Aliases []string `protobuf:"bytes,2,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	Summary string   `protobuf:"bytes,3,opt,name=summary,proto3" json:"summary,omitempty"`
+	// Severity is "low", "medium", "high", "critical", or empty if unknown.
+	Severity     string   `protobuf:"bytes,4,opt,name=severity,proto3" json:"severity,omitempty"`
+	Module       string   `protobuf:"bytes,5,opt,name=module,proto3" json:"module,omitempty"`
+	Version      string   `protobuf:"bytes,6,opt,name=version,proto3" json:"version,omitempty"`
+	FixedVersion string   `protobuf:"bytes,7,opt,name=fixed_version,json=fixedVersion,proto3" json:"fixed_version,omitempty"`
+	Symbols      []string `protobuf:"bytes,8,rep,name=symbols,proto3" json:"symbols,omitempty"`
+}
+
+func (x *Vulnerability) Reset() {
+	*x = Vulnerability{}
+	mi := &file_taggo_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Vulnerability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vulnerability) ProtoMessage() {}
+
+func (x *Vulnerability) ProtoReflect() protoreflect.Message {
+	mi := &file_taggo_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vulnerability.ProtoReflect.Descriptor instead.
+func (*Vulnerability) Descriptor() ([]byte, []int) {
+	return file_taggo_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Vulnerability) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Vulnerability) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *Vulnerability) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *Vulnerability) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *Vulnerability) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Vulnerability) GetFixedVersion() string {
+	if x != nil {
+		return x.FixedVersion
+	}
+	return ""
+}
+
+func (x *Vulnerability) GetSymbols() []string {
+	if x != nil {
+		return x.Symbols
+	}
+	return nil
+}
+
+type Finding struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          string      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind        FindingKind `protobuf:"varint,2,opt,name=kind,proto3,enum=taggo.v1.FindingKind" json:"kind,omitempty"`
+	Message     string      `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Severity    Severity    `protobuf:"varint,4,opt,name=severity,proto3,enum=taggo.v1.Severity" json:"severity,omitempty"`
+	Suppressed  bool        `protobuf:"varint,5,opt,name=suppressed,proto3" json:"suppressed,omitempty"`
+	Remediation string      `protobuf:"bytes,6,opt,name=remediation,proto3" json:"remediation,omitempty"`
+}
+
+func (x *Finding) Reset() {
+	*x = Finding{}
+	mi := &file_taggo_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Finding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Finding) ProtoMessage() {}
+
+func (x *Finding) ProtoReflect() protoreflect.Message {
+	mi := &file_taggo_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Finding.ProtoReflect.Descriptor instead.
+func (*Finding) Descriptor() ([]byte, []int) {
+	return file_taggo_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Finding) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Finding) GetKind() FindingKind {
+	if x != nil {
+		return x.Kind
+	}
+	return FindingKind_FINDING_KIND_UNSPECIFIED
+}
+
+func (x *Finding) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Finding) GetSeverity() Severity {
+	if x != nil {
+		return x.Severity
+	}
+	return Severity_SEVERITY_UNSPECIFIED
+}
+
+func (x *Finding) GetSuppressed() bool {
+	if x != nil {
+		return x.Suppressed
+	}
+	return false
+}
+
+func (x *Finding) GetRemediation() string {
+	if x != nil {
+		return x.Remediation
+	}
+	return ""
+}
+
+var File_taggo_proto protoreflect.FileDescriptor
+
+var file_taggo_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x74, 0x61, 0x67, 0x67, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x74,
+	0x61, 0x67, 0x67, 0x6f, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa0, 0x01, 0x0a, 0x0c, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x72, 0x61,
+	0x6e, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63,
+	0x68, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x61, 0x73,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x27, 0x0a,
+	0x0f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x6f, 0x64, 0x76, 0x65, 0x72,
+	0x5f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x6d,
+	0x6f, 0x64, 0x76, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x78, 0x0a, 0x0c, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x72,
+	0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6d,
+	0x6f, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x6f, 0x64,
+	0x75, 0x6c, 0x65, 0x12, 0x30, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x61, 0x67, 0x67, 0x6f, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x39, 0x0a, 0x0d, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x61, 0x67, 0x67, 0x6f, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x22, 0x7d, 0x0a, 0x0f, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x6f, 0x72, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x74, 0x72, 0x65, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x74, 0x72, 0x65, 0x65, 0x12, 0x30, 0x0a,
+	0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x74, 0x61, 0x67, 0x67, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22,
+	0x5b, 0x0a, 0x10, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x64, 0x69,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x44,
+	0x69, 0x72, 0x12, 0x28, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x61, 0x67, 0x67, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x92, 0x22, 0x0a,
+	0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0d, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25,
+	0x0a, 0x0e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x42,
+	0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x68, 0x65, 0x61, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x61, 0x68, 0x65, 0x61, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x62,
+	0x65, 0x68, 0x69, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x65, 0x68,
+	0x69, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x61, 0x74, 0x65, 0x73,
+	0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23,
+	0x0a, 0x0d, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x12, 0x46, 0x0a, 0x20, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1c, 0x6c,
+	0x61, 0x74, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x48, 0x61, 0x73, 0x4c, 0x61,
+	0x74, 0x65, 0x73, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x40, 0x0a, 0x1d, 0x6c,
+	0x61, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x68, 0x61, 0x73,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x61, 0x67, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x19, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x48, 0x61, 0x73, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x61, 0x67, 0x12, 0x21, 0x0a,
+	0x0c, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x6d, 0x61, 0x6a, 0x6f, 0x72, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0b, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x4d, 0x61, 0x6a, 0x6f, 0x72,
+	0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x6d, 0x69, 0x6e, 0x6f, 0x72,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x4d, 0x69,
+	0x6e, 0x6f, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x70, 0x61,
+	0x74, 0x63, 0x68, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6c, 0x61, 0x74, 0x65, 0x73,
+	0x74, 0x50, 0x61, 0x74, 0x63, 0x68, 0x12, 0x4a, 0x0a, 0x13, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x11, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x3f, 0x0a, 0x1c, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x73, 0x5f, 0x70, 0x72, 0x65, 0x72, 0x65, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x19, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x73, 0x50, 0x72, 0x65, 0x72, 0x65, 0x6c, 0x65,
+	0x61, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x17, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x75, 0x6e, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x11,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x55, 0x6e, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x65,
+	0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65,
+	0x73, 0x18, 0x12, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x67, 0x6f,
+	0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x67, 0x6f, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x2f, 0x0a,
+	0x13, 0x74, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x74, 0x6f, 0x6f, 0x6c,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x3d,
+	0x0a, 0x1b, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x5f, 0x67, 0x6f, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x15, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x18, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x47, 0x6f, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x4b, 0x0a,
+	0x22, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f,
+	0x74, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x1f, 0x6c, 0x61, 0x74, 0x65, 0x73,
+	0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x67, 0x6f,
+	0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x62, 0x75, 0x6d, 0x70, 0x18,
+	0x17, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x67, 0x6f, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x42, 0x75, 0x6d, 0x70, 0x12, 0x29, 0x0a, 0x10, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x5f,
+	0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x18, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0f, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x74,
+	0x73, 0x12, 0x38, 0x0a, 0x18, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x61, 0x67, 0x73, 0x18, 0x19, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x16, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x61, 0x67, 0x73, 0x12, 0x38, 0x0a, 0x18, 0x6c,
+	0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x5f, 0x64, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x73, 0x18, 0x1a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x16, 0x6c,
+	0x6f, 0x63, 0x61, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x6f, 0x64, 0x70, 0x61, 0x74, 0x68,
+	0x18, 0x1b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x6f, 0x64, 0x70, 0x61, 0x74, 0x68, 0x12,
+	0x29, 0x0a, 0x10, 0x6d, 0x6f, 0x64, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x6d, 0x69, 0x73, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x6d, 0x6f, 0x64, 0x70, 0x61,
+	0x74, 0x68, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x36, 0x0a, 0x17, 0x6d, 0x6f,
+	0x64, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x6d, 0x69, 0x73,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x6d, 0x6f, 0x64,
+	0x70, 0x61, 0x74, 0x68, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x5f, 0x73, 0x75, 0x62,
+	0x64, 0x69, 0x72, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x6f, 0x64, 0x75, 0x6c,
+	0x65, 0x53, 0x75, 0x62, 0x64, 0x69, 0x72, 0x12, 0x48, 0x0a, 0x12, 0x6d, 0x6f, 0x64, 0x76, 0x65,
+	0x72, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x1f, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x74, 0x61, 0x67, 0x67, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x4d,
+	0x6f, 0x64, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x52,
+	0x10, 0x6d, 0x6f, 0x64, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x64,
+	0x65, 0x12, 0x30, 0x0a, 0x14, 0x6d, 0x6f, 0x64, 0x76, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x20, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x12, 0x6d, 0x6f, 0x64, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x53, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x6f, 0x64, 0x76, 0x65, 0x72, 0x5f, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x18, 0x21, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x6f, 0x64, 0x76,
+	0x65, 0x72, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x22, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x34, 0x0a,
+	0x0b, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x23, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x61, 0x67, 0x67, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x50,
+	0x49, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0a, 0x61, 0x70, 0x69, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x5f, 0x6d, 0x61, 0x6a, 0x6f, 0x72,
+	0x18, 0x24, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x4d, 0x61, 0x6a, 0x6f, 0x72,
+	0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x5f, 0x6d, 0x69, 0x6e, 0x6f, 0x72, 0x18, 0x25, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x4d, 0x69, 0x6e, 0x6f, 0x72, 0x12, 0x1b, 0x0a,
+	0x09, 0x6e, 0x65, 0x77, 0x5f, 0x70, 0x61, 0x74, 0x63, 0x68, 0x18, 0x26, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x6e, 0x65, 0x77, 0x50, 0x61, 0x74, 0x63, 0x68, 0x12, 0x25, 0x0a, 0x0e, 0x6e, 0x65,
+	0x77, 0x5f, 0x70, 0x72, 0x65, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x27, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x77, 0x50, 0x72, 0x65, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73,
+	0x65, 0x12, 0x2c, 0x0a, 0x12, 0x6e, 0x65, 0x77, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x28, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6e,
+	0x65, 0x77, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12,
+	0x31, 0x0a, 0x14, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x73, 0x5f, 0x70, 0x72, 0x65,
+	0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x29, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x66,
+	0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x73, 0x50, 0x72, 0x65, 0x72, 0x65, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x6e, 0x65, 0x77, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x5f, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x18, 0x2a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10,
+	0x6e, 0x65, 0x77, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x45, 0x78, 0x69, 0x73, 0x74, 0x73,
+	0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x6f, 0x5f, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x18, 0x2b, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x6e, 0x6f, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x12, 0x28, 0x0a,
+	0x10, 0x6e, 0x6f, 0x5f, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x6d, 0x65, 0x72, 0x67,
+	0x65, 0x18, 0x2c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x6e, 0x6f, 0x52, 0x65, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x6e, 0x6f, 0x5f, 0x6e, 0x65,
+	0x74, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x2d, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0c, 0x6e, 0x6f, 0x4e, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x5d, 0x0a,
+	0x1d, 0x6f, 0x6c, 0x64, 0x65, 0x73, 0x74, 0x5f, 0x75, 0x6e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73,
+	0x65, 0x64, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x2e,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x1a, 0x6f, 0x6c, 0x64, 0x65, 0x73, 0x74, 0x55, 0x6e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73,
+	0x65, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x14,
+	0x6f, 0x70, 0x65, 0x6e, 0x5f, 0x76, 0x75, 0x6c, 0x6e, 0x65, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x18, 0x2f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x6f, 0x70, 0x65, 0x6e,
+	0x56, 0x75, 0x6c, 0x6e, 0x65, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12,
+	0x39, 0x0a, 0x19, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x5f,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x61, 0x67, 0x73, 0x18, 0x30, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x16, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x61, 0x67, 0x73, 0x12, 0x35, 0x0a, 0x0b, 0x70, 0x68,
+	0x61, 0x73, 0x65, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x31, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x74, 0x61, 0x67, 0x67, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x68, 0x61, 0x73, 0x65,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x0a, 0x70, 0x68, 0x61, 0x73, 0x65, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x12, 0x36, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x32,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x74, 0x61, 0x67, 0x67, 0x6f, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x09,
+	0x72, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x72, 0x65, 0x6c,
+	0x65, 0x61, 0x73, 0x65, 0x5f, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x18, 0x33, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x4d, 0x65, 0x72, 0x67, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x39, 0x0a, 0x0c, 0x72,
+	0x65, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x34, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x74, 0x61, 0x67, 0x67, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6d,
+	0x65, 0x64, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x72, 0x65, 0x6d, 0x65, 0x64, 0x69,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65,
+	0x18, 0x35, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x36, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x55, 0x72, 0x6c, 0x12, 0x1d, 0x0a,
+	0x0a, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x37, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x66, 0x6f, 0x72, 0x67, 0x65, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x37, 0x0a, 0x18,
+	0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x5f, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x61, 0x67, 0x73, 0x18, 0x38, 0x20, 0x03, 0x28, 0x09, 0x52, 0x15,
+	0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x4f, 0x6e, 0x6c, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x54, 0x61, 0x67, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65,
+	0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x73, 0x18, 0x39, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x11, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x73, 0x12, 0x40, 0x0a, 0x0f, 0x72, 0x75, 0x6c, 0x65, 0x5f, 0x76, 0x69, 0x6f,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x3a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x74, 0x61, 0x67, 0x67, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x56, 0x69, 0x6f,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x72, 0x75, 0x6c, 0x65, 0x56, 0x69, 0x6f, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73,
+	0x18, 0x3b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x73, 0x75, 0x70, 0x70, 0x72, 0x65, 0x73, 0x73, 0x18, 0x3c, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x08, 0x73, 0x75, 0x70, 0x70, 0x72, 0x65, 0x73, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x6b,
+	0x69, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x18, 0x3d, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x73, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x5f, 0x72, 0x65, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x18, 0x3e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x52,
+	0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x64, 0x79, 0x5f, 0x64,
+	0x69, 0x66, 0x66, 0x18, 0x3f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x64, 0x79, 0x44,
+	0x69, 0x66, 0x66, 0x12, 0x31, 0x0a, 0x14, 0x75, 0x6e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x61, 0x62,
+	0x6c, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x40, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x13, 0x75, 0x6e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x75, 0x6e, 0x72, 0x65, 0x6c, 0x65,
+	0x61, 0x73, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x41, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x11, 0x75, 0x6e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x64, 0x43, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x42, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x31, 0x0a, 0x08,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x43, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x74, 0x61, 0x67, 0x67, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x44, 0x0a, 0x0e, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x75, 0x66, 0x66, 0x69,
+	0x78, 0x18, 0x44, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x74, 0x61, 0x67, 0x67, 0x6f, 0x2e,
+	0x76, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x66, 0x66, 0x69, 0x78,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0d, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x53,
+	0x75, 0x66, 0x66, 0x69, 0x78, 0x12, 0x2c, 0x0a, 0x12, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x61, 0x67, 0x73, 0x18, 0x45, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x10, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x54,
+	0x61, 0x67, 0x73, 0x12, 0x48, 0x0a, 0x0e, 0x74, 0x61, 0x67, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f,
+	0x6d, 0x61, 0x6a, 0x6f, 0x72, 0x18, 0x46, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x74, 0x61,
+	0x67, 0x67, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x54, 0x61,
+	0x67, 0x73, 0x50, 0x65, 0x72, 0x4d, 0x61, 0x6a, 0x6f, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x0c, 0x74, 0x61, 0x67, 0x73, 0x50, 0x65, 0x72, 0x4d, 0x61, 0x6a, 0x6f, 0x72, 0x12, 0x25, 0x0a,
+	0x0e, 0x6f, 0x6c, 0x64, 0x65, 0x73, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x47, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6f, 0x6c, 0x64, 0x65, 0x73, 0x74, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x4a, 0x0a, 0x13, 0x6f, 0x6c, 0x64, 0x65, 0x73, 0x74, 0x5f, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x48, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x11, 0x6f,
+	0x6c, 0x64, 0x65, 0x73, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x65,
+	0x12, 0x25, 0x0a, 0x0e, 0x6e, 0x65, 0x77, 0x65, 0x73, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x49, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x77, 0x65, 0x73, 0x74,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x4a, 0x0a, 0x13, 0x6e, 0x65, 0x77, 0x65, 0x73,
+	0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x4a,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x11, 0x6e, 0x65, 0x77, 0x65, 0x73, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x44,
+	0x61, 0x74, 0x65, 0x12, 0x30, 0x0a, 0x14, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x18, 0x4b, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x12, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x41,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x12, 0x48, 0x0a, 0x12, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x4c, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x10, 0x6c,
+	0x61, 0x74, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x32, 0x0a, 0x15, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x5f, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x4d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13,
+	0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x75, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x12, 0x57, 0x0a, 0x1b, 0x75, 0x6e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x64, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69,
+	0x65, 0x73, 0x18, 0x4f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x61, 0x67, 0x67, 0x6f,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72,
+	0x79, 0x52, 0x19, 0x75, 0x6e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x64, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x54, 0x0a, 0x19,
+	0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x76, 0x75, 0x6c, 0x6e, 0x65, 0x72,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x50, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x74, 0x61, 0x67, 0x67, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x75, 0x6c, 0x6e, 0x65,
+	0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x18, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61,
+	0x62, 0x6c, 0x65, 0x56, 0x75, 0x6c, 0x6e, 0x65, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x12, 0x3b, 0x0a, 0x0b, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x5f, 0x62, 0x75, 0x6d,
+	0x70, 0x18, 0x51, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x74, 0x61, 0x67, 0x67, 0x6f, 0x2e,
+	0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x43,
+	0x6f, 0x64, 0x65, 0x52, 0x0a, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x42, 0x75, 0x6d, 0x70, 0x12,
+	0x34, 0x0a, 0x0a, 0x67, 0x72, 0x61, 0x64, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x52, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x61, 0x67, 0x67, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x72, 0x61, 0x64, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x67, 0x72, 0x61, 0x64, 0x75,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x53, 0x0a, 0x16, 0x6d, 0x61, 0x6c, 0x66, 0x6f, 0x72, 0x6d,
+	0x65, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x61, 0x67, 0x73, 0x18,
+	0x53, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x61, 0x67, 0x67, 0x6f, 0x2e, 0x76, 0x31,
+	0x2e, 0x4d, 0x61, 0x6c, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x54, 0x61, 0x67, 0x52, 0x14, 0x6d, 0x61, 0x6c, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x64, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x61, 0x67, 0x73, 0x12, 0x45, 0x0a, 0x13, 0x73, 0x74,
+	0x61, 0x6c, 0x65, 0x5f, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x61, 0x67,
+	0x73, 0x18, 0x54, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x61, 0x67, 0x67, 0x6f, 0x2e,
+	0x76, 0x31, 0x2e, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x54, 0x61, 0x67, 0x52, 0x11,
+	0x73, 0x74, 0x61, 0x6c, 0x65, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x54, 0x61, 0x67,
+	0x73, 0x12, 0x2c, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x55, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x74, 0x61, 0x67, 0x67, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x35, 0x0a, 0x0b, 0x6e, 0x65, 0x61, 0x72, 0x65, 0x73, 0x74, 0x5f, 0x74, 0x61, 0x67, 0x18, 0x56,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x61, 0x67, 0x67, 0x6f, 0x2e, 0x76, 0x31, 0x2e,
+	0x4e, 0x65, 0x61, 0x72, 0x65, 0x73, 0x74, 0x54, 0x61, 0x67, 0x52, 0x0a, 0x6e, 0x65, 0x61, 0x72,
+	0x65, 0x73, 0x74, 0x54, 0x61, 0x67, 0x12, 0x42, 0x0a, 0x13, 0x73, 0x75, 0x70, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x57, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x61, 0x67, 0x67, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x46,
+	0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x12, 0x73, 0x75, 0x70, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x64, 0x46, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65,
+	0x77, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x64, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x6e, 0x65, 0x77, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x08, 0x66,
+	0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x65, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x74, 0x61, 0x67, 0x67, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x52, 0x08, 0x66, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x1a, 0x3f, 0x0a, 0x11, 0x54, 0x61,
+	0x67, 0x73, 0x50, 0x65, 0x72, 0x4d, 0x61, 0x6a, 0x6f, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x4a, 0x04, 0x08, 0x4e, 0x10,
+	0x4f, 0x22, 0x69, 0x0a, 0x09, 0x41, 0x50, 0x49, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x3c, 0x0a, 0x0a,
+	0x50, 0x68, 0x61, 0x73, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68,
+	0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x4c, 0x0a, 0x0e, 0x52, 0x65,
+	0x61, 0x64, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b,
+	0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x22, 0x59, 0x0a, 0x13, 0x4d, 0x61, 0x6c, 0x66,
+	0x6f, 0x72, 0x6d, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x61, 0x67, 0x12,
+	0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61,
+	0x67, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x22, 0x74, 0x0a, 0x0b, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x54,
+	0x61, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x74, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61,
+	0x74, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x63,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6c, 0x61, 0x74,
+	0x65, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x22, 0x4e, 0x0a, 0x0a, 0x4e, 0x65, 0x61,
+	0x72, 0x65, 0x73, 0x74, 0x54, 0x61, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x64, 0x69, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0xd6, 0x01, 0x0a, 0x0a, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x6a, 0x6f, 0x72, 0x5f, 0x74, 0x61, 0x67, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x61, 0x6a, 0x6f, 0x72, 0x54, 0x61, 0x67, 0x12, 0x2a, 0x0a,
+	0x11, 0x6d, 0x61, 0x6a, 0x6f, 0x72, 0x5f, 0x74, 0x61, 0x67, 0x5f, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x6d, 0x61, 0x6a, 0x6f, 0x72, 0x54,
+	0x61, 0x67, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x12, 0x2c, 0x0a, 0x12, 0x72, 0x65, 0x6c,
+	0x65, 0x61, 0x73, 0x65, 0x5f, 0x6f, 0x66, 0x66, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4f, 0x66,
+	0x66, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x29, 0x0a, 0x10, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0f, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x64, 0x22, 0x5d, 0x0a, 0x0a, 0x47, 0x72, 0x61, 0x64, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x12, 0x39, 0x0a, 0x08, 0x63, 0x72, 0x69, 0x74, 0x65, 0x72,
+	0x69, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x61, 0x67, 0x67, 0x6f,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x72, 0x61, 0x64, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x72,
+	0x69, 0x74, 0x65, 0x72, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x63, 0x72, 0x69, 0x74, 0x65, 0x72, 0x69,
+	0x61, 0x22, 0x53, 0x0a, 0x13, 0x47, 0x72, 0x61, 0x64, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43,
+	0x72, 0x69, 0x74, 0x65, 0x72, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x10, 0x0a, 0x03,
+	0x6d, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x6d, 0x65, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x37, 0x0a, 0x0b, 0x52, 0x65, 0x6d, 0x65, 0x64, 0x69,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x22,
+	0x53, 0x0a, 0x0d, 0x52, 0x75, 0x6c, 0x65, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x12, 0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x72, 0x75, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x22, 0xad, 0x02, 0x0a, 0x0b, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16,
+	0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x3b, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x65,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x67, 0x67, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x74, 0x61, 0x67, 0x67, 0x65, 0x72, 0x12, 0x35, 0x0a, 0x08, 0x74, 0x61, 0x67,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x74, 0x61, 0x67, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x6f, 0x6e, 0x5f, 0x64,
+	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0f, 0x6f, 0x6e, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x42, 0x72,
+	0x61, 0x6e, 0x63, 0x68, 0x22, 0x85, 0x01, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53,
+	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x75,
+	0x74, 0x68, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68,
+	0x6f, 0x72, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x22, 0xe0, 0x01, 0x0a,
+	0x0d, 0x56, 0x75, 0x6c, 0x6e, 0x65, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x07, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x6d, 0x6d,
+	0x61, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61,
+	0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x16,
+	0x0a, 0x06, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x23, 0x0a, 0x0d, 0x66, 0x69, 0x78, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x66, 0x69, 0x78, 0x65, 0x64, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73,
+	0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x22,
+	0xd0, 0x01, 0x0a, 0x07, 0x46, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x29, 0x0a, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x74, 0x61, 0x67, 0x67,
+	0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x4b, 0x69, 0x6e, 0x64,
+	0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x2e, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x12, 0x2e, 0x74, 0x61, 0x67, 0x67, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65,
+	0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79,
+	0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x75, 0x70, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x75, 0x70, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64,
+	0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2a, 0x8e, 0x01, 0x0a, 0x10, 0x4d, 0x6f, 0x64, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x17, 0x4d, 0x4f, 0x44, 0x56, 0x45,
+	0x52, 0x5f, 0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x4e, 0x4f,
+	0x4e, 0x45, 0x10, 0x00, 0x12, 0x21, 0x0a, 0x1d, 0x4d, 0x4f, 0x44, 0x56, 0x45, 0x52, 0x5f, 0x52,
+	0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x50, 0x41, 0x54, 0x43, 0x48,
+	0x4c, 0x45, 0x56, 0x45, 0x4c, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x4d, 0x4f, 0x44, 0x56, 0x45,
+	0x52, 0x5f, 0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x4d, 0x49,
+	0x4e, 0x4f, 0x52, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18, 0x4d, 0x4f, 0x44, 0x56, 0x45, 0x52, 0x5f,
+	0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x4d, 0x41, 0x4a, 0x4f,
+	0x52, 0x10, 0x03, 0x2a, 0xc5, 0x01, 0x0a, 0x13, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x53,
+	0x75, 0x66, 0x66, 0x69, 0x78, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x25, 0x0a, 0x21, 0x56,
+	0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x55, 0x46, 0x46, 0x49, 0x58, 0x5f, 0x53, 0x54,
+	0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x1c, 0x0a, 0x18, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x55,
+	0x46, 0x46, 0x49, 0x58, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x4f, 0x4b, 0x10, 0x01,
+	0x12, 0x22, 0x0a, 0x1e, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x55, 0x46, 0x46,
+	0x49, 0x58, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x4d, 0x49, 0x53, 0x4d, 0x41, 0x54,
+	0x43, 0x48, 0x10, 0x02, 0x12, 0x21, 0x0a, 0x1d, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f,
+	0x53, 0x55, 0x46, 0x46, 0x49, 0x58, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x4d, 0x49,
+	0x53, 0x53, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x22, 0x0a, 0x1e, 0x56, 0x45, 0x52, 0x53, 0x49,
+	0x4f, 0x4e, 0x5f, 0x53, 0x55, 0x46, 0x46, 0x49, 0x58, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53,
+	0x5f, 0x55, 0x4e, 0x57, 0x41, 0x4e, 0x54, 0x45, 0x44, 0x10, 0x04, 0x2a, 0x71, 0x0a, 0x0b, 0x46,
+	0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x18, 0x46, 0x49,
+	0x4e, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x46, 0x49, 0x4e, 0x44,
+	0x49, 0x4e, 0x47, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x49, 0x4e, 0x46, 0x4f, 0x10, 0x01, 0x12,
+	0x13, 0x0a, 0x0f, 0x46, 0x49, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x4b, 0x49, 0x4e, 0x44, 0x5f,
+	0x4f, 0x4b, 0x10, 0x02, 0x12, 0x18, 0x0a, 0x14, 0x46, 0x49, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x5f,
+	0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x57, 0x41, 0x52, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x2a, 0x61,
+	0x0a, 0x08, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x45,
+	0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59,
+	0x5f, 0x49, 0x4e, 0x46, 0x4f, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x45, 0x56, 0x45, 0x52,
+	0x49, 0x54, 0x59, 0x5f, 0x57, 0x41, 0x52, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x12, 0x0a,
+	0x0e, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10,
+	0x03, 0x32, 0x86, 0x01, 0x0a, 0x05, 0x54, 0x61, 0x67, 0x67, 0x6f, 0x12, 0x38, 0x0a, 0x05, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x12, 0x16, 0x2e, 0x74, 0x61, 0x67, 0x67, 0x6f, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x74,
+	0x61, 0x67, 0x67, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x08, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x41, 0x6c,
+	0x6c, 0x12, 0x19, 0x2e, 0x74, 0x61, 0x67, 0x67, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x74,
+	0x61, 0x67, 0x67, 0x6f, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x41, 0x6c, 0x6c,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x42, 0x24, 0x5a, 0x22, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x6f, 0x62, 0x67, 0x2f, 0x74, 0x61,
+	0x67, 0x67, 0x6f, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x74, 0x61, 0x67, 0x67, 0x6f, 0x70, 0x62,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_taggo_proto_rawDescOnce sync.Once
+	file_taggo_proto_rawDescData = file_taggo_proto_rawDesc
+)
+
+func file_taggo_proto_rawDescGZIP() []byte {
+	file_taggo_proto_rawDescOnce.Do(func() {
+		file_taggo_proto_rawDescData = protoimpl.X.CompressGZIP(file_taggo_proto_rawDescData)
+	})
+	return file_taggo_proto_rawDescData
+}
+
+var file_taggo_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_taggo_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_taggo_proto_goTypes = []any{
+	(ModverResultCode)(0),         // 0: taggo.v1.ModverResultCode
+	(VersionSuffixStatus)(0),      // 1: taggo.v1.VersionSuffixStatus
+	(FindingKind)(0),              // 2: taggo.v1.FindingKind
+	(Severity)(0),                 // 3: taggo.v1.Severity
+	(*CheckOptions)(nil),          // 4: taggo.v1.CheckOptions
+	(*CheckRequest)(nil),          // 5: taggo.v1.CheckRequest
+	(*CheckResponse)(nil),         // 6: taggo.v1.CheckResponse
+	(*CheckAllRequest)(nil),       // 7: taggo.v1.CheckAllRequest
+	(*CheckAllResponse)(nil),      // 8: taggo.v1.CheckAllResponse
+	(*Result)(nil),                // 9: taggo.v1.Result
+	(*APIChange)(nil),             // 10: taggo.v1.APIChange
+	(*PhaseError)(nil),            // 11: taggo.v1.PhaseError
+	(*ReadinessCheck)(nil),        // 12: taggo.v1.ReadinessCheck
+	(*MalformedVersionTag)(nil),   // 13: taggo.v1.MalformedVersionTag
+	(*FloatingTag)(nil),           // 14: taggo.v1.FloatingTag
+	(*NearestTag)(nil),            // 15: taggo.v1.NearestTag
+	(*ActionInfo)(nil),            // 16: taggo.v1.ActionInfo
+	(*Graduation)(nil),            // 17: taggo.v1.Graduation
+	(*GraduationCriterion)(nil),   // 18: taggo.v1.GraduationCriterion
+	(*Remediation)(nil),           // 19: taggo.v1.Remediation
+	(*RuleViolation)(nil),         // 20: taggo.v1.RuleViolation
+	(*VersionInfo)(nil),           // 21: taggo.v1.VersionInfo
+	(*CommitSummary)(nil),         // 22: taggo.v1.CommitSummary
+	(*Vulnerability)(nil),         // 23: taggo.v1.Vulnerability
+	(*Finding)(nil),               // 24: taggo.v1.Finding
+	nil,                           // 25: taggo.v1.Result.TagsPerMajorEntry
+	(*timestamppb.Timestamp)(nil), // 26: google.protobuf.Timestamp
+}
+var file_taggo_proto_depIdxs = []int32{
+	4,  // 0: taggo.v1.CheckRequest.options:type_name -> taggo.v1.CheckOptions
+	9,  // 1: taggo.v1.CheckResponse.result:type_name -> taggo.v1.Result
+	4,  // 2: taggo.v1.CheckAllRequest.options:type_name -> taggo.v1.CheckOptions
+	9,  // 3: taggo.v1.CheckAllResponse.result:type_name -> taggo.v1.Result
+	26, // 4: taggo.v1.Result.latest_version_time:type_name -> google.protobuf.Timestamp
+	0,  // 5: taggo.v1.Result.modver_result_code:type_name -> taggo.v1.ModverResultCode
+	10, // 6: taggo.v1.Result.api_changes:type_name -> taggo.v1.APIChange
+	26, // 7: taggo.v1.Result.oldest_unreleased_commit_time:type_name -> google.protobuf.Timestamp
+	11, // 8: taggo.v1.Result.phase_error:type_name -> taggo.v1.PhaseError
+	12, // 9: taggo.v1.Result.readiness:type_name -> taggo.v1.ReadinessCheck
+	19, // 10: taggo.v1.Result.remediations:type_name -> taggo.v1.Remediation
+	20, // 11: taggo.v1.Result.rule_violations:type_name -> taggo.v1.RuleViolation
+	21, // 12: taggo.v1.Result.versions:type_name -> taggo.v1.VersionInfo
+	1,  // 13: taggo.v1.Result.version_suffix:type_name -> taggo.v1.VersionSuffixStatus
+	25, // 14: taggo.v1.Result.tags_per_major:type_name -> taggo.v1.Result.TagsPerMajorEntry
+	26, // 15: taggo.v1.Result.oldest_version_date:type_name -> google.protobuf.Timestamp
+	26, // 16: taggo.v1.Result.newest_version_date:type_name -> google.protobuf.Timestamp
+	26, // 17: taggo.v1.Result.latest_commit_time:type_name -> google.protobuf.Timestamp
+	22, // 18: taggo.v1.Result.unreleased_commit_summaries:type_name -> taggo.v1.CommitSummary
+	23, // 19: taggo.v1.Result.reachable_vulnerabilities:type_name -> taggo.v1.Vulnerability
+	0,  // 20: taggo.v1.Result.policy_bump:type_name -> taggo.v1.ModverResultCode
+	17, // 21: taggo.v1.Result.graduation:type_name -> taggo.v1.Graduation
+	13, // 22: taggo.v1.Result.malformed_version_tags:type_name -> taggo.v1.MalformedVersionTag
+	14, // 23: taggo.v1.Result.stale_floating_tags:type_name -> taggo.v1.FloatingTag
+	16, // 24: taggo.v1.Result.action:type_name -> taggo.v1.ActionInfo
+	15, // 25: taggo.v1.Result.nearest_tag:type_name -> taggo.v1.NearestTag
+	24, // 26: taggo.v1.Result.suppressed_findings:type_name -> taggo.v1.Finding
+	24, // 27: taggo.v1.Result.findings:type_name -> taggo.v1.Finding
+	18, // 28: taggo.v1.Graduation.criteria:type_name -> taggo.v1.GraduationCriterion
+	26, // 29: taggo.v1.VersionInfo.commit_time:type_name -> google.protobuf.Timestamp
+	26, // 30: taggo.v1.VersionInfo.tag_time:type_name -> google.protobuf.Timestamp
+	26, // 31: taggo.v1.CommitSummary.time:type_name -> google.protobuf.Timestamp
+	2,  // 32: taggo.v1.Finding.kind:type_name -> taggo.v1.FindingKind
+	3,  // 33: taggo.v1.Finding.severity:type_name -> taggo.v1.Severity
+	5,  // 34: taggo.v1.Taggo.Check:input_type -> taggo.v1.CheckRequest
+	7,  // 35: taggo.v1.Taggo.CheckAll:input_type -> taggo.v1.CheckAllRequest
+	6,  // 36: taggo.v1.Taggo.Check:output_type -> taggo.v1.CheckResponse
+	8,  // 37: taggo.v1.Taggo.CheckAll:output_type -> taggo.v1.CheckAllResponse
+	36, // [36:38] is the sub-list for method output_type
+	34, // [34:36] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
+}
+
+func init() { file_taggo_proto_init() }
+func file_taggo_proto_init() {
+	if File_taggo_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_taggo_proto_rawDesc,
+			NumEnums:      4,
+			NumMessages:   22,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_taggo_proto_goTypes,
+		DependencyIndexes: file_taggo_proto_depIdxs,
+		EnumInfos:         file_taggo_proto_enumTypes,
+		MessageInfos:      file_taggo_proto_msgTypes,
+	}.Build()
+	File_taggo_proto = out.File
+	file_taggo_proto_rawDesc = nil
+	file_taggo_proto_goTypes = nil
+	file_taggo_proto_depIdxs = nil
+}
@@ -0,0 +1,180 @@
+// Protocol buffer definitions for Taggo's results,
+// and a gRPC service producing them.
+// These mirror the Go types in github.com/bobg/taggo,
+// whose documentation has the details.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: taggo.proto
+
+package taggopb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Taggo_Check_FullMethodName    = "/taggo.v1.Taggo/Check"
+	Taggo_CheckAll_FullMethodName = "/taggo.v1.Taggo/CheckAll"
+)
+
+// TaggoClient is the client API for Taggo service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Taggo checks the Go modules in Git repositories.
+type TaggoClient interface {
+	// Check checks one module.
+	Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error)
+	// CheckAll checks all the modules in a repository,
+	// sending each result as soon as it is produced.
+	// It stops at the first module whose check fails.
+	CheckAll(ctx context.Context, in *CheckAllRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CheckAllResponse], error)
+}
+
+type taggoClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTaggoClient(cc grpc.ClientConnInterface) TaggoClient {
+	return &taggoClient{cc}
+}
+
+func (c *taggoClient) Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckResponse)
+	err := c.cc.Invoke(ctx, Taggo_Check_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taggoClient) CheckAll(ctx context.Context, in *CheckAllRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CheckAllResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Taggo_ServiceDesc.Streams[0], Taggo_CheckAll_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CheckAllRequest, CheckAllResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Taggo_CheckAllClient = grpc.ServerStreamingClient[CheckAllResponse]
+
+// TaggoServer is the server API for Taggo service.
+// All implementations must embed UnimplementedTaggoServer
+// for forward compatibility.
+//
+// Taggo checks the Go modules in Git repositories.
+type TaggoServer interface {
+	// Check checks one module.
+	Check(context.Context, *CheckRequest) (*CheckResponse, error)
+	// CheckAll checks all the modules in a repository,
+	// sending each result as soon as it is produced.
+	// It stops at the first module whose check fails.
+	CheckAll(*CheckAllRequest, grpc.ServerStreamingServer[CheckAllResponse]) error
+	mustEmbedUnimplementedTaggoServer()
+}
+
+// UnimplementedTaggoServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTaggoServer struct{}
+
+func (UnimplementedTaggoServer) Check(context.Context, *CheckRequest) (*CheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Check not implemented")
+}
+func (UnimplementedTaggoServer) CheckAll(*CheckAllRequest, grpc.ServerStreamingServer[CheckAllResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method CheckAll not implemented")
+}
+func (UnimplementedTaggoServer) mustEmbedUnimplementedTaggoServer() {}
+func (UnimplementedTaggoServer) testEmbeddedByValue()               {}
+
+// UnsafeTaggoServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TaggoServer will
+// result in compilation errors.
+type UnsafeTaggoServer interface {
+	mustEmbedUnimplementedTaggoServer()
+}
+
+func RegisterTaggoServer(s grpc.ServiceRegistrar, srv TaggoServer) {
+	// If the following call pancis, it indicates UnimplementedTaggoServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Taggo_ServiceDesc, srv)
+}
+
+func _Taggo_Check_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaggoServer).Check(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Taggo_Check_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaggoServer).Check(ctx, req.(*CheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Taggo_CheckAll_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CheckAllRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TaggoServer).CheckAll(m, &grpc.GenericServerStream[CheckAllRequest, CheckAllResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Taggo_CheckAllServer = grpc.ServerStreamingServer[CheckAllResponse]
+
+// Taggo_ServiceDesc is the grpc.ServiceDesc for Taggo service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Taggo_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "taggo.v1.Taggo",
+	HandlerType: (*TaggoServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Check",
+			Handler:    _Taggo_Check_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CheckAll",
+			Handler:       _Taggo_CheckAll_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "taggo.proto",
+}
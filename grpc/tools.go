@@ -0,0 +1,11 @@
+//go:build tools
+
+// This file pins the versions of the protoc plugins
+// that generate the code in package taggopb
+// (see taggopb/generate.sh).
+package tools
+
+import (
+	_ "google.golang.org/grpc/cmd/protoc-gen-go-grpc"
+	_ "google.golang.org/protobuf/cmd/protoc-gen-go"
+)
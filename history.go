@@ -0,0 +1,90 @@
+package taggo
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/bobg/errors"
+	"golang.org/x/mod/semver"
+
+	"github.com/bobg/taggo/gitutil"
+)
+
+// VersionInfo describes one version tag of a module.
+type VersionInfo struct {
+	// Version is the version, without any VersionPrefix.
+	Version string
+
+	// Commit is the hash of the tagged commit.
+	Commit string
+
+	// CommitTime is the committer time of Commit.
+	CommitTime time.Time
+
+	// Annotated is true if the tag is annotated rather than lightweight.
+	Annotated bool
+
+	// Tagger and TagTime are the name and email address of the tagger, and the time of tagging.
+	// Valid only when Annotated is true.
+	Tagger  string
+	TagTime time.Time
+
+	// Signed is true if the tag has a signature.
+	// (The signature is not verified.)
+	Signed bool
+
+	// OnDefaultBranch is true if Commit is reachable from the default branch.
+	// Valid only when Result.DefaultBranch is not empty.
+	OnDefaultBranch bool
+}
+
+// versionHistory returns information about the given version tags,
+// in ascending semantic-version order.
+// The versions map is from version (without prefix) to commit hash.
+func versionHistory(ctx context.Context, git, repodir, versionPrefix, defaultBranch string, versions map[string]string) ([]VersionInfo, error) {
+	details, err := gitutil.TagDetails(ctx, git, repodir)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting tag details")
+	}
+
+	var merged map[string]bool
+	if defaultBranch != "" {
+		merged, err = gitutil.TagsMerged(ctx, git, repodir, defaultBranch)
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting tags merged into %s", defaultBranch)
+		}
+	}
+
+	var result []VersionInfo
+	for version, commit := range versions {
+		var (
+			tag    = versionPrefix + version
+			detail = details[tag]
+		)
+		info := VersionInfo{
+			Version:         version,
+			Commit:          commit,
+			Annotated:       detail.Annotated,
+			Tagger:          detail.Tagger,
+			Signed:          detail.Signed,
+			OnDefaultBranch: merged[tag],
+		}
+		if detail.CommitTime != "" {
+			if info.CommitTime, err = time.Parse(time.RFC3339, detail.CommitTime); err != nil {
+				return nil, errors.Wrapf(err, "parsing commit time of %s", tag)
+			}
+		}
+		if detail.TagTime != "" {
+			if info.TagTime, err = time.Parse(time.RFC3339, detail.TagTime); err != nil {
+				return nil, errors.Wrapf(err, "parsing tag time of %s", tag)
+			}
+		}
+		result = append(result, info)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return semver.Compare(result[i].Version, result[j].Version) < 0
+	})
+	return result, nil
+}
@@ -0,0 +1,27 @@
+// Package testutil holds helpers shared by Taggo's tests.
+package testutil
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// Git runs git with args in dir,
+// with a fixed identity for any commits and tags it makes,
+// failing the test if it fails.
+// It returns the command's standard output with surrounding whitespace trimmed.
+func Git(t testing.TB, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", append([]string{"-c", "user.name=Taggo", "-c", "user.email=taggo@example.com"}, args...)...)
+	cmd.Dir = dir
+	stderr := new(bytes.Buffer)
+	cmd.Stderr = stderr
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("running git %s: %s\n%s", strings.Join(args, " "), err, stderr)
+	}
+	return strings.TrimSpace(string(output))
+}
@@ -0,0 +1,285 @@
+package taggo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo/gitutil"
+)
+
+// DefaultLockRef is the ref that [AcquireLock] uses by default.
+const DefaultLockRef = "refs/taggo/lock"
+
+// defaultLockInterval is the default for LockOptions.Interval.
+const defaultLockInterval = 5 * time.Second
+
+// LockOptions control [AcquireLock].
+type LockOptions struct {
+	// Remote is the remote repository in which to take the lock,
+	// so that it excludes jobs working in other clones,
+	// such as concurrent CI jobs.
+	// If it is empty,
+	// the lock is taken in the local repository only.
+	Remote string
+
+	// Ref is the ref that holds the lock.
+	// The default is [DefaultLockRef].
+	Ref string
+
+	// Wait is how long to keep trying while someone else holds the lock.
+	// Zero means try only once.
+	Wait time.Duration
+
+	// Interval is the delay between attempts.
+	// The default is 5 seconds.
+	Interval time.Duration
+
+	// Stale is how long a lock may be held
+	// before it is presumed abandoned,
+	// as by a job that crashed,
+	// and is broken.
+	// Zero means never.
+	Stale time.Duration
+
+	// Holder describes the one taking the lock,
+	// for reporting to anyone else who wants it.
+	// The default is the host name and process ID.
+	Holder string
+}
+
+// Lock is a lock acquired with [AcquireLock].
+type Lock struct {
+	git, repodir, remote, ref string
+
+	// obj is the hash of the blob describing this lock's holder,
+	// which the lock ref points to while the lock is held.
+	obj string
+}
+
+// LockedError is the error returned by [AcquireLock]
+// when someone else holds the lock.
+type LockedError struct {
+	// Ref is the ref that holds the lock.
+	Ref string
+
+	// Holder describes the one holding the lock,
+	// from [LockOptions.Holder].
+	Holder string
+
+	// Since is when the lock was taken.
+	Since time.Time
+}
+
+func (e *LockedError) Error() string {
+	if e.Holder == "" {
+		return fmt.Sprintf("%s is locked", e.Ref)
+	}
+	return fmt.Sprintf("%s is locked by %s since %s", e.Ref, e.Holder, e.Since.Format(time.RFC3339))
+}
+
+// AcquireLock takes a lock for creating version tags
+// in the repository in repodir,
+// so that concurrent jobs,
+// such as CI jobs for a merge queue,
+// do not race to create conflicting tags.
+// The lock is a ref,
+// which AcquireLock creates (in opts.Remote, if set) only if it does not exist,
+// in a single atomic check-and-set.
+// Release the lock with [Lock.Release].
+//
+// If someone else holds the lock,
+// AcquireLock tries again every opts.Interval for up to opts.Wait,
+// breaking the lock if it has been held longer than opts.Stale.
+// If it cannot get the lock,
+// the error is a [*LockedError].
+// If git is "", AcquireLock uses the result of exec.LookPath("git").
+func AcquireLock(ctx context.Context, git, repodir string, opts LockOptions) (*Lock, error) {
+	if git == "" {
+		var err error
+		git, err = exec.LookPath("git")
+		if err != nil {
+			return nil, errors.Wrap(err, "finding git binary")
+		}
+	}
+	if opts.Ref == "" {
+		opts.Ref = DefaultLockRef
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = defaultLockInterval
+	}
+	if opts.Holder == "" {
+		host, _ := os.Hostname()
+		opts.Holder = fmt.Sprintf("%s (pid %d)", host, os.Getpid())
+	}
+
+	l := &Lock{git: git, repodir: repodir, remote: opts.Remote, ref: opts.Ref}
+
+	info := fmt.Sprintf("holder %s\ntime %s\n", opts.Holder, time.Now().UTC().Format(time.RFC3339Nano))
+	cmd, done := gitutil.Command(ctx, git, repodir, "hash-object", "-w", "--stdin")
+	cmd.Stdin = strings.NewReader(info)
+	output, err := cmd.Output()
+	if err = done(err); err != nil {
+		return nil, errors.Wrapf(err, "running %s", cmd)
+	}
+	l.obj = strings.TrimSpace(string(output))
+
+	deadline := time.Now().Add(opts.Wait)
+
+	for {
+		ok, err := l.swap(ctx, "", l.obj)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return l, nil
+		}
+
+		current, err := l.current(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading %s", l.ref)
+		}
+		if current == "" {
+			continue // released in the meantime
+		}
+		lockedErr, err := l.describe(ctx, current)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading %s", l.ref)
+		}
+
+		if opts.Stale > 0 && !lockedErr.Since.IsZero() && time.Since(lockedErr.Since) > opts.Stale {
+			ok, err := l.swap(ctx, current, l.obj)
+			if err != nil {
+				return nil, errors.Wrapf(err, "breaking stale lock %s", l.ref)
+			}
+			if ok {
+				return l, nil
+			}
+			continue
+		}
+
+		if !time.Now().Add(opts.Interval).Before(deadline) {
+			return nil, lockedErr
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(opts.Interval):
+		}
+	}
+}
+
+// Release releases l,
+// unless someone else has broken it in the meantime,
+// which is an error.
+func (l *Lock) Release(ctx context.Context) error {
+	ok, err := l.swap(ctx, l.obj, "")
+	if err != nil {
+		return errors.Wrapf(err, "releasing %s", l.ref)
+	}
+	if !ok {
+		return fmt.Errorf("lock %s was broken by someone else", l.ref)
+	}
+	return nil
+}
+
+// swap sets the lock ref to newObj ("" to delete it)
+// if its value is oldObj ("" if it must not exist),
+// atomically.
+// It returns false if the ref had some other value.
+func (l *Lock) swap(ctx context.Context, oldObj, newObj string) (bool, error) {
+	var args []string
+	if l.remote == "" {
+		if newObj == "" {
+			args = []string{"update-ref", "-d", l.ref, oldObj}
+		} else {
+			args = []string{"update-ref", l.ref, newObj, oldObj}
+		}
+	} else {
+		args = []string{"push", "--quiet", "--no-verify", fmt.Sprintf("--force-with-lease=%s:%s", l.ref, oldObj), l.remote, newObj + ":" + l.ref}
+	}
+
+	cmd, done := gitutil.Command(ctx, l.git, l.repodir, args...)
+	output, err := cmd.CombinedOutput()
+	if err = done(err); err == nil {
+		return true, nil
+	}
+
+	// Tell a lost race from a failure to run the command at all.
+	current, curErr := l.current(ctx)
+	if curErr != nil || current == oldObj {
+		return false, errors.Wrapf(err, "running %s: %s", cmd, strings.TrimSpace(string(output)))
+	}
+	return false, nil
+}
+
+// current returns the value of the lock ref,
+// or "" if it does not exist.
+func (l *Lock) current(ctx context.Context) (string, error) {
+	if l.remote == "" {
+		cmd, done := gitutil.Command(ctx, l.git, l.repodir, "rev-parse", "--verify", "--quiet", l.ref)
+		output, err := cmd.Output()
+		err = done(err)
+
+		var ee *exec.ExitError
+		if errors.As(err, &ee) && ee.ExitCode() == 1 {
+			return "", nil
+		}
+		if err != nil {
+			return "", errors.Wrapf(err, "running %s", cmd)
+		}
+		return strings.TrimSpace(string(output)), nil
+	}
+
+	cmd, done := gitutil.Command(ctx, l.git, l.repodir, "ls-remote", l.remote, l.ref)
+	output, err := cmd.Output()
+	if err = done(err); err != nil {
+		return "", errors.Wrapf(err, "running %s", cmd)
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		if fields := strings.Fields(line); len(fields) == 2 && fields[1] == l.ref {
+			return fields[0], nil
+		}
+	}
+	return "", nil
+}
+
+// describe returns the error reporting that the lock is held
+// with the blob obj describing its holder,
+// fetching it from the remote if necessary.
+// A lock whose holder cannot be parsed has a zero Since time
+// and is never presumed stale.
+func (l *Lock) describe(ctx context.Context, obj string) (*LockedError, error) {
+	lockedErr := &LockedError{Ref: l.ref}
+
+	cmd, done := gitutil.Command(ctx, l.git, l.repodir, "cat-file", "-e", obj)
+	if done(cmd.Run()) != nil && l.remote != "" {
+		cmd, done = gitutil.Command(ctx, l.git, l.repodir, "fetch", "--quiet", "--no-tags", l.remote, l.ref)
+		output, err := cmd.CombinedOutput()
+		if err = done(err); err != nil {
+			return nil, errors.Wrapf(err, "running %s: %s", cmd, strings.TrimSpace(string(output)))
+		}
+	}
+
+	cmd, done = gitutil.Command(ctx, l.git, l.repodir, "cat-file", "blob", obj)
+	output, err := cmd.Output()
+	if done(err) != nil {
+		return lockedErr, nil
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		key, val, _ := strings.Cut(line, " ")
+		switch key {
+		case "holder":
+			lockedErr.Holder = val
+		case "time":
+			lockedErr.Since, _ = time.Parse(time.RFC3339Nano, val)
+		}
+	}
+	return lockedErr, nil
+}
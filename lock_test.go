@@ -0,0 +1,88 @@
+package taggo_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/internal/testutil"
+)
+
+func TestLock(t *testing.T) {
+	root := t.TempDir()
+	origin := filepath.Join(root, "origin")
+	clone1 := filepath.Join(root, "clone1")
+	clone2 := filepath.Join(root, "clone2")
+
+	testutil.Git(t, root, "init", "-q", "--bare", origin)
+	testutil.Git(t, root, "clone", "-q", origin, clone1)
+	testutil.Git(t, root, "clone", "-q", origin, clone2)
+
+	ctx := context.Background()
+
+	for _, remote := range []string{"origin", ""} {
+		name := remote
+		if name == "" {
+			name = "local"
+		}
+		t.Run(name, func(t *testing.T) {
+			other := clone2
+			if remote == "" {
+				other = clone1 // a local lock excludes only jobs in the same clone
+			}
+
+			l1, err := taggo.AcquireLock(ctx, "", clone1, taggo.LockOptions{Remote: remote, Holder: "job 1"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = taggo.AcquireLock(ctx, "", other, taggo.LockOptions{Remote: remote, Holder: "job 2"})
+			var lockedErr *taggo.LockedError
+			if !errors.As(err, &lockedErr) {
+				t.Fatalf("got error %v, want a LockedError", err)
+			}
+			if lockedErr.Holder != "job 1" {
+				t.Errorf("got holder %q, want job 1", lockedErr.Holder)
+			}
+			if time.Since(lockedErr.Since) > time.Minute {
+				t.Errorf("got lock time %s, want about now", lockedErr.Since)
+			}
+
+			// Waiting for the lock.
+			go func() {
+				time.Sleep(100 * time.Millisecond)
+				if err := l1.Release(ctx); err != nil {
+					t.Error(err)
+				}
+			}()
+			l2, err := taggo.AcquireLock(ctx, "", other, taggo.LockOptions{Remote: remote, Holder: "job 2", Wait: 5 * time.Second, Interval: 50 * time.Millisecond})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Breaking a stale lock.
+			time.Sleep(10 * time.Millisecond)
+			l3, err := taggo.AcquireLock(ctx, "", clone1, taggo.LockOptions{Remote: remote, Holder: "job 3", Stale: time.Millisecond})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := l2.Release(ctx); err == nil {
+				t.Error("released a broken lock")
+			}
+			if err := l3.Release(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			l4, err := taggo.AcquireLock(ctx, "", other, taggo.LockOptions{Remote: remote})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := l4.Release(ctx); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
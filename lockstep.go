@@ -0,0 +1,132 @@
+package taggo
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"golang.org/x/mod/semver"
+)
+
+// Possible values for Config.Policy.
+const (
+	PolicyIndependent = "independent"
+	PolicyLockstep    = "lockstep"
+)
+
+// LockstepPlan is the result of [PlanLockstep].
+type LockstepPlan struct {
+	// CurrentVersion is the highest latest version of any module,
+	// or "" if no module has a version tag.
+	CurrentVersion string
+
+	// Inconsistent lists the module directories whose latest version is not CurrentVersion,
+	// in sorted order.
+	Inconsistent []string
+
+	// Changed lists the module directories that need a new release,
+	// in sorted order.
+	Changed []string
+
+	// NewVersion is the version with which every module should be tagged,
+	// or "" if no module needs a new release.
+	NewVersion string
+}
+
+// PlanLockstep computes a release plan for a repository
+// whose modules are all released together with the same version number.
+// The results argument is as returned by [CheckAll].
+//
+// The new version bumps CurrentVersion by the largest bump that any single module requires.
+// A module with no version tags yet requires a minor-version bump.
+func PlanLockstep(results map[string]Result) LockstepPlan {
+	var plan LockstepPlan
+
+	for _, r := range results {
+		if r.LatestVersion != "" && (plan.CurrentVersion == "" || semver.Compare(r.LatestVersion, plan.CurrentVersion) > 0) {
+			plan.CurrentVersion = r.LatestVersion
+		}
+	}
+
+	bump := bumpNone
+	for dir, r := range results {
+		if r.LatestVersion != plan.CurrentVersion {
+			plan.Inconsistent = append(plan.Inconsistent, dir)
+		}
+
+		b := r.bump()
+		if b == bumpNone {
+			continue
+		}
+		plan.Changed = append(plan.Changed, dir)
+		if b > bump {
+			bump = b
+		}
+	}
+	sort.Strings(plan.Inconsistent)
+	sort.Strings(plan.Changed)
+
+	if bump == bumpNone {
+		return plan
+	}
+	if plan.CurrentVersion == "" {
+		plan.NewVersion = "v0.1.0"
+		return plan
+	}
+
+	major, minor, patch := splitVersion(plan.CurrentVersion)
+	switch bump {
+	case bumpMajor:
+		major, minor, patch = major+1, 0, 0
+	case bumpMinor:
+		minor, patch = minor+1, 0
+	case bumpPatch:
+		if semver.Prerelease(plan.CurrentVersion) == "" {
+			patch++
+		}
+	}
+	plan.NewVersion = fmt.Sprintf("v%d.%d.%d", major, minor, patch)
+	return plan
+}
+
+type bumpLevel int
+
+const (
+	bumpNone bumpLevel = iota
+	bumpPatch
+	bumpMinor
+	bumpMajor
+)
+
+// bump is the size of the version bump recommended for r.
+func (r Result) bump() bumpLevel {
+	if r.LatestVersion == "" {
+		if r.DefaultBranch == "" {
+			return bumpNone
+		}
+		return bumpMinor
+	}
+	if _, ok := r.recommendedTag(); !ok {
+		return bumpNone
+	}
+	switch {
+	case r.NewMajor > r.LatestMajor:
+		return bumpMajor
+	case r.NewMinor > r.LatestMinor:
+		return bumpMinor
+	default:
+		return bumpPatch
+	}
+}
+
+// splitVersion returns the major, minor, and patch numbers of a valid semantic version.
+func splitVersion(v string) (major, minor, patch int) {
+	m := versionRegex.FindStringSubmatch(v)
+	if len(m) == 0 {
+		return 0, 0, 0
+	}
+	major, _ = strconv.Atoi(m[1])
+	minor, _ = strconv.Atoi(m[2])
+	patch, _ = strconv.Atoi(m[3])
+	return major, minor, patch
+}
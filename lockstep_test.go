@@ -0,0 +1,166 @@
+package taggo_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/bobg/modver/v2"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/internal/testutil"
+)
+
+func TestPlanLockstep(t *testing.T) {
+	released := func(v string, major, minor, patch int) taggo.Result {
+		return taggo.Result{
+			DefaultBranch:             "main",
+			LatestVersion:             v,
+			LatestCommitHasVersionTag: true,
+			LatestMajor:               major,
+			LatestMinor:               minor,
+			LatestPatch:               patch,
+		}
+	}
+	changed := func(v string, code modver.ResultCode, newMajor, newMinor, newPatch int) taggo.Result {
+		r := released(v, 1, 2, 3)
+		r.LatestCommitHasVersionTag = false
+		r.ModverResultCode = code
+		r.NewMajor, r.NewMinor, r.NewPatch = newMajor, newMinor, newPatch
+		return r
+	}
+
+	cases := []struct {
+		name    string
+		results map[string]taggo.Result
+		want    taggo.LockstepPlan
+	}{{
+		name: "unchanged",
+		results: map[string]taggo.Result{
+			".":   released("v1.2.3", 1, 2, 3),
+			"sub": released("v1.2.3", 1, 2, 3),
+		},
+		want: taggo.LockstepPlan{CurrentVersion: "v1.2.3"},
+	}, {
+		name: "max-bump",
+		results: map[string]taggo.Result{
+			".":   changed("v1.2.3", modver.Patchlevel, 1, 2, 4),
+			"sub": changed("v1.2.3", modver.Minor, 1, 3, 0),
+			"etc": released("v1.2.3", 1, 2, 3),
+		},
+		want: taggo.LockstepPlan{
+			CurrentVersion: "v1.2.3",
+			Changed:        []string{".", "sub"},
+			NewVersion:     "v1.3.0",
+		},
+	}, {
+		name: "inconsistent",
+		results: map[string]taggo.Result{
+			".":   changed("v1.2.3", modver.Patchlevel, 1, 2, 4),
+			"sub": released("v1.1.0", 1, 1, 0),
+		},
+		want: taggo.LockstepPlan{
+			CurrentVersion: "v1.2.3",
+			Inconsistent:   []string{"sub"},
+			Changed:        []string{"."},
+			NewVersion:     "v1.2.4",
+		},
+	}, {
+		name: "new-module",
+		results: map[string]taggo.Result{
+			".":   released("v1.2.3", 1, 2, 3),
+			"sub": {DefaultBranch: "main"},
+		},
+		want: taggo.LockstepPlan{
+			CurrentVersion: "v1.2.3",
+			Inconsistent:   []string{"sub"},
+			Changed:        []string{"sub"},
+			NewVersion:     "v1.3.0",
+		},
+	}, {
+		name: "untagged",
+		results: map[string]taggo.Result{
+			".":   {DefaultBranch: "main"},
+			"sub": {DefaultBranch: "main"},
+		},
+		want: taggo.LockstepPlan{
+			Changed:    []string{".", "sub"},
+			NewVersion: "v0.1.0",
+		},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := taggo.PlanLockstep(tc.results)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInferPolicy(t *testing.T) {
+	cases := []struct {
+		name string
+		tags []string
+		want taggo.PolicyInference
+	}{{
+		name: "one-module",
+		tags: []string{"v1.0.0"},
+		want: taggo.PolicyInference{},
+	}, {
+		name: "lockstep",
+		tags: []string{"v1.0.0", "a/v1.0.0", "v1.1.0", "a/v1.1.0", "b/v1.1.0"},
+		want: taggo.PolicyInference{
+			Policy:   taggo.PolicyLockstep,
+			Lockstep: []string{".", "a", "b"},
+		},
+	}, {
+		name: "independent",
+		tags: []string{"v1.0.0", "a/v1.0.0", "v1.1.0", "a/v1.0.1", "b/v0.3.0"},
+		want: taggo.PolicyInference{
+			Policy:      taggo.PolicyIndependent,
+			Independent: []string{".", "a", "b"},
+		},
+	}, {
+		name: "mixed",
+		tags: []string{"v1.0.0", "a/v1.0.0", "v1.1.0", "a/v1.1.0", "b/v0.3.0"},
+		want: taggo.PolicyInference{
+			Policy:      taggo.PolicyIndependent,
+			Lockstep:    []string{".", "a"},
+			Independent: []string{"b"},
+		},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repodir := t.TempDir()
+
+			testutil.Git(t, repodir, "init", "-q")
+			testutil.Git(t, repodir, "commit", "-q", "--allow-empty", "-m", "initial")
+			for _, tag := range tc.tags {
+				testutil.Git(t, repodir, "tag", tag)
+			}
+
+			// Module dirs are as in the keys of the map returned by CheckAll.
+			join := func(dirs []string) []string {
+				var result []string
+				for _, dir := range dirs {
+					result = append(result, filepath.Join(repodir, dir))
+				}
+				return result
+			}
+
+			got, err := taggo.InferPolicy(context.Background(), "", repodir, join([]string{".", "a", "b"}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := tc.want
+			want.Lockstep, want.Independent = join(want.Lockstep), join(want.Independent)
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package taggo
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// MalformedVersionTag is a tag that looks like a version
+// but is not a valid Go module version tag,
+// such as "1.2.3" or "release-1.2.3",
+// and so is invisible to the Go toolchain.
+// See Result.MalformedVersionTags.
+type MalformedVersionTag struct {
+	// Tag is the full tag name.
+	Tag string
+
+	// Version is the version that Tag appears to mean,
+	// in canonical form, as in "v1.2.3".
+	// The properly formatted tag is Result.VersionPrefix + Version.
+	Version string
+
+	// Commit is the hash of the tagged commit.
+	Commit string
+}
+
+// malformedVersionRegex matches a tag name (after any version prefix)
+// that looks like a version but may not be a valid semantic version,
+// such as "1.2.3", "V1.2", or "release-1.2.3-rc.1".
+var malformedVersionRegex = regexp.MustCompile(`^(?:[A-Za-z]+[-_]?)?([0-9]+)\.([0-9]+)(?:\.([0-9]+))?([-+][0-9A-Za-z.+-]*)?$`)
+
+// malformedVersion returns the canonical semantic version
+// that name (a tag name after any version prefix) appears to mean,
+// if it is not already a valid semantic version.
+func malformedVersion(name string) (string, bool) {
+	if semver.IsValid(name) {
+		return "", false
+	}
+	m := malformedVersionRegex.FindStringSubmatch(name)
+	if m == nil {
+		return "", false
+	}
+	patch := m[3]
+	if patch == "" {
+		patch = "0"
+	}
+	v := "v" + m[1] + "." + m[2] + "." + patch + m[4]
+	if !semver.IsValid(v) {
+		return "", false
+	}
+	return semver.Canonical(v), true
+}
+
+// malformedVersionTags finds the tags whose names,
+// after versionPrefix,
+// look like versions but are not valid semantic versions.
+// The tags map is from tag name to commit hash,
+// and versions is from each valid version (after versionPrefix) to commit hash.
+// A tag is left out if its version is already properly tagged,
+// or if another malformed tag earlier in sorted order means the same version.
+// The result is in ascending semantic-version order.
+func malformedVersionTags(tags map[string]string, versionPrefix string, versions map[string]string) []MalformedVersionTag {
+	var names []string
+	for name := range tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	seen := make(map[string]bool) // canonical versions
+	for v := range versions {
+		seen[semver.Canonical(v)] = true
+	}
+
+	var result []MalformedVersionTag
+	for _, name := range names {
+		if !strings.HasPrefix(name, versionPrefix) {
+			continue
+		}
+		v, ok := malformedVersion(strings.TrimPrefix(name, versionPrefix))
+		if !ok || seen[v] {
+			continue
+		}
+		seen[v] = true
+		result = append(result, MalformedVersionTag{Tag: name, Version: v, Commit: tags[name]})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return semver.Compare(result[i].Version, result[j].Version) < 0
+	})
+	return result
+}
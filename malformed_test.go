@@ -0,0 +1,58 @@
+package taggo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/internal/testutil"
+)
+
+func TestMalformedVersionTags(t *testing.T) {
+	repodir := cloneBundle(t, "unstable") // latest version v0.1.2
+
+	head := testutil.Git(t, repodir, "rev-parse", "HEAD")
+	for _, tag := range []string{
+		"0.1.2",         // already properly tagged
+		"release-0.2.0", // the same as 0.2.0, which comes first
+		"0.2.0",
+		"V0.3",
+		"sub/1.0.0", // belongs to another module
+		"2024",      // not a version
+		"nightly",
+	} {
+		testutil.Git(t, repodir, "tag", tag)
+	}
+
+	result, err := taggo.Check(context.Background(), "", repodir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []taggo.MalformedVersionTag{
+		{Tag: "0.2.0", Version: "v0.2.0", Commit: head},
+		{Tag: "V0.3", Version: "v0.3.0", Commit: head},
+	}
+	if diff := cmp.Diff(want, result.MalformedVersionTags); diff != "" {
+		t.Errorf("malformed tags mismatch (-want +got):\n%s", diff)
+	}
+
+	var found bool
+	for _, f := range result.Findings() {
+		if f.ID != "malformed-version-tags" {
+			continue
+		}
+		found = true
+		if want := "Tag(s) 0.2.0, V0.3 look like versions but are invisible to the Go toolchain; tag the same commits as v0.2.0, v0.3.0"; f.Message != want {
+			t.Errorf("got message %q, want %q", f.Message, want)
+		}
+		if want := "git tag -a -m 'Version v0.2.0' v0.2.0 " + head + " && git tag -a -m 'Version v0.3.0' v0.3.0 " + head + " && git push origin v0.2.0 v0.3.0"; f.Remediation != want {
+			t.Errorf("got remediation %q, want %q", f.Remediation, want)
+		}
+	}
+	if !found {
+		t.Error("no malformed-version-tags finding")
+	}
+}
@@ -0,0 +1,163 @@
+package taggo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bobg/errors"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+
+	"github.com/bobg/taggo/gitutil"
+)
+
+// PrefixMigration is a historical version tag of a module
+// to be duplicated with the module's version prefix.
+// See [PlanPrefixMigration].
+type PrefixMigration struct {
+	// Tag is the existing tag, such as v1.2.3.
+	Tag string
+
+	// NewTag is the tag to create, such as sub/v1.2.3.
+	NewTag string
+
+	// Commit is the hash of the commit that Tag refers to,
+	// where NewTag will point.
+	Commit string
+
+	// Skip, if not empty, says why NewTag should not be created.
+	Skip string
+}
+
+// PlanPrefixMigration plans new version tags for the module in moduledir
+// (in the repository in repodir),
+// for a module that was moved into moduledir
+// after being tagged with fromPrefix
+// ("" for the repository root, or another directory such as "old/").
+// Its historical tags lack the version prefix moduledir/,
+// so the Go toolchain cannot resolve its earlier versions by its current location.
+//
+// For each version tag with fromPrefix,
+// or only those whose versions (without fromPrefix) are listed in versions, if it is not empty,
+// the plan has a tag with the prefix moduledir/ pointing to the same commit.
+// That tag is skipped, with a reason,
+// if it already exists,
+// or if the commit has no go.mod in moduledir declaring the module's path,
+// since the Go toolchain could not then resolve the version there either.
+// The plan is in ascending semantic-version order.
+func PlanPrefixMigration(ctx context.Context, git, repodir, moduledir, fromPrefix string, versions []string) ([]PrefixMigration, error) {
+	if git == "" {
+		var err error
+		git, err = exec.LookPath("git")
+		if err != nil {
+			return nil, errors.Wrap(err, "finding git binary")
+		}
+	}
+
+	moduledir, err := moduleSubdir(repodir, moduledir)
+	if err != nil {
+		return nil, err
+	}
+	if moduledir == "" {
+		return nil, fmt.Errorf("module must be in a subdirectory of the repository")
+	}
+	moduledir = filepath.ToSlash(moduledir)
+	if fromPrefix = strings.Trim(fromPrefix, "/"); fromPrefix != "" {
+		fromPrefix += "/"
+	}
+	newPrefix := moduledir + "/"
+	if fromPrefix == newPrefix {
+		return nil, fmt.Errorf("module is already tagged with prefix %s", newPrefix)
+	}
+
+	gomodPath := filepath.Join(repodir, filepath.FromSlash(moduledir), "go.mod")
+	gomodBytes, err := os.ReadFile(gomodPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %s", gomodPath)
+	}
+	modpath := modfile.ModulePath(gomodBytes)
+	if modpath == "" {
+		return nil, fmt.Errorf("%s has no module directive", gomodPath)
+	}
+	basePath, _, _ := module.SplitPathVersion(modpath)
+
+	tags := make(map[string]string) // tag -> commit
+	err = gitutil.Refs(ctx, git, repodir, func(name, _ string) error {
+		if !strings.HasPrefix(name, "refs/tags/") {
+			return nil
+		}
+		name = strings.TrimPrefix(name, "refs/tags/")
+		commit, err := gitutil.TagCommit(ctx, git, repodir, name)
+		if err != nil {
+			return errors.Wrapf(err, "resolving commit for tag %s", name)
+		}
+		tags[name] = commit
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting tags")
+	}
+
+	selected := make(map[string]bool)
+	for _, v := range versions {
+		if _, ok := tags[fromPrefix+v]; !ok {
+			return nil, fmt.Errorf("no tag %s%s", fromPrefix, v)
+		}
+		selected[v] = true
+	}
+
+	var plan []PrefixMigration
+	for tag, commit := range tags {
+		if !strings.HasPrefix(tag, fromPrefix) {
+			continue
+		}
+		v := strings.TrimPrefix(tag, fromPrefix)
+		if !semver.IsValid(v) || IsFloatingVersion(v) || (len(selected) > 0 && !selected[v]) {
+			continue
+		}
+
+		m := PrefixMigration{Tag: tag, NewTag: newPrefix + v, Commit: commit}
+		switch existing, ok := tags[m.NewTag]; {
+		case ok && existing == commit:
+			m.Skip = "already exists"
+		case ok:
+			m.Skip = fmt.Sprintf("already exists at commit %s", existing)
+		default:
+			m.Skip = migratedModuleProblem(ctx, git, repodir, moduledir, commit, basePath, v)
+		}
+		plan = append(plan, m)
+	}
+
+	sort.Slice(plan, func(i, j int) bool {
+		return semver.Compare(strings.TrimPrefix(plan[i].Tag, fromPrefix), strings.TrimPrefix(plan[j].Tag, fromPrefix)) < 0
+	})
+	return plan, nil
+}
+
+// migratedModuleProblem tells why the Go toolchain could not resolve version
+// of the module with basePath (without any major-version suffix)
+// in moduledir at commit,
+// or returns "" if it could.
+func migratedModuleProblem(ctx context.Context, git, repodir, moduledir, commit, basePath, version string) string {
+	gomodPath := path.Join(moduledir, "go.mod")
+	gomodBytes, err := gitutil.Show(ctx, git, repodir, commit, gomodPath)
+	if err != nil {
+		return fmt.Sprintf("no %s at that commit", gomodPath)
+	}
+	modpath := modfile.ModulePath(gomodBytes)
+	prefix, pathMajor, ok := module.SplitPathVersion(modpath)
+	if !ok || prefix != basePath {
+		return fmt.Sprintf("%s at that commit declares module %s", gomodPath, modpath)
+	}
+	if err := module.CheckPathMajor(version, pathMajor); err != nil {
+		return fmt.Sprintf("%s at that commit declares module %s, which does not match the version", gomodPath, modpath)
+	}
+	return ""
+}
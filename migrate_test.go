@@ -0,0 +1,92 @@
+package taggo_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/internal/testutil"
+)
+
+func TestPlanPrefixMigration(t *testing.T) {
+	repodir := t.TempDir()
+
+	write := func(name, contents string) {
+		t.Helper()
+		filename := filepath.Join(repodir, name)
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testutil.Git(t, repodir, "init", "-q", "-b", "main")
+
+	// At first the module is at the repository root.
+	write("go.mod", "module example.com/x\n")
+	testutil.Git(t, repodir, "add", ".")
+	testutil.Git(t, repodir, "commit", "-q", "-m", "first")
+	testutil.Git(t, repodir, "tag", "v0.1.0")
+
+	// Then it is in sub, but still tagged without a prefix.
+	testutil.Git(t, repodir, "rm", "-q", "go.mod")
+	write("sub/go.mod", "module example.com/x/sub\n")
+	testutil.Git(t, repodir, "add", ".")
+	testutil.Git(t, repodir, "commit", "-q", "-m", "second")
+	second := testutil.Git(t, repodir, "rev-parse", "HEAD")
+	testutil.Git(t, repodir, "tag", "v0.2.0")
+
+	testutil.Git(t, repodir, "commit", "-q", "--allow-empty", "-m", "third")
+	third := testutil.Git(t, repodir, "rev-parse", "HEAD")
+	testutil.Git(t, repodir, "tag", "v0.3.0")
+	testutil.Git(t, repodir, "tag", "sub/v0.3.0")
+
+	var (
+		ctx       = context.Background()
+		moduledir = filepath.Join(repodir, "sub")
+	)
+
+	plan, err := taggo.PlanPrefixMigration(ctx, "", repodir, moduledir, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []taggo.PrefixMigration{{
+		Tag:    "v0.1.0",
+		NewTag: "sub/v0.1.0",
+		Commit: testutil.Git(t, repodir, "rev-parse", "v0.1.0"),
+		Skip:   "no sub/go.mod at that commit",
+	}, {
+		Tag:    "v0.2.0",
+		NewTag: "sub/v0.2.0",
+		Commit: second,
+	}, {
+		Tag:    "v0.3.0",
+		NewTag: "sub/v0.3.0",
+		Commit: third,
+		Skip:   "already exists",
+	}}
+	if diff := cmp.Diff(want, plan); diff != "" {
+		t.Errorf("plan mismatch (-want +got):\n%s", diff)
+	}
+
+	plan, err = taggo.PlanPrefixMigration(ctx, "", repodir, moduledir, "", []string{"v0.2.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want[1:2], plan); diff != "" {
+		t.Errorf("plan for v0.2.0 mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := taggo.PlanPrefixMigration(ctx, "", repodir, moduledir, "", []string{"v9.0.0"}); err == nil {
+		t.Error("got no error for a nonexistent version")
+	}
+	if _, err := taggo.PlanPrefixMigration(ctx, "", repodir, moduledir, "sub", nil); err == nil {
+		t.Error("got no error migrating from the module's own prefix")
+	}
+}
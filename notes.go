@@ -0,0 +1,266 @@
+package taggo
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/bobg/errors"
+)
+
+// The titles of the default groups of [ReleaseNotes],
+// in the order they appear.
+// Groups from NotesConfig.Group come after NotesFixes and before NotesOther.
+const (
+	NotesBreaking = "Breaking Changes"
+	NotesFeatures = "Features"
+	NotesFixes    = "Fixes"
+	NotesOther    = "Other"
+)
+
+// ReleaseNotes are release notes for a new version of a module,
+// listing the commits since its latest version.
+// See [NewReleaseNotes].
+type ReleaseNotes struct {
+	// Modpath is the module path.
+	Modpath string
+
+	// Version is the new version tag, including any version prefix,
+	// or "" if no new version is recommended.
+	Version string
+
+	// Previous is the latest version tag, including any version prefix,
+	// or "" if there is none.
+	Previous string
+
+	// RepoURL is the web page of the repository on its forge,
+	// or "" if the forge is not recognized.
+	RepoURL string
+
+	// CompareURL is the forge's web page comparing Previous with the commit to be tagged,
+	// or "" if there is no Previous or the forge is not recognized.
+	CompareURL string
+
+	// Groups are the groups of commits, omitting empty ones.
+	Groups []NoteGroup
+}
+
+// NoteGroup is a titled group of commits in [ReleaseNotes].
+type NoteGroup struct {
+	Title   string
+	Entries []NoteEntry
+}
+
+// NoteEntry is one commit in [ReleaseNotes].
+type NoteEntry struct {
+	Commit CommitSummary
+
+	// Type, Scope, Breaking, and Description are the parts of a Conventional Commits subject line
+	// (see https://www.conventionalcommits.org/),
+	// as in "feat(parser)!: accept tabs".
+	// If the subject line does not follow that form,
+	// Type and Scope are empty
+	// and Description is the whole subject line.
+	Type, Scope string
+	Breaking    bool
+	Description string
+
+	// PRs are the numbers of the pull requests that the subject line mentions,
+	// as in "Fix crash (#123)" or "Merge pull request #123 from owner/branch".
+	PRs []int
+
+	// Markdown is Description with the pull-request numbers linked to the forge
+	// when it is GitHub.
+	Markdown string
+}
+
+// NotesConfig holds the settings for release notes in a [Config].
+type NotesConfig struct {
+	// Template, if set, is the name of a file,
+	// relative to the repository root,
+	// containing a template for the release notes.
+	// See [ReleaseNotes.WriteTemplate].
+	Template string `yaml:"template"`
+
+	// Group, if set, is a template (see [text/template])
+	// that chooses the group for each commit,
+	// given its [NoteEntry].
+	// It should produce a group title,
+	// such as "Documentation" for {{if eq .Type "docs"}}Documentation{{end}},
+	// or nothing for the default grouping by Type.
+	Group string `yaml:"group"`
+}
+
+var (
+	conventionalRegex = regexp.MustCompile(`^([A-Za-z]+)(?:\(([^)]*)\))?(!)?: *(.*)$`)
+	prRegex           = regexp.MustCompile(`(?:\(#([0-9]+)\)|^Merge pull request #([0-9]+) )`)
+	prRefRegex        = regexp.MustCompile(`#([0-9]+)\b`)
+)
+
+// NewReleaseNotes produces release notes from r,
+// grouping r.UnreleasedCommitSummaries
+// (which requires the [WithCommitSummaries] option)
+// by their Conventional Commits type.
+// Breaking changes, marked with "!" as in "feat!: ...", are grouped together first,
+// followed by features ("feat") and fixes ("fix"),
+// then any groups chosen by config.Group,
+// then everything else.
+// Within each group the commits are in the order they were made.
+func NewReleaseNotes(r Result, config NotesConfig) (ReleaseNotes, error) {
+	notes := ReleaseNotes{Modpath: r.Modpath}
+	if tag, ok := r.recommendedTag(); ok {
+		notes.Version = tag
+	}
+	forge, hasForge := r.Forge()
+	if hasForge {
+		notes.RepoURL = forge.RepoURL
+	}
+	if r.LatestVersion != "" {
+		notes.Previous = r.VersionPrefix + r.LatestVersion
+		if hasForge && r.LatestCommit != "" {
+			notes.CompareURL = forge.CompareURL(notes.Previous, r.LatestCommit)
+		}
+	}
+
+	var groupTmpl *template.Template
+	if config.Group != "" {
+		var err error
+		groupTmpl, err = template.New("").Option("missingkey=error").Parse(config.Group)
+		if err != nil {
+			return notes, errors.Wrap(err, "parsing notes group template")
+		}
+	}
+
+	var (
+		groups = make(map[string][]NoteEntry)
+		custom []string // titles of groups from config.Group, in order of appearance
+	)
+	for i := len(r.UnreleasedCommitSummaries) - 1; i >= 0; i-- { // oldest first
+		c := r.UnreleasedCommitSummaries[i]
+		entry := parseNoteEntry(c, forge, hasForge && forge.Kind == ForgeGitHub)
+
+		var title string
+		if groupTmpl != nil {
+			buf := new(bytes.Buffer)
+			if err := groupTmpl.Execute(buf, entry); err != nil {
+				return notes, errors.Wrapf(err, "choosing group for commit %s", c.Hash)
+			}
+			title = strings.TrimSpace(buf.String())
+			if title != "" && !slices.Contains(custom, title) && !slices.Contains([]string{NotesBreaking, NotesFeatures, NotesFixes, NotesOther}, title) {
+				custom = append(custom, title)
+			}
+		}
+		if title == "" {
+			switch {
+			case entry.Breaking:
+				title = NotesBreaking
+			case entry.Type == "feat":
+				title = NotesFeatures
+			case entry.Type == "fix":
+				title = NotesFixes
+			default:
+				title = NotesOther
+			}
+		}
+		groups[title] = append(groups[title], entry)
+	}
+
+	titles := append([]string{NotesBreaking, NotesFeatures, NotesFixes}, custom...)
+	titles = append(titles, NotesOther)
+	for _, title := range titles {
+		if entries := groups[title]; len(entries) > 0 {
+			notes.Groups = append(notes.Groups, NoteGroup{Title: title, Entries: entries})
+		}
+	}
+
+	return notes, nil
+}
+
+// parseNoteEntry parses the subject line of c.
+// If linkPRs is true,
+// the Markdown field links pull-request numbers to their pages on forge.
+func parseNoteEntry(c CommitSummary, forge Forge, linkPRs bool) NoteEntry {
+	entry := NoteEntry{Commit: c, Description: c.Subject}
+	if m := conventionalRegex.FindStringSubmatch(c.Subject); m != nil {
+		entry.Type = strings.ToLower(m[1])
+		entry.Scope = m[2]
+		entry.Breaking = m[3] != ""
+		entry.Description = m[4]
+	}
+
+	for _, m := range prRegex.FindAllStringSubmatch(c.Subject, -1) {
+		n, _ := strconv.Atoi(m[1] + m[2]) // only one of them is set
+		entry.PRs = append(entry.PRs, n)
+	}
+
+	entry.Markdown = entry.Description
+	if linkPRs && len(entry.PRs) > 0 {
+		entry.Markdown = prRefRegex.ReplaceAllStringFunc(entry.Description, func(ref string) string {
+			n, _ := strconv.Atoi(ref[1:])
+			if !slices.Contains(entry.PRs, n) {
+				return ref
+			}
+			return fmt.Sprintf("[%s](%s/pull/%d)", ref, forge.RepoURL, n)
+		})
+	}
+	return entry
+}
+
+// DefaultNotesTemplate is the template (see [text/template])
+// that [ReleaseNotes.WriteMarkdown] uses.
+// It is a starting point for custom templates for [ReleaseNotes.WriteTemplate].
+const DefaultNotesTemplate = `## {{with .Version}}{{.}}{{else}}Unreleased changes{{end}}
+{{range .Groups}}
+### {{.Title}}
+
+{{range .Entries}}- {{with .Scope}}**{{.}}:** {{end}}{{.Markdown}} ({{short .Commit.Hash}})
+{{end}}{{else}}
+No changes.
+{{end}}{{with .CompareURL}}
+**Full changelog:** {{.}}
+{{end}}`
+
+// notesFuncs are the functions available to release-notes templates,
+// beyond the builtin ones.
+var notesFuncs = template.FuncMap{
+	// short abbreviates a commit hash to 7 hex digits.
+	"short": func(hash string) string {
+		if len(hash) > 7 {
+			return hash[:7]
+		}
+		return hash
+	},
+}
+
+// WriteMarkdown writes n to w as Markdown,
+// using [DefaultNotesTemplate].
+func (n ReleaseNotes) WriteMarkdown(w io.Writer) error {
+	return n.WriteTemplate(w, DefaultNotesTemplate)
+}
+
+// WriteTemplate writes n to w by executing tmpl,
+// a template (see [text/template]) over n.
+// Besides the builtin functions,
+// tmpl may use short,
+// which abbreviates a commit hash to 7 hex digits,
+// as in {{short .Commit.Hash}}.
+// See [DefaultNotesTemplate] for an example.
+func (n ReleaseNotes) WriteTemplate(w io.Writer, tmpl string) error {
+	t, err := template.New("").Option("missingkey=error").Funcs(notesFuncs).Parse(tmpl)
+	if err != nil {
+		return errors.Wrap(err, "parsing notes template")
+	}
+
+	// Buffer the output so that nothing is written if execution fails partway.
+	buf := new(bytes.Buffer)
+	if err := t.Execute(buf, n); err != nil {
+		return errors.Wrap(err, "expanding notes template")
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,180 @@
+package taggo_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bobg/taggo"
+)
+
+func TestReleaseNotes(t *testing.T) {
+	r := taggo.Result{
+		Modpath:         "github.com/bobg/x",
+		DefaultBranch:   "main",
+		RemoteURL:       "https://github.com/bobg/x",
+		ForgeKind:       taggo.ForgeGitHub,
+		LatestVersion:   "v1.2.0",
+		LatestCommit:    "ffff000000000000000000000000000000000000",
+		GoDirectiveBump: true,
+		NewMajor:        1,
+		NewMinor:        3,
+
+		// Newest first, as Check produces them.
+		UnreleasedCommitSummaries: []taggo.CommitSummary{
+			{Hash: "6666666666666666666666666666666666666666", Subject: "docs: explain tabs"},
+			{Hash: "5555555555555555555555555555555555555555", Subject: "Merge pull request #12 from bobg/tabs"},
+			{Hash: "4444444444444444444444444444444444444444", Subject: "feat(parser)!: reject spaces"},
+			{Hash: "3333333333333333333333333333333333333333", Subject: "fix: off-by-one in #7 (#11)"},
+			{Hash: "2222222222222222222222222222222222222222", Subject: "feat(parser): accept tabs (#10)"},
+			{Hash: "1111111111111111111111111111111111111111", Subject: "Tidy up"},
+		},
+	}
+
+	const wantDefault = `## v1.3.0
+
+### Breaking Changes
+
+- **parser:** reject spaces (4444444)
+
+### Features
+
+- **parser:** accept tabs ([#10](https://github.com/bobg/x/pull/10)) (2222222)
+
+### Fixes
+
+- off-by-one in #7 ([#11](https://github.com/bobg/x/pull/11)) (3333333)
+
+### Other
+
+- Tidy up (1111111)
+- Merge pull request [#12](https://github.com/bobg/x/pull/12) from bobg/tabs (5555555)
+- explain tabs (6666666)
+
+**Full changelog:** https://github.com/bobg/x/compare/v1.2.0...ffff000000000000000000000000000000000000
+`
+
+	cases := []struct {
+		name   string
+		forge  taggo.ForgeKind
+		config taggo.NotesConfig
+		want   string
+	}{{
+		name: "default",
+		want: wantDefault,
+	}, {
+		name:   "custom",
+		config: taggo.NotesConfig{Group: `{{if eq .Type "docs"}}Documentation{{else if .PRs}}Other{{end}}`},
+		want: `## v1.3.0
+
+### Breaking Changes
+
+- **parser:** reject spaces (4444444)
+
+### Documentation
+
+- explain tabs (6666666)
+
+### Other
+
+- Tidy up (1111111)
+- **parser:** accept tabs ([#10](https://github.com/bobg/x/pull/10)) (2222222)
+- off-by-one in #7 ([#11](https://github.com/bobg/x/pull/11)) (3333333)
+- Merge pull request [#12](https://github.com/bobg/x/pull/12) from bobg/tabs (5555555)
+
+**Full changelog:** https://github.com/bobg/x/compare/v1.2.0...ffff000000000000000000000000000000000000
+`,
+	}, {
+		name:  "gitlab",
+		forge: taggo.ForgeGitLab,
+		want: `## v1.3.0
+
+### Breaking Changes
+
+- **parser:** reject spaces (4444444)
+
+### Features
+
+- **parser:** accept tabs (#10) (2222222)
+
+### Fixes
+
+- off-by-one in #7 (#11) (3333333)
+
+### Other
+
+- Tidy up (1111111)
+- Merge pull request #12 from bobg/tabs (5555555)
+- explain tabs (6666666)
+
+**Full changelog:** https://github.com/bobg/x/-/compare/v1.2.0...ffff000000000000000000000000000000000000
+`,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := r
+			if tc.forge != "" {
+				r.ForgeKind = tc.forge
+			}
+			notes, err := taggo.NewReleaseNotes(r, tc.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			buf := new(bytes.Buffer)
+			if err := notes.WriteMarkdown(buf); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tc.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("bad_template", func(t *testing.T) {
+		if _, err := taggo.NewReleaseNotes(r, taggo.NotesConfig{Group: "{{.Nope}}"}); err == nil {
+			t.Error("got no error for a template naming a nonexistent field")
+		}
+	})
+}
+
+func TestReleaseNotesTemplate(t *testing.T) {
+	notes := taggo.ReleaseNotes{
+		Version:  "v1.3.0",
+		Previous: "v1.2.0",
+		RepoURL:  "https://github.com/bobg/x",
+		Groups: []taggo.NoteGroup{{
+			Title: taggo.NotesFeatures,
+			Entries: []taggo.NoteEntry{{
+				Commit:      taggo.CommitSummary{Hash: "2222222222222222222222222222222222222222", Author: "Bob <bob@example.com>"},
+				Type:        "feat",
+				Description: "accept tabs",
+				PRs:         []int{10, 11},
+			}},
+		}},
+	}
+
+	const tmpl = `# Release {{.Version}} (since {{.Previous}})
+{{range .Groups}}{{.Title}}:{{range .Entries}} {{.Description}} by {{.Commit.Author}} in {{short .Commit.Hash}}{{range .PRs}} {{$.RepoURL}}/pull/{{.}}{{end}}{{end}}
+{{end}}`
+
+	buf := new(bytes.Buffer)
+	if err := notes.WriteTemplate(buf, tmpl); err != nil {
+		t.Fatal(err)
+	}
+	const want = `# Release v1.3.0 (since v1.2.0)
+Features: accept tabs by Bob <bob@example.com> in 2222222 https://github.com/bobg/x/pull/10 https://github.com/bobg/x/pull/11
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	for _, bad := range []string{"{{.Version", "{{.Nope}}"} {
+		buf.Reset()
+		if err := notes.WriteTemplate(buf, bad); err == nil {
+			t.Errorf("got no error for template %q", bad)
+		}
+		if buf.Len() > 0 {
+			t.Errorf("got output %q for bad template %q", buf.String(), bad)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package taggo
+
+import (
+	"net/http"
+	"os"
+
+	"github.com/bobg/errors"
+)
+
+// ErrOffline is the error returned for operations that require network access
+// when the [WithOffline] option is in effect.
+var ErrOffline = errors.New("network access disabled in offline mode")
+
+// offlineTransport is an [http.RoundTripper] that refuses every request.
+// It guards against network access in offline mode.
+type offlineTransport struct{}
+
+func (offlineTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, ErrOffline
+}
+
+// goCmdEnv is the environment for running the go command on a module.
+// In offline mode,
+// it also prevents the go command from downloading anything.
+func goCmdEnv(offline bool) []string {
+	env := append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod")
+	if offline {
+		env = append(env, "GOPROXY=off", "GOTOOLCHAIN=local")
+	}
+	return env
+}
@@ -0,0 +1,367 @@
+package taggo
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/bobg/taggo/gitutil"
+)
+
+// Option is the type of an option that can be passed to [Check] and [CheckAll].
+type Option func(*options)
+
+type options struct {
+	requireAnnotated bool
+	dependents       bool
+	osv              bool
+	history          bool
+	modverReport     bool
+	commitSummaries  bool
+	buildContext     *BuildContext
+	config           Config
+	verifyBuilds     bool
+	govulncheck      bool
+	graduation       bool
+	tidy             bool
+	subtree          string
+	workspace        bool
+	finalize         bool
+	releaseAge       bool
+	branch           string
+	base             string
+	target           string
+	remote           string
+	remoteTags       bool
+	offline          bool
+	progress         func(Phase, Result)
+	gitTimeout       time.Duration
+	gitDir           string
+	httpClient       *http.Client
+}
+
+func makeOptions(opts []Option) options {
+	o := options{httpClient: http.DefaultClient}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.offline {
+		o.httpClient = &http.Client{Transport: offlineTransport{}}
+	}
+	return o
+}
+
+// WithAnnotatedTagsRequired is an option for projects whose policy requires annotated version tags.
+// It causes [Check] to report any version tags that are lightweight
+// (in Result.LightweightVersionTags).
+func WithAnnotatedTagsRequired() Option {
+	return func(o *options) {
+		o.requireAnnotated = true
+	}
+}
+
+// WithDependents causes [Check], when it recommends a new major version,
+// to query deps.dev for the number of known dependents of the latest version
+// (in Result.KnownDependents),
+// since a breaking change will affect them.
+// This requires network access.
+func WithDependents() Option {
+	return func(o *options) {
+		o.dependents = true
+	}
+}
+
+// WithOSV causes [Check] to query the OSV database (osv.dev)
+// for vulnerabilities that affect the latest version of the module
+// (in Result.OpenVulnerabilities).
+// When a new version is recommended,
+// it may be the first to include the fix for those vulnerabilities,
+// in which case the vulnerability reports should be updated.
+// This requires network access.
+func WithOSV() Option {
+	return func(o *options) {
+		o.osv = true
+	}
+}
+
+// WithVersionHistory causes [Check] to report details about every version tag of the module
+// (in Result.Versions).
+func WithVersionHistory() Option {
+	return func(o *options) {
+		o.history = true
+	}
+}
+
+// WithModverReport causes [Check] to include Modver's full report
+// of the changes since the latest version
+// (in Result.ModverReport).
+func WithModverReport() Option {
+	return func(o *options) {
+		o.modverReport = true
+	}
+}
+
+// WithCommitSummaries causes [Check] to list the commits to the module
+// since its latest version
+// (in Result.UnreleasedCommitSummaries).
+func WithCommitSummaries() Option {
+	return func(o *options) {
+		o.commitSummaries = true
+	}
+}
+
+// WithBuildContext sets the build tags, GOOS, and GOARCH
+// under which Modver loads the module's packages
+// when comparing them with the latest version,
+// overriding any build context in the configuration (see [WithConfig]).
+func WithBuildContext(bc BuildContext) Option {
+	return func(o *options) {
+		o.buildContext = &bc
+	}
+}
+
+// WithBuildVerification causes [Check] to check out each version of the module
+// into a temporary Git worktree
+// and run "go build ./..." there,
+// reporting the versions that do not build
+// (in Result.UnbuildableVersions).
+// This can be slow,
+// and may require network access to download dependencies.
+func WithBuildVerification() Option {
+	return func(o *options) {
+		o.verifyBuilds = true
+	}
+}
+
+// WithGovulncheck causes [Check] to check out the commit to be tagged
+// into a temporary Git worktree
+// and run govulncheck there,
+// reporting the known vulnerabilities that affect code reachable from the module
+// (in Result.ReachableVulnerabilities).
+// The govulncheck command must be installed
+// (see https://go.dev/doc/tutorial/govulncheck).
+// This requires network access.
+// With the [WithOffline] option, the check is skipped.
+func WithGovulncheck() Option {
+	return func(o *options) {
+		o.govulncheck = true
+	}
+}
+
+// WithGraduation causes [Check] to evaluate a v0 module
+// for readiness to release v1.0.0
+// (in Result.Graduation),
+// by the criteria in Config.Graduation (see [WithConfig]):
+// the age of the v0 series,
+// the absence of breaking changes in its latest releases according to Modver,
+// and the presence of package doc comments and tests.
+// The packages are examined as they are in the working tree.
+func WithGraduation() Option {
+	return func(o *options) {
+		o.graduation = true
+	}
+}
+
+// WithTidyCheck causes [Check] to run "go mod tidy -diff" for the module
+// and report whether its go.mod and go.sum files need tidying
+// (in Result.Untidy and Result.TidyDiff).
+// This requires Go 1.23 or later,
+// and may require network access to download dependencies.
+func WithTidyCheck() Option {
+	return func(o *options) {
+		o.tidy = true
+	}
+}
+
+// WithSubtree limits [CheckAll] to the modules in subdir,
+// a directory relative to the repository root,
+// and its subdirectories.
+// Each module is still checked against all the refs in the repository.
+func WithSubtree(subdir string) Option {
+	return func(o *options) {
+		o.subtree = subdir
+	}
+}
+
+// WithWorkspace causes [CheckAll] to check the modules used by the go.work file
+// at the root of the repository,
+// instead of those found by walking its directory tree.
+// (See [ReadWorkspace] for how the two differ.)
+// It is an error if there is no go.work file.
+// [WithSubtree] still limits the modules checked.
+func WithWorkspace() Option {
+	return func(o *options) {
+		o.workspace = true
+	}
+}
+
+// WithFinalize causes [Check], when the latest version is a prerelease,
+// to recommend the corresponding final release
+// (e.g. v1.4.0 after v1.4.0-rc.1)
+// rather than another prerelease (v1.4.0-rc.2).
+func WithFinalize() Option {
+	return func(o *options) {
+		o.finalize = true
+	}
+}
+
+// WithReleaseAge causes [Check] to report when the latest version was released
+// (in Result.LatestVersionTime)
+// and when the oldest commit since then was made
+// (in Result.OldestUnreleasedCommitTime),
+// whether or not a stale-release threshold is configured.
+func WithReleaseAge() Option {
+	return func(o *options) {
+		o.releaseAge = true
+	}
+}
+
+// WithBranch causes [Check] to analyze the named branch,
+// such as a maintenance branch like release/v1,
+// instead of the default branch.
+// Only the version tags reachable from that branch count,
+// so the latest version is the latest one in the branch's own release series,
+// even if the default branch has moved on to a later major version.
+func WithBranch(name string) Option {
+	return func(o *options) {
+		o.branch = name
+	}
+}
+
+// WithBase causes [Check] to compare the module on the default branch
+// (or the branch named with [WithBranch])
+// with the given Git ref,
+// instead of with the latest version tag,
+// to determine the size of the needed version bump.
+// This answers questions like
+// "what bump would merging main into release/v1 require?"
+// The recommended new version is still relative to the latest version.
+// The option has no effect when the module has no version tags,
+// or when the latest commit has one.
+func WithBase(ref string) Option {
+	return func(o *options) {
+		o.base = ref
+	}
+}
+
+// WithTarget causes [Check] to analyze the given commit
+// (named by any Git ref, such as a commit hash, branch, or HEAD)
+// instead of the tip of the default branch
+// (or of the branch named with [WithBranch]),
+// as when releasing from a pinned commit that has already passed CI.
+// Only the version tags reachable from the commit count,
+// and the recommended new version tag is for that commit.
+// It overrides Config.ReleaseMerge.
+func WithTarget(ref string) Option {
+	return func(o *options) {
+		o.target = ref
+	}
+}
+
+// WithRemote causes [Check] to determine the default branch
+// from the refs of the named remote only,
+// such as "upstream" in a fork-based workflow.
+// Without it, Check prefers "origin"
+// but falls back to any other remote.
+func WithRemote(name string) Option {
+	return func(o *options) {
+		o.remote = name
+	}
+}
+
+// WithRemoteTags causes [Check] to list the tags in the remote
+// (the one named with [WithRemote], or else origin)
+// and include the version tags that are missing locally,
+// as in a clone made with --no-tags
+// (reporting them in Result.RemoteOnlyVersionTags).
+// A remote tag is analyzed only if its commit is present locally.
+// This requires network access.
+func WithRemoteTags() Option {
+	return func(o *options) {
+		o.remoteTags = true
+	}
+}
+
+// WithOffline prevents [Check] and [Verify] from touching the network,
+// for use in air-gapped environments.
+// Check skips the checks that require network access,
+// even when other options request them,
+// and lists them in Result.SkippedChecks.
+// The tidiness and build checks still run,
+// but use only the local module cache.
+// Verify returns [ErrOffline].
+func WithOffline() Option {
+	return func(o *options) {
+		o.offline = true
+	}
+}
+
+// WithProgress causes [Check] to call f at the end of each [Phase] of its analysis
+// with the Result so far,
+// in which the fields belonging to that phase and the earlier ones are complete.
+// This lets a user interface show partial results,
+// such as the latest version and the default branch,
+// while slower phases (notably [PhaseModver]) are still running.
+// Fields belonging to later phases are not yet valid,
+// so neither are findings that depend on them.
+// If a phase fails (see Result.PhaseError),
+// f is not called for the phases after PhaseBranch.
+// With [CheckAll], f is called for each module in turn.
+func WithProgress(f func(Phase, Result)) Option {
+	return func(o *options) {
+		o.progress = f
+	}
+}
+
+// WithGitDir sets the repository's Git directory,
+// for a repository whose Git directory is not at the root of its working tree,
+// as in a server-side hook.
+// The repodir passed to [Check], [CheckAll], [Verify], and [ValidatePlannedTag]
+// is then the working tree.
+// Without this option,
+// git commands see the GIT_DIR and GIT_WORK_TREE of the environment, if any.
+// (Other environment variables, such as GIT_CONFIG_GLOBAL, always apply.)
+func WithGitDir(dir string) Option {
+	return func(o *options) {
+		o.gitDir = dir
+	}
+}
+
+// WithGitTimeout limits each git command run by [Check], [Verify], and [ValidatePlannedTag],
+// and each Modver comparison in Check,
+// to the given duration,
+// so that a stalled credential helper or fsmonitor
+// produces an error wrapping [ErrGitTimeout]
+// instead of a hang.
+// (A Modver comparison that times out is reported in Result.PhaseError.)
+// The default is no limit.
+func WithGitTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.gitTimeout = timeout
+	}
+}
+
+// WithConfig supplies the settings from a configuration file
+// (see [LoadConfig]).
+// [Check] uses the settings for the module being checked.
+func WithConfig(config Config) Option {
+	return func(o *options) {
+		o.config = config
+	}
+}
+
+// WithHTTPClient sets the HTTP client that [Check] uses for options that require network access.
+// The default is [http.DefaultClient].
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = client
+	}
+}
+
+// gitContext returns ctx decorated for the git commands of [gitutil.Command]
+// according to o,
+// for the repository with the working tree in repodir.
+func (o options) gitContext(ctx context.Context, repodir string) context.Context {
+	ctx = gitutil.WithTimeout(ctx, o.gitTimeout)
+	return gitutil.WithGitDir(ctx, o.gitDir, repodir)
+}
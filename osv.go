@@ -0,0 +1,151 @@
+package taggo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bobg/errors"
+)
+
+const osvQueryURL = "https://api.osv.dev/v1/query"
+
+// osvVulnsAffecting queries OSV for the IDs of vulnerabilities
+// that affect version of the Go module modpath.
+func osvVulnsAffecting(ctx context.Context, client *http.Client, modpath, version string) ([]string, error) {
+	query := struct {
+		Version string `json:"version"`
+		Package struct {
+			Name      string `json:"name"`
+			Ecosystem string `json:"ecosystem"`
+		} `json:"package"`
+	}{
+		Version: strings.TrimPrefix(version, "v"), // OSV uses versions without the "v"
+	}
+	query.Package.Name = modpath
+	query.Package.Ecosystem = "Go"
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding OSV query")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, osvQueryURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrapf(err, "creating request for %s", osvQueryURL)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "requesting %s", osvQueryURL)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("requesting %s: status %d", osvQueryURL, resp.StatusCode)
+	}
+
+	var result struct {
+		Vulns []struct {
+			ID string `json:"id"`
+		} `json:"vulns"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, errors.Wrapf(err, "decoding response from %s", osvQueryURL)
+	}
+
+	var ids []string
+	for _, v := range result.Vulns {
+		ids = append(ids, v.ID)
+	}
+	return ids, nil
+}
+
+// OSVEntry is a vulnerability report in the OSV format.
+// See https://ossf.github.io/osv-schema/.
+type OSVEntry struct {
+	SchemaVersion string         `json:"schema_version"`
+	ID            string         `json:"id"`
+	Modified      time.Time      `json:"modified"`
+	Published     time.Time      `json:"published"`
+	Summary       string         `json:"summary"`
+	Details       string         `json:"details"`
+	Affected      []OSVAffected  `json:"affected"`
+	References    []OSVReference `json:"references,omitempty"`
+}
+
+// OSVAffected is the type of OSVEntry.Affected.
+type OSVAffected struct {
+	Package OSVPackage `json:"package"`
+	Ranges  []OSVRange `json:"ranges"`
+}
+
+// OSVPackage is the type of OSVAffected.Package.
+type OSVPackage struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+}
+
+// OSVRange is the type of OSVAffected.Ranges.
+type OSVRange struct {
+	Type   string     `json:"type"`
+	Events []OSVEvent `json:"events"`
+}
+
+// OSVEvent is the type of OSVRange.Events.
+type OSVEvent struct {
+	Introduced string `json:"introduced,omitempty"`
+	Fixed      string `json:"fixed,omitempty"`
+}
+
+// OSVReference is the type of OSVEntry.References.
+type OSVReference struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// AdvisoryStub produces a skeleton OSV report for a security fix
+// released as version fixed of the module described by r.
+// All versions before fixed
+// (i.e., up to and including r.LatestVersion)
+// are listed as affected.
+// The ID, summary, and details are placeholders for the maintainer to fill in
+// before submitting the report to the Go vulnerability database.
+// If forge is non-nil,
+// the report includes references to the fixing commit and the new release.
+func AdvisoryStub(r Result, fixed string, forge *Forge, now time.Time) OSVEntry {
+	now = now.UTC().Truncate(time.Second)
+
+	entry := OSVEntry{
+		SchemaVersion: "1.6.0",
+		ID:            "GO-ID-PENDING",
+		Modified:      now,
+		Published:     now,
+		Summary:       fmt.Sprintf("TODO: one-line summary of the vulnerability fixed in %s %s", r.Modpath, fixed),
+		Details:       "TODO: describe the vulnerability, its impact, and any workarounds.",
+		Affected: []OSVAffected{{
+			Package: OSVPackage{Ecosystem: "Go", Name: r.Modpath},
+			Ranges: []OSVRange{{
+				Type: "SEMVER",
+				Events: []OSVEvent{
+					{Introduced: "0"},
+					{Fixed: strings.TrimPrefix(fixed, "v")},
+				},
+			}},
+		}},
+	}
+
+	if forge != nil {
+		entry.References = []OSVReference{
+			{Type: "FIX", URL: forge.CommitURL(r.LatestCommit)},
+			{Type: "WEB", URL: forge.TagURL(r.VersionPrefix + fixed)},
+		}
+	}
+
+	return entry
+}
@@ -0,0 +1,44 @@
+package taggo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bobg/taggo"
+)
+
+func TestAdvisoryStub(t *testing.T) {
+	var (
+		r = taggo.Result{
+			Modpath:       "github.com/bobg/x/sub",
+			LatestVersion: "v1.2.3",
+			LatestCommit:  "abc123",
+			VersionPrefix: "sub/",
+		}
+		forge = &taggo.Forge{Kind: taggo.ForgeGitHub, RepoURL: "https://github.com/bobg/x"}
+		now   = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	)
+
+	got := taggo.AdvisoryStub(r, "v1.2.4", forge, now)
+
+	wantAffected := []taggo.OSVAffected{{
+		Package: taggo.OSVPackage{Ecosystem: "Go", Name: "github.com/bobg/x/sub"},
+		Ranges: []taggo.OSVRange{{
+			Type:   "SEMVER",
+			Events: []taggo.OSVEvent{{Introduced: "0"}, {Fixed: "1.2.4"}},
+		}},
+	}}
+	if diff := cmp.Diff(wantAffected, got.Affected); diff != "" {
+		t.Errorf("affected mismatch (-want +got):\n%s", diff)
+	}
+
+	wantRefs := []taggo.OSVReference{
+		{Type: "FIX", URL: "https://github.com/bobg/x/commit/abc123"},
+		{Type: "WEB", URL: "https://github.com/bobg/x/releases/tag/sub/v1.2.4"},
+	}
+	if diff := cmp.Diff(wantRefs, got.References); diff != "" {
+		t.Errorf("references mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,66 @@
+package taggo
+
+import "fmt"
+
+// Phase is a phase of the analysis performed by [Check].
+// See [WithProgress].
+type Phase string
+
+// The phases of [Check], in order,
+// with the Result fields that are complete at the end of each one
+// (in addition to those of the earlier phases).
+const (
+	// PhaseRefs reads the repository's refs.
+	// Complete: ModuleSubdir, VersionPrefix, RemoteOnlyVersionTags, OtherModuleVersionTags.
+	PhaseRefs Phase = "refs"
+
+	// PhaseVersions determines the latest version.
+	// Complete: LatestVersion, LatestMajor, LatestMinor, LatestPatch,
+	// LatestVersionIsPrerelease, LatestVersionUnstable, LightweightVersionTags,
+	// TotalVersionTags, TagsPerMajor, OldestVersion, OldestVersionDate, NewestVersion, NewestVersionDate.
+	PhaseVersions Phase = "versions"
+
+	// PhaseModfile reads the module's go.mod file.
+	// Complete: Modpath, VersionSuffix, ModpathMismatch,
+	// ReplaceDirectives, LocalReplaceDirectives, ExcludeDirectives.
+	PhaseModfile Phase = "modfile"
+
+	// PhaseBranch determines the default branch and the commit to analyze.
+	// Complete: DefaultBranch, Branch, NoRemote, Remote, RemoteURL, ForgeKind, ModpathRemoteMismatch,
+	// Ahead, Behind, ReleaseMergeCommit, NoReleaseMerge,
+	// LatestCommit, LatestCommitAuthor, LatestCommitTime, LatestCommitSubject,
+	// LatestCommitHasVersionTag, LatestCommitHasLatestVersion.
+	PhaseBranch Phase = "branch"
+
+	// PhaseModver compares the latest version with the commit being analyzed,
+	// which for a large module can take a while.
+	// Complete: NoNetChanges, ModverResultCode, ModverResultString,
+	// GoDirective, ToolchainDirective, LatestVersionGoDirective, LatestVersionToolchainDirective.
+	PhaseModver Phase = "modver"
+
+	// PhaseRecommendation determines the recommended new version.
+	// Complete: NewMajor, NewMinor, NewPatch, NewPrerelease, NewBuildMetadata,
+	// FinalizesPrerelease, GoDirectiveBump, Series, NewVersionExists.
+	PhaseRecommendation Phase = "recommendation"
+
+	// PhaseChecks performs the remaining checks requested with options,
+	// such as [WithTidyCheck] and [WithOSV],
+	// and the readiness checks and policy rules in the [Config] (see [WithConfig]).
+	// The Result is then complete.
+	PhaseChecks Phase = "checks"
+)
+
+// PhaseError describes the failure of a phase of [Check],
+// which leaves the fields belonging to that phase and the later ones incomplete.
+// See Result.PhaseError.
+type PhaseError struct {
+	// Phase is the phase that failed.
+	Phase Phase
+
+	// Message describes the failure.
+	Message string
+}
+
+func (e *PhaseError) Error() string {
+	return fmt.Sprintf("%s phase: %s", e.Phase, e.Message)
+}
@@ -0,0 +1,51 @@
+package taggo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/bobg/errors"
+	"golang.org/x/mod/semver"
+)
+
+// RequestPkgGoDev asks pkg.go.dev to fetch version of the module modpath,
+// through its fetch endpoint
+// (the one behind the "Request" button on the page for a missing version),
+// so that its documentation appears promptly
+// instead of whenever pkg.go.dev next learns of the version from the module proxy.
+// The module proxy must already serve the version
+// (see [WaitForProxy]).
+//
+// This requires network access.
+// The [WithHTTPClient] option applies.
+// With the [WithOffline] option, RequestPkgGoDev returns [ErrOffline].
+func RequestPkgGoDev(ctx context.Context, modpath, version string, opts ...Option) error {
+	o := makeOptions(opts)
+	if o.offline {
+		return ErrOffline
+	}
+
+	if !semver.IsValid(version) {
+		return fmt.Errorf("%s is not a valid semantic version", version)
+	}
+	version = semver.Canonical(version)
+
+	u := fmt.Sprintf("%s/fetch/%s@%s", pkgGoDevBaseURL, modpath, version)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, nil)
+	if err != nil {
+		return errors.Wrapf(err, "creating request for %s", u)
+	}
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "requesting %s", u)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("requesting %s: status %d: %s", u, resp.StatusCode, body)
+	}
+	return nil
+}
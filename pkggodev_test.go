@@ -0,0 +1,54 @@
+package taggo_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/bobg/taggo"
+)
+
+func TestRequestPkgGoDev(t *testing.T) {
+	var fetched []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.Host != "pkg.go.dev" {
+			http.NotFound(w, req)
+			return
+		}
+		switch req.URL.Path {
+		case "/fetch/example.com/x@v1.1.0":
+			fetched = append(fetched, req.URL.Path)
+		default:
+			http.Error(w, "not in the module proxy", http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	srvURL, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: redirectTransport{host: srvURL.Host}}
+
+	ctx := context.Background()
+
+	// Build metadata is ignored.
+	if err := taggo.RequestPkgGoDev(ctx, "example.com/x", "v1.1.0+build.7", taggo.WithHTTPClient(client)); err != nil {
+		t.Fatal(err)
+	}
+	if len(fetched) != 1 {
+		t.Errorf("got fetches %v, want 1", fetched)
+	}
+
+	if err := taggo.RequestPkgGoDev(ctx, "example.com/x", "v1.2.0", taggo.WithHTTPClient(client)); err == nil {
+		t.Error("got no error for a version pkg.go.dev cannot fetch")
+	}
+
+	if err := taggo.RequestPkgGoDev(ctx, "example.com/x", "v1.1.0", taggo.WithOffline()); !errors.Is(err, taggo.ErrOffline) {
+		t.Errorf("got error %v with WithOffline, want ErrOffline", err)
+	}
+}
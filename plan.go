@@ -0,0 +1,174 @@
+package taggo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"path"
+	"path/filepath"
+
+	"github.com/bobg/errors"
+	"golang.org/x/mod/modfile"
+
+	"github.com/bobg/taggo/gitutil"
+)
+
+// Plan is a set of version tags to create,
+// decided on ahead of time
+// (for example, reviewed and approved by a person)
+// and applied later with "taggo apply".
+//
+// Its JSON form looks like this:
+//
+//	{
+//	  "tags": [
+//	    {"module": "", "version": "v1.4.0", "commit": "4f2c9e1", "message": "Release 1.4.0"},
+//	    {"module": "sub", "version": "v0.3.1", "commit": "4f2c9e1", "branch": "release/v0"}
+//	  ]
+//	}
+type Plan struct {
+	Tags []PlannedTag `json:"tags"`
+}
+
+// PlannedTag is a version tag to create, as part of a [Plan].
+type PlannedTag struct {
+	// Module is the module's directory relative to the repository root,
+	// or "" for a module at the root.
+	Module string `json:"module"`
+
+	// Version is the version to tag, without the module's version prefix.
+	Version string `json:"version"`
+
+	// Commit is the commit to tag.
+	// It may be any revision that names a commit,
+	// but a full commit hash is best,
+	// so that the plan means the same thing when it is applied as when it was approved.
+	Commit string `json:"commit"`
+
+	// Message is the message for an annotated tag.
+	// If empty, a default message is used.
+	Message string `json:"message,omitempty"`
+
+	// Branch is the branch from which Commit must be reachable.
+	// If empty, it is the default branch.
+	Branch string `json:"branch,omitempty"`
+}
+
+// ReadPlan reads a [Plan] in JSON form from r.
+func ReadPlan(r io.Reader) (Plan, error) {
+	var plan Plan
+
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&plan); err != nil {
+		return plan, errors.Wrap(err, "decoding plan")
+	}
+	for i, pt := range plan.Tags {
+		plan.Tags[i].Module = moduleDirName(pt.Module)
+	}
+	return plan, nil
+}
+
+// moduleDirName normalizes a module directory relative to the repository root,
+// using "" for the root.
+func moduleDirName(dir string) string {
+	dir = path.Clean(filepath.ToSlash(dir))
+	if dir == "." {
+		return ""
+	}
+	return dir
+}
+
+// Tag is the name of the version tag for pt,
+// including the module's version prefix.
+func (pt PlannedTag) Tag() string {
+	if pt.Module == "" {
+		return pt.Version
+	}
+	return pt.Module + "/" + pt.Version
+}
+
+// ValidatePlannedTag checks pt against the current state of the repository in repodir:
+// Version must be a complete semantic version;
+// the tag must not already exist;
+// Commit must exist
+// and be reachable from Branch, or else from the default branch;
+// and the major-version suffix of the module path,
+// as of Commit,
+// must agree with Version.
+//
+// It returns the full hash of Commit,
+// and the [Result] of checking the module with [Check]
+// (including [WithBranch] if Branch is set, plus opts).
+// The [WithGitTimeout] option applies.
+func ValidatePlannedTag(ctx context.Context, git, repodir string, pt PlannedTag, opts ...Option) (string, Result, error) {
+	ctx = makeOptions(opts).gitContext(ctx, repodir)
+
+	if git == "" {
+		var err error
+		git, err = exec.LookPath("git")
+		if err != nil {
+			return "", Result{}, errors.Wrap(err, "finding git binary")
+		}
+	}
+
+	if err := checkCompleteVersion(pt.Version); err != nil {
+		return "", Result{}, err
+	}
+
+	tag := pt.Tag()
+	existing, err := gitutil.ResolveCommit(ctx, git, repodir, "refs/tags/"+tag)
+	if err != nil {
+		return "", Result{}, errors.Wrapf(err, "looking up tag %s", tag)
+	}
+	if existing != "" {
+		return "", Result{}, fmt.Errorf("tag %s already exists, at %s", tag, existing)
+	}
+
+	commit, err := gitutil.ResolveCommit(ctx, git, repodir, pt.Commit)
+	if err != nil {
+		return "", Result{}, errors.Wrapf(err, "resolving commit %s", pt.Commit)
+	}
+	if commit == "" {
+		return "", Result{}, fmt.Errorf("commit %s not found", pt.Commit)
+	}
+
+	if pt.Branch != "" {
+		opts = append(opts, WithBranch(pt.Branch))
+	}
+	result, err := Check(ctx, git, repodir, filepath.Join(repodir, pt.Module), opts...)
+	if err != nil {
+		return "", result, errors.Wrapf(err, "checking module %s", pt.Module)
+	}
+	if result.DefaultBranch == "" {
+		return "", result, fmt.Errorf("cannot determine the default branch, so cannot check that commit %s is on it", pt.Commit)
+	}
+
+	ok, err := gitutil.IsAncestor(ctx, git, repodir, commit, "refs/heads/"+result.DefaultBranch)
+	if err != nil {
+		return "", result, errors.Wrapf(err, "checking whether %s is on branch %s", pt.Commit, result.DefaultBranch)
+	}
+	if !ok {
+		return "", result, fmt.Errorf("commit %s is not reachable from branch %s", pt.Commit, result.DefaultBranch)
+	}
+
+	gomodPath := path.Join(pt.Module, "go.mod")
+	gomodBytes, err := gitutil.Show(ctx, git, repodir, commit, gomodPath)
+	if err != nil {
+		return "", result, errors.Wrapf(err, "reading %s at %s", gomodPath, pt.Commit)
+	}
+	gomod, err := modfile.ParseLax(gomodPath, gomodBytes, noopFixer)
+	if err != nil {
+		return "", result, errors.Wrapf(err, "parsing %s at %s", gomodPath, pt.Commit)
+	}
+	if gomod.Module == nil {
+		return "", result, fmt.Errorf("%s at %s has no module directive", gomodPath, pt.Commit)
+	}
+	if err := checkVersionSuffix(gomod.Module.Mod.Path, pt.Version); err != nil {
+		return "", result, err
+	}
+
+	return commit, result, nil
+}
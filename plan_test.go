@@ -0,0 +1,89 @@
+package taggo_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/internal/testutil"
+)
+
+func TestReadPlan(t *testing.T) {
+	const doc = `{"tags": [{"module": "./", "version": "v1.0.0", "commit": "abc"}, {"module": "sub/", "version": "v0.1.0", "commit": "def", "message": "hi"}]}`
+
+	plan, err := taggo.ReadPlan(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Tags) != 2 {
+		t.Fatalf("got %d tags, want 2", len(plan.Tags))
+	}
+	if got := plan.Tags[0].Tag(); got != "v1.0.0" {
+		t.Errorf("got tag %s, want v1.0.0", got)
+	}
+	if got := plan.Tags[1].Tag(); got != "sub/v0.1.0" {
+		t.Errorf("got tag %s, want sub/v0.1.0", got)
+	}
+
+	if _, err := taggo.ReadPlan(strings.NewReader(`{"tags": [{"mod": "x"}]}`)); err == nil {
+		t.Error("got no error for unknown field")
+	}
+}
+
+func TestValidatePlannedTag(t *testing.T) {
+	repodir := t.TempDir()
+
+	testutil.Git(t, repodir, "init", "-q", "-b", "main")
+	if err := os.WriteFile(filepath.Join(repodir, "go.mod"), []byte("module example.com/x\n\ngo 1.22\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	testutil.Git(t, repodir, "add", "go.mod")
+	testutil.Git(t, repodir, "commit", "-q", "-m", "initial")
+	testutil.Git(t, repodir, "tag", "v0.1.0")
+	commit := testutil.Git(t, repodir, "rev-parse", "HEAD")
+
+	testutil.Git(t, repodir, "checkout", "-q", "-b", "side")
+	testutil.Git(t, repodir, "commit", "-q", "--allow-empty", "-m", "side")
+	sideCommit := testutil.Git(t, repodir, "rev-parse", "HEAD")
+	testutil.Git(t, repodir, "checkout", "-q", "main")
+
+	ctx := context.Background()
+
+	got, _, err := taggo.ValidatePlannedTag(ctx, "", repodir, taggo.PlannedTag{Version: "v0.2.0", Commit: commit[:7]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != commit {
+		t.Errorf("got commit %s, want %s", got, commit)
+	}
+
+	if _, _, err := taggo.ValidatePlannedTag(ctx, "", repodir, taggo.PlannedTag{Version: "v0.2.0", Commit: sideCommit, Branch: "side"}); err != nil {
+		t.Errorf("got error %v for commit on named branch", err)
+	}
+
+	cases := []struct {
+		name string
+		pt   taggo.PlannedTag
+		want string
+	}{
+		{"incomplete", taggo.PlannedTag{Version: "v0.2", Commit: commit}, "not a complete semantic version"},
+		{"exists", taggo.PlannedTag{Version: "v0.1.0", Commit: commit}, "already exists"},
+		{"no-commit", taggo.PlannedTag{Version: "v0.2.0", Commit: "nosuchref"}, "not found"},
+		{"unreachable", taggo.PlannedTag{Version: "v0.2.0", Commit: sideCommit}, "not reachable from branch main"},
+		{"suffix", taggo.PlannedTag{Version: "v2.0.0", Commit: commit}, "must end in /v2"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := taggo.ValidatePlannedTag(ctx, "", repodir, tc.pt)
+			if err == nil {
+				t.Fatal("got no error")
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("got error %q, want one containing %q", err, tc.want)
+			}
+		})
+	}
+}
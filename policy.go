@@ -0,0 +1,159 @@
+package taggo
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/bobg/errors"
+	"golang.org/x/mod/semver"
+
+	"github.com/bobg/taggo/gitutil"
+)
+
+// PolicyInference is the result of [InferPolicy].
+type PolicyInference struct {
+	// Policy is the release policy suggested by the version tags:
+	// PolicyLockstep if all tagged modules have been released in step,
+	// PolicyIndependent otherwise,
+	// or "" if fewer than two modules have version tags.
+	Policy string
+
+	// Lockstep lists the directories of the largest group of modules that have been released in step,
+	// in sorted order.
+	// It is empty if no two modules have been released in step.
+	Lockstep []string
+
+	// Independent lists the directories of the other modules with version tags,
+	// in sorted order.
+	Independent []string
+}
+
+// Mixed tells whether some modules have been released in lockstep and others independently.
+func (p PolicyInference) Mixed() bool {
+	return len(p.Lockstep) > 0 && len(p.Independent) > 0
+}
+
+// InferPolicy analyzes the version tags of the modules in the given directories of repodir
+// (as in the keys of the map returned by [CheckAll])
+// to infer whether they have been released in lockstep or independently.
+//
+// Two modules count as released in step
+// if they have the same version tags
+// from the later of their two first releases onward.
+func InferPolicy(ctx context.Context, git, repodir string, moduledirs []string) (PolicyInference, error) {
+	var p PolicyInference
+
+	if git == "" {
+		var err error
+		git, err = exec.LookPath("git")
+		if err != nil {
+			return p, errors.Wrap(err, "finding git binary")
+		}
+	}
+
+	prefixes := make(map[string]string) // moduledir -> version prefix
+	for _, dir := range moduledirs {
+		subdir, err := moduleSubdir(repodir, dir)
+		if err != nil {
+			return p, err
+		}
+		if subdir != "" {
+			prefixes[dir] = filepath.ToSlash(subdir) + "/"
+		} else {
+			prefixes[dir] = ""
+		}
+	}
+
+	versions := make(map[string][]string) // moduledir -> sorted versions
+	err := gitutil.Refs(ctx, git, repodir, func(name, _ string) error {
+		tag, ok := strings.CutPrefix(name, "refs/tags/")
+		if !ok {
+			return nil
+		}
+		for dir, prefix := range prefixes {
+			v, ok := strings.CutPrefix(tag, prefix)
+			if !ok {
+				continue
+			}
+			if semver.IsValid(v) && !IsFloatingVersion(v) {
+				versions[dir] = append(versions[dir], v)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return p, errors.Wrap(err, "getting refs")
+	}
+	if len(versions) < 2 {
+		return p, nil
+	}
+
+	// Modules with the most versions come first,
+	// so each group is represented by its longest history.
+	dirs := make([]string, 0, len(versions))
+	for dir, vs := range versions {
+		semver.Sort(vs)
+		dirs = append(dirs, dir)
+	}
+	sort.Slice(dirs, func(i, j int) bool {
+		if ni, nj := len(versions[dirs[i]]), len(versions[dirs[j]]); ni != nj {
+			return ni > nj
+		}
+		return dirs[i] < dirs[j]
+	})
+
+	var groups [][]string
+	for _, dir := range dirs {
+		i := slices.IndexFunc(groups, func(g []string) bool {
+			return inStep(versions[g[0]], versions[dir])
+		})
+		if i < 0 {
+			groups = append(groups, []string{dir})
+		} else {
+			groups[i] = append(groups[i], dir)
+		}
+	}
+
+	var largest []string
+	for _, g := range groups {
+		if len(g) > len(largest) {
+			largest = g
+		}
+	}
+	if len(largest) > 1 {
+		p.Lockstep = largest
+	}
+	for _, dir := range dirs {
+		if !slices.Contains(p.Lockstep, dir) {
+			p.Independent = append(p.Independent, dir)
+		}
+	}
+	sort.Strings(p.Lockstep)
+	sort.Strings(p.Independent)
+
+	if len(p.Independent) == 0 {
+		p.Policy = PolicyLockstep
+	} else {
+		p.Policy = PolicyIndependent
+	}
+
+	return p, nil
+}
+
+// inStep tells whether the sorted version lists a and b are the same
+// from the later of their first versions onward.
+func inStep(a, b []string) bool {
+	first := a[0]
+	if semver.Compare(b[0], first) > 0 {
+		first = b[0]
+	}
+	since := func(vs []string) []string {
+		i := sort.Search(len(vs), func(i int) bool { return semver.Compare(vs[i], first) >= 0 })
+		return vs[i:]
+	}
+	return slices.Equal(since(a), since(b))
+}
@@ -0,0 +1,173 @@
+package taggo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/bobg/errors"
+	"github.com/bobg/modver/v2"
+
+	"github.com/bobg/taggo/gitutil"
+)
+
+// PullRequest describes the effect on the Go modules in a repository
+// of merging one branch into another,
+// as computed by [CheckPullRequest].
+type PullRequest struct {
+	// Base and Head are the refs given to CheckPullRequest.
+	Base, Head string
+
+	// MergeBase is the commit from which Head diverged from Base.
+	// Changes are measured from it.
+	MergeBase string
+
+	// Modules lists the modules that the pull request touches,
+	// in order of module directory.
+	Modules []PullRequestModule
+}
+
+// PullRequestModule describes the effect of a pull request on one module.
+type PullRequestModule struct {
+	// Result is the result of checking the module at the pull request's head,
+	// comparing it with the merge base
+	// (see [WithBase] and [WithTarget]).
+	// Its recommended new version is relative to the latest version reachable from the head.
+	Result Result
+
+	// Bump is the size of the version bump that merging the pull request requires:
+	// "none", "patch", "minor", or "major".
+	Bump string
+
+	// Files lists the changed files in the module,
+	// relative to the repository root.
+	Files []string
+}
+
+// CheckPullRequest tells which modules in the repository in repodir
+// are changed by merging head into base,
+// and what version bump each would then require.
+// Each file changed since the merge base of head and base
+// belongs to the innermost module containing it.
+// The options are passed to [Check] for each touched module,
+// along with [WithBase] and [WithTarget].
+func CheckPullRequest(ctx context.Context, git, repodir, base, head string, opts ...Option) (PullRequest, error) {
+	pr := PullRequest{Base: base, Head: head}
+
+	if git == "" {
+		var err error
+		git, err = exec.LookPath("git")
+		if err != nil {
+			return pr, errors.Wrap(err, "finding git binary")
+		}
+	}
+
+	ctx = makeOptions(opts).gitContext(ctx, repodir)
+
+	mergeBase, err := gitutil.MergeBase(ctx, git, repodir, base, head)
+	if err != nil {
+		return pr, errors.Wrapf(err, "finding merge base of %s and %s", base, head)
+	}
+	pr.MergeBase = mergeBase
+
+	files, err := gitutil.ChangedFiles(ctx, git, repodir, mergeBase, head)
+	if err != nil {
+		return pr, errors.Wrapf(err, "listing files changed between %s and %s", mergeBase, head)
+	}
+
+	opts = append(opts, WithBase(mergeBase), WithTarget(head))
+
+	var moduleDirs []string
+	results := make(map[string]Result)
+	err = CheckAllFunc(ctx, git, repodir, func(moduledir string, r Result, err error) error {
+		if err != nil {
+			return errors.Wrapf(err, "checking module %s", moduledir)
+		}
+		moduleDirs = append(moduleDirs, r.ModuleSubdir)
+		results[r.ModuleSubdir] = r
+		return nil
+	}, opts...)
+	if err != nil {
+		return pr, err
+	}
+
+	touched := make(map[string][]string)
+	for _, f := range files {
+		if dir, ok := innermostModule(f, moduleDirs); ok {
+			touched[dir] = append(touched[dir], f)
+		}
+	}
+
+	for dir, files := range touched {
+		r := results[dir]
+		pr.Modules = append(pr.Modules, PullRequestModule{
+			Result: r,
+			Bump:   r.bump().String(),
+			Files:  files,
+		})
+	}
+	sort.Slice(pr.Modules, func(i, j int) bool {
+		return pr.Modules[i].Result.ModuleSubdir < pr.Modules[j].Result.ModuleSubdir
+	})
+
+	return pr, nil
+}
+
+// innermostModule returns the module directory, among dirs, that contains file
+// (both relative to the repository root, with "" for the root).
+func innermostModule(file string, dirs []string) (string, bool) {
+	var (
+		best  string
+		found bool
+	)
+	for _, dir := range dirs {
+		if dir != "" && !strings.HasPrefix(file, path.Clean(dir)+"/") {
+			continue
+		}
+		if !found || len(dir) > len(best) {
+			best, found = dir, true
+		}
+	}
+	return best, found
+}
+
+// WriteMarkdown writes a summary of pr to w
+// in a form suitable for posting as a pull-request comment.
+func (pr PullRequest) WriteMarkdown(w io.Writer) error {
+	if len(pr.Modules) == 0 {
+		_, err := fmt.Fprintf(w, "Merging `%s` into `%s` changes no Go modules.\n", pr.Head, pr.Base)
+		return err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "Merging `%s` into `%s` changes %d Go module(s):\n\n", pr.Head, pr.Base, len(pr.Modules))
+	fmt.Fprintln(&buf, "| Module | Latest version | Required bump | New version |")
+	fmt.Fprintln(&buf, "|--------|----------------|---------------|-------------|")
+
+	var reasons []string
+	for _, m := range pr.Modules {
+		r := m.Result
+		latest, newVersion := "(none)", "-"
+		if r.LatestVersion != "" {
+			latest = r.VersionPrefix + r.LatestVersion
+		}
+		if tag, ok := r.recommendedTag(); ok {
+			newVersion = tag
+		}
+		fmt.Fprintf(&buf, "| `%s` | %s | %s | %s |\n", r.Modpath, latest, m.Bump, newVersion)
+		if r.ModverResultCode != modver.None {
+			reasons = append(reasons, fmt.Sprintf("- `%s`: %s", r.Modpath, r.ModverResultString))
+		}
+	}
+	if len(reasons) > 0 {
+		fmt.Fprintf(&buf, "\n%s\n", strings.Join(reasons, "\n"))
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,73 @@
+package taggo_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/internal/testutil"
+)
+
+func TestCheckPullRequest(t *testing.T) {
+	repodir := t.TempDir()
+
+	commit := func(filename, contents string) string {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(repodir, filename)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(repodir, filename), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+		testutil.Git(t, repodir, "add", filename)
+		testutil.Git(t, repodir, "commit", "-q", "-m", "update "+filename)
+		return testutil.Git(t, repodir, "rev-parse", "HEAD")
+	}
+
+	testutil.Git(t, repodir, "init", "-q", "-b", "main")
+	commit("go.mod", "module example.com/x\n\ngo 1.22\n")
+	commit("x.go", "package x\n\nfunc X() {}\n")
+	commit("sub/go.mod", "module example.com/x/sub\n\ngo 1.22\n")
+	forkPoint := commit("sub/sub.go", "package sub\n\nfunc S() {}\n")
+	testutil.Git(t, repodir, "tag", "v1.0.0")
+	testutil.Git(t, repodir, "tag", "sub/v1.0.0")
+
+	testutil.Git(t, repodir, "checkout", "-q", "-b", "feature")
+	commit("sub/sub.go", "package sub\n\nfunc S() {}\n\n// T is new.\nfunc T() {}\n")
+
+	testutil.Git(t, repodir, "checkout", "-q", "main")
+	commit("x.go", "package x\n\n// X does nothing.\nfunc X() {}\n")
+
+	pr, err := taggo.CheckPullRequest(context.Background(), "", repodir, "main", "feature")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pr.MergeBase != forkPoint {
+		t.Errorf("got merge base %s, want %s", pr.MergeBase, forkPoint)
+	}
+	if len(pr.Modules) != 1 {
+		t.Fatalf("got %d touched modules, want 1", len(pr.Modules))
+	}
+	m := pr.Modules[0]
+	if m.Result.Modpath != "example.com/x/sub" {
+		t.Errorf("got touched module %s, want example.com/x/sub", m.Result.Modpath)
+	}
+	if len(m.Files) != 1 || m.Files[0] != "sub/sub.go" {
+		t.Errorf("got changed files %v, want [sub/sub.go]", m.Files)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := pr.WriteMarkdown(buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "`example.com/x/sub`") {
+		t.Errorf("summary does not mention the touched module:\n%s", buf)
+	}
+	if strings.Contains(buf.String(), "`example.com/x`") {
+		t.Errorf("summary mentions the untouched root module:\n%s", buf)
+	}
+}
@@ -0,0 +1,64 @@
+package taggo
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/bobg/modver/v2"
+)
+
+// prereleaseSuccessor computes the recommended successor
+// to a prerelease version with the given components,
+// given a Modver result code for the changes since then.
+//
+// The prerelease anticipates a final version major.minor.patch.
+// If that final version already accounts for changes of the size that Modver reports
+// (e.g., minor changes since v1.4.0-rc.1),
+// the successor is the next prerelease (v1.4.0-rc.2),
+// or with finalize, the final version itself (v1.4.0).
+// With no changes (modver.None) and without finalize,
+// the successor is the prerelease itself,
+// meaning no new version is needed.
+// Otherwise the successor is a final version bumped from the anticipated one,
+// as for any other release.
+//
+// The result includes the prerelease component of the successor, if any,
+// without a leading hyphen.
+func prereleaseSuccessor(major, minor, patch int, prerelease string, code modver.ResultCode, finalize bool) (int, int, int, string) {
+	var contained bool
+	switch code {
+	case modver.Major:
+		contained = minor == 0 && patch == 0
+	case modver.Minor:
+		contained = patch == 0
+	default:
+		contained = true
+	}
+
+	switch {
+	case !contained && code == modver.Major:
+		return major + 1, 0, 0, ""
+	case !contained:
+		return major, minor + 1, 0, ""
+	case finalize:
+		return major, minor, patch, ""
+	case code == modver.None:
+		return major, minor, patch, prerelease
+	default:
+		return major, minor, patch, nextPrerelease(prerelease)
+	}
+}
+
+// nextPrerelease returns the prerelease component following pre
+// (which has no leading hyphen):
+// rc.2 after rc.1, or beta.1 after beta.
+func nextPrerelease(pre string) string {
+	i := strings.LastIndex(pre, ".")
+	last := pre[i+1:]
+	if last != "" && strings.Trim(last, "0123456789") == "" {
+		if n, err := strconv.Atoi(last); err == nil {
+			return pre[:i+1] + strconv.Itoa(n+1)
+		}
+	}
+	return pre + ".1"
+}
@@ -0,0 +1,280 @@
+package taggo
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/bobg/errors"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+)
+
+// Propagation is a requirement of one module in a repository on another
+// that must be updated,
+// because the required module is getting a new version.
+// The requiring module then needs a new release too.
+// See [PlanPropagation].
+type Propagation struct {
+	// Module is the directory of the requiring module,
+	// as a key in the results passed to PlanPropagation.
+	Module string
+
+	// Dependency is the directory of the required module,
+	// as a key in the results passed to PlanPropagation.
+	Dependency string
+
+	// DependencyPath is the module path of Dependency.
+	DependencyPath string
+
+	// Required is the version of Dependency that Module's go.mod requires.
+	Required string
+
+	// NewVersion is the new version of Dependency
+	// (without any version prefix),
+	// to which Module's requirement should be updated.
+	NewVersion string
+
+	// Indirect is true if Dependency needs a new release
+	// only because of a requirement on some other module that does.
+	Indirect bool
+}
+
+// PlanPropagation finds the modules in a repository
+// that need new releases because modules they require,
+// in the same repository,
+// are getting new versions.
+// The results argument is as returned by [CheckAll] for the repository in repodir.
+// The requirements are read from the go.mod files in the working tree.
+//
+// A module needs a new release if its Result recommends one
+// or if it requires a module that needs a new release
+// at a lower version than that new release.
+// In the latter case the new release is a patch-level bump.
+// The result lists each such requirement,
+// sorted by Module and then by Dependency.
+func PlanPropagation(repodir string, results map[string]Result) ([]Propagation, error) {
+	var (
+		dirByModpath = make(map[string]string)
+		requires     = make(map[string]map[string]string) // dir -> dependency dir -> required version
+	)
+	for dir, r := range results {
+		if r.Modpath != "" {
+			dirByModpath[r.Modpath] = dir
+		}
+	}
+	for dir, r := range results {
+		gomodPath := filepath.Join(repodir, r.ModuleSubdir, "go.mod")
+		gomodBytes, err := os.ReadFile(gomodPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading %s", gomodPath)
+		}
+		gomod, err := modfile.ParseLax(gomodPath, gomodBytes, noopFixer)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing %s", gomodPath)
+		}
+		for _, req := range gomod.Require {
+			depdir, ok := dirByModpath[req.Mod.Path]
+			if !ok || depdir == dir {
+				continue
+			}
+			if requires[dir] == nil {
+				requires[dir] = make(map[string]string)
+			}
+			requires[dir][depdir] = req.Mod.Version
+		}
+	}
+
+	var (
+		newVersions = make(map[string]string) // dir -> new version
+		indirect    = make(map[string]bool)
+	)
+	for dir, r := range results {
+		if r.bump() != bumpNone {
+			newVersions[dir] = r.NewVersion()
+		}
+	}
+
+	// Add modules requiring ones that need new releases,
+	// until there are no more.
+	for changed := true; changed; {
+		changed = false
+		for dir, deps := range requires {
+			if _, ok := newVersions[dir]; ok {
+				continue
+			}
+			for depdir, required := range deps {
+				if newVersion, ok := newVersions[depdir]; ok && semver.Compare(required, newVersion) < 0 {
+					newVersions[dir] = patchBump(results[dir].LatestVersion)
+					indirect[dir] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	var props []Propagation
+	for dir, deps := range requires {
+		for depdir, required := range deps {
+			newVersion, ok := newVersions[depdir]
+			if !ok || semver.Compare(required, newVersion) >= 0 {
+				continue
+			}
+			props = append(props, Propagation{
+				Module:         dir,
+				Dependency:     depdir,
+				DependencyPath: results[depdir].Modpath,
+				Required:       required,
+				NewVersion:     newVersion,
+				Indirect:       indirect[depdir],
+			})
+		}
+	}
+	sort.Slice(props, func(i, j int) bool {
+		if props[i].Module != props[j].Module {
+			return props[i].Module < props[j].Module
+		}
+		return props[i].Dependency < props[j].Dependency
+	})
+	return props, nil
+}
+
+// patchBump is the version after v with the patch number increased,
+// or v0.1.0 if v is empty.
+func patchBump(v string) string {
+	if v == "" {
+		return "v0.1.0"
+	}
+	major, minor, patch := splitVersion(v)
+	if semver.Prerelease(v) == "" {
+		patch++
+	}
+	return fmt.Sprintf("v%d.%d.%d", major, minor, patch)
+}
+
+// UpdateRequirements rewrites the go.mod files in the working tree of repodir
+// for the modules in results
+// (as returned by [CheckAll])
+// so that their requirements on the modules in newVersions,
+// a map from module path to version,
+// are at least those versions.
+// It returns the paths of the go.mod files it changed,
+// relative to repodir,
+// in sorted order.
+//
+// Only go.mod is changed;
+// go.sum may need updating too,
+// e.g. with "go mod tidy".
+func UpdateRequirements(repodir string, results map[string]Result, newVersions map[string]string) ([]string, error) {
+	var changed []string
+	for _, r := range results {
+		gomodPath := filepath.Join(repodir, r.ModuleSubdir, "go.mod")
+		gomodBytes, err := os.ReadFile(gomodPath)
+		if err != nil {
+			return changed, errors.Wrapf(err, "reading %s", gomodPath)
+		}
+		gomod, err := modfile.Parse(gomodPath, gomodBytes, noopFixer)
+		if err != nil {
+			return changed, errors.Wrapf(err, "parsing %s", gomodPath)
+		}
+
+		var updated bool
+		for _, req := range gomod.Require {
+			newVersion, ok := newVersions[req.Mod.Path]
+			if !ok || req.Mod.Path == r.Modpath || semver.Compare(req.Mod.Version, newVersion) >= 0 {
+				continue
+			}
+			if err := gomod.AddRequire(req.Mod.Path, newVersion); err != nil {
+				return changed, errors.Wrapf(err, "updating requirement on %s in %s", req.Mod.Path, gomodPath)
+			}
+			updated = true
+		}
+		if !updated {
+			continue
+		}
+
+		gomod.Cleanup()
+		out, err := gomod.Format()
+		if err != nil {
+			return changed, errors.Wrapf(err, "formatting %s", gomodPath)
+		}
+		if err := os.WriteFile(gomodPath, out, 0644); err != nil {
+			return changed, errors.Wrapf(err, "writing %s", gomodPath)
+		}
+		changed = append(changed, filepath.ToSlash(filepath.Join(r.ModuleSubdir, "go.mod")))
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// CommitConfig holds the settings for the commit
+// that records the changes made by [UpdateRequirements].
+type CommitConfig struct {
+	// Message, if set, is a template (see [text/template])
+	// for the commit message, given a [CommitMessageData],
+	// overriding [DefaultCommitMessage].
+	// Besides the builtin functions it may use join,
+	// which is [strings.Join].
+	Message string `yaml:"message"`
+
+	// Signoff, if true, adds a Signed-off-by trailer for the committer,
+	// as with "git commit --signoff".
+	Signoff bool `yaml:"signoff"`
+
+	// Sign, if true, signs the commit,
+	// as with "git commit -S".
+	Sign bool `yaml:"sign"`
+}
+
+// DefaultCommitMessage is the template for the message of the commit
+// recording the changes made by [UpdateRequirements],
+// when [CommitConfig.Message] is not set.
+const DefaultCommitMessage = `Update requirements to {{join .Tags ", "}}`
+
+// CommitMessageData is the data available to a [CommitConfig.Message] template.
+type CommitMessageData struct {
+	// Tags are the new version tags that requirements were updated to.
+	Tags []string
+
+	// Files are the go.mod files that changed,
+	// relative to the repository root,
+	// as returned by UpdateRequirements.
+	Files []string
+}
+
+var commitMessageFuncs = template.FuncMap{
+	"join": strings.Join,
+}
+
+// CommitMessage expands c.Message
+// (or [DefaultCommitMessage] if it is not set)
+// with data.
+func (c CommitConfig) CommitMessage(data CommitMessageData) (string, error) {
+	t, err := c.template()
+	if err != nil {
+		return "", err
+	}
+	buf := new(bytes.Buffer)
+	if err := t.Execute(buf, data); err != nil {
+		return "", errors.Wrap(err, "expanding commit-message template")
+	}
+	msg := strings.TrimSpace(buf.String())
+	if msg == "" {
+		return "", fmt.Errorf("commit-message template produced an empty message")
+	}
+	return msg, nil
+}
+
+func (c CommitConfig) template() (*template.Template, error) {
+	tmpl := c.Message
+	if tmpl == "" {
+		tmpl = DefaultCommitMessage
+	}
+	t, err := template.New("").Option("missingkey=error").Funcs(commitMessageFuncs).Parse(tmpl)
+	return t, errors.Wrap(err, "parsing commit-message template")
+}
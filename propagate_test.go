@@ -0,0 +1,139 @@
+package taggo_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bobg/modver/v2"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bobg/taggo"
+)
+
+func TestPlanPropagation(t *testing.T) {
+	repodir := t.TempDir()
+
+	gomods := map[string]string{
+		"a": "module example.com/a\n\ngo 1.22\n\nrequire example.com/b v1.0.0\n",
+		"b": "module example.com/b\n\ngo 1.22\n",
+		"c": "module example.com/c\n\ngo 1.22\n\nrequire example.com/a v0.3.0\n",
+		"d": "module example.com/d\n\ngo 1.22\n\nrequire example.com/b v1.1.0\n",
+	}
+	for dir, gomod := range gomods {
+		if err := os.MkdirAll(filepath.Join(repodir, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(repodir, dir, "go.mod"), []byte(gomod), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	unchanged := func(dir, version string, major, minor, patch int) taggo.Result {
+		return taggo.Result{
+			DefaultBranch:             "main",
+			Modpath:                   "example.com/" + dir,
+			ModuleSubdir:              dir,
+			VersionPrefix:             dir + "/",
+			LatestVersion:             version,
+			LatestMajor:               major,
+			LatestMinor:               minor,
+			LatestPatch:               patch,
+			LatestCommitHasVersionTag: true,
+			NewMajor:                  major,
+			NewMinor:                  minor,
+			NewPatch:                  patch,
+		}
+	}
+
+	b := unchanged("b", "v1.0.0", 1, 0, 0)
+	b.LatestCommitHasVersionTag = false
+	b.ModverResultCode = modver.Minor
+	b.NewMinor = 1
+
+	results := map[string]taggo.Result{
+		"a": unchanged("a", "v0.3.0", 0, 3, 0),
+		"b": b,
+		"c": unchanged("c", "v0.1.0", 0, 1, 0),
+		"d": unchanged("d", "v0.1.0", 0, 1, 0), // already requires b's new version
+	}
+
+	got, err := taggo.PlanPropagation(repodir, results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []taggo.Propagation{
+		{Module: "a", Dependency: "b", DependencyPath: "example.com/b", Required: "v1.0.0", NewVersion: "v1.1.0"},
+		{Module: "c", Dependency: "a", DependencyPath: "example.com/a", Required: "v0.3.0", NewVersion: "v0.3.1", Indirect: true},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestUpdateRequirements(t *testing.T) {
+	repodir := t.TempDir()
+
+	gomods := map[string]string{
+		"a": "module example.com/a\n\ngo 1.22\n\nrequire (\n\texample.com/b v1.0.0\n\texample.com/other v0.2.0\n)\n",
+		"b": "module example.com/b\n\ngo 1.22\n",
+		"c": "module example.com/c\n\ngo 1.22\n\nrequire example.com/b v1.2.0\n",
+	}
+	results := make(map[string]taggo.Result)
+	for dir, gomod := range gomods {
+		if err := os.MkdirAll(filepath.Join(repodir, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(repodir, dir, "go.mod"), []byte(gomod), 0644); err != nil {
+			t.Fatal(err)
+		}
+		results[dir] = taggo.Result{Modpath: "example.com/" + dir, ModuleSubdir: dir}
+	}
+
+	changed, err := taggo.UpdateRequirements(repodir, results, map[string]string{"example.com/b": "v1.1.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"a/go.mod"}, changed); diff != "" {
+		t.Errorf("changed files mismatch (-want +got):\n%s", diff)
+	}
+
+	got, err := os.ReadFile(filepath.Join(repodir, "a", "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = "module example.com/a\n\ngo 1.22\n\nrequire (\n\texample.com/b v1.1.0\n\texample.com/other v0.2.0\n)\n"
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("a/go.mod mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCommitMessage(t *testing.T) {
+	data := taggo.CommitMessageData{Tags: []string{"v1.2.0", "sub/v0.3.0"}, Files: []string{"go.mod"}}
+
+	cases := []struct {
+		tmpl, want string
+		wantErr    bool
+	}{
+		{tmpl: "", want: "Update requirements to v1.2.0, sub/v0.3.0"},
+		{tmpl: "chore(deps): bump {{join .Tags \" \"}}\n\nChanged {{len .Files}} file(s).\n", want: "chore(deps): bump v1.2.0 sub/v0.3.0\n\nChanged 1 file(s)."},
+		{tmpl: "{{.Nonesuch}}", wantErr: true},
+		{tmpl: "{{if false}}x{{end}}", wantErr: true},
+	}
+	for _, tc := range cases {
+		got, err := taggo.CommitConfig{Message: tc.tmpl}.CommitMessage(data)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("got no error for template %q", tc.tmpl)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("template %q: %s", tc.tmpl, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("template %q: got %q, want %q", tc.tmpl, got, tc.want)
+		}
+	}
+}
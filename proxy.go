@@ -0,0 +1,173 @@
+package taggo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bobg/errors"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// ProxyPoll controls [WaitForProxy].
+type ProxyPoll struct {
+	// Timeout is how long to keep polling.
+	// Zero means until the context is done.
+	Timeout time.Duration
+
+	// Interval is the delay after the first unsuccessful attempt.
+	// It doubles after each further one, up to MaxInterval.
+	// The default is 2 seconds.
+	Interval time.Duration
+
+	// MaxInterval is the longest delay between attempts.
+	// The default is 30 seconds.
+	MaxInterval time.Duration
+
+	// Warm means request the version itself from the proxy,
+	// which makes a caching proxy such as proxy.golang.org fetch it from the origin
+	// if it does not have it yet.
+	// Otherwise WaitForProxy only watches the proxy's list of versions of the module,
+	// waiting for the proxy to discover the new version on its own.
+	Warm bool
+}
+
+// ProxyAvailability is the result of [WaitForProxy].
+type ProxyAvailability struct {
+	// ProxyURL is the base URL of the module proxy that was polled.
+	ProxyURL string
+
+	// Available is true if the proxy serves the version,
+	// so that "go get MODULE@VERSION" works through it.
+	Available bool
+
+	// Attempts is the number of requests made to the proxy.
+	Attempts int
+
+	// Elapsed is how long the polling took.
+	Elapsed time.Duration
+}
+
+// WaitForProxy polls the module proxy named by $GOPROXY
+// (by default proxy.golang.org)
+// until it serves version of the module modpath
+// or poll.Timeout runs out,
+// with exponential backoff between attempts.
+// Running out of time is not an error;
+// the result's Available field is false.
+//
+// The version is canonicalized,
+// since the go command ignores any build metadata.
+//
+// This requires network access.
+// The [WithHTTPClient] option applies.
+// With the [WithOffline] option, WaitForProxy returns [ErrOffline].
+func WaitForProxy(ctx context.Context, modpath, version string, poll ProxyPoll, opts ...Option) (ProxyAvailability, error) {
+	var (
+		a ProxyAvailability
+		o = makeOptions(opts)
+	)
+
+	if o.offline {
+		return a, ErrOffline
+	}
+
+	proxyURL, err := goProxyURL(os.Getenv("GOPROXY"))
+	if err != nil {
+		return a, err
+	}
+	a.ProxyURL = proxyURL
+
+	if !semver.IsValid(version) {
+		return a, fmt.Errorf("%s is not a valid semantic version", version)
+	}
+	version = semver.Canonical(version)
+
+	escPath, err := module.EscapePath(modpath)
+	if err != nil {
+		return a, errors.Wrapf(err, "escaping module path %s", modpath)
+	}
+	escVersion, err := module.EscapeVersion(version)
+	if err != nil {
+		return a, errors.Wrapf(err, "escaping version %s", version)
+	}
+
+	probe := func() (bool, error) {
+		if poll.Warm {
+			_, found, err := httpGet(ctx, o.httpClient, fmt.Sprintf("%s/%s/@v/%s.info", proxyURL, escPath, escVersion))
+			return found, err
+		}
+		body, found, err := httpGet(ctx, o.httpClient, fmt.Sprintf("%s/%s/@v/list", proxyURL, escPath))
+		if err != nil || !found {
+			return false, err
+		}
+		sc := bufio.NewScanner(bytes.NewReader(body))
+		for sc.Scan() {
+			if strings.TrimSpace(sc.Text()) == version {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	var (
+		delay       = poll.Interval
+		maxInterval = poll.MaxInterval
+		start       = time.Now()
+	)
+	if delay <= 0 {
+		delay = 2 * time.Second
+	}
+	if maxInterval <= 0 {
+		maxInterval = 30 * time.Second
+	}
+
+	for {
+		a.Attempts++
+		found, err := probe()
+		a.Elapsed = time.Since(start)
+		if err != nil {
+			return a, errors.Wrapf(err, "polling module proxy for %s@%s", modpath, version)
+		}
+		if found {
+			a.Available = true
+			return a, nil
+		}
+
+		if poll.Timeout > 0 {
+			remaining := poll.Timeout - a.Elapsed
+			if remaining <= 0 {
+				return a, nil
+			}
+			delay = min(delay, remaining)
+		}
+
+		select {
+		case <-ctx.Done():
+			return a, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay = min(2*delay, maxInterval)
+	}
+}
+
+// goProxyURL is the URL of the first module proxy in goproxy,
+// a value of $GOPROXY,
+// which defaults to proxy.golang.org.
+func goProxyURL(goproxy string) (string, error) {
+	if goproxy == "" {
+		return proxyBaseURL, nil
+	}
+	first, _, _ := strings.Cut(goproxy, ",")
+	first, _, _ = strings.Cut(first, "|")
+	first = strings.TrimSpace(first)
+	if !strings.HasPrefix(first, "https://") && !strings.HasPrefix(first, "http://") {
+		return "", fmt.Errorf("GOPROXY=%s does not begin with a module proxy URL to poll", goproxy)
+	}
+	return strings.TrimSuffix(first, "/"), nil
+}
@@ -0,0 +1,81 @@
+package taggo_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bobg/taggo"
+)
+
+func TestWaitForProxy(t *testing.T) {
+	var listRequests, infoRequests atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/example.com/!x/@v/list":
+			// The new version shows up in the list on the third request.
+			fmt.Fprintln(w, "v1.0.0")
+			if listRequests.Add(1) >= 3 {
+				fmt.Fprintln(w, "v1.1.0")
+			}
+		case "/example.com/!x/@v/v1.1.0.info":
+			infoRequests.Add(1)
+			fmt.Fprint(w, `{"Version":"v1.1.0"}`)
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	defer srv.Close()
+
+	t.Setenv("GOPROXY", srv.URL+",direct")
+
+	var (
+		ctx  = context.Background()
+		poll = taggo.ProxyPoll{Interval: time.Millisecond, MaxInterval: 2 * time.Millisecond, Timeout: 10 * time.Second}
+	)
+
+	// Build metadata is ignored.
+	a, err := taggo.WaitForProxy(ctx, "example.com/X", "v1.1.0+build.7", poll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !a.Available || a.Attempts != 3 || a.ProxyURL != srv.URL {
+		t.Errorf("got %+v, want available from %s after 3 attempts", a, srv.URL)
+	}
+	if n := infoRequests.Load(); n != 0 {
+		t.Errorf("got %d info requests without Warm, want 0", n)
+	}
+
+	poll.Warm = true
+	a, err = taggo.WaitForProxy(ctx, "example.com/X", "v1.1.0", poll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !a.Available || a.Attempts != 1 || infoRequests.Load() != 1 {
+		t.Errorf("got %+v and %d info requests, want available after 1 request", a, infoRequests.Load())
+	}
+
+	poll.Timeout = 20 * time.Millisecond
+	a, err = taggo.WaitForProxy(ctx, "example.com/X", "v1.2.0", poll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Available || a.Attempts < 2 {
+		t.Errorf("got %+v, want unavailable after several attempts", a)
+	}
+
+	if _, err := taggo.WaitForProxy(ctx, "example.com/X", "v1.1.0", poll, taggo.WithOffline()); !errors.Is(err, taggo.ErrOffline) {
+		t.Errorf("got error %v with WithOffline, want ErrOffline", err)
+	}
+
+	t.Setenv("GOPROXY", "direct")
+	if _, err := taggo.WaitForProxy(ctx, "example.com/X", "v1.1.0", poll); err == nil {
+		t.Error("got no error with GOPROXY=direct")
+	}
+}
@@ -0,0 +1,132 @@
+package taggo
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bobg/errors"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+
+	"github.com/bobg/taggo/gitutil"
+)
+
+// PseudoVersion computes the version by which the Go toolchain knows revision rev
+// (default HEAD)
+// of the module in moduledir
+// (in the repository in repodir),
+// so that tooling can pin an unreleased commit precisely.
+//
+// That is the pseudo-version vX.Y.Z-0.yyyymmddhhmmss-abcdef123456
+// (or a variant of it)
+// based on the highest version tag of the module reachable from rev,
+// with the commit's time in UTC and its 12-character abbreviated hash.
+// Only tags agreeing with the major-version suffix of the module path in rev's go.mod count,
+// and only canonical ones,
+// so v1.2 and v1.2.3+meta do not.
+// If rev itself has such a tag,
+// the toolchain resolves the commit to that version instead,
+// and PseudoVersion returns it.
+//
+// If git is "", PseudoVersion uses the result of exec.LookPath("git").
+func PseudoVersion(ctx context.Context, git, repodir, moduledir, rev string) (string, error) {
+	if git == "" {
+		var err error
+		git, err = exec.LookPath("git")
+		if err != nil {
+			return "", errors.Wrap(err, "finding git binary")
+		}
+	}
+	if rev == "" {
+		rev = "HEAD"
+	}
+
+	moduledir, err := moduleSubdir(repodir, moduledir)
+	if err != nil {
+		return "", err
+	}
+	moduledir = filepath.ToSlash(moduledir)
+
+	var versionPrefix string
+	if moduledir != "" {
+		versionPrefix = moduledir + "/"
+	}
+
+	commit, err := gitutil.ResolveCommit(ctx, git, repodir, rev)
+	if err != nil {
+		return "", errors.Wrapf(err, "resolving %s", rev)
+	}
+
+	gomodPath := path.Join(moduledir, "go.mod")
+	gomodBytes, err := gitutil.Show(ctx, git, repodir, commit, gomodPath)
+	if err != nil {
+		return "", errors.Wrapf(err, "reading %s at %s", gomodPath, rev)
+	}
+	modpath := modfile.ModulePath(gomodBytes)
+	if modpath == "" {
+		return "", fmt.Errorf("%s at %s has no module directive", gomodPath, rev)
+	}
+	_, pathMajor, ok := module.SplitPathVersion(modpath)
+	if !ok {
+		return "", fmt.Errorf("invalid module path %s in %s at %s", modpath, gomodPath, rev)
+	}
+
+	// A tagged commit has its tag's version.
+	cmd, done := gitutil.Command(ctx, git, repodir, "tag", "--points-at", commit)
+	output, err := cmd.Output()
+	if err = done(err); err != nil {
+		return "", errors.Wrapf(err, "running %s", cmd)
+	}
+	if v := highestModuleVersion(strings.Fields(string(output)), versionPrefix, pathMajor); v != "" {
+		return v, nil
+	}
+
+	merged, err := gitutil.TagsMerged(ctx, git, repodir, commit)
+	if err != nil {
+		return "", errors.Wrapf(err, "getting tags reachable from %s", rev)
+	}
+	var tags []string
+	for tag := range merged {
+		tags = append(tags, tag)
+	}
+	base := highestModuleVersion(tags, versionPrefix, pathMajor)
+
+	timeStr, err := gitutil.CommitTime(ctx, git, repodir, commit)
+	if err != nil {
+		return "", errors.Wrapf(err, "getting time of %s", rev)
+	}
+	t, err := time.Parse(time.RFC3339, timeStr)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing time of %s", rev)
+	}
+
+	major := "v0"
+	if pathMajor != "" {
+		major = module.PathMajorPrefix(pathMajor)
+	}
+
+	return module.PseudoVersion(major, base, t, commit[:12]), nil
+}
+
+// highestModuleVersion returns the highest version (after versionPrefix) among tags
+// that the Go toolchain accepts for a module whose path has the major-version suffix pathMajor,
+// or "" if there is none.
+func highestModuleVersion(tags []string, versionPrefix, pathMajor string) string {
+	var result string
+	for _, tag := range tags {
+		v, ok := strings.CutPrefix(tag, versionPrefix)
+		if !ok || !semver.IsValid(v) || v != semver.Canonical(v) || module.CheckPathMajor(v, pathMajor) != nil {
+			continue
+		}
+		if result == "" || semver.Compare(v, result) > 0 {
+			result = v
+		}
+	}
+	return result
+}
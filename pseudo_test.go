@@ -0,0 +1,73 @@
+package taggo_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/internal/testutil"
+)
+
+func TestPseudoVersion(t *testing.T) {
+	repodir := t.TempDir()
+	t.Setenv("GIT_COMMITTER_DATE", "2024-01-02T10:04:05-05:00")
+
+	write := func(name, contents string) {
+		t.Helper()
+		filename := filepath.Join(repodir, name)
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := context.Background()
+
+	check := func(moduledir, rev, want string) {
+		t.Helper()
+		got, err := taggo.PseudoVersion(ctx, "", repodir, filepath.Join(repodir, moduledir), rev)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	}
+	hash := func() string {
+		t.Helper()
+		return testutil.Git(t, repodir, "rev-parse", "HEAD")[:12]
+	}
+
+	testutil.Git(t, repodir, "init", "-q", "-b", "main")
+	write("go.mod", "module example.com/x\n")
+	write("sub/go.mod", "module example.com/x/sub\n")
+	testutil.Git(t, repodir, "add", ".")
+	testutil.Git(t, repodir, "commit", "-q", "-m", "first")
+
+	check("", "", "v0.0.0-20240102150405-"+hash())
+
+	testutil.Git(t, repodir, "tag", "v0.1.0")
+	testutil.Git(t, repodir, "tag", "v0.1")        // floating
+	testutil.Git(t, repodir, "tag", "v0.9.0+meta") // not canonical
+	check("", "", "v0.1.0")
+	check("sub", "", "v0.0.0-20240102150405-"+hash())
+
+	testutil.Git(t, repodir, "commit", "-q", "--allow-empty", "-m", "second")
+	check("", "", "v0.1.1-0.20240102150405-"+hash())
+	check("", "HEAD~1", "v0.1.0")
+
+	testutil.Git(t, repodir, "tag", "v0.2.0-rc.1")
+	testutil.Git(t, repodir, "tag", "sub/v1.0.0")
+	testutil.Git(t, repodir, "commit", "-q", "--allow-empty", "-m", "third")
+	check("", "", "v0.2.0-rc.1.0.20240102150405-"+hash())
+	check("sub", "", "v1.0.1-0.20240102150405-"+hash())
+
+	// Tags without the major-version suffix of the module path do not count.
+	write("go.mod", "module example.com/x/v2\n")
+	testutil.Git(t, repodir, "commit", "-q", "-a", "-m", "fourth")
+	check("", "", "v2.0.0-20240102150405-"+hash())
+}
@@ -0,0 +1,184 @@
+package taggo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/bobg/errors"
+)
+
+// Names of the release-readiness checks,
+// for use in Config.Readiness.
+const (
+	// ReadinessLicense checks that the module has a license file,
+	// in its own directory or at the repository root,
+	// without which pkg.go.dev will not show its documentation.
+	ReadinessLicense = "license"
+
+	// ReadinessVet checks that "go vet ./..." reports no problems in the module.
+	ReadinessVet = "vet"
+
+	// ReadinessTest checks that "go test ./..." passes in the module.
+	ReadinessTest = "test"
+
+	// ReadinessDocMarkers checks that no doc comment on an exported identifier
+	// in the module's importable packages contains TODO or FIXME.
+	ReadinessDocMarkers = "doc-markers"
+)
+
+// ReadinessCheck is the outcome of one release-readiness check.
+// See Config.Readiness.
+type ReadinessCheck struct {
+	// Name is the check's name, such as [ReadinessLicense].
+	Name string
+
+	// OK is true if the check passed.
+	OK bool
+
+	// Detail explains a failure.
+	Detail string
+}
+
+// readinessMarkers are the words in doc comments that [ReadinessDocMarkers] reports.
+var readinessMarkers = []string{"TODO", "FIXME"}
+
+// maxReadinessDetail is the most lines of command output that a ReadinessCheck's Detail includes.
+const maxReadinessDetail = 10
+
+// readinessChecks runs the named release-readiness checks
+// on the module (with module path modpath) in moduledir
+// (relative to the repository root, "" for the root)
+// in the working tree of the repository in repodir.
+// If offline is true, the go command may use only the local module cache.
+func readinessChecks(ctx context.Context, repodir, moduledir, modpath string, names []string, offline bool) ([]ReadinessCheck, error) {
+	var (
+		checks []ReadinessCheck
+		moddir = filepath.Join(repodir, moduledir)
+	)
+
+	for _, name := range names {
+		check := ReadinessCheck{Name: name, OK: true}
+
+		switch name {
+		case ReadinessLicense:
+			found, err := hasLicenseFile(moddir)
+			if err == nil && !found && moduledir != "" {
+				found, err = hasLicenseFile(repodir)
+			}
+			if err != nil {
+				return checks, errors.Wrap(err, "looking for license file")
+			}
+			if !found {
+				check.OK = false
+				check.Detail = "no LICENSE file in the module or at the repository root"
+			}
+
+		case ReadinessVet, ReadinessTest:
+			output, ok, err := goCmdPasses(ctx, moddir, offline, name, "./...")
+			if err != nil {
+				return checks, errors.Wrapf(err, "running go %s", name)
+			}
+			if !ok {
+				check.OK = false
+				check.Detail = firstLines(output, maxReadinessDetail)
+			}
+
+		case ReadinessDocMarkers:
+			blockers, err := apiMarkerBlockers(moddir, modpath, readinessMarkers)
+			if err != nil {
+				return checks, errors.Wrap(err, "scanning exported API")
+			}
+			if len(blockers) > 0 {
+				var msgs []string
+				for _, b := range blockers {
+					msgs = append(msgs, b.Message)
+				}
+				check.OK = false
+				check.Detail = firstLines(strings.Join(msgs, "\n"), maxReadinessDetail)
+			}
+
+		default:
+			return checks, fmt.Errorf("unknown readiness check %q", name)
+		}
+
+		checks = append(checks, check)
+	}
+
+	return checks, nil
+}
+
+// hasLicenseFile tells whether dir contains a license file,
+// such as LICENSE, LICENSE.md, LICENCE, or COPYING.
+func hasLicenseFile(dir string) (bool, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false, errors.Wrapf(err, "reading directory %s", dir)
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		name := strings.ToUpper(e.Name())
+		for _, prefix := range []string{"LICENSE", "LICENCE", "COPYING"} {
+			if strings.HasPrefix(name, prefix) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// goCmdPasses runs the go command with the given arguments in moddir.
+// It returns the command's combined output,
+// and whether it exited successfully.
+// The error result is for failures to run the command at all.
+func goCmdPasses(ctx context.Context, moddir string, offline bool, args ...string) (string, bool, error) {
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		return "", false, errors.Wrap(err, "finding go binary")
+	}
+
+	var output bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, gobin, args...)
+	cmd.Dir = moddir
+	cmd.Env = goCmdEnv(offline)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	err = cmd.Run()
+
+	var ee *exec.ExitError
+	if errors.As(err, &ee) {
+		return output.String(), false, nil
+	}
+	if err != nil {
+		return "", false, errors.Wrapf(err, "running %s", cmd)
+	}
+	return output.String(), true, nil
+}
+
+// firstLines returns the first n lines of s,
+// noting how many more there are, if any.
+func firstLines(s string, n int) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	if len(lines) <= n {
+		return strings.Join(lines, "\n")
+	}
+	return fmt.Sprintf("%s\n(and %d more lines)", strings.Join(lines[:n], "\n"), len(lines)-n)
+}
+
+// ReadinessFailures returns the names of the failed checks in r.Readiness.
+func (r Result) ReadinessFailures() []string {
+	var names []string
+	for _, c := range r.Readiness {
+		if !c.OK {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
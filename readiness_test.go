@@ -0,0 +1,72 @@
+package taggo_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/internal/testutil"
+)
+
+func TestReadiness(t *testing.T) {
+	repodir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod":        "module example.com/x\n\ngo 1.22\n",
+		"x.go":          "package x\n\nimport \"fmt\"\n\n// X does something.\n// TODO: make it do something else.\nfunc X() string {\n\treturn fmt.Sprintf(\"%d\", \"x\")\n}\n",
+		"x_test.go":     "package x\n\nimport \"testing\"\n\nfunc TestX(t *testing.T) {\n\tt.Fail()\n}\n",
+		"sub/go.mod":    "module example.com/x/sub\n\ngo 1.22\n",
+		"sub/sub.go":    "package sub\n\n// S is done.\nfunc S() {}\n",
+		"sub/LICENSE":   "Public domain.\n",
+		"sub/s_test.go": "package sub\n\nimport \"testing\"\n\nfunc TestS(t *testing.T) {}\n",
+	}
+	for name, content := range files {
+		filename := filepath.Join(repodir, name)
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	testutil.Git(t, repodir, "init", "-q", "-b", "main")
+	testutil.Git(t, repodir, "add", ".")
+	testutil.Git(t, repodir, "commit", "-q", "-m", "initial")
+
+	config := taggo.Config{
+		Readiness: []string{taggo.ReadinessLicense, taggo.ReadinessVet, taggo.ReadinessTest, taggo.ReadinessDocMarkers},
+	}
+	ctx := context.Background()
+
+	result, err := taggo.Check(ctx, "", repodir, "", taggo.WithConfig(config), taggo.WithOffline())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Readiness) != 4 {
+		t.Fatalf("got %d readiness checks, want 4", len(result.Readiness))
+	}
+	for _, c := range result.Readiness {
+		if c.OK {
+			t.Errorf("readiness check %s passed, want failure", c.Name)
+		}
+	}
+	if !strings.Contains(result.Readiness[3].Detail, "TODO") {
+		t.Errorf("got doc-markers detail %q, want one mentioning TODO", result.Readiness[3].Detail)
+	}
+
+	result, err = taggo.Check(ctx, "", repodir, filepath.Join(repodir, "sub"), taggo.WithConfig(config), taggo.WithOffline())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failed := result.ReadinessFailures(); len(failed) > 0 {
+		t.Errorf("got failed readiness checks %v for sub, want none", failed)
+	}
+
+	config.Readiness = []string{"nonesuch"}
+	if _, err := taggo.Check(ctx, "", repodir, "", taggo.WithConfig(config)); err == nil {
+		t.Error("got no error for unknown readiness check")
+	}
+}
@@ -0,0 +1,40 @@
+package taggo
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo/gitutil"
+)
+
+// releaseMergeCommit finds the latest merge commit on branch
+// (following only first parents)
+// whose commit message matches pattern.
+// It returns "" if there is none.
+func releaseMergeCommit(ctx context.Context, git, repodir, branch, pattern string) (string, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", errors.Wrapf(err, "compiling release merge pattern %s", pattern)
+	}
+
+	cmd, done := gitutil.Command(ctx, git, repodir, "log", "--merges", "--first-parent", "--format=%H%x00%B%x00", "refs/heads/"+branch)
+	output, err := cmd.Output()
+	if err = done(err); err != nil {
+		return "", errors.Wrapf(err, "running %s", cmd)
+	}
+
+	fields := strings.Split(string(output), "\x00")
+	for i := 0; i+1 < len(fields); i += 2 {
+		var (
+			hash = strings.TrimSpace(fields[i])
+			msg  = fields[i+1]
+		)
+		if re.MatchString(msg) {
+			return hash, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,156 @@
+package taggo
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// Remediation is a concrete fix for a warning:
+// a shell command to run from the repository root.
+type Remediation struct {
+	// ID is the ID of the warning's finding.
+	ID string
+
+	// Command is the shell command that fixes the problem.
+	Command string
+}
+
+// remediations returns the fixes for the warnings in r.
+func (r Result) remediations() []Remediation {
+	var result []Remediation
+	for _, f := range r.Findings() {
+		if f.Kind == FindingWarning && f.Remediation != "" {
+			result = append(result, Remediation{ID: f.ID, Command: f.Remediation})
+		}
+	}
+	return result
+}
+
+// remediation returns a shell command fixing the warning with the given finding ID,
+// or "" if there is no such command.
+func (r Result) remediation(id string) string {
+	remote := r.Remote
+	if remote == "" {
+		remote = "origin"
+	}
+
+	switch id {
+	case "recommended-version", "no-version-tags":
+		tag, ok := r.recommendedTag()
+		if !ok || r.LatestCommit == "" {
+			return ""
+		}
+		cmd := fmt.Sprintf("git tag -a -m 'Version %s' %s %s", tag, tag, r.LatestCommit)
+		if !r.NoRemote {
+			cmd += fmt.Sprintf(" && git push %s %s", remote, tag)
+		}
+		return cmd
+
+	case "action-major-tag-missing":
+		tag := r.Action.MajorTag
+		cmd := fmt.Sprintf("git tag %s %s^{commit}", tag, r.LatestVersion)
+		if !r.NoRemote {
+			cmd += fmt.Sprintf(" && git push %s %s", remote, tag)
+		}
+		return cmd
+
+	case "action-release-steps":
+		tag, ok := r.recommendedTag()
+		if !ok || r.LatestCommit == "" {
+			return ""
+		}
+		major := semver.Major(tag)
+		cmd := fmt.Sprintf("git tag -a -m 'Version %s' %s %s && git tag -f %s %s", tag, tag, r.LatestCommit, major, r.LatestCommit)
+		if !r.NoRemote {
+			cmd += fmt.Sprintf(" && git push %s %s && git push -f %s %s", remote, tag, remote, major)
+		}
+		if r.ForgeKind == ForgeGitHub && !r.NoRemote {
+			cmd += fmt.Sprintf(" && gh release create %s --generate-notes", tag)
+		}
+		return cmd
+
+	case "malformed-version-tags":
+		var cmds, tags []string
+		for _, m := range r.MalformedVersionTags {
+			tag := r.VersionPrefix + m.Version
+			cmds = append(cmds, fmt.Sprintf("git tag -a -m 'Version %s' %s %s", tag, tag, m.Commit))
+			tags = append(tags, tag)
+		}
+		if len(cmds) == 0 {
+			return ""
+		}
+		if !r.NoRemote {
+			cmds = append(cmds, fmt.Sprintf("git push %s %s", remote, strings.Join(tags, " ")))
+		}
+		return strings.Join(cmds, " && ")
+
+	case "stale-floating-tags":
+		var cmds, tags []string
+		for _, f := range r.StaleFloatingTags {
+			cmds = append(cmds, fmt.Sprintf("git tag -f %s %s", f.Tag, f.LatestCommit))
+			tags = append(tags, f.Tag)
+		}
+		if len(cmds) == 0 {
+			return ""
+		}
+		if !r.NoRemote {
+			cmds = append(cmds, fmt.Sprintf("git push -f %s %s", remote, strings.Join(tags, " ")))
+		}
+		return strings.Join(cmds, " && ")
+
+	case "remote-only-tags":
+		return "git fetch --tags " + remote
+
+	case "diverged":
+		return fmt.Sprintf("git checkout %s && git pull --rebase %s %s", r.DefaultBranch, remote, r.DefaultBranch)
+
+	case "version-suffix-mismatch", "version-suffix-missing":
+		return r.inModule("go mod edit -module " + r.modpathForMajor(r.LatestMajor))
+
+	case "version-suffix-unwanted":
+		return r.inModule("go mod edit -module " + r.modpathForMajor(0))
+
+	case "new-version-suffix":
+		return r.inModule("go mod edit -module " + r.modpathForMajor(r.NewMajor))
+
+	case "untidy":
+		return r.inModule("go mod tidy")
+
+	case "local-replace":
+		var args []string
+		for _, d := range r.LocalReplaceDirectives {
+			old, _, _ := strings.Cut(d, " => ")
+			args = append(args, "-dropreplace="+strings.Replace(strings.TrimSpace(old), " ", "@", 1))
+		}
+		if len(args) == 0 {
+			return ""
+		}
+		return r.inModule("go mod edit " + strings.Join(args, " "))
+	}
+
+	return ""
+}
+
+// modpathForMajor is r.Modpath with the version suffix for the given major version,
+// or with none if major is less than 2.
+func (r Result) modpathForMajor(major int) string {
+	prefix, _, ok := module.SplitPathVersion(r.Modpath)
+	if !ok {
+		prefix = r.Modpath
+	}
+	if major < 2 {
+		return prefix
+	}
+	return fmt.Sprintf("%s/v%d", prefix, major)
+}
+
+// inModule makes cmd run in the module's directory.
+func (r Result) inModule(cmd string) string {
+	if r.ModuleSubdir == "" || r.ModuleSubdir == "." {
+		return cmd
+	}
+	return fmt.Sprintf("(cd %s && %s)", r.ModuleSubdir, cmd)
+}
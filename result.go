@@ -3,16 +3,55 @@ package taggo
 import (
 	"fmt"
 	"io"
+	"strings"
+	"time"
 
 	"github.com/bobg/modver/v2"
 )
 
+// ResultSchemaVersion is the version of the JSON form of [Result]
+// (see [ResultSchema]).
+// It changes whenever a change to Result could break a consumer of that JSON,
+// such as the removal, renaming, or retyping of a field.
+// Adding a field does not change it.
+const ResultSchemaVersion = 1
+
 // Result holds the results of a call to [Check].
 type Result struct {
+	// SchemaVersion is the value of [ResultSchemaVersion]
+	// for the taggo that produced this Result.
+	SchemaVersion int
+
 	// DefaultBranch is the name of the default branch of the repository, typically "main" or "master".
-	// This is determined heuristically from the repository's remote refs.
+	// This is determined from the repository's remote refs,
+	// unless Config.DefaultBranch is set
+	// or there are no remotes (see NoRemote).
+	// With the [WithBranch] option, this is instead the branch being analyzed.
 	DefaultBranch string
 
+	// Ahead and Behind are the numbers of commits
+	// by which the local default branch is ahead of and behind
+	// its counterpart in Remote.
+	// See [Result.Diverged].
+	// Valid only when Remote is not empty.
+	Ahead, Behind int
+
+	// Branch is the branch named with the [WithBranch] option, if any.
+	// When it is set, the version information in this Result
+	// is limited to the version tags reachable from it.
+	Branch string
+
+	// Target is the Git ref named with the [WithTarget] option, if any.
+	// When it is set, LatestCommit is the commit it names,
+	// and the version information in this Result
+	// is limited to the version tags reachable from it.
+	Target string
+
+	// Base is the Git ref with which the module was compared,
+	// instead of the latest version tag,
+	// when the [WithBase] option is used.
+	Base string
+
 	// LatestVersion is the highest semantic version tag in the repository.
 	LatestVersion string
 
@@ -20,6 +59,14 @@ type Result struct {
 	// Valid only when DefaultBranch is not empty.
 	LatestCommit string
 
+	// LatestCommitAuthor is the author of LatestCommit, as "name <email>",
+	// LatestCommitTime is its committer time,
+	// and LatestCommitSubject is the first line of its message.
+	// Valid only when LatestCommit is not empty.
+	LatestCommitAuthor  string
+	LatestCommitTime    time.Time
+	LatestCommitSubject string
+
 	// LatestCommitHasLatestVersion is true if the latest commit on the main branch is tagged with the highest semantic version.
 	// Valid only when DefaultBranch and LatestVersion are both non-empty.
 	LatestCommitHasLatestVersion bool
@@ -28,10 +75,23 @@ type Result struct {
 	// Valid only when DefaultBranch is not empty.
 	LatestCommitHasVersionTag bool
 
+	// NearestTag is the version tag nearest to LatestCommit,
+	// with the distance to it,
+	// as reported by "git describe --tags".
+	// Unlike LatestVersion,
+	// it is reachable from LatestCommit,
+	// but it may not be the latest version reachable from there.
+	// Valid only when LatestCommit is not empty.
+	NearestTag *NearestTag
+
 	// LatestMajor, LatestMinor, LatestPatch are the major, minor, and patch components of the latest version tag.
 	// Valid only when LatestVersion is not empty.
 	LatestMajor, LatestMinor, LatestPatch int
 
+	// LatestVersionTime is the commit time of LatestVersion.
+	// Populated only when the [WithReleaseAge] option is used and LatestVersion is not empty.
+	LatestVersionTime time.Time
+
 	// LatestVersionIsPrerelease is true if the latest version tag is a prerelease.
 	// Valid only when LatestVersion is not empty.
 	LatestVersionIsPrerelease bool
@@ -41,6 +101,45 @@ type Result struct {
 	// Valid only when LatestVersion is not empty.
 	LatestVersionUnstable bool
 
+	// ExcludeDirectives lists the exclude directives in go.mod, as "path version".
+	ExcludeDirectives []string
+
+	// GoDirective and ToolchainDirective are the go and toolchain directives in go.mod
+	// as of the latest commit on the default branch,
+	// and LatestVersionGoDirective and LatestVersionToolchainDirective are the same as of the latest version tag.
+	// The toolchain directives may be empty.
+	// Valid only when DefaultBranch and LatestVersion are not empty and LatestCommitHasVersionTag is false.
+	GoDirective, ToolchainDirective                           string
+	LatestVersionGoDirective, LatestVersionToolchainDirective string
+
+	// GoDirectiveBump is true if the recommended new version was raised to at least a minor-version bump
+	// because the go or toolchain directive was raised
+	// and the configuration requires that (see [Config]).
+	GoDirectiveBump bool
+
+	// PolicyBump, if not modver.None,
+	// is the version bump that the bump policy in the configuration (see [BumpConfig])
+	// chose for the changes in ModverResultCode,
+	// when that differs from the usual one.
+	PolicyBump modver.ResultCode
+
+	// KnownDependents is the number of known dependents of the latest version of the module,
+	// according to deps.dev.
+	// Valid only when the [WithDependents] option is used
+	// and NewMajor is greater than LatestMajor.
+	KnownDependents int
+
+	// LightweightVersionTags lists the version tags that are lightweight rather than annotated
+	// (after removing any VersionPrefix).
+	// Populated only when [WithAnnotatedTagsRequired] is used.
+	LightweightVersionTags []string
+
+	// LocalReplaceDirectives lists the replace directives in go.mod
+	// whose replacements are filesystem paths
+	// (including paths within the same repository).
+	// These are a subset of ReplaceDirectives.
+	LocalReplaceDirectives []string
+
 	// Modpath is the import path of the Go module.
 	Modpath string
 
@@ -51,6 +150,15 @@ type Result struct {
 	// we'd expect Modpath to end with .../foo/bar.
 	ModpathMismatch bool
 
+	// ModpathRemoteMismatch is true if Modpath
+	// (excluding any version suffix)
+	// is not within the repository that RemoteURL names,
+	// as after a fork or a repository transfer.
+	// The go command fetches the module from its module path,
+	// not from this remote.
+	// Vanity import paths, whose hosts are not forges, are not checked.
+	ModpathRemoteMismatch bool
+
 	// ModuleSubdir is the subdir in the repository where the module lives.
 	ModuleSubdir string
 
@@ -64,18 +172,252 @@ type Result struct {
 	// Valid only when DefaultBranch is not empty and LatestCommitHasVersionTag is false.
 	ModverResultString string
 
+	// ModverReport is Modver's full report of the changes since the latest version,
+	// which may span many lines.
+	// Populated only when [WithModverReport] is used.
+	ModverReport string
+
+	// BuildContext describes the build tags, GOOS, and GOARCH
+	// under which Modver compared the module's packages
+	// (see [BuildContext]),
+	// or is empty for the go command's defaults.
+	BuildContext string
+
+	// UnreleasedCommitSummaries describes the commits counted in UnreleasedCommits,
+	// newest first.
+	// Populated only when [WithCommitSummaries] is used.
+	UnreleasedCommitSummaries []CommitSummary
+
+	// Action describes the GitHub Action defined in the repository,
+	// if the module is at the repository root
+	// and the repository has an action.yml or action.yaml file there.
+	Action *ActionInfo
+
+	// APIChanges lists the changes to the module's API
+	// that explain ModverResultCode,
+	// when it is not None.
+	// Modver reports only the first change it finds that determines its result.
+	APIChanges []APIChange
+
 	// NewMajor, NewMinor, NewPatch are the major, minor, and patch components of the recommended new version.
 	// Valid when DefaultBranch is not empty and LatestCommitHasVersionTag is false,
 	// or when there are not yet any version tags
 	// (in which case the recommended new version is v0.1.0).
 	NewMajor, NewMinor, NewPatch int
 
+	// NewPrerelease is the prerelease component of the recommended new version
+	// (without the leading hyphen),
+	// or "" if the recommended new version is not a prerelease.
+	// This is set only when LatestVersion is a prerelease.
+	NewPrerelease string
+
+	// NewBuildMetadata is the build metadata for the recommended new version
+	// (without the leading plus sign),
+	// from the template in Config.BuildMetadata,
+	// or "" if there is none.
+	NewBuildMetadata string
+
+	// FinalizesPrerelease is true if LatestVersion is a prerelease
+	// and the recommended new version is the corresponding final release,
+	// as requested with the [WithFinalize] option.
+	FinalizesPrerelease bool
+
+	// NewVersionExists is true if the recommended new version is already tagged
+	// on a commit not reachable from Branch.
+	NewVersionExists bool
+
+	// NoRemote is true if the repository has no remote refs,
+	// so DefaultBranch is instead the current branch.
+	NoRemote bool
+
+	// NoReleaseMerge is true if Config.ReleaseMerge is set
+	// but no merge commit on the default branch matches it.
+	// In that case LatestCommit is empty and no new version is recommended.
+	NoReleaseMerge bool
+
+	// NoNetChanges is true if the commits since LatestVersion
+	// leave the module's files exactly as they were in that version,
+	// as when they only revert one another.
+	// In that case no new version is needed.
+	// Valid only when DefaultBranch and LatestVersion are not empty and LatestCommitHasVersionTag is false.
+	NoNetChanges bool
+
+	// OldestVersion and NewestVersion are the version tags
+	// (after removing any VersionPrefix)
+	// on the earliest and latest commits,
+	// by commit time,
+	// and OldestVersionDate and NewestVersionDate are those commit times.
+	// NewestVersion differs from LatestVersion
+	// when a lower version, such as a patch on a maintenance branch,
+	// was released after the highest one.
+	// Valid only when TotalVersionTags is not zero.
+	OldestVersion, NewestVersion         string
+	OldestVersionDate, NewestVersionDate time.Time
+
+	// OldestUnreleasedCommitTime is the commit time of the oldest of the UnreleasedCommits.
+	// Valid only when UnreleasedCommits is not zero.
+	OldestUnreleasedCommitTime time.Time
+
+	// OpenVulnerabilities lists the IDs of vulnerabilities in the OSV database
+	// that affect LatestVersion.
+	// Valid only when the [WithOSV] option is used and LatestVersion is not empty.
+	OpenVulnerabilities []string
+
+	// MalformedVersionTags lists the tags with VersionPrefix
+	// that look like versions but are not valid semantic versions,
+	// such as "1.2.3" or "release-1.2.3",
+	// and so are invisible to the Go toolchain,
+	// leaving out those whose versions are also properly tagged.
+	// They are in ascending semantic-version order.
+	MalformedVersionTags []MalformedVersionTag
+
+	// StaleFloatingTags lists the floating major- and minor-version tags with VersionPrefix,
+	// such as v1 and v1.2,
+	// that do not point to the latest releases they stand for.
+	// They are sorted by tag name.
+	StaleFloatingTags []FloatingTag
+
+	// OtherModuleVersionTags lists, when the module has no version tags of its own,
+	// the version tags in the repository that belong to other modules:
+	// those whose last path element is a semantic version
+	// but whose prefix is not VersionPrefix,
+	// such as "v1.2.3" for a module in a subdir,
+	// or "foo/v1.2.3" for the module at the repository root.
+	// These are the full tag names, sorted.
+	OtherModuleVersionTags []string
+
+	// PhaseError, if not nil, describes the failure of a phase of the analysis
+	// that Check could recover from:
+	// reading go.mod ([PhaseModfile])
+	// or comparing versions ([PhaseModver]).
+	// The fields belonging to the failed phase and later ones are then incomplete,
+	// except that the [PhaseBranch] fields are still filled in after a PhaseModfile failure.
+	// No new version is recommended.
+	PhaseError *PhaseError
+
+	// ReachableVulnerabilities lists the known vulnerabilities,
+	// in the module's dependencies or the standard library,
+	// that govulncheck finds reachable from the module's code
+	// at LatestCommit.
+	// Populated only when [WithGovulncheck] is used.
+	ReachableVulnerabilities []Vulnerability
+
+	// Graduation is the evaluation of a v0 module's readiness to release v1.0.0.
+	// Populated only when [WithGraduation] is used
+	// and the latest version is a v0 version.
+	Graduation *Graduation
+
+	// Readiness holds the results of the release-readiness checks
+	// named in Config.Readiness (see [WithConfig]),
+	// in that order.
+	Readiness []ReadinessCheck
+
+	// ReleaseMergeCommit is the latest merge commit on the default branch
+	// matching Config.ReleaseMerge,
+	// or "" if that is not set or no merge commit matches.
+	// When it is set, it is also LatestCommit,
+	// and the analysis is of that commit rather than the tip of the default branch.
+	ReleaseMergeCommit string
+
+	// Remediations are shell commands that fix some of the warnings about the module.
+	// See [Finding.Remediation].
+	Remediations []Remediation
+
+	// Remote is the remote whose refs determined DefaultBranch,
+	// or "" if DefaultBranch could not be determined.
+	// When DefaultBranch is from Config.DefaultBranch or the [WithBranch] option,
+	// this is the remote named with [WithRemote], or else origin if it has that branch.
+	// See [WithRemote].
+	Remote string
+
+	// RemoteURL is the URL of Remote,
+	// normalized to https form by [NormalizeRemoteURL],
+	// or "" if Remote is empty or is a local filesystem path.
+	RemoteURL string
+
+	// ForgeKind is the kind of forge hosting RemoteURL,
+	// or "" if it is not recognized.
+	// See [Result.Forge].
+	ForgeKind ForgeKind
+
+	// RemoteOnlyVersionTags lists the version tags
+	// (after removing any VersionPrefix)
+	// that exist in the remote but not in the local repository.
+	// Those whose commits are present locally are included in the analysis.
+	// Populated only when [WithRemoteTags] is used.
+	RemoteOnlyVersionTags []string
+
+	// ReplaceDirectives lists the replace directives in go.mod, as "old => new".
+	ReplaceDirectives []string
+
+	// RuleViolations lists the violations of the policy rules in Config.Rules (see [WithConfig]),
+	// in the order of the rules.
+	RuleViolations []RuleViolation
+
+	// Series is the release series named by Branch,
+	// such as "v1" for release/v1 or "v1.8" for release-1.8,
+	// or "" if Branch is not set or does not name a series.
+	// See [Result.InSeries].
+	Series string
+
+	// Suppress lists the IDs of the findings that the configuration suppresses for this module
+	// (see ModuleConfig.Suppress).
+	// The conditions they report are still reflected in the other fields of the Result,
+	// but [Result.Findings] reports them as suppressed.
+	Suppress []string
+
+	// SuppressedFindings are the findings that Suppress suppressed,
+	// each with Suppressed set.
+	SuppressedFindings []Finding
+
+	// SkippedChecks lists the checks that require network access
+	// and were requested but skipped because of the [WithOffline] option:
+	// "remote-tags", "govulncheck", "osv", and "dependents".
+	SkippedChecks []string
+
+	// StaleRelease is true if the unreleased changes to the module
+	// exceed the configured stale-release threshold (see [StaleThreshold]).
+	StaleRelease bool
+
+	// TotalVersionTags is the number of version tags of the module
+	// (including those that exist only in the remote, with [WithRemoteTags]),
+	// and TagsPerMajor is the number for each major version, such as "v1".
+	// With the [WithBranch] or [WithTarget] option,
+	// only the version tags reachable from the branch or target count.
+	TotalVersionTags int
+	TagsPerMajor     map[string]int
+
+	// TidyDiff is the output of "go mod tidy -diff" for the module,
+	// showing the changes needed to tidy go.mod and go.sum.
+	// Populated only when [WithTidyCheck] is used.
+	TidyDiff string
+
+	// UnbuildableVersions lists the versions of the module
+	// (after removing any VersionPrefix)
+	// for which "go build ./..." fails.
+	// Populated only when [WithBuildVerification] is used.
+	UnbuildableVersions []string
+
+	// UnreleasedCommits is the number of commits
+	// from LatestVersion to LatestCommit
+	// (touching the module's subdir, if it is not at the repository root),
+	// or all the commits up to LatestCommit if there is no LatestVersion.
+	// Valid only when LatestCommit is not empty,
+	// LatestCommitHasVersionTag is false,
+	// and PhaseError is nil.
+	UnreleasedCommits int
+
 	// VersionPrefix is the prefix for version tags in the repository.
 	// When the root of a Go module is in subdir foo/bar of its repository,
 	// version tags must look like "foo/bar/v1.2.3";
 	// this field holds the "foo/bar/" part.
 	VersionPrefix string
 
+	// Versions describes every version tag of the module,
+	// in ascending semantic-version order.
+	// Populated only when [WithVersionHistory] is used.
+	Versions []VersionInfo
+
 	// VersionSuffix is the status of the module path's version suffix.
 	// Valid only when LatestVersion is not empty.
 	//
@@ -99,104 +441,255 @@ const (
 	VSUnwanted VersionSuffixStatus = "unwanted"
 )
 
+// Forge is the forge hosting the repository,
+// according to RemoteURL and ForgeKind.
+// The boolean result is false if the forge is not recognized.
+func (r Result) Forge() (Forge, bool) {
+	if r.ForgeKind == "" {
+		return Forge{}, false
+	}
+	return Forge{Kind: r.ForgeKind, RepoURL: r.RemoteURL}, true
+}
+
+// Diverged tells whether the local default branch and its counterpart in Remote
+// each have commits the other lacks,
+// as when the local branch has been rewritten.
+// Tags added to the local branch then may never exist upstream.
+func (r Result) Diverged() bool {
+	return r.Ahead > 0 && r.Behind > 0
+}
+
+// NewVersion is the recommended new version,
+// formed from NewMajor, NewMinor, NewPatch, NewPrerelease, and NewBuildMetadata,
+// without any VersionPrefix.
+func (r Result) NewVersion() string {
+	v := fmt.Sprintf("v%d.%d.%d", r.NewMajor, r.NewMinor, r.NewPatch)
+	if r.NewPrerelease != "" {
+		v += "-" + r.NewPrerelease
+	}
+	if r.NewBuildMetadata != "" {
+		v += "+" + r.NewBuildMetadata
+	}
+	return v
+}
+
 // Describe writes a human-readable description of r to w.
 // If quiet is true, the description omits all but the warnings from the output, if any.
 // The return value is the number of warnings emitted.
 func (r Result) Describe(w io.Writer, quiet bool) int {
-	var warnings int
+	return r.DescribeWith(w, DescribeOptions{Quiet: quiet})
+}
 
-	warnf := func(format string, args ...any) {
-		warnings++
-		showf(w, "⛔️", format, args...)
-	}
+// DescribeOptions controls the output of [Result.DescribeWith].
+type DescribeOptions struct {
+	// Verbosity is how much to include in the output.
+	Verbosity Verbosity
+
+	// Quiet means omit all but the warnings from the output.
+	// It is the same as Verbosity [VerbosityQuiet].
+	Quiet bool
+
+	// HideOK means omit the findings of things that are as they should be.
+	HideOK bool
+
+	// NoEmoji means mark each line of output with a word,
+	// such as "WARNING:",
+	// instead of an emoji.
+	NoEmoji bool
+
+	// Color means render warnings in red and OK findings in green
+	// with ANSI terminal escape sequences.
+	Color bool
+
+	// Forge, if non-nil, is the forge hosting the repository.
+	// Commit hashes, tags, and compare ranges in the output
+	// are then rendered as OSC 8 terminal hyperlinks to the forge's web pages.
+	Forge *Forge
+
+	// Threshold, if set, is the least severe warning that counts
+	// toward the return value of [Result.DescribeWith].
+	// All warnings are still displayed.
+	Threshold Severity
+
+	// Severities overrides the severities of warnings, by finding ID.
+	// See [Finding.SeverityWith].
+	Severities map[string]Severity
+
+	// Baseline, if non-nil, holds known warnings.
+	// They are neither displayed nor counted,
+	// and a note at the end tells how many there were.
+	Baseline *Baseline
+}
 
+// Verbosity is the type of DescribeOptions.Verbosity.
+type Verbosity int
+
+// Possible values for Verbosity.
+const (
+	// VerbosityQuiet omits all but the warnings.
+	VerbosityQuiet Verbosity = -1
+
+	// VerbosityNormal includes all findings.
+	VerbosityNormal Verbosity = 0
+
+	// VerbosityVerbose adds details:
+	// Modver's full report (in Result.ModverReport, see [WithModverReport])
+	// and information about each version tag (in Result.Versions, see [WithVersionHistory]).
+	VerbosityVerbose Verbosity = 1
+)
+
+// DescribeWith is like [Result.Describe] but takes a [DescribeOptions].
+func (r Result) DescribeWith(w io.Writer, opts DescribeOptions) int {
 	var (
-		infof = func(_ string, _ ...any) {}
-		okf   = func(_ string, _ ...any) {}
+		warnings, known int
+		quiet           = opts.Quiet || opts.Verbosity <= VerbosityQuiet
 	)
-	if !quiet {
-		infof = func(format string, args ...any) {
-			showf(w, "ℹ️", format, args...)
+
+	for _, f := range r.Findings() {
+		if f.Kind == FindingWarning && opts.Baseline.Contains(r, f) {
+			known++
+			continue
 		}
-		okf = func(format string, args ...any) {
-			showf(w, "✅", format, args...)
+
+		msg := f.Message
+		if opts.Forge != nil {
+			if text, url := r.findingLink(f, *opts.Forge); text != "" {
+				msg = strings.Replace(msg, text, hyperlink(url, text), 1)
+			}
 		}
-	}
 
-	infof("Module path: %s", r.Modpath)
-	if r.VersionPrefix != "" {
-		infof("Version prefix: %s (n.b., this prefix is stripped from version tags appearing in this report)", r.VersionPrefix)
+		switch f.Kind {
+		case FindingWarning:
+			if opts.Threshold == "" || f.SeverityWith(opts.Severities).AtLeast(opts.Threshold) {
+				warnings++
+			}
+		case FindingOK:
+			if quiet || opts.HideOK {
+				continue
+			}
+		default:
+			if quiet {
+				continue
+			}
+			if f.Suppressed {
+				msg = "(suppressed) " + msg
+			}
+		}
+		opts.show(w, f.Kind, msg)
+		if f.Remediation != "" {
+			fmt.Fprintf(w, "    Fix: %s\n", f.Remediation)
+		}
 	}
 
-	if r.DefaultBranch != "" {
-		okf("Default branch: %s", r.DefaultBranch)
-		infof("Latest commit hash: %s", r.LatestCommit)
-	} else {
-		warnf("Could not determine default branch")
+	if known > 0 && !quiet {
+		opts.show(w, FindingInfo, fmt.Sprintf("Suppressed %d known warning(s) listed in %s", known, BaselineFile))
 	}
 
-	if r.LatestVersion != "" {
-		okf("Latest version tag: %s", r.LatestVersion)
-
-		if r.LatestVersionIsPrerelease {
-			warnf("Latest version %s is a prerelease", r.LatestVersion)
-		} else {
-			okf("Latest version %s is not a prerelease", r.LatestVersion)
+	if opts.Verbosity >= VerbosityVerbose && !quiet {
+		if report := strings.TrimSpace(r.ModverReport); report != "" {
+			opts.show(w, FindingInfo, "Modver report:")
+			showIndented(w, report)
 		}
-
-		if r.LatestVersionUnstable {
-			warnf("Latest version %s is unstable", r.LatestVersion)
-		} else {
-			okf("Latest version %s is stable", r.LatestVersion)
+		if len(r.Versions) > 0 {
+			opts.show(w, FindingInfo, "Version tags:")
+			var lines []string
+			for _, v := range r.Versions {
+				lines = append(lines, r.describeVersion(v))
+			}
+			showIndented(w, strings.Join(lines, "\n"))
 		}
-
-		switch r.VersionSuffix {
-		case VSOK:
-			if r.LatestMajor > 1 {
-				okf("Module path %s has suffix matching major version %d", r.Modpath, r.LatestMajor)
-			} else {
-				okf("Module path %s neither needs nor has a version suffix", r.Modpath)
+		if len(r.UnreleasedCommitSummaries) > 0 {
+			opts.show(w, FindingInfo, "Unreleased commits:")
+			var lines []string
+			for _, c := range r.UnreleasedCommitSummaries {
+				lines = append(lines, describeCommit(c))
 			}
-		case VSMismatch:
-			warnf("Module path %s version suffix does not agree with latest version %s", r.Modpath, r.LatestVersion)
-		case VSMissing:
-			warnf("Module path %s lacks suffix matching major version %d", r.Modpath, r.LatestMajor)
-		case VSUnwanted:
-			warnf("Module path %s contains an unwanted version suffix", r.Modpath)
+			showIndented(w, strings.Join(lines, "\n"))
 		}
+	}
 
-		if r.DefaultBranch != "" {
-			if r.LatestCommitHasVersionTag {
-				if r.LatestCommitHasLatestVersion {
-					okf("Latest commit on the default branch has latest version tag")
-				} else {
-					warnf("Latest commit on the default branch has version tag, but it is not latest version %s", r.LatestVersion)
-				}
-			} else {
-				warnf("Latest commit on the default branch lacks version tag")
-
-				if r.ModverResultCode == modver.None {
-					okf("Modver analysis: no new version tag required")
-				} else {
-					warnf("Modver analysis: %s", r.ModverResultString)
-					warnf("Recommended new version tag: %sv%d.%d.%d", r.VersionPrefix, r.NewMajor, r.NewMinor, r.NewPatch)
-					if r.NewMajor > r.LatestMajor && r.NewMajor > 1 {
-						warnf("Module path will require new version suffix /v%d", r.NewMajor)
-					}
-				}
-			}
+	return warnings
+}
+
+// show writes one line of output of the given kind.
+func (opts DescribeOptions) show(w io.Writer, kind FindingKind, msg string) {
+	var prefix, color string
+	switch kind {
+	case FindingWarning:
+		prefix, color = "⛔️", "\x1b[31m"
+		if opts.NoEmoji {
+			prefix = "WARNING:"
+		}
+	case FindingOK:
+		prefix, color = "✅", "\x1b[32m"
+		if opts.NoEmoji {
+			prefix = "OK:"
 		}
+	default:
+		prefix = "ℹ️"
+		if opts.NoEmoji {
+			prefix = "INFO:"
+		}
+	}
+	if opts.Color && color != "" {
+		msg = color + msg + "\x1b[0m"
+	}
+	showf(w, prefix, "%s", msg)
+}
+
+// describeVersion describes one version tag, for verbose output.
+func (r Result) describeVersion(v VersionInfo) string {
+	commit := v.Commit
+	if len(commit) > 12 {
+		commit = commit[:12]
+	}
+	desc := fmt.Sprintf("%s%s  %s  %s", r.VersionPrefix, v.Version, commit, v.CommitTime.Format(time.DateOnly))
+	if v.Annotated {
+		desc += ", tagged by " + v.Tagger
 	} else {
-		warnf("No version tags")
+		desc += ", lightweight"
+	}
+	if v.Signed {
+		desc += ", signed"
 	}
+	if r.DefaultBranch != "" && !v.OnDefaultBranch {
+		desc += ", not on " + r.DefaultBranch
+	}
+	return desc
+}
 
-	if r.ModpathMismatch {
-		warnf("Module path %s does not agree with module subdir in repository %s", r.Modpath, r.ModuleSubdir)
-	} else if r.ModuleSubdir != "" {
-		okf("Module path %s agrees with module subdir in repository %s", r.Modpath, r.ModuleSubdir)
+func describeCommit(c CommitSummary) string {
+	hash := c.Hash
+	if len(hash) > 12 {
+		hash = hash[:12]
 	}
+	author, _, _ := strings.Cut(c.Author, " <")
+	return fmt.Sprintf("%s  %s  %s (%s)", hash, c.Time.Format(time.DateOnly), c.Subject, author)
+}
 
-	return warnings
+func showIndented(w io.Writer, text string) {
+	for _, line := range strings.Split(text, "\n") {
+		fmt.Fprintf(w, "    %s\n", line)
+	}
+}
+
+// findingLink tells which part of a finding's message, if any,
+// should link to which page on the forge.
+func (r Result) findingLink(f Finding, forge Forge) (text, url string) {
+	switch f.ID {
+	case "latest-commit", "target":
+		return r.LatestCommit, forge.CommitURL(r.LatestCommit)
+	case "latest-version":
+		return r.LatestVersion, forge.TagURL(r.VersionPrefix + r.LatestVersion)
+	case "latest-commit-untagged":
+		return f.Message, forge.CompareURL(r.VersionPrefix+r.LatestVersion, r.LatestCommit)
+	}
+	return "", ""
+}
+
+// hyperlink renders text as an OSC 8 terminal hyperlink to url.
+func hyperlink(url, text string) string {
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
 }
 
 func showf(w io.Writer, prefix, format string, args ...interface{}) {
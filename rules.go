@@ -0,0 +1,235 @@
+package taggo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/bobg/errors"
+)
+
+// Rule is a policy rule declared in the configuration file (see Config.Rules).
+// It applies to a module when all of its conditions on the module's [Result] hold.
+// A rule that applies is violated,
+// unless it has a RequireChanged path and that path has changed since the latest version.
+//
+// For example, this rule makes a prerelease on the main branch an error:
+//
+//	name: no-prereleases-on-main
+//	when:
+//	  DefaultBranch: main
+//	  LatestVersionIsPrerelease: true
+//	severity: error
+//	message: Prereleases belong on release branches
+//
+// And this one requires a change to MIGRATION.md for a new major version:
+//
+//	name: migration-guide
+//	when:
+//	  Bump: major
+//	requireChanged: MIGRATION.md
+type Rule struct {
+	// Name identifies the rule in reports.
+	Name string `yaml:"name"`
+
+	// When holds the rule's conditions,
+	// keyed by the name of a Result field
+	// (as it appears in the JSON form of the Result),
+	// or by "Bump",
+	// the size of the recommended version bump:
+	// "none", "patch", "minor", or "major".
+	// A condition's value may be a single value, which the field must equal;
+	// a list of values, one of which the field must equal;
+	// or a map with the single key "not",
+	// whose value (a single value or a list) the field must not equal.
+	// Values are compared in their text forms,
+	// so "true" and true are the same.
+	When map[string]any `yaml:"when"`
+
+	// RequireChanged, if set, is a path relative to the module's directory
+	// that must have changed since the latest version
+	// for the rule to be satisfied.
+	// The requirement is met trivially when the module has no version tags.
+	RequireChanged string `yaml:"requireChanged"`
+
+	// Message describes a violation of the rule.
+	Message string `yaml:"message"`
+
+	// Severity is "warning" (the default) or "error".
+	// A violation of a rule with severity "error" prevents adding a tag.
+	Severity string `yaml:"severity"`
+}
+
+// RuleViolation is a violation of a [Rule] by a module.
+type RuleViolation struct {
+	// Rule is the rule's name.
+	Rule string
+
+	// Message is the rule's message,
+	// or a description of the rule if it has none.
+	Message string
+
+	// Error is true if the rule's severity is "error".
+	Error bool
+}
+
+// ruleChangedFunc tells whether a path in the repository has changed since the latest version.
+type ruleChangedFunc func(ctx context.Context, path string) (bool, error)
+
+// evalRules evaluates rules against r,
+// using changed to evaluate any RequireChanged paths
+// (relative to the repository root),
+// and returns the violations.
+func evalRules(ctx context.Context, r Result, rules []Rule, changed ruleChangedFunc) ([]RuleViolation, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	encoded, err := json.Marshal(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding result")
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil, errors.Wrap(err, "decoding result")
+	}
+	fields["Bump"] = r.bump().String()
+
+	var violations []RuleViolation
+	for _, rule := range rules {
+		var isError bool
+		switch rule.Severity {
+		case "", "warning":
+		case "error":
+			isError = true
+		default:
+			return violations, fmt.Errorf("rule %s: unknown severity %q", rule.Name, rule.Severity)
+		}
+
+		applies, err := ruleApplies(rule, fields)
+		if err != nil {
+			return violations, errors.Wrapf(err, "rule %s", rule.Name)
+		}
+		if !applies {
+			continue
+		}
+
+		msg := rule.Message
+		if rule.RequireChanged != "" {
+			if r.LatestVersion == "" {
+				continue
+			}
+			p := path.Join(r.ModuleSubdir, rule.RequireChanged)
+			ok, err := changed(ctx, p)
+			if err != nil {
+				return violations, errors.Wrapf(err, "rule %s: checking for changes to %s", rule.Name, p)
+			}
+			if ok {
+				continue
+			}
+			if msg == "" {
+				msg = fmt.Sprintf("%s has not changed since %s", rule.RequireChanged, r.LatestVersion)
+			}
+		}
+		if msg == "" {
+			msg = "conditions met: " + describeConditions(rule.When)
+		}
+
+		violations = append(violations, RuleViolation{Rule: rule.Name, Message: msg, Error: isError})
+	}
+	return violations, nil
+}
+
+// ruleApplies tells whether all the conditions of rule hold for fields,
+// the JSON form of a Result.
+func ruleApplies(rule Rule, fields map[string]any) (bool, error) {
+	for name, cond := range rule.When {
+		val, ok := fields[name]
+		if !ok {
+			return false, fmt.Errorf("unknown field %s", name)
+		}
+		want := true
+		if m, ok := cond.(map[string]any); ok {
+			not, ok := m["not"]
+			if !ok || len(m) != 1 {
+				return false, fmt.Errorf("condition on %s: a map must have the single key \"not\"", name)
+			}
+			cond, want = not, false
+		}
+		if matchesCondition(val, cond) != want {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// matchesCondition tells whether val equals cond,
+// or any element of cond if it is a list,
+// comparing text forms.
+func matchesCondition(val, cond any) bool {
+	text := conditionText(val)
+	if list, ok := cond.([]any); ok {
+		for _, c := range list {
+			if conditionText(c) == text {
+				return true
+			}
+		}
+		return false
+	}
+	return conditionText(cond) == text
+}
+
+func conditionText(v any) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// describeConditions describes a rule's conditions, in sorted order.
+func describeConditions(when map[string]any) string {
+	names := make([]string, 0, len(when))
+	for name := range when {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var s string
+	for i, name := range names {
+		if i > 0 {
+			s += ", "
+		}
+		cond, op := when[name], "="
+		if m, ok := cond.(map[string]any); ok {
+			cond, op = m["not"], "!="
+		}
+		if list, ok := cond.([]any); ok {
+			op = map[string]string{"=": "in", "!=": "not in"}[op]
+			texts := make([]string, 0, len(list))
+			for _, c := range list {
+				texts = append(texts, conditionText(c))
+			}
+			s += fmt.Sprintf("%s %s [%s]", name, op, strings.Join(texts, ", "))
+		} else {
+			s += fmt.Sprintf("%s %s %s", name, op, conditionText(cond))
+		}
+	}
+	return s
+}
+
+// String is the name of the bump level, as used in Rule conditions.
+func (b bumpLevel) String() string {
+	switch b {
+	case bumpPatch:
+		return "patch"
+	case bumpMinor:
+		return "minor"
+	case bumpMajor:
+		return "major"
+	default:
+		return "none"
+	}
+}
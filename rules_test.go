@@ -0,0 +1,91 @@
+package taggo_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/internal/testutil"
+)
+
+func TestRules(t *testing.T) {
+	repodir := t.TempDir()
+
+	writeFile := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repodir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testutil.Git(t, repodir, "init", "-q", "-b", "main")
+	writeFile("go.mod", "module example.com/x\n\ngo 1.22\n")
+	writeFile("x.go", "package x\n")
+	writeFile("MIGRATION.md", "# Migrating\n")
+	testutil.Git(t, repodir, "add", ".")
+	testutil.Git(t, repodir, "commit", "-q", "-m", "initial")
+	testutil.Git(t, repodir, "tag", "v1.0.0-rc.1")
+	writeFile("x.go", "package x\n\n// X is new.\nfunc X() {}\n")
+	testutil.Git(t, repodir, "commit", "-q", "-a", "-m", "add X")
+
+	const rulesYAML = `
+rules:
+  - name: no-prereleases-on-main
+    when:
+      DefaultBranch: main
+      LatestVersionIsPrerelease: "true"
+    severity: error
+    message: Prereleases belong on release branches
+  - name: migration-guide
+    requireChanged: MIGRATION.md
+  - name: not-v0
+    when:
+      LatestVersion:
+        not: [v0.1.0, v0.2.0]
+  - name: other-branch
+    when:
+      DefaultBranch: [release, stable]
+`
+	var config taggo.Config
+	if err := yaml.Unmarshal([]byte(rulesYAML), &config); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	result, err := taggo.Check(ctx, "", repodir, "", taggo.WithConfig(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []taggo.RuleViolation{
+		{Rule: "no-prereleases-on-main", Message: "Prereleases belong on release branches", Error: true},
+		{Rule: "migration-guide", Message: "MIGRATION.md has not changed since v1.0.0-rc.1"},
+		{Rule: "not-v0", Message: "conditions met: LatestVersion not in [v0.1.0, v0.2.0]"},
+	}
+	if diff := cmp.Diff(want, result.RuleViolations); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	writeFile("MIGRATION.md", "# Migrating\n\nNothing to do.\n")
+	testutil.Git(t, repodir, "commit", "-q", "-a", "-m", "update migration guide")
+
+	result, err = taggo.Check(ctx, "", repodir, "", taggo.WithConfig(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range result.RuleViolations {
+		if v.Rule == "migration-guide" {
+			t.Errorf("got violation of migration-guide after changing MIGRATION.md")
+		}
+	}
+
+	config.Rules = []taggo.Rule{{Name: "bad", When: map[string]any{"NoSuchField": true}}}
+	if _, err := taggo.Check(ctx, "", repodir, "", taggo.WithConfig(config)); err == nil {
+		t.Error("got no error for rule with unknown field")
+	}
+}
@@ -0,0 +1,132 @@
+package taggo
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/bobg/modver/v2"
+)
+
+// ResultSchema returns a JSON Schema (draft 2020-12) describing the JSON form of [Result],
+// as produced by encoding/json.
+// If all is true,
+// it instead describes the JSON form of the map returned by [CheckAll],
+// from module directory to Result.
+//
+// The schema is derived from the Result type itself,
+// so it always agrees with the fields of this version of taggo.
+// Its SchemaVersion property is constrained to [ResultSchemaVersion].
+func ResultSchema(all bool) map[string]any {
+	defs := make(map[string]any)
+	ref := schemaFor(reflect.TypeOf(Result{}), defs)
+
+	schema := map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$defs":   defs,
+	}
+	if all {
+		schema["title"] = "taggo CheckAll result"
+		schema["type"] = "object"
+		schema["additionalProperties"] = ref
+	} else {
+		schema["title"] = "taggo Result"
+		schema["$ref"] = ref["$ref"]
+	}
+	return schema
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// schemaEnums lists the possible JSON values of types that have a fixed set of them.
+var schemaEnums = map[reflect.Type][]any{
+	reflect.TypeOf(modver.None): {"None", "Patchlevel", "Minor", "Major"},
+	reflect.TypeOf(VSOK):        {VSOK, VSMismatch, VSMissing, VSUnwanted},
+	reflect.TypeOf(ForgeGitHub): {"", ForgeGitHub, ForgeGitLab, ForgeCodeberg},
+}
+
+// schemaFor returns the JSON Schema for typ.
+// Struct types are added to defs (keyed by type name) and referred to with $ref.
+func schemaFor(typ reflect.Type, defs map[string]any) map[string]any {
+	if enum, ok := schemaEnums[typ]; ok {
+		return map[string]any{"enum": enum}
+	}
+
+	switch {
+	case typ == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case typ.Implements(textMarshalerType):
+		return map[string]any{"type": "string"}
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+
+	case reflect.String:
+		return map[string]any{"type": "string"}
+
+	case reflect.Slice:
+		// A nil slice encodes as null.
+		return map[string]any{
+			"type":  []string{"array", "null"},
+			"items": schemaFor(typ.Elem(), defs),
+		}
+
+	case reflect.Map:
+		// A nil map encodes as null.
+		return map[string]any{
+			"type":                 []string{"object", "null"},
+			"additionalProperties": schemaFor(typ.Elem(), defs),
+		}
+
+	case reflect.Pointer:
+		return map[string]any{
+			"anyOf": []any{schemaFor(typ.Elem(), defs), map[string]any{"type": "null"}},
+		}
+
+	case reflect.Struct:
+		name := typ.Name()
+		if _, ok := defs[name]; !ok {
+			defs[name] = nil // placeholder, in case of recursion
+			defs[name] = structSchema(typ, defs)
+		}
+		return map[string]any{"$ref": "#/$defs/" + name}
+	}
+
+	panic(fmt.Sprintf("no JSON schema for type %s", typ))
+}
+
+func structSchema(typ reflect.Type, defs map[string]any) map[string]any {
+	var (
+		props    = make(map[string]any)
+		required []string
+	)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		props[field.Name] = schemaFor(field.Type, defs)
+		required = append(required, field.Name)
+	}
+	if typ == reflect.TypeOf(Result{}) {
+		props["SchemaVersion"] = map[string]any{"const": ResultSchemaVersion}
+	}
+	return map[string]any{
+		"type":       "object",
+		"properties": props,
+		"required":   required,
+	}
+}
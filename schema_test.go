@@ -0,0 +1,64 @@
+package taggo_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/bobg/taggo"
+)
+
+func TestResultSchema(t *testing.T) {
+	repodir := cloneBundle(t, "unstable")
+
+	result, err := taggo.Check(context.Background(), "", repodir, "", taggo.WithVersionHistory())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.SchemaVersion != taggo.ResultSchemaVersion {
+		t.Errorf("got schema version %d, want %d", result.SchemaVersion, taggo.ResultSchemaVersion)
+	}
+
+	j, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(j, &fields); err != nil {
+		t.Fatal(err)
+	}
+
+	// Round-trip the schema through JSON to check that it encodes.
+	j, err = json.Marshal(taggo.ResultSchema(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema struct {
+		Ref  string `json:"$ref"`
+		Defs map[string]struct {
+			Properties map[string]any
+			Required   []string
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(j, &schema); err != nil {
+		t.Fatal(err)
+	}
+	if schema.Ref != "#/$defs/Result" {
+		t.Errorf("got $ref %s, want #/$defs/Result", schema.Ref)
+	}
+	def, ok := schema.Defs["Result"]
+	if !ok {
+		t.Fatal("no Result definition in schema")
+	}
+	if _, ok := schema.Defs["VersionInfo"]; !ok {
+		t.Error("no VersionInfo definition in schema")
+	}
+	for name := range fields {
+		if _, ok := def.Properties[name]; !ok {
+			t.Errorf("Result field %s missing from schema", name)
+		}
+	}
+	if len(def.Required) != len(fields) {
+		t.Errorf("schema requires %d fields, Result has %d", len(def.Required), len(fields))
+	}
+}
@@ -0,0 +1,58 @@
+package taggo
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"golang.org/x/mod/semver"
+)
+
+// seriesRegexes match the release series at the end of a maintenance branch name,
+// as in release/v1, release-1.8, v2, or 1.8.x.
+// A bare number, as in feature-123, does not name a series.
+var seriesRegexes = []*regexp.Regexp{
+	regexp.MustCompile(`(?:^|/)release[-/_]?v?([0-9]+)(?:\.([0-9]+))?(?:\.x)?$`),
+	regexp.MustCompile(`(?:^|/)v([0-9]+)(?:\.([0-9]+))?(?:\.x)?$`),
+	regexp.MustCompile(`(?:^|/)([0-9]+)(?:\.([0-9]+))?\.x$`),
+}
+
+// branchSeries parses the release series named by a maintenance branch,
+// returning "vN" for a major series, "vN.M" for a minor series,
+// or "" if the branch name does not end in a series.
+func branchSeries(branch string) string {
+	for _, re := range seriesRegexes {
+		m := re.FindStringSubmatch(branch)
+		if len(m) == 0 {
+			continue
+		}
+		major, err := strconv.Atoi(m[1])
+		if err != nil {
+			return ""
+		}
+		if m[2] == "" {
+			return fmt.Sprintf("v%d", major)
+		}
+		minor, err := strconv.Atoi(m[2])
+		if err != nil {
+			return ""
+		}
+		return fmt.Sprintf("v%d.%d", major, minor)
+	}
+	return ""
+}
+
+// InSeries tells whether version (without any VersionPrefix) belongs to r.Series.
+// It is always true when r.Series is empty.
+func (r Result) InSeries(version string) bool {
+	if r.Series == "" {
+		return true
+	}
+	if !semver.IsValid(version) {
+		return false
+	}
+	if semver.Major(r.Series) == r.Series {
+		return semver.Major(version) == r.Series
+	}
+	return semver.MajorMinor(version) == r.Series
+}
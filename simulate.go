@@ -0,0 +1,308 @@
+package taggo
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bobg/errors"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+	modzip "golang.org/x/mod/zip"
+
+	"github.com/bobg/taggo/gitutil"
+)
+
+// Simulate shows what users of a Go module would experience
+// if the given version of it were published from revision rev of its repository.
+//
+// It builds the module zip for that version
+// and serves it from a temporary, file-based module proxy.
+// Then, in a temporary consumer module that imports every importable package of the module,
+// it runs "go get MODPATH@VERSION" and "go build",
+// with GOPROXY limited to that proxy and the local module download cache,
+// and with a temporary GOMODCACHE.
+// Path, version-suffix, and packaging problems
+// therefore show up here exactly as they would for end users.
+//
+// If rev is empty,
+// the version tag is used if it already exists,
+// and HEAD otherwise.
+//
+// The output of the go commands is written to w.
+// The error result is non-nil if any step fails.
+func Simulate(ctx context.Context, git, repodir, moduledir, version, rev string, w io.Writer) error {
+	if git == "" {
+		var err error
+		git, err = exec.LookPath("git")
+		if err != nil {
+			return errors.Wrap(err, "finding git binary")
+		}
+	}
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		return errors.Wrap(err, "finding go binary")
+	}
+
+	if !semver.IsValid(version) {
+		return fmt.Errorf("%s is not a valid semantic version", version)
+	}
+
+	moduledir, err = moduleSubdir(repodir, moduledir)
+	if err != nil {
+		return err
+	}
+
+	if rev == "" {
+		rev = "HEAD"
+		tag := version
+		if moduledir != "" {
+			tag = moduledir + "/" + version
+		}
+		if _, err := gitutil.TagCommit(ctx, git, repodir, tag); err == nil {
+			rev = tag
+		}
+	}
+
+	gomodPath := path.Join(filepath.ToSlash(moduledir), "go.mod")
+	gomodBytes, err := gitutil.Show(ctx, git, repodir, rev, gomodPath)
+	if err != nil {
+		return errors.Wrapf(err, "reading %s at %s", gomodPath, rev)
+	}
+	gomod, err := modfile.ParseLax(gomodPath, gomodBytes, noopFixer)
+	if err != nil {
+		return errors.Wrapf(err, "parsing %s at %s", gomodPath, rev)
+	}
+	modpath := gomod.Module.Mod.Path
+
+	commitTime, err := gitutil.CommitTime(ctx, git, repodir, rev)
+	if err != nil {
+		return errors.Wrapf(err, "getting commit time of %s", rev)
+	}
+
+	tmpdir, err := os.MkdirTemp("", "taggo-simulate")
+	if err != nil {
+		return errors.Wrap(err, "creating temp dir")
+	}
+	defer os.RemoveAll(tmpdir)
+
+	// Build the proxy.
+
+	escPath, err := module.EscapePath(modpath)
+	if err != nil {
+		return errors.Wrapf(err, "escaping module path %s", modpath)
+	}
+	escVersion, err := module.EscapeVersion(version)
+	if err != nil {
+		return errors.Wrapf(err, "escaping version %s", version)
+	}
+
+	proxydir := filepath.Join(tmpdir, "proxy")
+	vdir := filepath.Join(proxydir, filepath.FromSlash(escPath), "@v")
+	if err := os.MkdirAll(vdir, 0755); err != nil {
+		return errors.Wrapf(err, "creating %s", vdir)
+	}
+
+	zipPath := filepath.Join(vdir, escVersion+".zip")
+	if err := writeModuleZip(zipPath, modpath, version, repodir, rev, moduledir); err != nil {
+		return errors.Wrapf(err, "creating module zip for %s@%s", modpath, version)
+	}
+
+	info, err := json.Marshal(struct {
+		Version string
+		Time    string
+	}{
+		Version: version,
+		Time:    commitTime,
+	})
+	if err != nil {
+		return errors.Wrap(err, "encoding version info")
+	}
+
+	files := map[string][]byte{
+		"list":               []byte(version + "\n"),
+		escVersion + ".info": info,
+		escVersion + ".mod":  gomodBytes,
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(vdir, name), data, 0644); err != nil {
+			return errors.Wrapf(err, "writing %s", name)
+		}
+	}
+
+	// Build the consumer.
+
+	pkgs, err := importablePackages(zipPath, modpath, version)
+	if err != nil {
+		return errors.Wrap(err, "listing importable packages")
+	}
+
+	consumerdir := filepath.Join(tmpdir, "consumer")
+	if err := os.Mkdir(consumerdir, 0755); err != nil {
+		return errors.Wrapf(err, "creating %s", consumerdir)
+	}
+
+	consumerGomod := "module taggo.invalid/consumer\n"
+	if gomod.Go != nil {
+		consumerGomod += "\ngo " + gomod.Go.Version + "\n"
+	}
+	if err := os.WriteFile(filepath.Join(consumerdir, "go.mod"), []byte(consumerGomod), 0644); err != nil {
+		return errors.Wrap(err, "writing consumer go.mod")
+	}
+
+	mainGo := new(bytes.Buffer)
+	fmt.Fprintln(mainGo, "package main")
+	fmt.Fprintln(mainGo)
+	for _, pkg := range pkgs {
+		fmt.Fprintf(mainGo, "import _ %q\n", pkg)
+	}
+	fmt.Fprintln(mainGo)
+	fmt.Fprintln(mainGo, "func main() {}")
+	if err := os.WriteFile(filepath.Join(consumerdir, "main.go"), mainGo.Bytes(), 0644); err != nil {
+		return errors.Wrap(err, "writing consumer main.go")
+	}
+
+	// Run go get and go build.
+
+	goproxy := "file://" + filepath.ToSlash(proxydir)
+	if cachedir, err := goEnv(ctx, gobin, "GOMODCACHE"); err == nil && cachedir != "" {
+		// Dependencies already in the local download cache are available too.
+		goproxy += ",file://" + filepath.ToSlash(filepath.Join(cachedir, "cache", "download"))
+	}
+	goproxy += ",off"
+
+	env := append(os.Environ(),
+		"GOPROXY="+goproxy,
+		"GOMODCACHE="+filepath.Join(tmpdir, "modcache"),
+		"GOFLAGS=-mod=mod",
+		"GOSUMDB=off",
+		"GOWORK=off",
+		"GOTOOLCHAIN=local",
+	)
+
+	for _, args := range [][]string{
+		{"get", modpath + "@" + version},
+		{"build", "-o", os.DevNull, "."},
+	} {
+		cmd := exec.CommandContext(ctx, gobin, args...)
+		cmd.Dir = consumerdir
+		cmd.Env = env
+		cmd.Stdout, cmd.Stderr = w, w
+		fmt.Fprintf(w, "$ go %s\n", strings.Join(args, " "))
+		if err := cmd.Run(); err != nil {
+			return errors.Wrapf(err, "running go %s", strings.Join(args, " "))
+		}
+	}
+
+	// Clean up read-only files in the module cache so the deferred RemoveAll can succeed.
+	cmd := exec.CommandContext(ctx, gobin, "clean", "-modcache")
+	cmd.Dir = consumerdir
+	cmd.Env = env
+	_ = cmd.Run()
+
+	return nil
+}
+
+func writeModuleZip(zipPath, modpath, version, repodir, rev, moduledir string) error {
+	f, err := os.Create(zipPath)
+	if err != nil {
+		return errors.Wrapf(err, "creating %s", zipPath)
+	}
+	defer f.Close()
+
+	mv := module.Version{Path: modpath, Version: version}
+	if err := modzip.CreateFromVCS(f, mv, repodir, rev, filepath.ToSlash(moduledir)); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// importablePackages lists the packages in a module zip
+// that a consumer of the module could import:
+// not commands, not internal packages, and not in testdata.
+func importablePackages(zipPath, modpath, version string) ([]string, error) {
+	zr, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening %s", zipPath)
+	}
+	defer zr.Close()
+
+	var (
+		prefix = modpath + "@" + version + "/"
+		fset   = token.NewFileSet()
+		pkgs   = make(map[string]bool) // import path -> importable
+	)
+
+	for _, f := range zr.File {
+		name := strings.TrimPrefix(f.Name, prefix)
+		if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		dir := path.Dir(name)
+		if isNonImportableDir(dir) {
+			continue
+		}
+
+		importPath := modpath
+		if dir != "." {
+			importPath += "/" + dir
+		}
+		if _, ok := pkgs[importPath]; ok {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, errors.Wrapf(err, "opening %s", f.Name)
+		}
+		file, err := parser.ParseFile(fset, name, rc, parser.PackageClauseOnly)
+		rc.Close()
+		if err != nil {
+			// Let the go command report the problem.
+			pkgs[importPath] = true
+			continue
+		}
+		pkgs[importPath] = file.Name.Name != "main"
+	}
+
+	var result []string
+	for pkg, ok := range pkgs {
+		if ok {
+			result = append(result, pkg)
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func isNonImportableDir(dir string) bool {
+	for _, elt := range strings.Split(dir, "/") {
+		switch {
+		case elt == "internal", elt == "testdata", elt == "vendor":
+			return true
+		case strings.HasPrefix(elt, "."), strings.HasPrefix(elt, "_"):
+			return true
+		}
+	}
+	return false
+}
+
+func goEnv(ctx context.Context, gobin, name string) (string, error) {
+	output, err := exec.CommandContext(ctx, gobin, "env", name).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(bytes.TrimSpace(output)), nil
+}
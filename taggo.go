@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/bobg/errors"
@@ -18,36 +21,97 @@ import (
 	"github.com/bobg/modver/v2"
 	"golang.org/x/mod/modfile"
 	"golang.org/x/mod/semver"
+
+	"github.com/bobg/taggo/gitutil"
 )
 
 // CheckAll calls [Check] on each Go module in a Git repository.
 // It returns a map from module directory to the Result for that module.
 // The git argument is the path to the git executable.
 // If it is empty, [CheckAll] will look for "git" in PATH using [exec.LookPath].
-func CheckAll(ctx context.Context, git, repodir string) (map[string]Result, error) {
+// The [WithSubtree] option limits the modules checked.
+// The [WithWorkspace] option takes the modules from the repository's go.work file
+// instead of from its directory tree.
+//
+// CheckAll stops at the first module whose check fails,
+// returning the results so far along with the error.
+// See [CheckAllFunc] for a streaming alternative.
+func CheckAll(ctx context.Context, git, repodir string, opts ...Option) (map[string]Result, error) {
+	result := make(map[string]Result)
+	err := CheckAllFunc(ctx, git, repodir, func(moduledir string, r Result, err error) error {
+		if err != nil {
+			return err
+		}
+		result[moduledir] = r
+		return nil
+	}, opts...)
+	return result, err
+}
+
+// CheckAllFunc is like [CheckAll],
+// but instead of collecting the results in a map,
+// it calls f with each one as soon as it is produced,
+// along with the module directory and any error from [Check].
+// If f returns an error,
+// CheckAllFunc stops and returns that error,
+// wrapped with the module directory.
+// CheckAllFunc also stops, returning ctx.Err() (wrapped the same way),
+// if ctx is canceled.
+func CheckAllFunc(ctx context.Context, git, repodir string, f func(moduledir string, r Result, err error) error, opts ...Option) error {
+	o := makeOptions(opts)
+
 	if git == "" {
 		var err error
 		git, err = exec.LookPath("git")
 		if err != nil {
-			return nil, errors.Wrap(err, "finding git binary")
+			return errors.Wrap(err, "finding git binary")
 		}
 	}
 
-	result := make(map[string]Result)
-	err := modules.Each(repodir, func(moduledir string) error {
-		res, err := Check(ctx, git, repodir, moduledir)
-		if err == nil { // sic
-			result[moduledir] = res
+	root := repodir
+	if o.subtree != "" {
+		root = filepath.Join(repodir, o.subtree)
+	}
+
+	each := func(moduledir string) error {
+		if err := ctx.Err(); err != nil {
+			return err
 		}
-		return err
-	})
-	return result, err
+		r, err := Check(ctx, git, repodir, moduledir, opts...)
+		return f(moduledir, r, err)
+	}
+
+	if o.workspace {
+		moduledirs, err := workspaceModules(repodir, root)
+		if err != nil {
+			return err
+		}
+		for _, moduledir := range moduledirs {
+			// Wrap the error the way modules.Each does.
+			if err := each(moduledir); err != nil {
+				return errors.Wrapf(err, "in %s", moduledir)
+			}
+		}
+		return nil
+	}
+
+	return modules.Each(root, each)
 }
 
 // Check checks a Go module in a Git repository.
 // It returns a Result with information about the module and its repository.
-func Check(ctx context.Context, git, repodir, moduledir string) (Result, error) {
-	var result Result
+func Check(ctx context.Context, git, repodir, moduledir string, opts ...Option) (Result, error) {
+	result, err := check(ctx, git, repodir, moduledir, opts...)
+	result.Remediations = result.remediations()
+	result.SuppressedFindings = result.suppressedFindings()
+	return result, err
+}
+
+func check(ctx context.Context, git, repodir, moduledir string, opts ...Option) (Result, error) {
+	var (
+		result = Result{SchemaVersion: ResultSchemaVersion}
+		o      = makeOptions(opts)
+	)
 
 	if git == "" {
 		var err error
@@ -57,33 +121,20 @@ func Check(ctx context.Context, git, repodir, moduledir string) (Result, error)
 		}
 	}
 
-	if moduledir != "" {
-		repodir = filepath.Clean(repodir)
-		moduledir = filepath.Clean(moduledir)
-
-		switch {
-		case moduledir == repodir:
-			moduledir = ""
-
-		case filepath.IsAbs(moduledir):
-			rel, err := filepath.Rel(repodir, moduledir)
-			if err != nil {
-				return result, errors.Wrapf(err, "finding relative path from %s to %s", repodir, moduledir)
-			}
-			if rel == ".." || strings.HasPrefix(rel, "../") {
-				return result, fmt.Errorf("module dir %s is not in repository %s", moduledir, repodir)
-			}
-			if rel == "." {
-				moduledir = ""
-			} else {
-				moduledir = rel
-			}
+	ctx = o.gitContext(ctx, repodir)
 
-		default:
-			moduledir = strings.TrimPrefix(moduledir, repodir+"/")
+	progress := func(p Phase) {
+		if o.progress != nil {
+			o.progress(p, result)
 		}
 	}
+
+	moduledir, err := moduleSubdir(repodir, moduledir)
+	if err != nil {
+		return result, err
+	}
 	result.ModuleSubdir = moduledir
+	result.Suppress = o.config.module(moduledir).Suppress
 
 	var versionPrefix string
 	if moduledir != "" {
@@ -96,9 +147,11 @@ func Check(ctx context.Context, git, repodir, moduledir string) (Result, error)
 		remotes  = make(map[string]map[string]string) // remote -> ref -> hash
 		tags     = make(map[string]string)
 		versions = make(map[string]string)
+
+		lightweightVersions []string
 	)
 
-	err := gitRefs(ctx, git, repodir, func(name, hash string) error {
+	err = gitutil.Refs(ctx, git, repodir, func(name, hash string) error {
 		switch {
 		case strings.HasPrefix(name, "refs/heads/"):
 			name = strings.TrimPrefix(name, "refs/heads/")
@@ -125,10 +178,12 @@ func Check(ctx context.Context, git, repodir, moduledir string) (Result, error)
 
 			// Extra step to resolve the tag's underlying commit,
 			// if it's an annotated tag.
-			hash, err := gitTagCommit(ctx, git, repodir, name)
+			refHash := hash
+			hash, err := gitutil.TagCommit(ctx, git, repodir, name)
 			if err != nil {
 				return errors.Wrapf(err, "resolving commit for tag %s", name)
 			}
+			lightweight := refHash == hash // an annotated tag is its own object
 
 			tags[name] = hash
 
@@ -138,8 +193,11 @@ func Check(ctx context.Context, git, repodir, moduledir string) (Result, error)
 				}
 				name = strings.TrimPrefix(name, versionPrefix)
 			}
-			if semver.IsValid(name) {
+			if semver.IsValid(name) && !IsFloatingVersion(name) {
 				versions[name] = hash
+				if lightweight {
+					lightweightVersions = append(lightweightVersions, name)
+				}
 			}
 		}
 		return nil
@@ -148,6 +206,99 @@ func Check(ctx context.Context, git, repodir, moduledir string) (Result, error)
 		return result, errors.Wrap(err, "getting refs")
 	}
 
+	// Version tags that exist only in the remote.
+	remoteOnlyVersions := set.New[string]()
+
+	if o.remoteTags && o.offline {
+		result.SkippedChecks = append(result.SkippedChecks, "remote-tags")
+	} else if o.remoteTags {
+		remote := o.remote
+		if remote == "" {
+			remote = "origin"
+		}
+		remoteTags, remoteAnnotated, err := gitutil.RemoteTags(ctx, git, repodir, remote)
+		if err != nil {
+			return result, errors.Wrapf(err, "listing tags in remote %s", remote)
+		}
+		for name, hash := range remoteTags {
+			if _, ok := tags[name]; ok {
+				continue
+			}
+			if versionPrefix != "" {
+				if !strings.HasPrefix(name, versionPrefix) {
+					continue
+				}
+				name = strings.TrimPrefix(name, versionPrefix)
+			}
+			if !semver.IsValid(name) || IsFloatingVersion(name) {
+				continue
+			}
+			result.RemoteOnlyVersionTags = append(result.RemoteOnlyVersionTags, name)
+
+			// Only a tag whose commit is in the local clone can be analyzed.
+			if !gitutil.HasCommit(ctx, git, repodir, hash) {
+				continue
+			}
+			remoteOnlyVersions.Add(name)
+			versions[name] = hash
+			if !remoteAnnotated[versionPrefix+name] {
+				lightweightVersions = append(lightweightVersions, name)
+			}
+		}
+		semver.Sort(result.RemoteOnlyVersionTags)
+	}
+
+	result.MalformedVersionTags = malformedVersionTags(tags, versionPrefix, versions)
+	result.StaleFloatingTags = staleFloatingTags(tags, versionPrefix, versions)
+
+	// The commit named with o.target, if any.
+	var target string
+	if o.target != "" {
+		target, err = gitutil.ResolveCommit(ctx, git, repodir, o.target)
+		if err != nil {
+			return result, errors.Wrapf(err, "resolving target %s", o.target)
+		}
+		if target == "" {
+			return result, fmt.Errorf("target %s is not a commit", o.target)
+		}
+	}
+
+	// Version tags not reachable from o.branch or o.target.
+	otherVersions := set.New[string]()
+
+	if o.branch != "" || target != "" {
+		reachableFrom, name := target, o.target
+		if o.branch != "" {
+			if _, ok := heads[o.branch]; !ok {
+				return result, fmt.Errorf("no branch named %s", o.branch)
+			}
+			if reachableFrom == "" {
+				reachableFrom, name = "refs/heads/"+o.branch, o.branch
+			}
+		}
+
+		// Only versions reachable from the branch or target count.
+		merged, err := gitutil.TagsMerged(ctx, git, repodir, reachableFrom)
+		if err != nil {
+			return result, errors.Wrapf(err, "getting tags reachable from %s", name)
+		}
+		for v := range versions {
+			if !merged[versionPrefix+v] {
+				otherVersions.Add(v)
+				delete(versions, v)
+			}
+		}
+		lightweightVersions = slices.DeleteFunc(lightweightVersions, func(v string) bool {
+			return !merged[versionPrefix+v]
+		})
+	}
+
+	if len(versions) == 0 && otherVersions.Len() == 0 {
+		result.OtherModuleVersionTags = otherModuleVersionTags(tags, versionPrefix)
+	}
+
+	progress(PhaseRefs)
+
 	var (
 		latestVersion                         string
 		latestMajor, latestMinor, latestPatch int // valid only if latestVersion is non-empty
@@ -171,121 +322,608 @@ func Check(ctx context.Context, git, repodir, moduledir string) (Result, error)
 		latestVersionUnstable = latestMajor == 0 || latestVersionIsPrerelease
 	}
 	result.LatestVersion = latestVersion
+
+	// The revision of the latest version for git commands:
+	// its tag, or its commit if the tag exists only in the remote.
+	latestVersionRev := versionPrefix + latestVersion
+	if remoteOnlyVersions.Has(latestVersion) {
+		latestVersionRev = versions[latestVersion]
+	}
 	result.LatestMajor = latestMajor
 	result.LatestMinor = latestMinor
 	result.LatestPatch = latestPatch
 	result.LatestVersionIsPrerelease = latestVersionIsPrerelease
 	result.LatestVersionUnstable = latestVersionUnstable
 
-	gomodPath := filepath.Join(repodir, moduledir, "go.mod")
-	gomodBytes, err := os.ReadFile(gomodPath)
-	if err != nil {
-		return result, errors.Wrapf(err, "reading %s", gomodPath)
+	if o.requireAnnotated && len(lightweightVersions) > 0 {
+		semver.Sort(lightweightVersions)
+		result.LightweightVersionTags = lightweightVersions
 	}
-	gomod, err := modfile.ParseLax(gomodPath, gomodBytes, noopFixer)
-	if err != nil {
-		return result, errors.Wrapf(err, "parsing %s", gomodPath)
+
+	if err := setTagStats(ctx, git, repodir, versionPrefix, versions, remoteOnlyVersions, &result); err != nil {
+		return result, err
 	}
 
-	result.Modpath = gomod.Module.Mod.Path
-	result.VersionSuffix = VSOK
+	progress(PhaseVersions)
 
-	baseModpath, modpathSuffixVersion, hasModpathVersionSuffix := decomposeModpath(gomod.Module.Mod.Path)
-	if hasModpathVersionSuffix {
-		switch modpathSuffixVersion {
-		case 0, 1:
-			result.VersionSuffix = VSUnwanted
+	// A go.mod file that cannot be read or parsed spoils only the later phases,
+	// so the tag and branch findings can still be reported.
+	baseModpath, err := readModfile(&result, repodir, moduledir, latestMajor)
+	if err != nil {
+		result.PhaseError = &PhaseError{Phase: PhaseModfile, Message: err.Error()}
+	}
 
-		case latestMajor:
-			// ok, do nothing
+	progress(PhaseModfile)
 
-		default:
-			result.VersionSuffix = VSMismatch
+	var (
+		defaultBranch = o.branch
+		remote        string
+	)
+	// trackingRemote is the remote (if any) with a counterpart to defaultBranch,
+	// for when defaultBranch does not come from the remote's refs.
+	trackingRemote := func() string {
+		if o.remote != "" {
+			return o.remote
 		}
-	} else if latestMajor > 1 {
-		result.VersionSuffix = VSMissing
+		if _, ok := remotes["origin"][defaultBranch]; ok {
+			return "origin"
+		}
+		return ""
 	}
 
-	if moduledir != "" {
-		suffix := "/" + moduledir
-		if !strings.HasSuffix(baseModpath, suffix) {
-			result.ModpathMismatch = true
+	switch {
+	case defaultBranch != "":
+		remote = trackingRemote()
+
+	case o.config.DefaultBranch != "":
+		defaultBranch = o.config.DefaultBranch
+		if _, ok := heads[defaultBranch]; !ok {
+			return result, fmt.Errorf("no branch named %s (the configured default branch)", defaultBranch)
+		}
+		remote = trackingRemote()
+
+	case o.remote != "":
+		remoteRefs, ok := remotes[o.remote]
+		if !ok {
+			return result, fmt.Errorf("no refs for remote %s", o.remote)
+		}
+		defaultBranch, err = remoteDefaultBranch(ctx, git, repodir, o.remote, remoteRefs, heads)
+		if err != nil {
+			return result, err
+		}
+		if defaultBranch != "" {
+			remote = o.remote
 		}
-	}
 
-	defaultBranch := detectDefaultBranch(remotes["origin"], heads)
-	if defaultBranch == "" {
-		for _, remoteRefs := range remotes {
-			if defaultBranch = detectDefaultBranch(remoteRefs, heads); defaultBranch != "" {
+	default:
+		remoteNames := maps.Keys(remotes)
+		slices.Sort(remoteNames)
+		remoteNames = slices.DeleteFunc(remoteNames, func(name string) bool { return name == "origin" })
+		remoteNames = append([]string{"origin"}, remoteNames...) // try origin first
+
+		for _, name := range remoteNames {
+			defaultBranch, err = remoteDefaultBranch(ctx, git, repodir, name, remotes[name], heads)
+			if err != nil {
+				return result, err
+			}
+			if defaultBranch != "" {
+				remote = name
 				break
 			}
 		}
+
+		if len(remotes) == 0 {
+			// With no remotes to go by, use the current branch.
+			ref, err := gitutil.SymbolicRef(ctx, git, repodir, "HEAD")
+			if err != nil {
+				return result, errors.Wrap(err, "reading HEAD")
+			}
+			if branch, ok := strings.CutPrefix(ref, "refs/heads/"); ok {
+				if _, ok := heads[branch]; ok {
+					defaultBranch = branch
+					result.NoRemote = true
+				}
+			}
+		}
 	}
 	result.DefaultBranch = defaultBranch
+	result.Remote = remote
+	result.Branch = o.branch
+
+	if remote != "" {
+		// The refs of a remote can outlive its configuration,
+		// so a missing URL is not an error.
+		if remoteURL, err := gitutil.RemoteURL(ctx, git, repodir, remote); err == nil {
+			result.RemoteURL, _ = NormalizeRemoteURL(remoteURL)
+			if forge, ok := ParseForge(remoteURL); ok {
+				result.ForgeKind = forge.Kind
+			}
+		}
+
+		if result.RemoteURL != "" && baseModpath != "" {
+			if matches, ok := modpathMatchesRemote(baseModpath, result.RemoteURL); ok && !matches {
+				result.ModpathRemoteMismatch = true
+			}
+		}
+
+		local, upstream := heads[defaultBranch], remotes[remote][defaultBranch]
+		if upstream != "" && local != upstream {
+			result.Ahead, result.Behind, err = gitutil.AheadBehind(ctx, git, repodir, local, upstream)
+			if err != nil {
+				return result, errors.Wrapf(err, "comparing %s with %s/%s", defaultBranch, remote, defaultBranch)
+			}
+		}
+	}
 
 	var latestCommitHasVersionTag bool
 
-	if defaultBranch != "" {
-		if latestCommit, ok := heads[defaultBranch]; ok {
-			latestCommitHasLatestVersion := versions[latestVersion] == latestCommit
-
-			latestCommitHasVersionTag = latestCommitHasLatestVersion
-			if !latestCommitHasVersionTag {
-				for _, h := range versions {
-					if h == latestCommit {
-						latestCommitHasVersionTag = true
-						break
-					}
+	// The revision to analyze:
+	// normally the default branch,
+	// but with Config.ReleaseMerge, the latest release merge commit on it
+	// ("" if there is none),
+	// and with o.target, that commit.
+	var (
+		head                          = defaultBranch
+		latestCommit, hasLatestCommit = heads[defaultBranch]
+	)
+
+	if target != "" {
+		head, latestCommit, hasLatestCommit = target, target, true
+		result.Target = o.target
+	} else if pattern := o.config.ReleaseMerge; pattern != "" && defaultBranch != "" {
+		commit, err := releaseMergeCommit(ctx, git, repodir, defaultBranch, pattern)
+		if err != nil {
+			return result, errors.Wrapf(err, "finding release merge commit on %s", defaultBranch)
+		}
+		head, latestCommit, hasLatestCommit = commit, commit, commit != ""
+		result.ReleaseMergeCommit = commit
+		result.NoReleaseMerge = commit == ""
+	}
+
+	if hasLatestCommit {
+		latestCommitHasLatestVersion := versions[latestVersion] == latestCommit
+
+		latestCommitHasVersionTag = latestCommitHasLatestVersion
+		if !latestCommitHasVersionTag {
+			for _, h := range versions {
+				if h == latestCommit {
+					latestCommitHasVersionTag = true
+					break
 				}
 			}
+		}
 
-			result.LatestCommit = latestCommit
-			result.LatestCommitHasVersionTag = latestCommitHasVersionTag
-			result.LatestCommitHasLatestVersion = latestCommitHasLatestVersion
+		result.LatestCommit = latestCommit
+		detail, err := gitutil.CommitDetails(ctx, git, repodir, latestCommit)
+		if err != nil {
+			return result, errors.Wrapf(err, "getting details of commit %s", latestCommit)
+		}
+		result.LatestCommitAuthor = detail.Author
+		result.LatestCommitSubject = detail.Subject
+		if result.LatestCommitTime, err = time.Parse(time.RFC3339, detail.CommitTime); err != nil {
+			return result, errors.Wrapf(err, "parsing commit time %s", detail.CommitTime)
+		}
+		result.LatestCommitHasVersionTag = latestCommitHasVersionTag
+		result.LatestCommitHasLatestVersion = latestCommitHasLatestVersion
+
+		result.NearestTag, err = nearestTag(ctx, git, repodir, versionPrefix, latestCommit)
+		if err != nil {
+			return result, errors.Wrapf(err, "describing commit %s", latestCommit)
 		}
 	}
 
-	var newMajor, newMinor, newPatch int
+	// An Action's tags are the repository's unprefixed tags,
+	// which belong to the module at the root.
+	if moduledir == "" {
+		result.Action, err = actionInfo(ctx, git, repodir, tags, latestVersionRev, result)
+		if err != nil {
+			return result, errors.Wrap(err, "checking GitHub Action")
+		}
+	}
 
-	if latestVersion != "" {
-		if defaultBranch != "" && !latestCommitHasVersionTag {
-			latestVersionWithPrefix := versionPrefix + latestVersion
+	progress(PhaseBranch)
+
+	if result.PhaseError != nil {
+		return result, nil
+	}
 
+	var (
+		newMajor, newMinor, newPatch int
+		newPrerelease                string
+	)
+
+	if latestVersion != "" {
+		if head != "" && !latestCommitHasVersionTag {
 			newMajor, newMinor, newPatch = latestMajor, latestMinor, latestPatch
 
-			ctx = modver.WithGit(ctx, git)
+			base := latestVersionRev
+			if o.base != "" {
+				base, err = gitutil.ResolveCommit(ctx, git, repodir, o.base)
+				if err != nil {
+					return result, errors.Wrapf(err, "resolving base %s", o.base)
+				}
+				if base == "" {
+					return result, fmt.Errorf("base %s is not a commit", o.base)
+				}
+				result.Base = o.base
+			}
 
-			dotgitdir := filepath.Join(repodir, ".git")
-			modverResult, err := modver.CompareGit(ctx, dotgitdir, latestVersionWithPrefix, defaultBranch)
+			// Commits that only revert unreleased changes leave nothing to release,
+			// whatever Modver makes of them.
+			changed, err := gitutil.TreeChanged(ctx, git, repodir, base, head, moduledir)
 			if err != nil {
-				return result, errors.Wrapf(err, "comparing %s to %s", latestVersionWithPrefix, defaultBranch)
+				return result, errors.Wrapf(err, "comparing trees of %s and %s", base, head)
+			}
+			result.NoNetChanges = !changed
+
+			code := modver.None
+			if changed {
+				gitdir, err := gitutil.CommonDir(ctx, git, repodir)
+				if err != nil {
+					return result, errors.Wrap(err, "finding Git directory")
+				}
+				modverResult, build, err := o.compareRevs(ctx, git, gitdir, moduledir, base, head)
+				if err != nil {
+					result.PhaseError = &PhaseError{Phase: PhaseModver, Message: errors.Wrapf(err, "comparing %s to %s", base, head).Error()}
+					return result, nil
+				}
+				if build.isSet() {
+					result.BuildContext = build.String()
+				}
+				code = modverResult.Code()
+				result.ModverResultCode = code
+				result.ModverResultString = modverResult.String()
+				result.APIChanges = apiChanges(modverResult)
+				if o.modverReport {
+					var report strings.Builder
+					modver.Pretty(&report, modverResult)
+					result.ModverReport = report.String()
+				}
+			}
+
+			if bumpCode := o.config.Bump.apply(code, latestMajor); bumpCode != code {
+				result.PolicyBump = bumpCode
+				code = bumpCode
 			}
-			result.ModverResultCode = modverResult.Code()
-			result.ModverResultString = modverResult.String()
 
-			switch modverResult.Code() {
-			case modver.Major:
+			switch {
+			case latestVersionIsPrerelease:
+				latestPrerelease := strings.TrimPrefix(semver.Prerelease(latestVersion), "-")
+				newMajor, newMinor, newPatch, newPrerelease = prereleaseSuccessor(latestMajor, latestMinor, latestPatch, latestPrerelease, code, o.finalize)
+				result.FinalizesPrerelease = o.finalize && newPrerelease == "" && newMajor == latestMajor && newMinor == latestMinor && newPatch == latestPatch
+
+			case code == modver.Major:
 				newMajor, newMinor, newPatch = latestMajor+1, 0, 0
 
-			case modver.Minor:
+			case code == modver.Minor:
 				newMajor, newMinor, newPatch = latestMajor, latestMinor+1, 0
 
-			case modver.Patchlevel:
-				if !latestVersionIsPrerelease {
-					newPatch = latestPatch + 1
-				}
+			case code == modver.Patchlevel:
+				newPatch = latestPatch + 1
+			}
+
+			gomodRelPath := path.Join(filepath.ToSlash(moduledir), "go.mod")
+
+			// The tagged version may predate go.mod, so errors here are not fatal.
+			result.LatestVersionGoDirective, result.LatestVersionToolchainDirective, _ = goDirectives(ctx, git, repodir, latestVersionRev, gomodRelPath)
+
+			result.GoDirective, result.ToolchainDirective, err = goDirectives(ctx, git, repodir, head, gomodRelPath)
+			if err != nil {
+				result.PhaseError = &PhaseError{Phase: PhaseModver, Message: errors.Wrapf(err, "reading %s on %s", gomodRelPath, head).Error()}
+				return result, nil
+			}
+
+			// A prerelease of a new minor version already accounts for a raised directive.
+			if o.config.GoDirectiveBump == "minor" && (result.GoDirectiveRaised() || result.ToolchainDirectiveRaised()) && newMajor == latestMajor && newMinor == latestMinor && (!latestVersionIsPrerelease || latestPatch != 0) {
+				newMinor, newPatch, newPrerelease = latestMinor+1, 0, ""
+				result.FinalizesPrerelease = false
+				result.GoDirectiveBump = true
 			}
 		}
 	} else {
 		newMajor, newMinor, newPatch = 0, 1, 0
 	}
+
+	progress(PhaseModver)
+
 	result.NewMajor = newMajor
 	result.NewMinor = newMinor
 	result.NewPatch = newPatch
+	result.NewPrerelease = newPrerelease
+
+	if o.branch != "" {
+		result.Series = branchSeries(o.branch)
+		if latestVersion != "" && head != "" && !latestCommitHasVersionTag {
+			result.NewVersionExists = otherVersions.Has(semver.Canonical(result.NewVersion()))
+		}
+	}
+
+	if tmpl := o.config.BuildMetadata; tmpl != "" && result.LatestCommit != "" && !latestCommitHasVersionTag {
+		result.NewBuildMetadata, err = buildMetadata(ctx, git, repodir, tmpl, result.LatestCommit)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	progress(PhaseRecommendation)
+
+	if o.releaseAge && latestVersion != "" {
+		s, err := gitutil.CommitTime(ctx, git, repodir, latestVersionRev)
+		if err != nil {
+			return result, errors.Wrapf(err, "getting commit time of %s", latestVersion)
+		}
+		result.LatestVersionTime, err = time.Parse(time.RFC3339, s)
+		if err != nil {
+			return result, errors.Wrapf(err, "parsing commit time %s", s)
+		}
+	}
+
+	if head != "" && !latestCommitHasVersionTag {
+		// With no latest version, every commit is unreleased.
+		var since string
+		if latestVersion != "" {
+			since = latestVersionRev
+		}
+
+		// List the unreleased commits only once,
+		// in full if summaries were requested.
+		var times []time.Time
+		if o.commitSummaries {
+			summaries, err := commitSummaries(ctx, git, repodir, since, head, moduledir)
+			if err != nil {
+				return result, errors.Wrap(err, "listing commits since latest version")
+			}
+			if len(summaries) > 0 {
+				result.UnreleasedCommitSummaries = summaries
+			}
+			for _, c := range summaries {
+				times = append(times, c.Time)
+			}
+		} else {
+			ss, err := gitutil.CommitTimes(ctx, git, repodir, since, head, moduledir)
+			if err != nil {
+				return result, errors.Wrap(err, "listing commits since latest version")
+			}
+			for _, s := range ss {
+				t, err := time.Parse(time.RFC3339, s)
+				if err != nil {
+					return result, errors.Wrapf(err, "parsing commit time %s", s)
+				}
+				times = append(times, t)
+			}
+		}
+		result.UnreleasedCommits = len(times)
+
+		if stale := *o.config.module(moduledir).Stale; (stale.isSet() || o.releaseAge) && latestVersion != "" {
+			for _, t := range times {
+				if result.OldestUnreleasedCommitTime.IsZero() || t.Before(result.OldestUnreleasedCommitTime) {
+					result.OldestUnreleasedCommitTime = t
+				}
+			}
+			if result.NoNetChanges {
+				// Nothing to release, so nothing is stale.
+			} else if stale.Commits > 0 && result.UnreleasedCommits > stale.Commits {
+				result.StaleRelease = true
+			} else if stale.Days > 0 && result.UnreleasedCommits > 0 && time.Since(result.OldestUnreleasedCommitTime) > time.Duration(stale.Days)*24*time.Hour {
+				result.StaleRelease = true
+			}
+		}
+	}
+
+	if o.tidy {
+		result.TidyDiff, err = tidyDiff(ctx, filepath.Join(repodir, moduledir), o.offline)
+		if err != nil {
+			return result, errors.Wrap(err, "checking tidiness")
+		}
+	}
+
+	if o.verifyBuilds {
+		result.UnbuildableVersions, err = unbuildableVersions(ctx, git, repodir, moduledir, versionPrefix, versionTags, o.offline)
+		if err != nil {
+			return result, errors.Wrap(err, "verifying builds")
+		}
+	}
+
+	if o.govulncheck && head != "" && o.offline {
+		result.SkippedChecks = append(result.SkippedChecks, "govulncheck")
+	} else if o.govulncheck && head != "" {
+		result.ReachableVulnerabilities, err = reachableVulnerabilities(ctx, git, repodir, moduledir, head)
+		if err != nil {
+			return result, errors.Wrap(err, "running govulncheck")
+		}
+	}
+
+	if o.history {
+		result.Versions, err = versionHistory(ctx, git, repodir, versionPrefix, defaultBranch, versions)
+		if err != nil {
+			return result, errors.Wrap(err, "getting version history")
+		}
+	}
+
+	if o.graduation && latestVersion != "" && latestMajor == 0 && head != "" {
+		history := result.Versions
+		if !o.history {
+			history, err = versionHistory(ctx, git, repodir, versionPrefix, defaultBranch, versions)
+			if err != nil {
+				return result, errors.Wrap(err, "getting version history")
+			}
+		}
+		gitdir, err := gitutil.CommonDir(ctx, git, repodir)
+		if err != nil {
+			return result, errors.Wrap(err, "finding Git directory")
+		}
+		result.Graduation, err = o.evalGraduation(ctx, git, repodir, gitdir, moduledir, history, result)
+		if err != nil {
+			return result, errors.Wrap(err, "evaluating graduation to v1")
+		}
+	}
+
+	if len(o.config.Readiness) > 0 {
+		result.Readiness, err = readinessChecks(ctx, repodir, moduledir, result.Modpath, o.config.Readiness, o.offline)
+		if err != nil {
+			return result, errors.Wrap(err, "running readiness checks")
+		}
+	}
+
+	if o.osv && latestVersion != "" && o.offline {
+		result.SkippedChecks = append(result.SkippedChecks, "osv")
+	} else if o.osv && latestVersion != "" {
+		ids, err := osvVulnsAffecting(ctx, o.httpClient, result.Modpath, latestVersion)
+		if err != nil {
+			return result, errors.Wrapf(err, "querying OSV for %s@%s", result.Modpath, latestVersion)
+		}
+		result.OpenVulnerabilities = ids
+	}
+
+	if o.dependents && latestVersion != "" && newMajor > latestMajor && o.offline {
+		result.SkippedChecks = append(result.SkippedChecks, "dependents")
+	} else if o.dependents && latestVersion != "" && newMajor > latestMajor {
+		n, err := depsDevDependents(ctx, o.httpClient, result.Modpath, latestVersion)
+		if err != nil {
+			return result, errors.Wrapf(err, "querying dependents of %s@%s", result.Modpath, latestVersion)
+		}
+		result.KnownDependents = n
+	}
+
+	result.RuleViolations, err = evalRules(ctx, result, o.config.Rules, func(ctx context.Context, path string) (bool, error) {
+		if head == "" {
+			return true, nil // nothing to analyze
+		}
+		return gitutil.TreeChanged(ctx, git, repodir, latestVersionRev, head, path)
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "evaluating policy rules")
+	}
+
+	progress(PhaseChecks)
 
 	return result, nil
 }
 
+// readModfile reads the go.mod file of the module in moduledir
+// and fills in the corresponding fields of result
+// (see [PhaseModfile]).
+// It returns the module path without any major-version suffix.
+func readModfile(result *Result, repodir, moduledir string, latestMajor int) (string, error) {
+	gomodPath := filepath.Join(repodir, moduledir, "go.mod")
+	gomodBytes, err := os.ReadFile(gomodPath)
+	if err != nil {
+		return "", errors.Wrapf(err, "reading %s", gomodPath)
+	}
+	gomod, err := modfile.ParseLax(gomodPath, gomodBytes, noopFixer)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing %s", gomodPath)
+	}
+	if gomod.Module == nil {
+		return "", fmt.Errorf("%s has no module directive", gomodPath)
+	}
+
+	result.Modpath = gomod.Module.Mod.Path
+	result.VersionSuffix = VSOK
+
+	// ParseLax ignores replace and exclude directives.
+	strictGomod, err := modfile.Parse(gomodPath, gomodBytes, noopFixer)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing %s", gomodPath)
+	}
+	for _, r := range strictGomod.Replace {
+		directive := formatReplace(r)
+		result.ReplaceDirectives = append(result.ReplaceDirectives, directive)
+		if modfile.IsDirectoryPath(r.New.Path) {
+			result.LocalReplaceDirectives = append(result.LocalReplaceDirectives, directive)
+		}
+	}
+	for _, x := range strictGomod.Exclude {
+		result.ExcludeDirectives = append(result.ExcludeDirectives, x.Mod.String())
+	}
+
+	baseModpath, modpathSuffixVersion, hasModpathVersionSuffix := decomposeModpath(gomod.Module.Mod.Path)
+	if hasModpathVersionSuffix {
+		switch modpathSuffixVersion {
+		case 0, 1:
+			result.VersionSuffix = VSUnwanted
+
+		case latestMajor:
+			// ok, do nothing
+
+		default:
+			result.VersionSuffix = VSMismatch
+		}
+	} else if latestMajor > 1 {
+		result.VersionSuffix = VSMissing
+	}
+
+	if moduledir != "" {
+		suffix := "/" + moduledir
+		if !strings.HasSuffix(baseModpath, suffix) {
+			result.ModpathMismatch = true
+		}
+	}
+
+	return baseModpath, nil
+}
+
+// moduleSubdir normalizes moduledir,
+// which may be absolute or relative to the current directory or to repodir,
+// to a path relative to repodir.
+// The result is "" for the repository root.
+func moduleSubdir(repodir, moduledir string) (string, error) {
+	if moduledir == "" {
+		return "", nil
+	}
+
+	repodir = filepath.Clean(repodir)
+	moduledir = filepath.Clean(moduledir)
+
+	switch {
+	case moduledir == repodir:
+		return "", nil
+
+	case filepath.IsAbs(moduledir):
+		rel, err := filepath.Rel(repodir, moduledir)
+		if err != nil {
+			return "", errors.Wrapf(err, "finding relative path from %s to %s", repodir, moduledir)
+		}
+		if rel == ".." || strings.HasPrefix(rel, "../") {
+			return "", fmt.Errorf("module dir %s is not in repository %s", moduledir, repodir)
+		}
+		if rel == "." {
+			return "", nil
+		}
+		return rel, nil
+
+	case filepath.IsAbs(repodir):
+		// Moduledir is relative to the current directory.
+		abs, err := filepath.Abs(moduledir)
+		if err != nil {
+			return "", errors.Wrapf(err, "making %s absolute", moduledir)
+		}
+		return moduleSubdir(repodir, abs)
+
+	default:
+		return strings.TrimPrefix(moduledir, repodir+"/"), nil
+	}
+}
+
+// remoteDefaultBranch determines the default branch from the given remote,
+// whose refs are in remoteRefs.
+// The remote's HEAD symref
+// (refs/remotes/REMOTE/HEAD, set by "git clone" or "git remote set-head")
+// is authoritative, if it names a local branch.
+// Otherwise the result is from [detectDefaultBranch].
+func remoteDefaultBranch(ctx context.Context, git, repodir, remote string, remoteRefs, heads map[string]string) (string, error) {
+	ref, err := gitutil.SymbolicRef(ctx, git, repodir, "refs/remotes/"+remote+"/HEAD")
+	if err != nil {
+		return "", errors.Wrapf(err, "reading HEAD of remote %s", remote)
+	}
+	if branch, ok := strings.CutPrefix(ref, "refs/remotes/"+remote+"/"); ok {
+		if _, ok := heads[branch]; ok {
+			return branch, nil
+		}
+	}
+	return detectDefaultBranch(remoteRefs, heads), nil
+}
+
 var likelyDefaultBranchNames = []string{"main", "master", "default", "trunk"}
 
 func detectDefaultBranch(remoteRefs map[string]string, heads map[string]string) string {
@@ -330,7 +968,13 @@ func detectDefaultBranch(remoteRefs map[string]string, heads map[string]string)
 	return ""
 }
 
+// nonDefaultBranchRune tells whether r makes a branch name an unlikely guess for the default branch,
+// as the slash in feature/foo does.
+// A default branch with such a name can be configured (see [Config]).
 func nonDefaultBranchRune(r rune) bool {
+	if r == '-' || r == '_' || r == '.' {
+		return false
+	}
 	return !unicode.IsOneOf([]*unicode.RangeTable{unicode.Letter, unicode.Digit}, r)
 }
 
@@ -338,6 +982,15 @@ func noopFixer(_, version string) (string, error) {
 	return version, nil
 }
 
+// formatReplace formats a replace directive as it appears in go.mod.
+func formatReplace(r *modfile.Replace) string {
+	s := r.Old.String() + " => " + r.New.Path
+	if r.New.Version != "" {
+		s += " " + r.New.Version
+	}
+	return s
+}
+
 func decomposeModpath(modpath string) (baseModpath string, suffixVersion int, hasVersionSuffix bool) {
 	if m := modpathVersionSuffixRegex.FindStringSubmatchIndex(modpath); len(m) > 0 {
 		baseModpath = modpath[:m[2]]
@@ -351,3 +1004,18 @@ var (
 	modpathVersionSuffixRegex = regexp.MustCompile(`/v([1-9][0-9]*)$`)
 	versionRegex              = regexp.MustCompile(`v([0-9]+)\.([0-9]+)\.([0-9]+)`)
 )
+
+// otherModuleVersionTags returns the names of the tags
+// that look like version tags for some module
+// other than the one whose version tags have the given prefix,
+// sorted.
+func otherModuleVersionTags(tags map[string]string, versionPrefix string) []string {
+	var result []string
+	for name := range tags {
+		if dir, v := path.Split(name); dir != versionPrefix && semver.IsValid(v) && !IsFloatingVersion(v) {
+			result = append(result, name)
+		}
+	}
+	slices.Sort(result)
+	return result
+}
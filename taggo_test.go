@@ -4,17 +4,24 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bobg/go-generics/v3/maps"
+	"github.com/bobg/modver/v2"
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/internal/testutil"
 )
 
 func TestCheckAll(t *testing.T) {
@@ -116,3 +123,1402 @@ func TestCheckAll(t *testing.T) {
 		})
 	}
 }
+
+func TestAnnotatedTagsRequired(t *testing.T) {
+	repodir := cloneBundle(t, "unstable")
+
+	result, err := taggo.Check(context.Background(), "", repodir, "", taggo.WithAnnotatedTagsRequired())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"v0.1.2"}, result.LightweightVersionTags); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestVersionHistory(t *testing.T) {
+	repodir := cloneBundle(t, "unstable")
+
+	result, err := taggo.Check(context.Background(), "", repodir, "", taggo.WithVersionHistory())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []taggo.VersionInfo{{
+		Version:         "v0.1.2",
+		Commit:          "9676a02c78861f87b2f1140143798e07a206f463",
+		CommitTime:      time.Date(2024, 6, 30, 15, 37, 39, 0, time.UTC),
+		OnDefaultBranch: true,
+	}}
+	if diff := cmp.Diff(want, result.Versions, cmp.Comparer(time.Time.Equal)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestStaleRelease(t *testing.T) {
+	repodir := cloneBundle(t, "minor-upgrade")
+
+	config := taggo.Config{
+		Stale: taggo.StaleThreshold{Days: 30},
+	}
+	result, err := taggo.Check(context.Background(), "", repodir, "", taggo.WithConfig(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.StaleRelease {
+		t.Error("got StaleRelease false, want true")
+	}
+	if result.UnreleasedCommits != 1 {
+		t.Errorf("got %d unreleased commits, want 1", result.UnreleasedCommits)
+	}
+
+	config.Modules = map[string]taggo.ModuleConfig{
+		".": {Stale: &taggo.StaleThreshold{Commits: 5}},
+	}
+	result, err = taggo.Check(context.Background(), "", repodir, "", taggo.WithConfig(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.StaleRelease {
+		t.Error("got StaleRelease true with per-module threshold, want false")
+	}
+}
+
+func TestReleaseAge(t *testing.T) {
+	repodir := cloneBundle(t, "minor-upgrade")
+
+	result, err := taggo.Check(context.Background(), "", repodir, "", taggo.WithReleaseAge())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.LatestVersionTime.IsZero() {
+		t.Error("got zero LatestVersionTime")
+	}
+	if result.UnreleasedCommits != 1 {
+		t.Errorf("got %d unreleased commits, want 1", result.UnreleasedCommits)
+	}
+	if result.StaleRelease {
+		t.Error("got StaleRelease true with no threshold, want false")
+	}
+}
+
+func TestBuildMetadata(t *testing.T) {
+	repodir := cloneBundle(t, "minor-upgrade")
+
+	config := taggo.Config{BuildMetadata: "sha.{{.ShortCommit}}"}
+	result, err := taggo.Check(context.Background(), "", repodir, "", taggo.WithConfig(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "sha." + result.LatestCommit[:7]; result.NewBuildMetadata != want {
+		t.Errorf("got build metadata %q, want %q", result.NewBuildMetadata, want)
+	}
+	if got, want := result.NewVersion(), fmt.Sprintf("v%d.%d.%d+%s", result.NewMajor, result.NewMinor, result.NewPatch, result.NewBuildMetadata); got != want {
+		t.Errorf("got new version %s, want %s", got, want)
+	}
+
+	config.BuildMetadata = "not valid!"
+	if _, err := taggo.Check(context.Background(), "", repodir, "", taggo.WithConfig(config)); err == nil {
+		t.Error("got no error for invalid build metadata")
+	}
+}
+
+func TestBuildVerification(t *testing.T) {
+	repodir := cloneBundle(t, "unstable")
+
+	// Add a version that does not compile.
+	if err := os.WriteFile(filepath.Join(repodir, "broken.go"), []byte("package x\n\nfunc Broken() { undefined() }\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"add", "broken.go"},
+		{"-c", "user.name=Taggo", "-c", "user.email=taggo@example.com", "commit", "-q", "-m", "broken"},
+		{"tag", "v0.1.3"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repodir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("running git %s: %s\n%s", strings.Join(args, " "), err, output)
+		}
+	}
+
+	result, err := taggo.Check(context.Background(), "", repodir, "", taggo.WithBuildVerification())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"v0.1.3"}, result.UnbuildableVersions); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGoDirectiveBump(t *testing.T) {
+	repodir := cloneBundle(t, "unstable")
+
+	if err := os.WriteFile(filepath.Join(repodir, "go.mod"), []byte("module x\n\ngo 1.23.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command("git", "-c", "user.name=Taggo", "-c", "user.email=taggo@example.com", "commit", "-q", "-a", "-m", "raise go directive")
+	cmd.Dir = repodir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("committing: %s\n%s", err, output)
+	}
+
+	config := taggo.Config{GoDirectiveBump: "minor"}
+	result, err := taggo.Check(context.Background(), "", repodir, "", taggo.WithConfig(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.LatestVersionGoDirective != "1.22.2" || result.GoDirective != "1.23.0" {
+		t.Errorf("got go directives %s -> %s, want 1.22.2 -> 1.23.0", result.LatestVersionGoDirective, result.GoDirective)
+	}
+	if !result.GoDirectiveRaised() {
+		t.Error("got GoDirectiveRaised false, want true")
+	}
+	if !result.GoDirectiveBump {
+		t.Error("got GoDirectiveBump false, want true")
+	}
+	if result.NewMajor != 0 || result.NewMinor != 2 || result.NewPatch != 0 {
+		t.Errorf("got new version v%d.%d.%d, want v0.2.0", result.NewMajor, result.NewMinor, result.NewPatch)
+	}
+}
+
+func TestReplaceDirectives(t *testing.T) {
+	repodir := cloneBundle(t, "unstable")
+
+	const gomod = `module x
+
+go 1.22.2
+
+replace example.com/a => ../a
+
+replace example.com/b v1.0.0 => example.com/c v1.1.0
+
+exclude example.com/d v0.2.0
+`
+	if err := os.WriteFile(filepath.Join(repodir, "go.mod"), []byte(gomod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := taggo.Check(context.Background(), "", repodir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff([]string{"example.com/a => ../a", "example.com/b@v1.0.0 => example.com/c v1.1.0"}, result.ReplaceDirectives); diff != "" {
+		t.Errorf("replace mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"example.com/a => ../a"}, result.LocalReplaceDirectives); diff != "" {
+		t.Errorf("local replace mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"example.com/d@v0.2.0"}, result.ExcludeDirectives); diff != "" {
+		t.Errorf("exclude mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestTidyCheck(t *testing.T) {
+	repodir := cloneBundle(t, "unstable")
+
+	result, err := taggo.Check(context.Background(), "", repodir, "", taggo.WithTidyCheck())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TidyDiff != "" {
+		t.Errorf("got tidy diff %q, want none", result.TidyDiff)
+	}
+
+	const gosum = "example.com/unused v1.0.0 h1:AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=\n"
+	if err := os.WriteFile(filepath.Join(repodir, "go.sum"), []byte(gosum), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err = taggo.Check(context.Background(), "", repodir, "", taggo.WithTidyCheck())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(result.TidyDiff, "example.com/unused") {
+		t.Errorf("got tidy diff %q, want one removing example.com/unused", result.TidyDiff)
+	}
+}
+
+func TestOffline(t *testing.T) {
+	repodir := cloneBundle(t, "unstable")
+
+	client := &http.Client{Transport: failTransport{t: t}}
+
+	result, err := taggo.Check(context.Background(), "", repodir, "", taggo.WithOffline(), taggo.WithOSV(), taggo.WithRemoteTags(), taggo.WithTidyCheck(), taggo.WithHTTPClient(client))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"remote-tags", "osv"}, result.SkippedChecks); diff != "" {
+		t.Errorf("skipped checks mismatch (-want +got):\n%s", diff)
+	}
+	if len(result.OpenVulnerabilities) > 0 {
+		t.Errorf("got open vulnerabilities %v, want none", result.OpenVulnerabilities)
+	}
+
+	_, err = taggo.Verify(context.Background(), "", repodir, "", "v0.1.2", "origin", taggo.WithOffline(), taggo.WithHTTPClient(client))
+	if !errors.Is(err, taggo.ErrOffline) {
+		t.Errorf("got error %v, want ErrOffline", err)
+	}
+}
+
+// failTransport fails the test on any HTTP request.
+type failTransport struct {
+	t *testing.T
+}
+
+func (ft failTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ft.t.Errorf("unexpected request for %s in offline mode", req.URL)
+	return nil, taggo.ErrOffline
+}
+
+func TestCheckAllSubtree(t *testing.T) {
+	repodir := cloneBundle(t, "sub-ok-path")
+
+	results, err := taggo.CheckAll(context.Background(), "", repodir, taggo.WithSubtree("sub"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	result, ok := results[filepath.Join(repodir, "sub")]
+	if !ok {
+		t.Fatalf("no result for module in sub")
+	}
+	if result.ModuleSubdir != "sub" {
+		t.Errorf("got module subdir %q, want sub", result.ModuleSubdir)
+	}
+}
+
+func TestProgress(t *testing.T) {
+	repodir := cloneBundle(t, "unstable")
+
+	var phases []taggo.Phase
+	progress := func(p taggo.Phase, r taggo.Result) {
+		phases = append(phases, p)
+		switch p {
+		case taggo.PhaseRefs:
+			if r.LatestVersion != "" {
+				t.Errorf("got latest version %s after refs phase, want none yet", r.LatestVersion)
+			}
+		case taggo.PhaseVersions:
+			if r.LatestVersion != "v0.1.2" {
+				t.Errorf("got latest version %q after versions phase, want v0.1.2", r.LatestVersion)
+			}
+		case taggo.PhaseModfile:
+			if r.Modpath != "x" {
+				t.Errorf("got module path %q after modfile phase, want x", r.Modpath)
+			}
+		case taggo.PhaseBranch:
+			if r.DefaultBranch != "main" {
+				t.Errorf("got default branch %q after branch phase, want main", r.DefaultBranch)
+			}
+		}
+	}
+
+	result, err := taggo.Check(context.Background(), "", repodir, "", taggo.WithProgress(progress))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []taggo.Phase{taggo.PhaseRefs, taggo.PhaseVersions, taggo.PhaseModfile, taggo.PhaseBranch, taggo.PhaseModver, taggo.PhaseRecommendation, taggo.PhaseChecks}
+	if diff := cmp.Diff(want, phases); diff != "" {
+		t.Errorf("phases mismatch (-want +got):\n%s", diff)
+	}
+	if result.DefaultBranch != "main" {
+		t.Errorf("got default branch %q, want main", result.DefaultBranch)
+	}
+}
+
+func TestCheckAllFunc(t *testing.T) {
+	repodir := cloneBundle(t, "sub-ok-path")
+
+	var (
+		calls   int
+		errStop = errors.New("stop")
+	)
+	err := taggo.CheckAllFunc(context.Background(), "", repodir, func(moduledir string, r taggo.Result, err error) error {
+		calls++
+		if err != nil {
+			t.Errorf("checking %s: %s", moduledir, err)
+		}
+		if r.Modpath == "" {
+			t.Errorf("got no module path for %s", moduledir)
+		}
+		return errStop
+	})
+	if !errors.Is(err, errStop) {
+		t.Errorf("got error %v, want errStop", err)
+	}
+	if calls != 1 {
+		t.Errorf("got %d calls, want 1", calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = taggo.CheckAllFunc(ctx, "", repodir, func(string, taggo.Result, error) error {
+		t.Error("unexpected call after cancellation")
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want context.Canceled", err)
+	}
+}
+
+func TestPrerelease(t *testing.T) {
+	var (
+		tmpdir  = t.TempDir()
+		remote  = filepath.Join(tmpdir, "remote.git")
+		repodir = filepath.Join(tmpdir, "repo")
+	)
+
+	commit := func(filename, contents string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repodir, filename), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+		testutil.Git(t, repodir, "add", filename)
+		testutil.Git(t, repodir, "commit", "-q", "-m", "update "+filename)
+	}
+
+	// The default branch is the one that agrees with origin.
+	testutil.Git(t, tmpdir, "init", "-q", "--bare", remote)
+	testutil.Git(t, tmpdir, "init", "-q", "-b", "main", repodir)
+	testutil.Git(t, repodir, "remote", "add", "origin", remote)
+	commit("go.mod", "module example.com/x\n\ngo 1.22\n")
+	commit("x.go", "package x\n\nfunc X() {}\n")
+	testutil.Git(t, repodir, "tag", "v1.4.0-rc.1")
+	commit("y.go", "package x\n\nfunc Y() {}\n")
+	testutil.Git(t, repodir, "push", "-q", "origin", "main")
+
+	result, err := taggo.Check(context.Background(), "", repodir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := result.NewVersion(); got != "v1.4.0-rc.2" {
+		t.Errorf("got new version %s, want v1.4.0-rc.2", got)
+	}
+	if result.FinalizesPrerelease {
+		t.Error("got FinalizesPrerelease true, want false")
+	}
+
+	result, err = taggo.Check(context.Background(), "", repodir, "", taggo.WithFinalize())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := result.NewVersion(); got != "v1.4.0" {
+		t.Errorf("got new version %s with WithFinalize, want v1.4.0", got)
+	}
+	if !result.FinalizesPrerelease {
+		t.Error("got FinalizesPrerelease false with WithFinalize, want true")
+	}
+}
+
+func TestBranch(t *testing.T) {
+	repodir := t.TempDir()
+
+	commit := func(filename, contents string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repodir, filename), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+		testutil.Git(t, repodir, "add", filename)
+		testutil.Git(t, repodir, "commit", "-q", "-m", "update "+filename)
+	}
+
+	testutil.Git(t, repodir, "init", "-q", "-b", "main")
+	commit("go.mod", "module example.com/x\n\ngo 1.22\n")
+	commit("x.go", "package x\n\nfunc X() {}\n")
+	testutil.Git(t, repodir, "tag", "v1.0.0")
+	testutil.Git(t, repodir, "branch", "release/v1")
+	commit("go.mod", "module example.com/x/v2\n\ngo 1.22\n")
+	testutil.Git(t, repodir, "tag", "v2.0.0")
+	testutil.Git(t, repodir, "checkout", "-q", "release/v1")
+	commit("x.go", "package x\n") // breaking change, calls for v2.0.0
+
+	result, err := taggo.Check(context.Background(), "", repodir, "", taggo.WithBranch("release/v1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.LatestVersion != "v1.0.0" {
+		t.Errorf("got latest version %s, want v1.0.0", result.LatestVersion)
+	}
+	if result.DefaultBranch != "release/v1" {
+		t.Errorf("got branch %s, want release/v1", result.DefaultBranch)
+	}
+	if result.LatestCommitHasVersionTag {
+		t.Error("got LatestCommitHasVersionTag true, want false")
+	}
+	if result.Modpath != "example.com/x" {
+		t.Errorf("got module path %s, want example.com/x", result.Modpath)
+	}
+	if result.Series != "v1" {
+		t.Errorf("got series %s, want v1", result.Series)
+	}
+	if !result.NewVersionExists {
+		t.Error("got NewVersionExists false, want true")
+	}
+	if result.InSeries(result.NewVersion()) {
+		t.Errorf("recommended version %s is in series %s, want it outside", result.NewVersion(), result.Series)
+	}
+
+	if _, err := taggo.Check(context.Background(), "", repodir, "", taggo.WithBranch("nonexistent")); err == nil {
+		t.Error("got no error for nonexistent branch")
+	}
+}
+
+func TestTagStats(t *testing.T) {
+	repodir := t.TempDir()
+
+	commit := func(date, filename, contents string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repodir, filename), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv("GIT_AUTHOR_DATE", date)
+		t.Setenv("GIT_COMMITTER_DATE", date)
+		testutil.Git(t, repodir, "add", filename)
+		testutil.Git(t, repodir, "commit", "-q", "-m", "update "+filename)
+	}
+
+	testutil.Git(t, repodir, "init", "-q", "-b", "main")
+	commit("2024-01-01T00:00:00Z", "go.mod", "module example.com/x\n\ngo 1.22\n")
+	testutil.Git(t, repodir, "tag", "v1.0.0")
+	commit("2024-02-01T00:00:00Z", "x.go", "package x\n\nfunc X() {}\n")
+	testutil.Git(t, repodir, "tag", "v1.1.0")
+	testutil.Git(t, repodir, "branch", "release/v1")
+	commit("2024-03-01T00:00:00Z", "go.mod", "module example.com/x/v2\n\ngo 1.22\n")
+	testutil.Git(t, repodir, "tag", "v2.0.0")
+
+	// A backported fix, released after v2.0.0.
+	testutil.Git(t, repodir, "checkout", "-q", "release/v1")
+	commit("2024-04-01T00:00:00Z", "x.go", "package x\n\n// X does nothing.\nfunc X() {}\n")
+	testutil.Git(t, repodir, "tag", "v1.1.1")
+	testutil.Git(t, repodir, "checkout", "-q", "main")
+
+	result, err := taggo.Check(context.Background(), "", repodir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TotalVersionTags != 4 {
+		t.Errorf("got %d version tags, want 4", result.TotalVersionTags)
+	}
+	if diff := cmp.Diff(map[string]int{"v1": 3, "v2": 1}, result.TagsPerMajor); diff != "" {
+		t.Errorf("tags per major mismatch (-want +got):\n%s", diff)
+	}
+	if want := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC); result.OldestVersion != "v1.0.0" || !result.OldestVersionDate.Equal(want) {
+		t.Errorf("got oldest version %s at %s, want v1.0.0 at %s", result.OldestVersion, result.OldestVersionDate, want)
+	}
+	if want := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC); result.NewestVersion != "v1.1.1" || !result.NewestVersionDate.Equal(want) {
+		t.Errorf("got newest version %s at %s, want v1.1.1 at %s", result.NewestVersion, result.NewestVersionDate, want)
+	}
+	if result.LatestVersion != "v2.0.0" {
+		t.Errorf("got latest version %s, want v2.0.0", result.LatestVersion)
+	}
+
+	result, err = taggo.Check(context.Background(), "", repodir, "", taggo.WithBranch("release/v1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TotalVersionTags != 3 || result.NewestVersion != "v1.1.1" {
+		t.Errorf("on release/v1, got %d version tags, newest %s; want 3, newest v1.1.1", result.TotalVersionTags, result.NewestVersion)
+	}
+}
+
+func TestCommitsSinceLatestVersion(t *testing.T) {
+	repodir := t.TempDir()
+
+	commit := func(filename, contents string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(repodir, filename)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(repodir, filename), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+		testutil.Git(t, repodir, "add", filename)
+		testutil.Git(t, repodir, "commit", "-q", "-m", "update "+filename)
+	}
+
+	testutil.Git(t, repodir, "init", "-q", "-b", "main")
+	commit("go.mod", "module example.com/x\n\ngo 1.22\n")
+	commit("sub/go.mod", "module example.com/x/sub\n\ngo 1.22\n")
+	testutil.Git(t, repodir, "tag", "sub/v1.0.0")
+	commit("x.go", "package x\n")
+	commit("sub/s.go", "package sub\n")
+	commit("sub/t.go", "package sub\n")
+
+	ctx := context.Background()
+
+	result, err := taggo.Check(ctx, "", repodir, filepath.Join(repodir, "sub"), taggo.WithCommitSummaries())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.UnreleasedCommits != 2 {
+		t.Errorf("got %d commits since %s, want 2", result.UnreleasedCommits, result.LatestVersion)
+	}
+	var subjects []string
+	for _, c := range result.UnreleasedCommitSummaries {
+		subjects = append(subjects, c.Subject)
+		if c.Author != "Taggo <taggo@example.com>" {
+			t.Errorf("got author %q for %s", c.Author, c.Hash)
+		}
+	}
+	if diff := cmp.Diff([]string{"update sub/t.go", "update sub/s.go"}, subjects); diff != "" {
+		t.Errorf("subjects mismatch (-want +got):\n%s", diff)
+	}
+
+	// The root module has no version tags, so all its commits are unreleased.
+	result, err = taggo.Check(ctx, "", repodir, repodir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.UnreleasedCommits != 5 {
+		t.Errorf("got %d commits for the root module, want 5", result.UnreleasedCommits)
+	}
+	if result.UnreleasedCommitSummaries != nil {
+		t.Errorf("got commit summaries %v without WithCommitSummaries", result.UnreleasedCommitSummaries)
+	}
+}
+
+func TestInSeries(t *testing.T) {
+	cases := []struct {
+		series, version string
+		want            bool
+	}{
+		{series: "", version: "v2.0.0", want: true},
+		{series: "v1", version: "v1.9.0", want: true},
+		{series: "v1", version: "v2.0.0", want: false},
+		{series: "v1.8", version: "v1.8.4", want: true},
+		{series: "v1.8", version: "v1.8.4-rc.1", want: true},
+		{series: "v1.8", version: "v1.9.0", want: false},
+		{series: "v1.8", version: "bogus", want: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("%s_%s", tc.series, tc.version), func(t *testing.T) {
+			r := taggo.Result{Series: tc.series}
+			if got := r.InSeries(tc.version); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBranchSeries(t *testing.T) {
+	repodir := t.TempDir()
+
+	testutil.Git(t, repodir, "init", "-q", "-b", "main")
+	if err := os.WriteFile(filepath.Join(repodir, "go.mod"), []byte("module example.com/x\n\ngo 1.22\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	testutil.Git(t, repodir, "add", "go.mod")
+	testutil.Git(t, repodir, "commit", "-q", "-m", "initial")
+	testutil.Git(t, repodir, "tag", "v1.8.0")
+
+	cases := []struct {
+		branch, want string
+	}{
+		{branch: "release/v1", want: "v1"},
+		{branch: "release-1.8", want: "v1.8"},
+		{branch: "release_v2.x", want: "v2"},
+		{branch: "v2", want: "v2"},
+		{branch: "backport/v1.8", want: "v1.8"},
+		{branch: "1.8.x", want: "v1.8"},
+		{branch: "feature-123", want: ""},
+		{branch: "fix/issue-42", want: ""},
+		{branch: "1.8", want: ""},
+		{branch: "main", want: ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(strings.ReplaceAll(tc.branch, "/", "_"), func(t *testing.T) {
+			if tc.branch != "main" {
+				testutil.Git(t, repodir, "branch", tc.branch)
+			}
+			result, err := taggo.Check(context.Background(), "", repodir, "", taggo.WithBranch(tc.branch))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Series != tc.want {
+				t.Errorf("got series %q, want %q", result.Series, tc.want)
+			}
+		})
+	}
+}
+
+func TestReleaseMerge(t *testing.T) {
+	var (
+		tmpdir  = t.TempDir()
+		remote  = filepath.Join(tmpdir, "remote.git")
+		repodir = filepath.Join(tmpdir, "repo")
+	)
+
+	commit := func(filename, contents string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repodir, filename), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+		testutil.Git(t, repodir, "add", filename)
+		testutil.Git(t, repodir, "commit", "-q", "-m", "update "+filename)
+	}
+
+	testutil.Git(t, tmpdir, "init", "-q", "--bare", remote)
+	testutil.Git(t, tmpdir, "init", "-q", "-b", "main", repodir)
+	testutil.Git(t, repodir, "remote", "add", "origin", remote)
+	commit("go.mod", "module example.com/x\n\ngo 1.22\n")
+	commit("x.go", "package x\n\nfunc X() {}\n")
+	testutil.Git(t, repodir, "tag", "v1.0.0")
+
+	testutil.Git(t, repodir, "checkout", "-q", "-b", "feature")
+	commit("y.go", "package x\n\nfunc Y() {}\n")
+	testutil.Git(t, repodir, "checkout", "-q", "main")
+	testutil.Git(t, repodir, "merge", "-q", "--no-ff", "-m", "Merge pull request #1 from example/feature", "feature")
+
+	testutil.Git(t, repodir, "checkout", "-q", "-b", "release")
+	commit("Changelog", "v1.1.0\n")
+	testutil.Git(t, repodir, "checkout", "-q", "main")
+	testutil.Git(t, repodir, "merge", "-q", "--no-ff", "-m", "Merge pull request #2 from example/release\n\nRelease v1.1.0", "release")
+
+	commit("z.go", "package x\n\nfunc Z() {}\n")
+	testutil.Git(t, repodir, "push", "-q", "origin", "main")
+
+	config := taggo.Config{ReleaseMerge: `Release v[0-9]`}
+	result, err := taggo.Check(context.Background(), "", repodir, "", taggo.WithConfig(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.ReleaseMergeCommit == "" {
+		t.Fatal("no release merge commit found")
+	}
+	if result.LatestCommit != result.ReleaseMergeCommit {
+		t.Errorf("got latest commit %s, want release merge commit %s", result.LatestCommit, result.ReleaseMergeCommit)
+	}
+
+	cmd := exec.Command("git", "rev-parse", "main^")
+	cmd.Dir = repodir
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := strings.TrimSpace(string(output)); result.ReleaseMergeCommit != want {
+		t.Errorf("got release merge commit %s, want %s", result.ReleaseMergeCommit, want)
+	}
+
+	config.ReleaseMerge = `^Hotfix`
+	result, err = taggo.Check(context.Background(), "", repodir, "", taggo.WithConfig(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.NoReleaseMerge {
+		t.Error("got NoReleaseMerge false, want true")
+	}
+	if result.LatestCommit != "" {
+		t.Errorf("got latest commit %s, want none", result.LatestCommit)
+	}
+}
+
+func TestNoRemote(t *testing.T) {
+	repodir := t.TempDir()
+
+	testutil.Git(t, repodir, "init", "-q", "-b", "trunk")
+	if err := os.WriteFile(filepath.Join(repodir, "go.mod"), []byte("module example.com/x\n\ngo 1.22\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	testutil.Git(t, repodir, "add", "go.mod")
+	testutil.Git(t, repodir, "commit", "-q", "-m", "initial")
+
+	result, err := taggo.Check(context.Background(), "", repodir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.NoRemote {
+		t.Error("got NoRemote false, want true")
+	}
+	if result.DefaultBranch != "trunk" {
+		t.Errorf("got default branch %q, want trunk", result.DefaultBranch)
+	}
+	if result.LatestCommit == "" {
+		t.Error("got no latest commit")
+	}
+	if got := result.NewVersion(); got != "v0.1.0" {
+		t.Errorf("got new version %s, want v0.1.0", got)
+	}
+}
+
+func TestPhaseError(t *testing.T) {
+	repodir := t.TempDir()
+
+	testutil.Git(t, repodir, "init", "-q", "-b", "main")
+	if err := os.WriteFile(filepath.Join(repodir, "go.mod"), []byte("module example.com/x\n\ngo 1.22\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	testutil.Git(t, repodir, "add", "go.mod")
+	testutil.Git(t, repodir, "commit", "-q", "-m", "initial")
+	testutil.Git(t, repodir, "tag", "v0.1.0")
+
+	if err := os.WriteFile(filepath.Join(repodir, "go.mod"), []byte("module example.com/x\n\ngo 1.22\nrequire (\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	testutil.Git(t, repodir, "commit", "-q", "-a", "-m", "break go.mod")
+
+	result, err := taggo.Check(context.Background(), "", repodir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.PhaseError == nil {
+		t.Fatal("got no phase error")
+	}
+	if result.PhaseError.Phase != taggo.PhaseModfile {
+		t.Errorf("got failed phase %s, want %s", result.PhaseError.Phase, taggo.PhaseModfile)
+	}
+	if result.LatestVersion != "v0.1.0" {
+		t.Errorf("got latest version %q, want v0.1.0", result.LatestVersion)
+	}
+	if result.DefaultBranch != "main" {
+		t.Errorf("got default branch %q, want main", result.DefaultBranch)
+	}
+
+	ids := make(map[string]bool)
+	for _, f := range result.Findings() {
+		ids[f.ID] = true
+	}
+	for _, id := range []string{"phase-error", "latest-version", "latest-commit-untagged"} {
+		if !ids[id] {
+			t.Errorf("missing finding %s", id)
+		}
+	}
+	if ids["recommended-version"] || ids["modver-none"] {
+		t.Error("got modver findings despite failed phase")
+	}
+}
+
+func TestRemoteHead(t *testing.T) {
+	var (
+		tmpdir  = t.TempDir()
+		remote  = filepath.Join(tmpdir, "remote.git")
+		repodir = filepath.Join(tmpdir, "repo")
+	)
+
+	testutil.Git(t, tmpdir, "init", "-q", "--bare", remote)
+	testutil.Git(t, tmpdir, "init", "-q", "-b", "develop", repodir)
+	testutil.Git(t, repodir, "remote", "add", "origin", remote)
+	if err := os.WriteFile(filepath.Join(repodir, "go.mod"), []byte("module example.com/x\n\ngo 1.22\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	testutil.Git(t, repodir, "add", "go.mod")
+	testutil.Git(t, repodir, "commit", "-q", "-m", "initial")
+	testutil.Git(t, repodir, "branch", "feature")
+	testutil.Git(t, repodir, "push", "-q", "origin", "develop", "feature")
+
+	// The local default branch drifts from the remote,
+	// so hash matching can't tell which branch is the default.
+	testutil.Git(t, repodir, "commit", "-q", "--allow-empty", "-m", "local change")
+
+	result, err := taggo.Check(context.Background(), "", repodir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.DefaultBranch != "" {
+		t.Fatalf("got default branch %s without remote HEAD, want none", result.DefaultBranch)
+	}
+
+	testutil.Git(t, repodir, "remote", "set-head", "origin", "develop")
+
+	result, err = taggo.Check(context.Background(), "", repodir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.DefaultBranch != "develop" {
+		t.Errorf("got default branch %s, want develop", result.DefaultBranch)
+	}
+	if result.Ahead != 1 || result.Behind != 0 {
+		t.Errorf("got %d ahead, %d behind, want 1 and 0", result.Ahead, result.Behind)
+	}
+}
+
+func TestUnusualDefaultBranch(t *testing.T) {
+	var (
+		tmpdir  = t.TempDir()
+		remote  = filepath.Join(tmpdir, "remote.git")
+		repodir = filepath.Join(tmpdir, "repo")
+	)
+
+	testutil.Git(t, tmpdir, "init", "-q", "--bare", remote)
+	testutil.Git(t, tmpdir, "init", "-q", "-b", "trunk-2024", repodir)
+	testutil.Git(t, repodir, "remote", "add", "origin", remote)
+	if err := os.WriteFile(filepath.Join(repodir, "go.mod"), []byte("module example.com/x\n\ngo 1.22\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	testutil.Git(t, repodir, "add", "go.mod")
+	testutil.Git(t, repodir, "commit", "-q", "-m", "initial")
+	testutil.Git(t, repodir, "push", "-q", "origin", "trunk-2024")
+
+	result, err := taggo.Check(context.Background(), "", repodir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.DefaultBranch != "trunk-2024" {
+		t.Errorf("got default branch %q, want trunk-2024", result.DefaultBranch)
+	}
+
+	testutil.Git(t, repodir, "branch", "-m", "release/stable")
+	testutil.Git(t, repodir, "push", "-q", "origin", "release/stable")
+
+	// A name with a slash is too unlikely a guess...
+	result, err = taggo.Check(context.Background(), "", repodir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.DefaultBranch != "" {
+		t.Errorf("got default branch %q, want none", result.DefaultBranch)
+	}
+
+	// ...but can be configured.
+	result, err = taggo.Check(context.Background(), "", repodir, "", taggo.WithConfig(taggo.Config{DefaultBranch: "release/stable"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.DefaultBranch != "release/stable" || result.Remote != "origin" {
+		t.Errorf("got default branch %q from remote %q, want release/stable from origin", result.DefaultBranch, result.Remote)
+	}
+}
+
+func TestRemoteTags(t *testing.T) {
+	var (
+		tmpdir  = t.TempDir()
+		remote  = filepath.Join(tmpdir, "remote.git")
+		srcdir  = filepath.Join(tmpdir, "src")
+		repodir = filepath.Join(tmpdir, "repo")
+	)
+
+	testutil.Git(t, tmpdir, "init", "-q", "--bare", "-b", "main", remote)
+	testutil.Git(t, tmpdir, "init", "-q", "-b", "main", srcdir)
+	if err := os.WriteFile(filepath.Join(srcdir, "go.mod"), []byte("module example.com/x\n\ngo 1.22\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	testutil.Git(t, srcdir, "add", "go.mod")
+	testutil.Git(t, srcdir, "commit", "-q", "-m", "initial")
+	testutil.Git(t, srcdir, "tag", "-a", "-m", "v1.0.0", "v1.0.0")
+	testutil.Git(t, srcdir, "commit", "-q", "--allow-empty", "-m", "second")
+	testutil.Git(t, srcdir, "tag", "v1.1.0")
+	testutil.Git(t, srcdir, "push", "-q", "--tags", remote, "main")
+
+	testutil.Git(t, tmpdir, "clone", "-q", "--no-tags", remote, repodir)
+
+	result, err := taggo.Check(context.Background(), "", repodir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.LatestVersion != "" {
+		t.Fatalf("got latest version %s in --no-tags clone, want none", result.LatestVersion)
+	}
+
+	result, err = taggo.Check(context.Background(), "", repodir, "", taggo.WithRemoteTags(), taggo.WithAnnotatedTagsRequired())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.LatestVersion != "v1.1.0" {
+		t.Errorf("got latest version %s, want v1.1.0", result.LatestVersion)
+	}
+	if !result.LatestCommitHasLatestVersion {
+		t.Error("got LatestCommitHasLatestVersion false, want true")
+	}
+	if diff := cmp.Diff([]string{"v1.0.0", "v1.1.0"}, result.RemoteOnlyVersionTags); diff != "" {
+		t.Errorf("remote-only tags mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"v1.1.0"}, result.LightweightVersionTags); diff != "" {
+		t.Errorf("lightweight tags mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRevertOnly(t *testing.T) {
+	var (
+		tmpdir  = t.TempDir()
+		remote  = filepath.Join(tmpdir, "remote.git")
+		repodir = filepath.Join(tmpdir, "repo")
+	)
+
+	commit := func(filename, contents string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repodir, filename), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+		testutil.Git(t, repodir, "add", filename)
+		testutil.Git(t, repodir, "commit", "-q", "-m", "update "+filename)
+	}
+
+	testutil.Git(t, tmpdir, "init", "-q", "--bare", remote)
+	testutil.Git(t, tmpdir, "init", "-q", "-b", "main", repodir)
+	testutil.Git(t, repodir, "remote", "add", "origin", remote)
+	commit("go.mod", "module example.com/x\n\ngo 1.22\n")
+	commit("x.go", "package x\n\nfunc X() {}\n")
+	testutil.Git(t, repodir, "tag", "v1.0.0")
+	commit("y.go", "package x\n\nfunc Y() {}\n")
+	testutil.Git(t, repodir, "revert", "--no-edit", "HEAD")
+	testutil.Git(t, repodir, "push", "-q", "origin", "main")
+
+	result, err := taggo.Check(context.Background(), "", repodir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.LatestCommitHasVersionTag {
+		t.Fatal("got LatestCommitHasVersionTag true, want false")
+	}
+	if !result.NoNetChanges {
+		t.Error("got NoNetChanges false, want true")
+	}
+	if got := result.NewVersion(); got != "v1.0.0" {
+		t.Errorf("got new version %s, want v1.0.0 (i.e., none)", got)
+	}
+
+	commit("y.go", "package x\n\nfunc Y() {}\n")
+	testutil.Git(t, repodir, "push", "-q", "origin", "main")
+
+	result, err = taggo.Check(context.Background(), "", repodir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.NoNetChanges {
+		t.Error("got NoNetChanges true, want false")
+	}
+}
+
+func TestRemote(t *testing.T) {
+	var (
+		tmpdir   = t.TempDir()
+		origin   = filepath.Join(tmpdir, "origin.git")
+		upstream = filepath.Join(tmpdir, "upstream.git")
+		repodir  = filepath.Join(tmpdir, "repo")
+	)
+
+	// In a fork-based workflow, origin is the fork and upstream is the main repository.
+	testutil.Git(t, tmpdir, "init", "-q", "--bare", origin)
+	testutil.Git(t, tmpdir, "init", "-q", "--bare", upstream)
+	testutil.Git(t, tmpdir, "init", "-q", "-b", "main", repodir)
+	testutil.Git(t, repodir, "remote", "add", "origin", origin)
+	testutil.Git(t, repodir, "remote", "add", "upstream", upstream)
+	if err := os.WriteFile(filepath.Join(repodir, "go.mod"), []byte("module example.com/x\n\ngo 1.22\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	testutil.Git(t, repodir, "add", "go.mod")
+	testutil.Git(t, repodir, "commit", "-q", "-m", "initial")
+	testutil.Git(t, repodir, "push", "-q", "upstream", "main")
+	testutil.Git(t, repodir, "checkout", "-q", "-b", "devel")
+	testutil.Git(t, repodir, "push", "-q", "origin", "devel")
+
+	result, err := taggo.Check(context.Background(), "", repodir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.DefaultBranch != "devel" || result.Remote != "origin" {
+		t.Errorf("got default branch %s from remote %s, want devel from origin", result.DefaultBranch, result.Remote)
+	}
+
+	result, err = taggo.Check(context.Background(), "", repodir, "", taggo.WithRemote("upstream"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.DefaultBranch != "main" || result.Remote != "upstream" {
+		t.Errorf("got default branch %s from remote %s, want main from upstream", result.DefaultBranch, result.Remote)
+	}
+
+	if result.Diverged() {
+		t.Errorf("got diverged (%d ahead, %d behind), want in sync", result.Ahead, result.Behind)
+	}
+
+	if _, err := taggo.Check(context.Background(), "", repodir, "", taggo.WithRemote("nonexistent")); err == nil {
+		t.Error("got no error for nonexistent remote")
+	}
+
+	// Rewrite the local main branch.
+	testutil.Git(t, repodir, "checkout", "-q", "main")
+	testutil.Git(t, repodir, "commit", "-q", "--amend", "-m", "rewritten")
+
+	result, err = taggo.Check(context.Background(), "", repodir, "", taggo.WithRemote("upstream"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Diverged() || result.Ahead != 1 || result.Behind != 1 {
+		t.Errorf("got %d ahead, %d behind, want 1 and 1", result.Ahead, result.Behind)
+	}
+
+	testutil.Git(t, repodir, "remote", "set-url", "origin", "git@github.com:example/fork.git")
+
+	result, err = taggo.Check(context.Background(), "", repodir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RemoteURL != "https://github.com/example/fork" {
+		t.Errorf("got remote URL %s, want https://github.com/example/fork", result.RemoteURL)
+	}
+	if result.ForgeKind != taggo.ForgeGitHub {
+		t.Errorf("got forge kind %q, want %q", result.ForgeKind, taggo.ForgeGitHub)
+	}
+
+	modpathCases := []struct {
+		modpath      string
+		wantMismatch bool
+	}{
+		{modpath: "example.com/x"}, // vanity path, can't tell
+		{modpath: "github.com/example/fork"},
+		{modpath: "github.com/Example/Fork/v2"},
+		{modpath: "github.com/example/upstream", wantMismatch: true},
+		{modpath: "gitlab.com/example/fork", wantMismatch: true},
+	}
+	for _, c := range modpathCases {
+		if err := os.WriteFile(filepath.Join(repodir, "go.mod"), []byte("module "+c.modpath+"\n\ngo 1.22\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		result, err = taggo.Check(context.Background(), "", repodir, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.ModpathRemoteMismatch != c.wantMismatch {
+			t.Errorf("with module path %s, got ModpathRemoteMismatch %v, want %v", c.modpath, result.ModpathRemoteMismatch, c.wantMismatch)
+		}
+	}
+
+	// GitHub's host for SSH on port 443 names the same repository.
+	testutil.Git(t, repodir, "remote", "set-url", "origin", "ssh://git@ssh.github.com:443/example/fork.git")
+	if err := os.WriteFile(filepath.Join(repodir, "go.mod"), []byte("module github.com/example/fork\n\ngo 1.22\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	result, err = taggo.Check(context.Background(), "", repodir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RemoteURL != "https://github.com/example/fork" {
+		t.Errorf("got remote URL %s, want https://github.com/example/fork", result.RemoteURL)
+	}
+	if result.ModpathRemoteMismatch {
+		t.Errorf("got ModpathRemoteMismatch for remote URL %s", result.RemoteURL)
+	}
+}
+
+func TestOtherModuleVersionTags(t *testing.T) {
+	repodir := t.TempDir()
+
+	testutil.Git(t, repodir, "init", "-q", "-b", "main")
+	if err := os.MkdirAll(filepath.Join(repodir, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repodir, "go.mod"), []byte("module example.com/x\n\ngo 1.22\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repodir, "sub", "go.mod"), []byte("module example.com/x/sub\n\ngo 1.22\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	testutil.Git(t, repodir, "add", ".")
+	testutil.Git(t, repodir, "commit", "-q", "-m", "initial")
+	testutil.Git(t, repodir, "tag", "sub/v1.0.0")
+	testutil.Git(t, repodir, "tag", "sub/not-a-version")
+
+	result, err := taggo.Check(context.Background(), "", repodir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"sub/v1.0.0"}, result.OtherModuleVersionTags); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	result, err = taggo.Check(context.Background(), "", repodir, filepath.Join(repodir, "sub"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.OtherModuleVersionTags) > 0 {
+		t.Errorf("got other-module version tags %v for a module with tags of its own", result.OtherModuleVersionTags)
+	}
+}
+
+func TestGitTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the git binary")
+	}
+
+	repodir := cloneBundle(t, "unstable")
+
+	// A "git" that hangs, like one waiting on a stalled credential helper.
+	slowgit := filepath.Join(t.TempDir(), "git")
+	if err := os.WriteFile(slowgit, []byte("#!/bin/sh\nexec sleep 10\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	_, err := taggo.Check(context.Background(), slowgit, repodir, "", taggo.WithGitTimeout(100*time.Millisecond))
+	if !errors.Is(err, taggo.ErrGitTimeout) {
+		t.Errorf("got error %v, want one wrapping ErrGitTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Check took %s", elapsed)
+	}
+}
+
+// cloneBundle clones the bundle in testdata/NAME into a temporary directory,
+// returning the directory.
+func cloneBundle(t *testing.T, name string) string {
+	t.Helper()
+
+	tmpdir := t.TempDir()
+
+	bundlePath := filepath.Join("testdata", name, "bundle")
+	cmd := exec.Command("git", "clone", "-c", "init.defaultBranch=main", bundlePath, tmpdir)
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	return tmpdir
+}
+
+func TestBase(t *testing.T) {
+	repodir := t.TempDir()
+
+	commit := func(filename, contents string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repodir, filename), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+		testutil.Git(t, repodir, "add", filename)
+		testutil.Git(t, repodir, "commit", "-q", "-m", "update "+filename)
+	}
+
+	testutil.Git(t, repodir, "init", "-q", "-b", "main")
+	commit("go.mod", "module example.com/x\n\ngo 1.22\n")
+	commit("x.go", "package x\n\nfunc X() {}\n")
+	testutil.Git(t, repodir, "tag", "v1.0.0")
+	commit("x.go", "package x\n\n// X does nothing.\nfunc X() {}\n")
+	testutil.Git(t, repodir, "branch", "release")
+	testutil.Git(t, repodir, "commit", "-q", "--allow-empty", "-m", "empty")
+
+	ctx := context.Background()
+
+	result, err := taggo.Check(ctx, "", repodir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.NoNetChanges {
+		t.Error("got no net changes since v1.0.0, want some")
+	}
+
+	result, err = taggo.Check(ctx, "", repodir, "", taggo.WithBase("release"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Base != "release" {
+		t.Errorf("got base %q, want release", result.Base)
+	}
+	if !result.NoNetChanges {
+		t.Error("got changes to the module since release, want none")
+	}
+	if result.LatestVersion != "v1.0.0" {
+		t.Errorf("got latest version %s, want v1.0.0", result.LatestVersion)
+	}
+
+	if _, err := taggo.Check(ctx, "", repodir, "", taggo.WithBase("nonesuch")); err == nil {
+		t.Error("got no error for unknown base")
+	}
+}
+
+func TestTarget(t *testing.T) {
+	repodir := t.TempDir()
+
+	commit := func(filename, contents string) string {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repodir, filename), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+		testutil.Git(t, repodir, "add", filename)
+		testutil.Git(t, repodir, "commit", "-q", "-m", "update "+filename)
+		return testutil.Git(t, repodir, "rev-parse", "HEAD")
+	}
+
+	testutil.Git(t, repodir, "init", "-q", "-b", "main")
+	commit("go.mod", "module example.com/x\n\ngo 1.22\n")
+	commit("x.go", "package x\n\nfunc X() {}\n")
+	testutil.Git(t, repodir, "tag", "v1.0.0")
+	pinned := commit("x.go", "package x\n\n// X does nothing.\nfunc X() {}\n")
+	commit("x.go", "package x\n\n// X does nothing at all.\nfunc X() {}\n")
+	testutil.Git(t, repodir, "tag", "v1.0.1")
+	commit("y.go", "package x\n\n// Y is new.\nfunc Y() {}\n")
+
+	result, err := taggo.Check(context.Background(), "", repodir, "", taggo.WithTarget(pinned[:12]), taggo.WithReleaseAge())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.LatestCommit != pinned {
+		t.Errorf("got latest commit %s, want %s", result.LatestCommit, pinned)
+	}
+	if result.Target != pinned[:12] {
+		t.Errorf("got target %q, want %q", result.Target, pinned[:12])
+	}
+	if result.LatestVersion != "v1.0.0" {
+		t.Errorf("got latest version %s, want v1.0.0 (v1.0.1 is not reachable from the target)", result.LatestVersion)
+	}
+	if result.LatestCommitHasVersionTag {
+		t.Error("got LatestCommitHasVersionTag true, want false")
+	}
+	if result.UnreleasedCommits != 1 {
+		t.Errorf("got %d unreleased commits, want 1", result.UnreleasedCommits)
+	}
+}
+
+func TestBuildContext(t *testing.T) {
+	repodir := t.TempDir()
+
+	commit := func(filename, contents string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repodir, filename), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+		testutil.Git(t, repodir, "add", filename)
+		testutil.Git(t, repodir, "commit", "-q", "-m", "update "+filename)
+	}
+
+	// The package has no files at all without the "extra" build tag.
+	testutil.Git(t, repodir, "init", "-q", "-b", "main")
+	commit("go.mod", "module example.com/x\n\ngo 1.22\n")
+	commit("x.go", "//go:build extra\n\npackage x\n\nfunc X() {}\n")
+	testutil.Git(t, repodir, "tag", "v1.0.0")
+	commit("x.go", "//go:build extra\n\npackage x\n\nfunc X() {}\n\nfunc Y() {}\n")
+
+	config := taggo.Config{
+		Build: taggo.BuildContext{GOOS: "plan9"},
+		Modules: map[string]taggo.ModuleConfig{
+			".": {Build: &taggo.BuildContext{Tags: []string{"extra"}}},
+		},
+	}
+	result, err := taggo.Check(context.Background(), "", repodir, "", taggo.WithConfig(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.PhaseError != nil {
+		t.Fatalf("got phase error %s", result.PhaseError.Message)
+	}
+	if result.BuildContext != "-tags=extra" {
+		t.Errorf("got build context %q, want -tags=extra", result.BuildContext)
+	}
+
+	bc := taggo.BuildContext{Tags: []string{"extra"}, GOOS: "linux", GOARCH: "arm64"}
+	result, err = taggo.Check(context.Background(), "", repodir, "", taggo.WithConfig(config), taggo.WithBuildContext(bc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.PhaseError != nil {
+		t.Fatalf("got phase error %s", result.PhaseError.Message)
+	}
+	if want := "GOOS=linux GOARCH=arm64 -tags=extra"; result.BuildContext != want {
+		t.Errorf("got build context %q, want %q", result.BuildContext, want)
+	}
+}
+
+func TestIgnore(t *testing.T) {
+	repodir := t.TempDir()
+
+	commit := func(filename, contents string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(repodir, filename)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(repodir, filename), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+		testutil.Git(t, repodir, "add", filename)
+		testutil.Git(t, repodir, "commit", "-q", "-m", "update "+filename)
+	}
+
+	testutil.Git(t, repodir, "init", "-q", "-b", "main")
+	commit("go.mod", "module example.com/x\n\ngo 1.22\n")
+	commit("x.go", "package x\n\nfunc X() {}\n")
+	commit("x.pb.go", "package x\n\nfunc Gen1() {}\n")
+	commit("testutil/util.go", "package testutil\n\nfunc Helper1() {}\n")
+	testutil.Git(t, repodir, "tag", "v1.0.0")
+
+	// Only ignored files change, and not compatibly.
+	commit("x.pb.go", "package x\n\nfunc Gen2() {}\n")
+	commit("testutil/util.go", "package testutil\n\nfunc Helper2() {}\n")
+
+	if err := os.WriteFile(filepath.Join(repodir, taggo.ConfigFile), []byte("ignore:\n  - '*.pb.go'\nmodules:\n  .:\n    ignore:\n      - testutil/...\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config, err := taggo.LoadConfig(repodir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := taggo.Check(context.Background(), "", repodir, "", taggo.WithConfig(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.PhaseError != nil {
+		t.Fatalf("got phase error %s", result.PhaseError.Message)
+	}
+	if result.ModverResultCode != modver.None {
+		t.Errorf("got Modver result %s, want None", result.ModverResultString)
+	}
+
+	if err := os.WriteFile(filepath.Join(repodir, taggo.ConfigFile), []byte("ignore:\n  - '[*.pb.go'\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := taggo.LoadConfig(repodir); err == nil {
+		t.Error("got no error for malformed ignore pattern")
+	}
+}
+
+func TestGitDir(t *testing.T) {
+	var (
+		root     = t.TempDir()
+		worktree = filepath.Join(root, "worktree")
+		gitdir   = filepath.Join(root, "repo.git")
+	)
+	if err := os.Mkdir(worktree, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	commit := func(filename, contents string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(worktree, filename), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+		testutil.Git(t, worktree, "add", filename)
+		testutil.Git(t, worktree, "commit", "-q", "-m", "update "+filename)
+	}
+
+	testutil.Git(t, worktree, "init", "-q", "-b", "main")
+	commit("go.mod", "module example.com/x\n\ngo 1.22\n")
+	commit("x.go", "package x\n\nfunc X() {}\n")
+	testutil.Git(t, worktree, "tag", "v1.0.0")
+	commit("x.go", "package x\n\n// X does nothing.\nfunc X() {}\n")
+
+	// Move the Git directory out of the working tree,
+	// as for a server-side hook.
+	if err := os.Rename(filepath.Join(worktree, ".git"), gitdir); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	check := func(opts ...taggo.Option) {
+		t.Helper()
+		result, err := taggo.Check(ctx, "", worktree, "", opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.PhaseError != nil {
+			t.Fatalf("got phase error %s", result.PhaseError.Message)
+		}
+		if result.LatestVersion != "v1.0.0" {
+			t.Errorf("got latest version %s, want v1.0.0", result.LatestVersion)
+		}
+		if result.LatestCommitHasVersionTag {
+			t.Error("got LatestCommitHasVersionTag true, want false")
+		}
+	}
+
+	check(taggo.WithGitDir(gitdir))
+
+	t.Setenv("GIT_DIR", gitdir)
+	t.Setenv("GIT_WORK_TREE", worktree)
+	check()
+}
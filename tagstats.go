@@ -0,0 +1,67 @@
+package taggo
+
+import (
+	"context"
+	"time"
+
+	"github.com/bobg/errors"
+	"github.com/bobg/go-generics/v3/maps"
+	"github.com/bobg/go-generics/v3/set"
+	"golang.org/x/mod/semver"
+
+	"github.com/bobg/taggo/gitutil"
+)
+
+// setTagStats fills in the version-tag statistics in result:
+// TotalVersionTags, TagsPerMajor,
+// OldestVersion, OldestVersionDate,
+// NewestVersion, and NewestVersionDate.
+// The versions map is from version (without prefix) to commit hash.
+// The commit times of the versions in remoteOnly,
+// which have no local tags,
+// come from their commits.
+func setTagStats(ctx context.Context, git, repodir, versionPrefix string, versions map[string]string, remoteOnly set.Of[string], result *Result) error {
+	result.TotalVersionTags = len(versions)
+	if len(versions) == 0 {
+		return nil
+	}
+
+	details, err := gitutil.TagDetails(ctx, git, repodir)
+	if err != nil {
+		return errors.Wrap(err, "getting tag details")
+	}
+
+	result.TagsPerMajor = make(map[string]int)
+
+	vlist := maps.Keys(versions)
+	semver.Sort(vlist)
+
+	for _, v := range vlist {
+		result.TagsPerMajor[semver.Major(v)]++
+
+		s := details[versionPrefix+v].CommitTime
+		if remoteOnly.Has(v) {
+			if s, err = gitutil.CommitTime(ctx, git, repodir, versions[v]); err != nil {
+				return errors.Wrapf(err, "getting commit time of %s", v)
+			}
+		}
+		if s == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return errors.Wrapf(err, "parsing commit time %s", s)
+		}
+
+		// Ties go to the lower version for OldestVersion
+		// and to the higher one for NewestVersion.
+		if result.OldestVersion == "" || t.Before(result.OldestVersionDate) {
+			result.OldestVersion, result.OldestVersionDate = v, t
+		}
+		if result.NewestVersion == "" || !t.Before(result.NewestVersionDate) {
+			result.NewestVersion, result.NewestVersionDate = v, t
+		}
+	}
+
+	return nil
+}
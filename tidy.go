@@ -0,0 +1,39 @@
+package taggo
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+
+	"github.com/bobg/errors"
+)
+
+// tidyDiff runs "go mod tidy -diff" in moddir.
+// It returns the resulting diff,
+// which is empty if go.mod and go.sum are already tidy.
+// If offline is true, the go command may use only the local module cache.
+func tidyDiff(ctx context.Context, moddir string, offline bool) (string, error) {
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		return "", errors.Wrap(err, "finding go binary")
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, gobin, "mod", "tidy", "-diff")
+	cmd.Dir = moddir
+	cmd.Env = goCmdEnv(offline)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+
+	// With -diff, go mod tidy exits with status 1 when it would make changes.
+	var ee *exec.ExitError
+	if errors.As(err, &ee) && ee.ExitCode() == 1 && stdout.Len() > 0 {
+		return stdout.String(), nil
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "running %s: %s", cmd, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return "", nil
+}
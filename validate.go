@@ -0,0 +1,51 @@
+package taggo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// ValidateNewVersion checks whether version
+// (without any VersionPrefix)
+// is acceptable as the next version tag for the module described by r.
+// It must be a complete semantic version ("vX.Y.Z", optionally with prerelease and build-metadata suffixes),
+// it must be higher than the latest version,
+// and the module path's major-version suffix must agree with it.
+func ValidateNewVersion(r Result, version string) error {
+	if err := checkCompleteVersion(version); err != nil {
+		return err
+	}
+
+	if r.LatestVersion != "" && semver.Compare(version, r.LatestVersion) <= 0 {
+		return fmt.Errorf("%s is not higher than latest version %s", version, r.LatestVersion)
+	}
+
+	return checkVersionSuffix(r.Modpath, version)
+}
+
+// checkCompleteVersion checks that version is a complete semantic version,
+// "vX.Y.Z" optionally with prerelease and build-metadata suffixes.
+func checkCompleteVersion(version string) error {
+	if !semver.IsValid(version) || semver.Canonical(version) != strings.TrimSuffix(version, semver.Build(version)) {
+		return fmt.Errorf("%s is not a complete semantic version of the form vX.Y.Z", version)
+	}
+	return nil
+}
+
+// checkVersionSuffix checks that the major-version suffix of modpath agrees with version.
+func checkVersionSuffix(modpath, version string) error {
+	major, _ := strconv.Atoi(strings.TrimPrefix(semver.Major(version), "v"))
+	_, suffixVersion, hasSuffix := decomposeModpath(modpath)
+	switch {
+	case major <= 1 && hasSuffix:
+		return fmt.Errorf("module path %s must not have a version suffix for %s", modpath, version)
+	case major > 1 && !hasSuffix:
+		return fmt.Errorf("module path %s must end in /v%d for %s", modpath, major, version)
+	case major > 1 && suffixVersion != major:
+		return fmt.Errorf("module path %s must end in /v%d, not /v%d, for %s", modpath, major, suffixVersion, version)
+	}
+	return nil
+}
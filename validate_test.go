@@ -0,0 +1,41 @@
+package taggo_test
+
+import (
+	"testing"
+
+	"github.com/bobg/taggo"
+)
+
+func TestValidateNewVersion(t *testing.T) {
+	cases := []struct {
+		modpath, latest, version string
+		wantErr                  bool
+	}{
+		{modpath: "x", version: "v0.1.0"},
+		{modpath: "x", latest: "v0.1.0", version: "v0.1.1"},
+		{modpath: "x", latest: "v0.1.0", version: "v1.0.0-rc.1"},
+		{modpath: "x", latest: "v0.1.0", version: "v0.1.1+build.20240601"},
+		{modpath: "x", latest: "v0.1.0+sha.abcdef", version: "v0.1.1"},
+		{modpath: "x", latest: "v0.1.0", version: "v0.1.0", wantErr: true},
+		{modpath: "x", latest: "v0.1.0", version: "v0.1.0+build.1", wantErr: true},
+		{modpath: "x", latest: "v0.1.0", version: "v0.0.9", wantErr: true},
+		{modpath: "x", version: "v1.2", wantErr: true},
+		{modpath: "x", version: "1.2.3", wantErr: true},
+		{modpath: "x", latest: "v1.2.3", version: "v2.0.0", wantErr: true},
+		{modpath: "x/v2", latest: "v1.2.3", version: "v2.0.0"},
+		{modpath: "x/v3", latest: "v1.2.3", version: "v2.0.0", wantErr: true},
+		{modpath: "x/v2", version: "v1.0.0", wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.modpath+"_"+c.latest+"_"+c.version, func(t *testing.T) {
+			r := taggo.Result{Modpath: c.modpath, LatestVersion: c.latest}
+			err := taggo.ValidateNewVersion(r, c.version)
+			if c.wantErr && err == nil {
+				t.Error("got no error, want one")
+			} else if !c.wantErr && err != nil {
+				t.Errorf("got error %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package taggo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bobg/errors"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+	"golang.org/x/mod/sumdb/dirhash"
+
+	"github.com/bobg/taggo/gitutil"
+)
+
+const (
+	proxyBaseURL    = "https://proxy.golang.org"
+	sumDBBaseURL    = "https://sum.golang.org"
+	pkgGoDevBaseURL = "https://pkg.go.dev"
+)
+
+// Verification is the result of a call to [Verify].
+type Verification struct {
+	// Modpath is the module path, as of the version tag.
+	Modpath string
+
+	// Tag is the version tag, including any version prefix.
+	Tag string
+
+	// LocalCommit is the commit that Tag refers to in the local repository,
+	// or "" if the tag does not exist locally.
+	LocalCommit string
+
+	// RemoteCommit is the commit that Tag refers to in the remote repository,
+	// or "" if the tag does not exist there.
+	RemoteCommit string
+
+	// ProxyHasVersion is true if the module proxy serves the version.
+	ProxyHasVersion bool
+
+	// ProxyCommit is the commit the module proxy reports for the version,
+	// if it reports one.
+	ProxyCommit string
+
+	// SumDBHash is the hash of the module zip recorded in the checksum database,
+	// or "" if the checksum database has no record of the version.
+	SumDBHash string
+
+	// ZipHash is the hash of the module zip built from LocalCommit.
+	// Valid only when LocalCommit is not empty.
+	ZipHash string
+
+	// PkgGoDevHasVersion is true if pkg.go.dev shows the version.
+	// This can lag behind the module proxy.
+	PkgGoDevHasVersion bool
+}
+
+// OK tells whether v shows a fully propagated release,
+// not counting PkgGoDevHasVersion.
+func (v Verification) OK() bool {
+	if v.LocalCommit == "" || v.RemoteCommit != v.LocalCommit {
+		return false
+	}
+	if !v.ProxyHasVersion || (v.ProxyCommit != "" && v.ProxyCommit != v.LocalCommit) {
+		return false
+	}
+	return v.SumDBHash != "" && v.SumDBHash == v.ZipHash
+}
+
+// Verify checks that a release of version of the module in moduledir has fully propagated:
+// that the version tag exists both in the local repository and in the given remote,
+// referring to the same commit;
+// that the module proxy (proxy.golang.org) serves the version;
+// that the checksum database (sum.golang.org) records it
+// with the same hash as the module zip built from the local tag;
+// and whether pkg.go.dev shows it.
+//
+// This requires network access.
+// The [WithHTTPClient] option applies.
+// With the [WithOffline] option, Verify returns [ErrOffline].
+func Verify(ctx context.Context, git, repodir, moduledir, version, remote string, opts ...Option) (Verification, error) {
+	var (
+		v Verification
+		o = makeOptions(opts)
+	)
+
+	if o.offline {
+		return v, ErrOffline
+	}
+	ctx = o.gitContext(ctx, repodir)
+
+	if git == "" {
+		var err error
+		git, err = exec.LookPath("git")
+		if err != nil {
+			return v, errors.Wrap(err, "finding git binary")
+		}
+	}
+
+	if !semver.IsValid(version) {
+		return v, fmt.Errorf("%s is not a valid semantic version", version)
+	}
+
+	moduledir, err := moduleSubdir(repodir, moduledir)
+	if err != nil {
+		return v, err
+	}
+	v.Tag = version
+	if moduledir != "" {
+		v.Tag = moduledir + "/" + version
+	}
+
+	if commit, err := gitutil.TagCommit(ctx, git, repodir, v.Tag); err == nil {
+		v.LocalCommit = commit
+	}
+
+	v.RemoteCommit, err = gitutil.RemoteTagCommit(ctx, git, repodir, remote, v.Tag)
+	if err != nil {
+		return v, errors.Wrapf(err, "looking up %s in remote %s", v.Tag, remote)
+	}
+
+	// The module path comes from the tagged go.mod if possible,
+	// otherwise from the one in the working tree.
+	var (
+		gomodPath  = path.Join(filepath.ToSlash(moduledir), "go.mod")
+		gomodBytes []byte
+	)
+	if v.LocalCommit != "" {
+		gomodBytes, err = gitutil.Show(ctx, git, repodir, v.LocalCommit, gomodPath)
+	} else {
+		gomodBytes, err = os.ReadFile(filepath.Join(repodir, moduledir, "go.mod"))
+	}
+	if err != nil {
+		return v, errors.Wrapf(err, "reading %s", gomodPath)
+	}
+	gomod, err := modfile.ParseLax(gomodPath, gomodBytes, noopFixer)
+	if err != nil {
+		return v, errors.Wrapf(err, "parsing %s", gomodPath)
+	}
+	v.Modpath = gomod.Module.Mod.Path
+
+	escPath, err := module.EscapePath(v.Modpath)
+	if err != nil {
+		return v, errors.Wrapf(err, "escaping module path %s", v.Modpath)
+	}
+	escVersion, err := module.EscapeVersion(version)
+	if err != nil {
+		return v, errors.Wrapf(err, "escaping version %s", version)
+	}
+
+	// Module proxy.
+
+	infoURL := fmt.Sprintf("%s/%s/@v/%s.info", proxyBaseURL, escPath, escVersion)
+	body, found, err := httpGet(ctx, o.httpClient, infoURL)
+	if err != nil {
+		return v, err
+	}
+	if found {
+		v.ProxyHasVersion = true
+
+		var info struct {
+			Origin struct {
+				Hash string
+			}
+		}
+		if err := json.Unmarshal(body, &info); err != nil {
+			return v, errors.Wrapf(err, "decoding response from %s", infoURL)
+		}
+		v.ProxyCommit = info.Origin.Hash
+	}
+
+	// Checksum database.
+
+	lookupURL := fmt.Sprintf("%s/lookup/%s@%s", sumDBBaseURL, escPath, escVersion)
+	body, found, err = httpGet(ctx, o.httpClient, lookupURL)
+	if err != nil {
+		return v, err
+	}
+	if found {
+		v.SumDBHash = sumDBHash(body, v.Modpath, version)
+	}
+
+	// Local module zip.
+
+	if v.LocalCommit != "" {
+		v.ZipHash, err = localZipHash(v.Modpath, version, repodir, v.LocalCommit, moduledir)
+		if err != nil {
+			return v, errors.Wrap(err, "hashing local module zip")
+		}
+	}
+
+	// Pkg.go.dev.
+
+	_, v.PkgGoDevHasVersion, err = httpGet(ctx, o.httpClient, fmt.Sprintf("%s/%s@%s", pkgGoDevBaseURL, v.Modpath, version))
+	if err != nil {
+		return v, err
+	}
+
+	return v, nil
+}
+
+// sumDBHash finds the hash of the module zip for modpath@version
+// in a response from the checksum database's lookup endpoint.
+func sumDBHash(body []byte, modpath, version string) string {
+	sc := bufio.NewScanner(bytes.NewReader(body))
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 3 && fields[0] == modpath && fields[1] == version {
+			return fields[2]
+		}
+	}
+	return ""
+}
+
+func localZipHash(modpath, version, repodir, rev, moduledir string) (string, error) {
+	tmpdir, err := os.MkdirTemp("", "taggo-verify")
+	if err != nil {
+		return "", errors.Wrap(err, "creating temp dir")
+	}
+	defer os.RemoveAll(tmpdir)
+
+	zipPath := filepath.Join(tmpdir, "module.zip")
+	if err := writeModuleZip(zipPath, modpath, version, repodir, rev, moduledir); err != nil {
+		return "", errors.Wrapf(err, "creating module zip for %s@%s", modpath, version)
+	}
+	return dirhash.HashZip(zipPath, dirhash.Hash1)
+}
+
+// httpGet gets the given URL.
+// The boolean result is false if the server reports that it was not found.
+func httpGet(ctx context.Context, client *http.Client, u string) ([]byte, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "creating request for %s", u)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "requesting %s", u)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// ok
+	case http.StatusNotFound, http.StatusGone:
+		return nil, false, nil
+	default:
+		return nil, false, fmt.Errorf("requesting %s: status %d", u, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "reading response from %s", u)
+	}
+	return body, true, nil
+}
@@ -0,0 +1,103 @@
+package taggo_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/internal/testutil"
+)
+
+func TestVerify(t *testing.T) {
+	var (
+		tmpdir  = t.TempDir()
+		remote  = filepath.Join(tmpdir, "remote.git")
+		repodir = filepath.Join(tmpdir, "repo")
+	)
+
+	if err := os.Mkdir(repodir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repodir, "go.mod"), []byte("module example.com/x\n\ngo 1.22\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repodir, "x.go"), []byte("package x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	testutil.Git(t, tmpdir, "init", "-q", "--bare", remote)
+	testutil.Git(t, repodir, "init", "-q")
+	testutil.Git(t, repodir, "add", ".")
+	testutil.Git(t, repodir, "commit", "-q", "-m", "initial")
+	testutil.Git(t, repodir, "tag", "v0.1.2")
+	testutil.Git(t, repodir, "remote", "add", "origin", remote)
+	testutil.Git(t, repodir, "push", "-q", "origin", "refs/tags/v0.1.2")
+
+	commit := testutil.Git(t, repodir, "rev-parse", "HEAD")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.Host + req.URL.Path {
+		case "proxy.golang.org/example.com/x/@v/v0.1.2.info":
+			fmt.Fprintf(w, `{"Version":"v0.1.2","Origin":{"VCS":"git","Hash":%q}}`, commit)
+		case "sum.golang.org/lookup/example.com/x@v0.1.2":
+			fmt.Fprint(w, "123\nexample.com/x v0.1.2 h1:bogus=\nexample.com/x v0.1.2/go.mod h1:alsobogus=\n")
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	defer srv.Close()
+
+	srvURL, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: redirectTransport{host: srvURL.Host}}
+
+	v, err := taggo.Verify(context.Background(), "", repodir, "", "v0.1.2", "origin", taggo.WithHTTPClient(client))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v.LocalCommit != commit {
+		t.Errorf("got local commit %s, want %s", v.LocalCommit, commit)
+	}
+	if v.RemoteCommit != commit {
+		t.Errorf("got remote commit %s, want %s", v.RemoteCommit, commit)
+	}
+	if !v.ProxyHasVersion || v.ProxyCommit != commit {
+		t.Errorf("got proxy %v/%s, want true/%s", v.ProxyHasVersion, v.ProxyCommit, commit)
+	}
+	if v.SumDBHash != "h1:bogus=" {
+		t.Errorf("got sumdb hash %s, want h1:bogus=", v.SumDBHash)
+	}
+	if !strings.HasPrefix(v.ZipHash, "h1:") {
+		t.Errorf("got zip hash %s, want h1:...", v.ZipHash)
+	}
+	if v.PkgGoDevHasVersion {
+		t.Error("got PkgGoDevHasVersion true, want false")
+	}
+	if v.OK() {
+		t.Error("got OK true despite hash mismatch")
+	}
+}
+
+// redirectTransport sends all requests to host,
+// preserving the original Host header.
+type redirectTransport struct {
+	host string
+}
+
+func (rt redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Host = req.URL.Host
+	req.URL.Scheme = "http"
+	req.URL.Host = rt.host
+	return http.DefaultTransport.RoundTrip(req)
+}
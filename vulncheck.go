@@ -0,0 +1,382 @@
+package taggo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/bobg/errors"
+
+	"github.com/bobg/taggo/gitutil"
+)
+
+// VulnSeverity is the severity of a [Vulnerability],
+// from its CVSS v3 score if the vulnerability database records one.
+type VulnSeverity string
+
+// The vulnerability severities, in increasing order.
+// VulnSeverityUnknown is for vulnerabilities with no recorded severity,
+// which includes most entries in the Go vulnerability database.
+const (
+	VulnSeverityUnknown  VulnSeverity = ""
+	VulnSeverityLow      VulnSeverity = "low"
+	VulnSeverityMedium   VulnSeverity = "medium"
+	VulnSeverityHigh     VulnSeverity = "high"
+	VulnSeverityCritical VulnSeverity = "critical"
+)
+
+var vulnSeverityRanks = map[VulnSeverity]int{
+	VulnSeverityLow:      1,
+	VulnSeverityMedium:   2,
+	VulnSeverityHigh:     3,
+	VulnSeverityCritical: 4,
+}
+
+// ParseVulnSeverity parses a vulnerability severity:
+// low, medium, high, or critical.
+func ParseVulnSeverity(s string) (VulnSeverity, error) {
+	if _, ok := vulnSeverityRanks[VulnSeverity(s)]; !ok {
+		return "", fmt.Errorf("unknown vulnerability severity %q (want low, medium, high, or critical)", s)
+	}
+	return VulnSeverity(s), nil
+}
+
+// AtLeast tells whether s is at least as severe as other.
+// An unknown severity counts as high,
+// since the Go vulnerability database records no severities
+// but includes only vulnerabilities that its reviewers consider real risks.
+func (s VulnSeverity) AtLeast(other VulnSeverity) bool {
+	rank := func(s VulnSeverity) int {
+		if s == VulnSeverityUnknown {
+			s = VulnSeverityHigh
+		}
+		return vulnSeverityRanks[s]
+	}
+	return rank(s) >= rank(other)
+}
+
+// GovulncheckConfig holds the settings for govulncheck in a [Config].
+// See [WithGovulncheck].
+type GovulncheckConfig struct {
+	// Block, if set, is the least severe reachable vulnerability
+	// that blocks adding a new version tag,
+	// as in "high".
+	// See [Result.BlockingVulnerabilities].
+	Block VulnSeverity `yaml:"block"`
+}
+
+// Vulnerability is a known vulnerability
+// that govulncheck finds reachable from a module's code.
+// See [WithGovulncheck].
+type Vulnerability struct {
+	// ID is the vulnerability's ID in the Go vulnerability database,
+	// such as GO-2024-2687.
+	ID string
+
+	// Aliases are other IDs for the vulnerability,
+	// such as CVE and GHSA IDs.
+	Aliases []string
+
+	// Summary is a one-line description of the vulnerability.
+	Summary string
+
+	// Severity is the vulnerability's severity,
+	// or VulnSeverityUnknown if the database does not record one.
+	Severity VulnSeverity
+
+	// Module is the path of the vulnerable module,
+	// which may be a dependency or the standard library ("stdlib").
+	Module string
+
+	// Version is the version of Module in use.
+	Version string
+
+	// FixedVersion is the earliest version of Module that fixes the vulnerability,
+	// or "" if there is none.
+	FixedVersion string
+
+	// Symbols are the vulnerable functions that the module's code reaches,
+	// as "package.Function" or "package.Type.Method".
+	Symbols []string
+}
+
+// BlockingVulnerabilities returns the vulnerabilities in r.ReachableVulnerabilities
+// that are at least as severe as threshold
+// (see [VulnSeverity.AtLeast]).
+func (r Result) BlockingVulnerabilities(threshold VulnSeverity) []Vulnerability {
+	var result []Vulnerability
+	for _, v := range r.ReachableVulnerabilities {
+		if v.Severity.AtLeast(threshold) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// reachableVulnerabilities checks out commit into a temporary worktree
+// and runs "govulncheck -json ./..." on the module in moduledir there.
+// It returns the vulnerabilities that affect code reachable from the module's packages,
+// in order of ID.
+func reachableVulnerabilities(ctx context.Context, git, repodir, moduledir, commit string) ([]Vulnerability, error) {
+	govulncheck, err := exec.LookPath("govulncheck")
+	if err != nil {
+		return nil, errors.Wrap(err, "finding govulncheck binary (install it with go install golang.org/x/vuln/cmd/govulncheck@latest)")
+	}
+
+	tmpdir, err := os.MkdirTemp("", "taggo-govulncheck")
+	if err != nil {
+		return nil, errors.Wrap(err, "creating temp dir")
+	}
+	defer os.RemoveAll(tmpdir)
+
+	worktree := filepath.Join(tmpdir, "worktree")
+	cmd, done := gitutil.Command(ctx, git, repodir, "worktree", "add", "--detach", worktree, commit)
+	output, err := cmd.CombinedOutput()
+	if err = done(err); err != nil {
+		return nil, errors.Wrapf(err, "running %s: %s", cmd, output)
+	}
+	defer func() {
+		cmd := exec.CommandContext(context.WithoutCancel(ctx), git, "worktree", "remove", "--force", worktree)
+		cmd.Dir = repodir
+		_ = cmd.Run()
+	}()
+
+	var stdout, stderr bytes.Buffer
+
+	cmd = exec.CommandContext(ctx, govulncheck, "-json", "./...")
+	cmd.Dir = filepath.Join(worktree, moduledir)
+	cmd.Env = goCmdEnv(false)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "running %s: %s", cmd, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	return parseGovulncheck(&stdout)
+}
+
+// govulncheckMessage is one of the messages in the output of "govulncheck -json".
+// See https://pkg.go.dev/golang.org/x/vuln/internal/govulncheck.
+type govulncheckMessage struct {
+	OSV *struct {
+		ID       string   `json:"id"`
+		Aliases  []string `json:"aliases"`
+		Summary  string   `json:"summary"`
+		Severity []struct {
+			Type  string `json:"type"`
+			Score string `json:"score"`
+		} `json:"severity"`
+		DatabaseSpecific struct {
+			Severity string `json:"severity"`
+		} `json:"database_specific"`
+	} `json:"osv"`
+
+	Finding *struct {
+		OSV          string `json:"osv"`
+		FixedVersion string `json:"fixed_version"`
+		Trace        []struct {
+			Module   string `json:"module"`
+			Version  string `json:"version"`
+			Package  string `json:"package"`
+			Function string `json:"function"`
+			Receiver string `json:"receiver"`
+		} `json:"trace"`
+	} `json:"finding"`
+}
+
+// parseGovulncheck parses the output of "govulncheck -json",
+// a stream of JSON messages,
+// into the vulnerabilities that affect reachable code:
+// those with findings whose traces begin at a vulnerable function,
+// not merely at an imported package or required module.
+func parseGovulncheck(r io.Reader) ([]Vulnerability, error) {
+	var (
+		dec   = json.NewDecoder(r)
+		vulns = make(map[string]*Vulnerability)
+		ids   []string
+	)
+	for {
+		var msg govulncheckMessage
+		if err := dec.Decode(&msg); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, errors.Wrap(err, "decoding govulncheck output")
+		}
+
+		switch {
+		case msg.OSV != nil:
+			v := vulnFor(vulns, &ids, msg.OSV.ID)
+			v.Aliases = msg.OSV.Aliases
+			v.Summary = msg.OSV.Summary
+			for _, s := range msg.OSV.Severity {
+				if s.Type == "CVSS_V3" {
+					if score, ok := cvss3BaseScore(s.Score); ok {
+						v.Severity = cvssSeverity(score)
+					}
+				}
+			}
+			if v.Severity == VulnSeverityUnknown {
+				switch strings.ToLower(msg.OSV.DatabaseSpecific.Severity) {
+				case "low":
+					v.Severity = VulnSeverityLow
+				case "medium", "moderate":
+					v.Severity = VulnSeverityMedium
+				case "high":
+					v.Severity = VulnSeverityHigh
+				case "critical":
+					v.Severity = VulnSeverityCritical
+				}
+			}
+
+		case msg.Finding != nil && len(msg.Finding.Trace) > 0 && msg.Finding.Trace[0].Function != "":
+			var (
+				f   = msg.Finding
+				t   = f.Trace[0]
+				v   = vulnFor(vulns, &ids, f.OSV)
+				sym = t.Package + "." + t.Function
+			)
+			if t.Receiver != "" {
+				sym = t.Package + "." + strings.TrimPrefix(t.Receiver, "*") + "." + t.Function
+			}
+			v.Module, v.Version, v.FixedVersion = t.Module, t.Version, f.FixedVersion
+			if !slices.Contains(v.Symbols, sym) {
+				v.Symbols = append(v.Symbols, sym)
+			}
+		}
+	}
+
+	// Only the vulnerabilities with reachable findings count,
+	// not every OSV entry that govulncheck reports.
+	var result []Vulnerability
+	slices.Sort(ids)
+	for _, id := range ids {
+		if v := vulns[id]; len(v.Symbols) > 0 {
+			slices.Sort(v.Symbols)
+			result = append(result, *v)
+		}
+	}
+	return result, nil
+}
+
+// vulnFor returns the entry for id in vulns,
+// creating it (and adding id to ids) if necessary.
+func vulnFor(vulns map[string]*Vulnerability, ids *[]string, id string) *Vulnerability {
+	v, ok := vulns[id]
+	if !ok {
+		v = &Vulnerability{ID: id}
+		vulns[id] = v
+		*ids = append(*ids, id)
+	}
+	return v
+}
+
+// cvssSeverity is the qualitative severity of a CVSS v3 base score.
+func cvssSeverity(score float64) VulnSeverity {
+	switch {
+	case score >= 9:
+		return VulnSeverityCritical
+	case score >= 7:
+		return VulnSeverityHigh
+	case score >= 4:
+		return VulnSeverityMedium
+	case score > 0:
+		return VulnSeverityLow
+	default:
+		return VulnSeverityUnknown
+	}
+}
+
+// cvss3Weights are the weights of the values of the CVSS v3 base metrics.
+// The weights of PR (privileges required) depend on S (scope)
+// and are handled separately.
+var cvss3Weights = map[string]map[string]float64{
+	"AV": {"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2},
+	"AC": {"L": 0.77, "H": 0.44},
+	"UI": {"N": 0.85, "R": 0.62},
+	"C":  {"H": 0.56, "L": 0.22, "N": 0},
+	"I":  {"H": 0.56, "L": 0.22, "N": 0},
+	"A":  {"H": 0.56, "L": 0.22, "N": 0},
+}
+
+// cvss3BaseScore computes the base score of a CVSS v3 vector,
+// such as "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H".
+// See https://www.first.org/cvss/v3.1/specification-document#7-4-Metric-Values.
+// The boolean result is false if the vector cannot be parsed.
+func cvss3BaseScore(vector string) (float64, bool) {
+	parts := strings.Split(vector, "/")
+	if len(parts) < 9 || !strings.HasPrefix(parts[0], "CVSS:3") {
+		return 0, false
+	}
+	metrics := make(map[string]string)
+	for _, p := range parts[1:] {
+		k, v, ok := strings.Cut(p, ":")
+		if !ok {
+			return 0, false
+		}
+		metrics[k] = v
+	}
+
+	w := make(map[string]float64)
+	for k, weights := range cvss3Weights {
+		weight, ok := weights[metrics[k]]
+		if !ok {
+			return 0, false
+		}
+		w[k] = weight
+	}
+
+	changed := metrics["S"] == "C"
+	if !changed && metrics["S"] != "U" {
+		return 0, false
+	}
+	switch metrics["PR"] {
+	case "N":
+		w["PR"] = 0.85
+	case "L":
+		w["PR"] = 0.62
+		if changed {
+			w["PR"] = 0.68
+		}
+	case "H":
+		w["PR"] = 0.27
+		if changed {
+			w["PR"] = 0.5
+		}
+	default:
+		return 0, false
+	}
+
+	iss := 1 - (1-w["C"])*(1-w["I"])*(1-w["A"])
+	impact := 6.42 * iss
+	if changed {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+	}
+	if impact <= 0 {
+		return 0, true
+	}
+	exploitability := 8.22 * w["AV"] * w["AC"] * w["PR"] * w["UI"]
+	score := impact + exploitability
+	if changed {
+		score *= 1.08
+	}
+	return cvssRoundup(min(score, 10)), true
+}
+
+// cvssRoundup rounds x up to one decimal place,
+// as the CVSS v3.1 specification defines it
+// (avoiding floating-point surprises).
+func cvssRoundup(x float64) float64 {
+	i := int(math.Round(x * 100000))
+	if i%10000 == 0 {
+		return float64(i) / 100000
+	}
+	return float64(i/10000+1) / 10
+}
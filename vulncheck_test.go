@@ -0,0 +1,153 @@
+package taggo_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bobg/taggo"
+)
+
+// govulncheckOutput is like the output of "govulncheck -json",
+// abridged.
+const govulncheckOutput = `{
+  "config": {"protocol_version": "v1.0.0", "scanner_name": "govulncheck", "scan_level": "symbol"}
+}
+{
+  "osv": {
+    "id": "GO-2024-0001",
+    "aliases": ["CVE-2024-0001"],
+    "summary": "Remote code execution in example.com/dep",
+    "severity": [{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"}]
+  }
+}
+{
+  "osv": {"id": "GO-2024-0002", "summary": "Cross-site scripting in example.com/dep/html",
+    "severity": [{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N"}]}
+}
+{
+  "osv": {"id": "GO-2024-0003", "summary": "Panic in net/http"}
+}
+{
+  "osv": {"id": "GO-2024-0004", "summary": "Imported but not called"}
+}
+{
+  "finding": {"osv": "GO-2024-0001", "fixed_version": "v1.2.4",
+    "trace": [{"module": "example.com/dep", "version": "v1.2.3", "package": "example.com/dep", "function": "Run"}, {"module": "example.com/x", "package": "example.com/x", "function": "X"}]}
+}
+{
+  "finding": {"osv": "GO-2024-0001", "fixed_version": "v1.2.4",
+    "trace": [{"module": "example.com/dep", "version": "v1.2.3", "package": "example.com/dep", "function": "Exec"}]}
+}
+{
+  "finding": {"osv": "GO-2024-0002", "fixed_version": "v1.2.4",
+    "trace": [{"module": "example.com/dep", "version": "v1.2.3", "package": "example.com/dep/html", "function": "Render"}]}
+}
+{
+  "finding": {"osv": "GO-2024-0003", "fixed_version": "v1.22.5",
+    "trace": [{"module": "stdlib", "version": "v1.22.1", "package": "net/http", "function": "ServeHTTP", "receiver": "*Server"}]}
+}
+{
+  "finding": {"osv": "GO-2024-0004",
+    "trace": [{"module": "example.com/other", "version": "v0.1.0", "package": "example.com/other"}]}
+}
+`
+
+func TestGovulncheck(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the govulncheck binary")
+	}
+
+	repodir := cloneBundle(t, "unstable")
+
+	// A "govulncheck" that produces canned output,
+	// after making sure it runs in the module's directory.
+	bindir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bindir, "output.json"), []byte(govulncheckOutput), 0644); err != nil {
+		t.Fatal(err)
+	}
+	script := "#!/bin/sh\ntest -f go.mod || exit 1\nexec cat \"$(dirname \"$0\")/output.json\"\n"
+	if err := os.WriteFile(filepath.Join(bindir, "govulncheck"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bindir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	result, err := taggo.Check(context.Background(), "", repodir, "", taggo.WithGovulncheck())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []taggo.Vulnerability{{
+		ID:           "GO-2024-0001",
+		Aliases:      []string{"CVE-2024-0001"},
+		Summary:      "Remote code execution in example.com/dep",
+		Severity:     taggo.VulnSeverityCritical, // 9.8
+		Module:       "example.com/dep",
+		Version:      "v1.2.3",
+		FixedVersion: "v1.2.4",
+		Symbols:      []string{"example.com/dep.Exec", "example.com/dep.Run"},
+	}, {
+		ID:           "GO-2024-0002",
+		Summary:      "Cross-site scripting in example.com/dep/html",
+		Severity:     taggo.VulnSeverityMedium, // 6.1
+		Module:       "example.com/dep",
+		Version:      "v1.2.3",
+		FixedVersion: "v1.2.4",
+		Symbols:      []string{"example.com/dep/html.Render"},
+	}, {
+		ID:           "GO-2024-0003",
+		Summary:      "Panic in net/http",
+		Module:       "stdlib",
+		Version:      "v1.22.1",
+		FixedVersion: "v1.22.5",
+		Symbols:      []string{"net/http.Server.ServeHTTP"},
+	}}
+	if diff := cmp.Diff(want, result.ReachableVulnerabilities); diff != "" {
+		t.Errorf("vulnerabilities mismatch (-want +got):\n%s", diff)
+	}
+
+	blocking := func(threshold taggo.VulnSeverity) string {
+		var ids []string
+		for _, v := range result.BlockingVulnerabilities(threshold) {
+			ids = append(ids, v.ID)
+		}
+		return strings.Join(ids, " ")
+	}
+	for threshold, want := range map[taggo.VulnSeverity]string{
+		taggo.VulnSeverityCritical: "GO-2024-0001",
+		taggo.VulnSeverityHigh:     "GO-2024-0001 GO-2024-0003", // unknown severity counts as high
+		taggo.VulnSeverityLow:      "GO-2024-0001 GO-2024-0002 GO-2024-0003",
+	} {
+		if got := blocking(threshold); got != want {
+			t.Errorf("got blocking vulnerabilities %q at %s, want %q", got, threshold, want)
+		}
+	}
+
+	var msgs []string
+	for _, f := range result.Findings() {
+		if f.ID == "reachable-vulnerability" {
+			msgs = append(msgs, f.Message)
+		}
+	}
+	wantMsgs := []string{
+		"Vulnerability GO-2024-0001 (severity critical) in example.com/dep@v1.2.3 is reachable via example.com/dep.Exec, example.com/dep.Run; fixed in v1.2.4",
+		"Vulnerability GO-2024-0002 (severity medium) in example.com/dep@v1.2.3 is reachable via example.com/dep/html.Render; fixed in v1.2.4",
+		"Vulnerability GO-2024-0003 (severity unknown) in stdlib@v1.22.1 is reachable via net/http.Server.ServeHTTP; fixed in v1.22.5",
+	}
+	if diff := cmp.Diff(wantMsgs, msgs); diff != "" {
+		t.Errorf("findings mismatch (-want +got):\n%s", diff)
+	}
+
+	result, err = taggo.Check(context.Background(), "", repodir, "", taggo.WithGovulncheck(), taggo.WithOffline())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.ReachableVulnerabilities) > 0 || len(result.SkippedChecks) != 1 || result.SkippedChecks[0] != "govulncheck" {
+		t.Errorf("got vulnerabilities %v and skipped checks %v offline, want none and govulncheck", result.ReachableVulnerabilities, result.SkippedChecks)
+	}
+}
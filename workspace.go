@@ -0,0 +1,132 @@
+package taggo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bobg/errors"
+	"github.com/bobg/modules"
+	"golang.org/x/mod/modfile"
+)
+
+// Workspace describes the go.work file at the root of a repository
+// and how it agrees with the modules in the repository.
+// See [ReadWorkspace].
+//
+// Each directory is relative to the repository root and slash-separated,
+// with "." for the root.
+type Workspace struct {
+	// Modules are the directories of the modules that go.work uses.
+	Modules []string
+
+	// Unlisted are the directories of modules in the repository,
+	// found by walking its directory tree as [CheckAll] does,
+	// that go.work does not use.
+	Unlisted []string
+
+	// Missing are the directories that go.work uses
+	// but that contain no go.mod.
+	Missing []string
+}
+
+// ReadWorkspace reads the go.work file at the root of the repository in repodir,
+// or returns nil if there is none.
+// Directories that go.work uses outside the repository are ignored.
+// Each list in the result is sorted.
+func ReadWorkspace(repodir string) (*Workspace, error) {
+	goworkPath := filepath.Join(repodir, "go.work")
+	data, err := os.ReadFile(goworkPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %s", goworkPath)
+	}
+	wf, err := modfile.ParseWork(goworkPath, data, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", goworkPath)
+	}
+
+	var (
+		ws   = &Workspace{}
+		used = make(map[string]bool)
+	)
+	for _, u := range wf.Use {
+		dir := filepath.FromSlash(u.Path)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(repodir, dir)
+		}
+		rel, err := filepath.Rel(repodir, dir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "finding relative path from %s to %s", repodir, dir)
+		}
+		if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		rel = filepath.ToSlash(rel)
+		if used[rel] {
+			continue
+		}
+		used[rel] = true
+
+		_, err = os.Stat(filepath.Join(dir, "go.mod"))
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			ws.Missing = append(ws.Missing, rel)
+		case err != nil:
+			return nil, errors.Wrapf(err, "statting go.mod in %s", dir)
+		default:
+			ws.Modules = append(ws.Modules, rel)
+		}
+	}
+
+	err = modules.Each(repodir, func(moduledir string) error {
+		rel, err := filepath.Rel(repodir, moduledir)
+		if err != nil {
+			return errors.Wrapf(err, "finding relative path from %s to %s", repodir, moduledir)
+		}
+		if rel = filepath.ToSlash(rel); !used[rel] {
+			ws.Unlisted = append(ws.Unlisted, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "scanning %s for modules", repodir)
+	}
+
+	sort.Strings(ws.Modules)
+	sort.Strings(ws.Unlisted)
+	sort.Strings(ws.Missing)
+
+	return ws, nil
+}
+
+// workspaceModules returns the directories of the modules
+// used by the go.work file at the root of the repository in repodir
+// that are in root or its subdirectories.
+func workspaceModules(repodir, root string) ([]string, error) {
+	ws, err := ReadWorkspace(repodir)
+	if err != nil {
+		return nil, err
+	}
+	if ws == nil {
+		return nil, fmt.Errorf("no go.work in %s", repodir)
+	}
+
+	var result []string
+	for _, dir := range ws.Modules {
+		moduledir := filepath.Join(repodir, filepath.FromSlash(dir))
+		rel, err := filepath.Rel(root, moduledir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "finding relative path from %s to %s", root, moduledir)
+		}
+		if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		result = append(result, moduledir)
+	}
+	return result, nil
+}
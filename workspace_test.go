@@ -0,0 +1,88 @@
+package taggo_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/bobg/taggo"
+	"github.com/bobg/taggo/internal/testutil"
+)
+
+func TestWorkspace(t *testing.T) {
+	repodir := t.TempDir()
+
+	write := func(name, contents string) {
+		t.Helper()
+		filename := filepath.Join(repodir, name)
+		if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ws, err := taggo.ReadWorkspace(repodir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ws != nil {
+		t.Errorf("got %+v without go.work, want nil", ws)
+	}
+
+	write("go.mod", "module example.com/x\n")
+	write("a/go.mod", "module example.com/x/a\n")
+	write("b/go.mod", "module example.com/x/b\n")
+	write("c/README", "no module here\n")
+	write("go.work", "go 1.22\n\nuse (\n\t.\n\t./a\n\t./c\n\t../elsewhere\n)\n")
+
+	ws, err = taggo.ReadWorkspace(repodir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &taggo.Workspace{
+		Modules:  []string{".", "a"},
+		Unlisted: []string{"b"},
+		Missing:  []string{"c"},
+	}
+	if diff := cmp.Diff(want, ws); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	testutil.Git(t, repodir, "init", "-q", "-b", "main")
+	testutil.Git(t, repodir, "add", ".")
+	testutil.Git(t, repodir, "commit", "-q", "-m", "first")
+
+	results, err := taggo.CheckAll(context.Background(), "", repodir, taggo.WithWorkspace())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dirs []string
+	for dir := range results {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	wantDirs := []string{repodir, filepath.Join(repodir, "a")}
+	if diff := cmp.Diff(wantDirs, dirs); diff != "" {
+		t.Errorf("modules mismatch (-want +got):\n%s", diff)
+	}
+
+	// Errors from the callback are wrapped the same way with and without the workspace.
+	errStop := errors.New("stop")
+	for _, opts := range [][]taggo.Option{nil, {taggo.WithWorkspace()}} {
+		err := taggo.CheckAllFunc(context.Background(), "", repodir, func(string, taggo.Result, error) error {
+			return errStop
+		}, opts...)
+		if !errors.Is(err, errStop) {
+			t.Errorf("with %d option(s), got error %v, want %v", len(opts), err, errStop)
+		} else if want := "in " + repodir + ": stop"; err.Error() != want {
+			t.Errorf("with %d option(s), got error %q, want %q", len(opts), err, want)
+		}
+	}
+}